@@ -61,3 +61,53 @@ func validatePortNumber(i interface{}, k string, allowZero bool) (warnings []str
 
 	return warnings, errors
 }
+
+// SubnetReservedAddresses is the number of addresses which Azure reserves within each subnet: the network address,
+// the default gateway, two addresses used to map the Azure DNS IPs and the broadcast address.
+const SubnetReservedAddresses = 5
+
+// SubnetMinimumPrefixLengths are the largest prefix lengths (i.e. the smallest subnets) which Azure requires for
+// subnets with names reserved for a specific service.
+var SubnetMinimumPrefixLengths = map[string]int{
+	"AzureBastionSubnet":            26,
+	"AzureFirewallManagementSubnet": 26,
+	"AzureFirewallSubnet":           26,
+	"GatewaySubnet":                 27,
+	"RouteServerSubnet":             27,
+}
+
+// SubnetCIDR is a SchemaValidateFunc which tests if the provided value is an IPv4 CIDR which can be used as the address
+// prefix of a subnet, that is a network address with a prefix length between 2 and 29
+func SubnetCIDR(i interface{}, k string) (warnings []string, errors []error) {
+	v, ok := i.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected type of %q to be string", k))
+		return
+	}
+
+	ip, network, err := net.ParseCIDR(v)
+	if err != nil || ip.To4() == nil {
+		errors = append(errors, fmt.Errorf("%q must be an IPv4 CIDR, for example 10.0.1.0/24. Got %q.", k, v))
+		return
+	}
+
+	if !ip.Equal(network.IP) {
+		errors = append(errors, fmt.Errorf("%q must start with the network address %q. Got %q.", k, network.String(), v))
+	}
+
+	if ones, _ := network.Mask.Size(); ones < 2 || ones > 29 {
+		errors = append(errors, fmt.Errorf("%q must have a prefix length between 2 and 29. Got %q.", k, v))
+	}
+
+	return warnings, errors
+}
+
+// SubnetPrefixLengthForName returns an error when the prefix length is too large for a subnet with a name reserved for a
+// specific service (e.g. `AzureFirewallSubnet`)
+func SubnetPrefixLengthForName(name string, prefixLength int) error {
+	if minimum, ok := SubnetMinimumPrefixLengths[name]; ok && prefixLength > minimum {
+		return fmt.Errorf("the subnet %q must have a prefix length of %d or less but got %d", name, minimum, prefixLength)
+	}
+
+	return nil
+}
@@ -187,3 +187,53 @@ func TestPortNumberOrZero(t *testing.T) {
 		})
 	}
 }
+
+func TestSubnetCIDR(t *testing.T) {
+	cases := []struct {
+		CIDR   string
+		Errors int
+	}{
+		{
+			CIDR:   "",
+			Errors: 1,
+		},
+		{
+			CIDR:   "10.0.0.0",
+			Errors: 1,
+		},
+		{
+			CIDR:   "10.0.1.0/24",
+			Errors: 0,
+		},
+		{
+			CIDR:   "10.0.1.1/24",
+			Errors: 1,
+		},
+		{
+			CIDR:   "10.0.1.0/29",
+			Errors: 0,
+		},
+		{
+			CIDR:   "10.0.1.0/30",
+			Errors: 1,
+		},
+		{
+			CIDR:   "0.0.0.0/1",
+			Errors: 1,
+		},
+		{
+			CIDR:   "2001:db8::/64",
+			Errors: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.CIDR, func(t *testing.T) {
+			_, errors := SubnetCIDR(tc.CIDR, "test")
+
+			if len(errors) != tc.Errors {
+				t.Fatalf("Expected SubnetCIDR to return %d error(s) not %d", tc.Errors, len(errors))
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
@@ -55,10 +56,19 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// recorder is used to record (or replay) the random values generated whilst the test is running, when enabled
+	recorder *recording.Recorder
 }
 
 // BuildTestData generates some test data for the given resource
 func BuildTestData(t *testing.T, resourceType string, resourceLabel string) TestData {
+	var recorder *recording.Recorder
+	if recording.Enabled() {
+		// this configures the environment when replaying, so must happen prior to it being read below
+		recorder = recording.Start(t)
+	}
+
 	testData := TestData{
 		RandomInteger:   RandTimeInt(),
 		RandomString:    randString(5),
@@ -68,6 +78,7 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 
 		ResourceType:  resourceType,
 		resourceLabel: resourceLabel,
+		recorder:      recorder,
 	}
 
 	if features.UseDynamicTestLocations() {
@@ -85,6 +96,24 @@ func BuildTestData(t *testing.T, resourceType string, resourceLabel string) Test
 		Secondary: os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"),
 	}
 
+	if recorder != nil {
+		// the same random values/locations must be used when replaying since they're part of the recorded requests
+		values := recorder.Values(recording.TestValues{
+			RandomInteger: testData.RandomInteger,
+			RandomString:  testData.RandomString,
+			Locations:     []string{testData.Locations.Primary, testData.Locations.Secondary, testData.Locations.Ternary},
+		})
+		testData.RandomInteger = values.RandomInteger
+		testData.RandomString = values.RandomString
+		if len(values.Locations) == 3 {
+			testData.Locations = Regions{
+				Primary:   values.Locations[0],
+				Secondary: values.Locations[1],
+				Ternary:   values.Locations[2],
+			}
+		}
+	}
+
 	return testData
 }
 
@@ -123,6 +152,10 @@ func (td *TestData) RandomStringOfLength(len int) string {
 		panic("Invalid Test: RandomStringOfLength: length argument must be between 1 and 1024 characters")
 	}
 
+	if td.recorder != nil {
+		return td.recorder.RandomString(randString(len))
+	}
+
 	return randString(len)
 }
 
@@ -0,0 +1,272 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+var _ common.HTTPMiddleware = middleware{}
+
+// Middleware returns the HTTPMiddleware which records requests to (or replays requests from) the cassette for the test
+// which is currently running - and which is configured for every client used in the Acceptance Tests.
+func Middleware() common.HTTPMiddleware {
+	return middleware{}
+}
+
+type middleware struct{}
+
+func (middleware) RequestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		return beforeRequest(request)
+	}
+}
+
+func (middleware) ResponseMiddleware() client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		return afterResponse(request, response)
+	}
+}
+
+func (middleware) PrepareDecorator() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			return beforeRequest(r)
+		})
+	}
+}
+
+func (middleware) RespondDecorator() autorest.RespondDecorator {
+	return func(r autorest.Responder) autorest.Responder {
+		return autorest.ResponderFunc(func(resp *http.Response) error {
+			if resp != nil && resp.Request != nil {
+				updated, err := afterResponse(resp.Request, resp)
+				if err != nil {
+					return err
+				}
+				*resp = *updated
+			}
+			return r.Respond(resp)
+		})
+	}
+}
+
+func beforeRequest(request *http.Request) (*http.Request, error) {
+	recorder := currentRecorder()
+	switch CurrentMode() {
+	case ModeRecord:
+		if recorder != nil {
+			recorder.observeIdentity(request)
+		}
+		return request, nil
+
+	case ModeReplay:
+		if recorder == nil {
+			return nil, fmt.Errorf("unable to replay %s %s since no test is currently being replayed", request.Method, request.URL.String())
+		}
+		return replayRequest(request), nil
+	}
+
+	return request, nil
+}
+
+func afterResponse(request *http.Request, response *http.Response) (*http.Response, error) {
+	if request == nil || response == nil {
+		return response, nil
+	}
+
+	switch CurrentMode() {
+	case ModeRecord:
+		recorder := currentRecorder()
+		if recorder == nil {
+			return response, nil
+		}
+
+		var body []byte
+		if response.Body != nil && response.Body != http.NoBody {
+			var err error
+			body, err = io.ReadAll(response.Body)
+			response.Body.Close()
+			if err != nil {
+				return response, fmt.Errorf("reading the response body to record it: %+v", err)
+			}
+			response.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		recorder.record(newInteraction(request, response, body))
+
+	case ModeReplay:
+		// the response is associated with the original request, since the URL is used when polling
+		if original, ok := originalRequestUrl(request.URL); ok {
+			response.Request = request.Clone(request.Context())
+			response.Request.URL = original
+			response.Request.Host = original.Host
+		}
+	}
+
+	return response, nil
+}
+
+func (r *Recorder) record(i interaction) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+}
+
+// replay returns the next recorded interaction matching the method and URL
+func (r *Recorder) replay(method string, u *url.URL) (*interaction, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	key := requestKey(method, u.String())
+	for i, v := range r.cassette.Interactions {
+		if _, used := r.used[i]; used {
+			continue
+		}
+		if requestKey(v.Method, v.URL) != key {
+			continue
+		}
+
+		r.used[i] = struct{}{}
+		r.lastUsed[key] = i
+		return &r.cassette.Interactions[i], nil
+	}
+
+	// requests which are repeated until a condition is met (e.g. polling until a resource is available) can be made
+	// more times than when they were recorded, in which case the last recorded response is returned again
+	if i, ok := r.lastUsed[key]; ok && strings.EqualFold(method, http.MethodGet) {
+		return &r.cassette.Interactions[i], nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction matches %s %s", method, u.String())
+}
+
+var (
+	replayServerLock sync.Mutex
+	replayServer     *httptest.Server
+)
+
+// startReplayServer starts the local server which returns the recorded responses, which is shared between all tests
+func startReplayServer() {
+	replayServerLock.Lock()
+	defer replayServerLock.Unlock()
+
+	if replayServer == nil {
+		replayServer = httptest.NewServer(http.HandlerFunc(serveRecordedResponse))
+	}
+}
+
+func replayServerUrl() *url.URL {
+	replayServerLock.Lock()
+	defer replayServerLock.Unlock()
+
+	if replayServer == nil {
+		return nil
+	}
+	u, _ := url.Parse(replayServer.URL)
+	return u
+}
+
+// replayRequest updates the request to be sent to the replay server - with the host of the original URL included as
+// the first segment of the path, so that the original URL can be determined for subsequent requests (e.g. polling)
+func replayRequest(request *http.Request) *http.Request {
+	server := replayServerUrl()
+	if server == nil || request.URL.Host == server.Host {
+		return request
+	}
+
+	u := *request.URL
+	u.Scheme = server.Scheme
+	u.Host = server.Host
+	u.Path = "/" + request.URL.Host + request.URL.Path
+	if request.URL.RawPath != "" {
+		u.RawPath = "/" + request.URL.Host + request.URL.RawPath
+	}
+
+	request.URL = &u
+	request.Host = ""
+	return request
+}
+
+// originalRequestUrl returns the URL which a request sent to the replay server was originally sent to
+func originalRequestUrl(input *url.URL) (*url.URL, bool) {
+	server := replayServerUrl()
+	if server == nil || input == nil || input.Host != server.Host {
+		return nil, false
+	}
+
+	return originalRequestUrlFromPath(input), true
+}
+
+// originalRequestUrlFromPath returns the original URL for a request sent to the replay server, from its path
+func originalRequestUrlFromPath(input *url.URL) *url.URL {
+	path := strings.TrimPrefix(input.Path, "/")
+	host, path, _ := strings.Cut(path, "/")
+
+	u := *input
+	u.Scheme = "https"
+	u.Host = host
+	u.Path = "/" + path
+	u.RawPath = ""
+	if input.RawPath != "" {
+		u.RawPath = strings.TrimPrefix(input.RawPath, "/"+host)
+	}
+	return &u
+}
+
+func serveRecordedResponse(w http.ResponseWriter, r *http.Request) {
+	recorder := currentRecorder()
+	if recorder == nil {
+		http.Error(w, "no test is currently being replayed", http.StatusBadRequest)
+		return
+	}
+
+	original := originalRequestUrlFromPath(r.URL)
+	recorded, err := recorder.replay(r.Method, original)
+	if err != nil {
+		// surfacing this as a test failure (rather than only through the API error) makes it clear the recording is
+		// out of date, since a 400 may otherwise be handled by the Provider
+		recorder.t.Errorf("replaying: %+v - the recording at %q may need to be updated", err, recorder.path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"error":{"code":"RecordingNotFound","message":%q}}`, err.Error())
+		return
+	}
+
+	body, err := recorded.body()
+	if err != nil {
+		recorder.t.Errorf("decoding the recorded body for %s %s: %+v", recorded.Method, recorded.URL, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	for k, v := range recorded.Headers {
+		w.Header()[k] = append([]string{}, v...)
+	}
+	// there's no need to wait between requests (e.g. when polling) since the responses are already known
+	if w.Header().Get("Retry-After") != "" || recorded.StatusCode == http.StatusCreated || recorded.StatusCode == http.StatusAccepted {
+		w.Header().Set("Retry-After", "0")
+	}
+
+	w.WriteHeader(recorded.StatusCode)
+	if _, err := w.Write(body); err != nil {
+		log.Printf("[DEBUG] writing the recorded response for %s %s: %+v", recorded.Method, recorded.URL, err)
+	}
+}
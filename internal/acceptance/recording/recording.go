@@ -0,0 +1,295 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// The Acceptance Tests can either be run against Azure (the default), record the requests made whilst being run
+// against Azure into a cassette stored alongside the tests, or replay the requests from that cassette - which allows
+// the Acceptance Tests to be run without access to (or credentials for) Azure.
+//
+// Since the same recording is used for all of the requests made during a test (including those made by the test
+// client, which is shared between tests, when checking the resource exists/has been destroyed) only a single test is
+// recorded/replayed at once within a process - tests which are run in parallel wait for the test being recorded or
+// replayed to complete. Tests can instead be run in parallel by running each test in a separate process.
+
+const (
+	// EnvMode is the name of the Environment Variable used to specify the Mode
+	EnvMode = "ARM_TEST_RECORDING_MODE"
+
+	// recordingsDirectory is the directory (relative to the package containing the test) where cassettes are stored
+	recordingsDirectory = "testdata/recordings"
+)
+
+type Mode string
+
+const (
+	ModeLive   Mode = ""
+	ModeRecord Mode = "record"
+	ModeReplay Mode = "replay"
+)
+
+// CurrentMode returns the Mode the Acceptance Tests are being run in
+func CurrentMode() Mode {
+	return Mode(os.Getenv(EnvMode))
+}
+
+// Enabled returns whether requests are being either recorded or replayed
+func Enabled() bool {
+	mode := CurrentMode()
+	return mode == ModeRecord || mode == ModeReplay
+}
+
+// Replaying returns whether requests are being replayed from a cassette, rather than sent to Azure
+func Replaying() bool {
+	return CurrentMode() == ModeReplay
+}
+
+// TestValues are the randomly generated values used by a test, which are recorded so that the same values (and thus
+// the same resource names) are used when the test is replayed
+type TestValues struct {
+	RandomInteger int      `json:"randomInteger"`
+	RandomString  string   `json:"randomString"`
+	Locations     []string `json:"locations"`
+
+	// RandomStrings are the random strings generated whilst the test is running (e.g. by RandomStringOfLength), in the
+	// order they were generated
+	RandomStrings []string `json:"randomStrings,omitempty"`
+}
+
+type cassette struct {
+	Values       *TestValues   `json:"values,omitempty"`
+	Interactions []interaction `json:"interactions"`
+}
+
+// Recorder records the requests made during a single test to a cassette, or replays the requests from it
+type Recorder struct {
+	name string
+	path string
+	mode Mode
+	t    *testing.T
+
+	// parent is the Recorder for the test which this (sub)test is running within, if any
+	parent *Recorder
+
+	lock     sync.Mutex
+	cassette cassette
+
+	// randomStrings is the number of random strings which have been requested by the test
+	randomStrings int
+
+	// used tracks which interactions have been replayed, and lastUsed the most recent interaction which was replayed
+	// for each request - which is replayed again once all of the recorded interactions have been used (e.g. polling)
+	used     map[int]struct{}
+	lastUsed map[string]int
+
+	// identity contains the IDs of the principal which the requests were recorded as, which are removed from the
+	// cassette when it's saved
+	identity identity
+}
+
+var (
+	currentLock    sync.Mutex
+	currentChanged = sync.NewCond(&currentLock)
+	current        *Recorder
+)
+
+var testNameSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_\-.]`)
+
+// Start returns the Recorder for the test `t`, creating it if one hasn't been started for this test - when replaying
+// the cassette for the test is loaded, and the test is skipped if no cassette exists. If another test is being
+// recorded/replayed this waits for it to complete, unless `t` is a subtest of it - in which case the subtest is
+// recorded into its own cassette whilst the parent test waits for the subtest to complete.
+func Start(t *testing.T) *Recorder {
+	mode := CurrentMode()
+	if mode != ModeRecord && mode != ModeReplay {
+		t.Fatalf("unsupported value %q for `%s`, expected %q or %q", mode, EnvMode, ModeRecord, ModeReplay)
+		return nil
+	}
+	configureEnvironment(mode)
+
+	currentLock.Lock()
+	defer currentLock.Unlock()
+
+	for current != nil {
+		if current.name == t.Name() {
+			return current
+		}
+		if strings.HasPrefix(t.Name(), current.name+"/") {
+			break
+		}
+		// the test client is shared between tests, so requests can't be attributed to a test when more than one is running
+		currentChanged.Wait()
+	}
+
+	r := &Recorder{
+		name:     t.Name(),
+		path:     filepath.Join(recordingsDirectory, testNameSanitizer.ReplaceAllString(t.Name(), "_")+".json"),
+		mode:     mode,
+		t:        t,
+		parent:   current,
+		used:     make(map[int]struct{}),
+		lastUsed: make(map[string]int),
+	}
+
+	if mode == ModeReplay {
+		if err := r.load(); err != nil {
+			if os.IsNotExist(err) {
+				t.Skipf("skipping since no recording exists for this test at %q", r.path)
+				return nil
+			}
+			t.Fatalf("loading the recording for this test from %q: %+v", r.path, err)
+			return nil
+		}
+		startReplayServer()
+	}
+
+	current = r
+	t.Cleanup(func() {
+		currentLock.Lock()
+		current = r.parent
+		currentChanged.Broadcast()
+		currentLock.Unlock()
+
+		if r.mode == ModeRecord && !t.Skipped() {
+			if err := r.save(); err != nil {
+				t.Errorf("saving the recording for this test to %q: %+v", r.path, err)
+			}
+		}
+	})
+
+	return r
+}
+
+// currentRecorder returns the Recorder for the test which is currently running, if any
+func currentRecorder() *Recorder {
+	currentLock.Lock()
+	defer currentLock.Unlock()
+	return current
+}
+
+// Values returns the randomly generated values which should be used for this test. When recording the `generated`
+// values are stored in the cassette, when replaying the values from the cassette are returned.
+func (r *Recorder) Values(generated TestValues) TestValues {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.cassette.Values == nil {
+		if r.mode == ModeReplay {
+			r.t.Fatalf("the recording at %q doesn't contain the values used by the test", r.path)
+		}
+		r.cassette.Values = &generated
+	}
+
+	return *r.cassette.Values
+}
+
+// RandomString returns the random string which should be used for this test, for random strings which are generated
+// whilst the test is running rather than when it's started. When recording the `generated` value is stored in the
+// cassette, when replaying the recorded values are returned in the order they were generated.
+func (r *Recorder) RandomString(generated string) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.cassette.Values == nil {
+		if r.mode == ModeReplay {
+			r.t.Fatalf("the recording at %q doesn't contain the values used by the test", r.path)
+		}
+		r.cassette.Values = &TestValues{}
+	}
+
+	i := r.randomStrings
+	r.randomStrings++
+
+	if r.mode == ModeRecord {
+		r.cassette.Values.RandomStrings = append(r.cassette.Values.RandomStrings, generated)
+		return generated
+	}
+
+	if i >= len(r.cassette.Values.RandomStrings) || len(r.cassette.Values.RandomStrings[i]) != len(generated) {
+		r.t.Fatalf("the recording at %q doesn't contain random string %d (of length %d) used by the test - the recording may need to be updated", r.path, i, len(generated))
+	}
+	return r.cassette.Values.RandomStrings[i]
+}
+
+func (r *Recorder) load() error {
+	contents, err := os.ReadFile(r.path)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(contents, &r.cassette); err != nil {
+		return fmt.Errorf("parsing: %+v", err)
+	}
+
+	return nil
+}
+
+func (r *Recorder) save() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	sanitizeCassette(&r.cassette, r.identity)
+
+	contents, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing: %+v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("creating directory: %+v", err)
+	}
+
+	log.Printf("[DEBUG] Saving %d interactions for %q to %q", len(r.cassette.Interactions), r.name, r.path)
+	return os.WriteFile(r.path, append(contents, '\n'), 0o644) // nolint: gosec
+}
+
+var configureEnvironmentOnce sync.Once
+
+// configureEnvironment sets the Environment Variables required to record/replay the tests, which when replaying
+// includes placeholder credentials since no requests are sent to Azure.
+func configureEnvironment(mode Mode) {
+	configureEnvironmentOnce.Do(func() {
+		// the supported locations and resource providers are cached per process rather than per test, so the
+		// requests made to populate them wouldn't be recorded consistently
+		variables := map[string]string{
+			"ARM_PROVIDER_ENHANCED_VALIDATION": "false",
+		}
+		if os.Getenv("ARM_SKIP_PROVIDER_REGISTRATION") == "" && os.Getenv("ARM_RESOURCE_PROVIDER_REGISTRATIONS") == "" {
+			variables["ARM_SKIP_PROVIDER_REGISTRATION"] = "true"
+		}
+
+		if mode == ModeReplay {
+			variables["ARM_SUBSCRIPTION_ID"] = placeholderSubscriptionId
+			variables["ARM_TEST_SUBSCRIPTION_ID_ALT"] = placeholderSubscriptionIdAlt
+			variables["ARM_TENANT_ID"] = placeholderTenantId
+			variables["ARM_CLIENT_ID"] = placeholderClientId
+			variables["ARM_CLIENT_SECRET"] = "replayed"
+			variables["ARM_METADATA_HOSTNAME"] = ""
+
+			// the locations used by each test are loaded from the recording
+			for _, v := range []string{"ARM_TEST_LOCATION", "ARM_TEST_LOCATION_ALT", "ARM_TEST_LOCATION_ALT2"} {
+				if os.Getenv(v) == "" {
+					variables[v] = "replayed"
+				}
+			}
+		}
+
+		for k, v := range variables {
+			if err := os.Setenv(k, v); err != nil {
+				log.Printf("[WARN] setting the environment variable %q: %+v", k, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,304 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/claims"
+)
+
+func TestRequestKey(t *testing.T) {
+	testData := []struct {
+		First    string
+		Second   string
+		Expected bool
+	}{
+		{
+			// random suffixes from TestData.RandomInteger (and RandomIntOfLength) are ignored
+			First:    "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/acctestRG-240101123456789012?api-version=2022-09-01",
+			Second:   "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/acctestRG-240202987654321098?api-version=2022-09-01",
+			Expected: true,
+		},
+		{
+			First:    "https://acctestsa2401011234.blob.core.windows.net/container/blob",
+			Second:   "https://acctestsa2402029876.blob.core.windows.net/container/blob",
+			Expected: true,
+		},
+		{
+			// the case of the URL and the order of the query string are ignored
+			First:    "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example?api-version=2022-09-01&$expand=properties",
+			Second:   "https://MANAGEMENT.azure.com/Subscriptions/22222222-2222-2222-2222-222222222222/resourcegroups/Example/?$expand=properties&api-version=2022-09-01",
+			Expected: true,
+		},
+		{
+			// as are the signature and validity period of a SAS
+			First:    "https://example.blob.core.windows.net/container/blob?sv=2023-11-03&se=2024-01-01T00%3A00%3A00Z&sig=abc",
+			Second:   "https://example.blob.core.windows.net/container/blob?sv=2023-11-03&se=2024-02-02T00%3A00%3A00Z&sig=def",
+			Expected: true,
+		},
+		{
+			// and timestamps, such as the time period for metrics
+			First:    "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/providers/Microsoft.Insights/metrics?api-version=2018-01-01&timespan=2024-01-01T00%3A00%3A00Z%2F2024-01-01T01%3A00%3A00.123Z",
+			Second:   "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/providers/Microsoft.Insights/metrics?api-version=2018-01-01&timespan=2024-02-02T10%3A30%3A00%2B01%3A00%2F2024-02-02T11%3A30%3A00%2B01%3A00",
+			Expected: true,
+		},
+		{
+			First:    "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example?api-version=2022-09-01",
+			Second:   "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example?api-version=2023-07-01",
+			Expected: false,
+		},
+		{
+			First:    "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example1",
+			Second:   "https://management.azure.com/subscriptions/11111111-1111-1111-1111-111111111111/resourceGroups/example2",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q and %q", v.First, v.Second)
+
+		actual := requestKey(http.MethodGet, v.First) == requestKey(http.MethodGet, v.Second)
+		if actual != v.Expected {
+			t.Fatalf("expected the requests to match to be %t but got %t (%q and %q)", v.Expected, actual, requestKey(http.MethodGet, v.First), requestKey(http.MethodGet, v.Second))
+		}
+	}
+
+	if requestKey(http.MethodGet, testData[0].First) == requestKey(http.MethodDelete, testData[0].First) {
+		t.Fatalf("expected requests with different methods not to match")
+	}
+}
+
+func TestSanitizeCassette(t *testing.T) {
+	t.Setenv("ARM_SUBSCRIPTION_ID", "12345678-1234-9876-4563-123456789012")
+	t.Setenv("ARM_TEST_SUBSCRIPTION_ID_ALT", "")
+	t.Setenv("ARM_TENANT_ID", "")
+	t.Setenv("ARM_CLIENT_ID", "")
+
+	key := "c2VjcmV0LWtleS12YWx1ZS10aGF0LWlzLWxvbmc="
+	c := cassette{
+		Interactions: []interaction{
+			{
+				Method:     http.MethodPost,
+				URL:        "https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys",
+				StatusCode: http.StatusOK,
+				Headers:    http.Header{"Content-Type": []string{"application/json"}},
+				Body:       fmt.Sprintf(`{"keys":[{"keyName":"key1","permissions":"FULL","value":%q}]}`, key),
+			},
+			{
+				Method:     http.MethodGet,
+				URL:        "https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012/providers/Microsoft.Example/things/example",
+				StatusCode: http.StatusOK,
+				Headers:    http.Header{"Content-Type": []string{"application/json"}},
+				Body:       fmt.Sprintf(`{"properties":{"connectionString":"AccountName=example;AccountKey=%s","principalId":"AAAAAAAA-0000-0000-0000-000000000000"}}`, key),
+			},
+			{
+				Method:     http.MethodGet,
+				URL:        "https://example.blob.core.windows.net/container/blob?sv=2023-11-03&sig=c2lnbmF0dXJl",
+				StatusCode: http.StatusOK,
+				Body:       "hello world",
+			},
+		},
+	}
+
+	sanitizeCassette(&c, identity{ObjectId: "aaaaaaaa-0000-0000-0000-000000000000"})
+
+	for _, v := range c.Interactions {
+		for _, unexpected := range []string{"12345678-1234-9876-4563-123456789012", key, "aaaaaaaa-0000-0000-0000-000000000000", "c2lnbmF0dXJl"} {
+			if strings.Contains(strings.ToLower(v.URL+v.Body), strings.ToLower(unexpected)) {
+				t.Fatalf("expected %q to be removed from %s %s: %s", unexpected, v.Method, v.URL, v.Body)
+			}
+		}
+	}
+
+	if !strings.Contains(c.Interactions[0].URL, placeholderSubscriptionId) {
+		t.Fatalf("expected the Subscription ID to be replaced with the placeholder but got %q", c.Interactions[0].URL)
+	}
+	if !strings.Contains(c.Interactions[1].Body, "AccountKey="+placeholderSecret) {
+		t.Fatalf("expected the key to be replaced within the connection string but got %q", c.Interactions[1].Body)
+	}
+	if !strings.Contains(c.Interactions[1].Body, placeholderObjectId) {
+		t.Fatalf("expected the Object ID to be replaced with the placeholder but got %q", c.Interactions[1].Body)
+	}
+}
+
+func TestRecorderRandomString(t *testing.T) {
+	recorder := &Recorder{
+		name: t.Name(),
+		path: t.Name() + ".json",
+		mode: ModeRecord,
+		t:    t,
+	}
+	recorder.Values(TestValues{RandomString: "abcde"})
+
+	for _, v := range []string{"first", "second"} {
+		if actual := recorder.RandomString(v); actual != v {
+			t.Fatalf("expected the generated value %q to be used when recording but got %q", v, actual)
+		}
+	}
+
+	// the recorded values are returned (in order) when replaying, rather than the newly generated values
+	replayer := &Recorder{
+		name:     t.Name(),
+		path:     t.Name() + ".json",
+		mode:     ModeReplay,
+		t:        t,
+		cassette: recorder.cassette,
+	}
+	for _, v := range []struct {
+		Generated string
+		Expected  string
+	}{
+		{
+			Generated: "aaaaa",
+			Expected:  "first",
+		},
+		{
+			Generated: "bbbbbb",
+			Expected:  "second",
+		},
+	} {
+		if actual := replayer.RandomString(v.Generated); actual != v.Expected {
+			t.Fatalf("expected the recorded value %q to be used when replaying but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestReplayAuthorizer(t *testing.T) {
+	token, err := replayAuthorizer{}.Token(context.Background(), &http.Request{})
+	if err != nil {
+		t.Fatalf("obtaining token: %+v", err)
+	}
+
+	tokenClaims, err := claims.ParseClaims(token)
+	if err != nil {
+		t.Fatalf("parsing claims: %+v", err)
+	}
+
+	if tokenClaims.ObjectId != placeholderObjectId || tokenClaims.TenantId != placeholderTenantId || tokenClaims.AppId != placeholderClientId {
+		t.Fatalf("expected the claims to contain the placeholder IDs but got %+v", *tokenClaims)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Setenv("ARM_SUBSCRIPTION_ID", "")
+	t.Setenv("ARM_TEST_SUBSCRIPTION_ID_ALT", "")
+	t.Setenv("ARM_TENANT_ID", "")
+	t.Setenv("ARM_CLIENT_ID", "")
+
+	requests := 0
+	azure := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Azure-AsyncOperation", "https://management.azure.com/operations/1?api-version=2022-09-01")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"name":%q}`, strings.TrimPrefix(r.URL.Path, "/resourceGroups/"))
+	}))
+	defer azure.Close()
+
+	// record a request to the "live" API
+	t.Setenv(EnvMode, string(ModeRecord))
+	recorder := useTestRecorder(t, ModeRecord, cassette{})
+	response := sendTestRequest(t, azure.URL+"/resourceGroups/acctestRG-240101123456789012?api-version=2022-09-01")
+	if response.body != `{"name":"acctestRG-240101123456789012"}` {
+		t.Fatalf("expected the response body to be unchanged when recording but got %q", response.body)
+	}
+	if len(recorder.cassette.Interactions) != 1 {
+		t.Fatalf("expected 1 interaction to be recorded but got %d", len(recorder.cassette.Interactions))
+	}
+
+	// then replay it, with a different random suffix
+	t.Setenv(EnvMode, string(ModeReplay))
+	useTestRecorder(t, ModeReplay, recorder.cassette)
+	startReplayServer()
+
+	response = sendTestRequest(t, azure.URL+"/resourceGroups/acctestRG-240202987654321098?api-version=2022-09-01")
+	if requests != 1 {
+		t.Fatalf("expected the request to be replayed rather than sent but got %d requests", requests)
+	}
+	if response.body != `{"name":"acctestRG-240101123456789012"}` {
+		t.Fatalf("expected the recorded response body but got %q", response.body)
+	}
+	if response.statusCode != http.StatusCreated {
+		t.Fatalf("expected the recorded status code %d but got %d", http.StatusCreated, response.statusCode)
+	}
+	if v := response.header.Get("Retry-After"); v != "0" {
+		t.Fatalf("expected a Retry-After of 0 when replaying but got %q", v)
+	}
+	if response.requestUrl != "https://"+strings.TrimPrefix(azure.URL, "http://")+"/resourceGroups/acctestRG-240202987654321098?api-version=2022-09-01" {
+		t.Fatalf("expected the response to reference the original request URL but got %q", response.requestUrl)
+	}
+}
+
+func useTestRecorder(t *testing.T, mode Mode, c cassette) *Recorder {
+	r := &Recorder{
+		name:     t.Name(),
+		path:     t.Name() + ".json",
+		mode:     mode,
+		t:        t,
+		cassette: c,
+		used:     make(map[int]struct{}),
+		lastUsed: make(map[string]int),
+	}
+
+	currentLock.Lock()
+	current = r
+	currentLock.Unlock()
+	t.Cleanup(func() {
+		currentLock.Lock()
+		current = nil
+		currentLock.Unlock()
+	})
+
+	return r
+}
+
+type testResponse struct {
+	statusCode int
+	header     http.Header
+	body       string
+	requestUrl string
+}
+
+func sendTestRequest(t *testing.T, url string) testResponse {
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodPut, url, nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+
+	m := Middleware()
+	request, err = m.RequestMiddleware()(request)
+	if err != nil {
+		t.Fatalf("request middleware: %+v", err)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	defer response.Body.Close()
+
+	response, err = m.ResponseMiddleware()(request, response)
+	if err != nil {
+		t.Fatalf("response middleware: %+v", err)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("reading body: %+v", err)
+	}
+
+	return testResponse{
+		statusCode: response.StatusCode,
+		header:     response.Header,
+		body:       string(body),
+		requestUrl: response.Request.URL.String(),
+	}
+}
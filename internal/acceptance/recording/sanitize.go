@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package recording
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// the IDs of the Subscriptions and principal used to record the tests are replaced with these placeholders in the
+// cassette, which are also used when the tests are replayed
+const (
+	placeholderSubscriptionId    = "11111111-1111-1111-1111-111111111111"
+	placeholderSubscriptionIdAlt = "22222222-2222-2222-2222-222222222222"
+	placeholderTenantId          = "33333333-3333-3333-3333-333333333333"
+	placeholderClientId          = "44444444-4444-4444-4444-444444444444"
+	placeholderObjectId          = "55555555-5555-5555-5555-555555555555"
+
+	// placeholderSecret replaces secrets (such as Storage Account Keys) in the cassette - this is valid base64 since
+	// the keys are decoded when signing requests
+	placeholderSecret = "cmVkYWN0ZWQ="
+
+	bodyEncodingBase64 = "base64"
+)
+
+// interaction is a single request and the response which was returned for it
+type interaction struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+
+	StatusCode   int         `json:"statusCode"`
+	Headers      http.Header `json:"headers,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"bodyEncoding,omitempty"`
+}
+
+func newInteraction(request *http.Request, response *http.Response, body []byte) interaction {
+	output := interaction{
+		Method:     request.Method,
+		URL:        request.URL.String(),
+		StatusCode: response.StatusCode,
+		Headers:    make(http.Header),
+	}
+
+	for k, v := range response.Header {
+		if _, ok := ignoredResponseHeaders[strings.ToLower(k)]; ok {
+			continue
+		}
+		output.Headers[k] = append([]string{}, v...)
+	}
+
+	if utf8.Valid(body) {
+		output.Body = string(body)
+	} else {
+		output.Body = base64.StdEncoding.EncodeToString(body)
+		output.BodyEncoding = bodyEncodingBase64
+	}
+
+	return output
+}
+
+func (i interaction) body() ([]byte, error) {
+	if i.BodyEncoding == bodyEncodingBase64 {
+		return base64.StdEncoding.DecodeString(i.Body)
+	}
+	return []byte(i.Body), nil
+}
+
+// ignoredResponseHeaders are the (lower-cased) names of response headers which aren't recorded
+var ignoredResponseHeaders = map[string]struct{}{
+	"content-length": {},
+	"date":           {},
+	"set-cookie":     {},
+}
+
+// identity is the principal (and tenant) which the requests were made as
+type identity struct {
+	ClientId string
+	ObjectId string
+	TenantId string
+}
+
+// secretFieldPattern matches the names of fields within a response body whose values are secrets, such as the keys
+// for a Storage Account - which are replaced throughout the cassette (including within connection strings)
+var secretFieldPattern = regexp.MustCompile(`(?i)^((primary|secondary)(readonly)?(master)?key|accountkey|accesskey|sharedaccesskey|sharedkey)$`)
+
+// sanitizeCassette removes the IDs of the Subscriptions and principal used to record the tests, the signatures of any
+// Shared Access Signatures and any secrets returned from the API from the cassette
+func sanitizeCassette(c *cassette, recordedAs identity) {
+	replacements := make(map[string]string)
+	addReplacement := func(value, placeholder string) {
+		if value != "" && !strings.EqualFold(value, placeholder) {
+			replacements[value] = placeholder
+		}
+	}
+	addReplacement(os.Getenv("ARM_SUBSCRIPTION_ID"), placeholderSubscriptionId)
+	addReplacement(os.Getenv("ARM_TEST_SUBSCRIPTION_ID_ALT"), placeholderSubscriptionIdAlt)
+	addReplacement(os.Getenv("ARM_TENANT_ID"), placeholderTenantId)
+	addReplacement(os.Getenv("ARM_CLIENT_ID"), placeholderClientId)
+	addReplacement(recordedAs.TenantId, placeholderTenantId)
+	addReplacement(recordedAs.ClientId, placeholderClientId)
+	addReplacement(recordedAs.ObjectId, placeholderObjectId)
+
+	for _, v := range c.Interactions {
+		if v.BodyEncoding != "" || !strings.Contains(strings.ToLower(v.Headers.Get("Content-Type")), "json") {
+			continue
+		}
+		var body interface{}
+		if err := json.Unmarshal([]byte(v.Body), &body); err == nil {
+			for _, secret := range findSecrets(body, nil) {
+				// short values are unlikely to be secrets, and would result in unrelated values being replaced
+				if len(secret) >= 16 {
+					addReplacement(secret, placeholderSecret)
+				}
+			}
+		}
+	}
+
+	// replace the longest values first, in case one value contains another
+	values := make([]string, 0, len(replacements))
+	for k := range replacements {
+		values = append(values, k)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	patterns := make([]*regexp.Regexp, 0, len(values))
+	for _, v := range values {
+		patterns = append(patterns, regexp.MustCompile(`(?i)`+regexp.QuoteMeta(v)))
+	}
+	replace := func(input string) string {
+		for i, pattern := range patterns {
+			input = pattern.ReplaceAllLiteralString(input, replacements[values[i]])
+		}
+		return input
+	}
+
+	for i, v := range c.Interactions {
+		v.URL = replace(redactSignature(v.URL))
+		for k, headerValues := range v.Headers {
+			for j, headerValue := range headerValues {
+				headerValues[j] = replace(redactSignature(headerValue))
+			}
+			v.Headers[k] = headerValues
+		}
+		if v.BodyEncoding == "" {
+			v.Body = replace(v.Body)
+		}
+		c.Interactions[i] = v
+	}
+}
+
+// findSecrets returns the values of the fields within the (JSON) body which are secrets
+func findSecrets(input interface{}, secrets []string) []string {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		_, isKey := v["keyName"]
+		for key, val := range v {
+			if s, ok := val.(string); ok {
+				if secretFieldPattern.MatchString(key) || (isKey && strings.EqualFold(key, "value")) {
+					secrets = append(secrets, s)
+				}
+				continue
+			}
+			secrets = findSecrets(val, secrets)
+		}
+
+	case []interface{}:
+		for _, val := range v {
+			secrets = findSecrets(val, secrets)
+		}
+	}
+
+	return secrets
+}
+
+var signaturePattern = regexp.MustCompile(`([?&]sig=)[^&"\s]+`)
+
+// redactSignature removes the signature from any Shared Access Signatures in the input
+func redactSignature(input string) string {
+	return signaturePattern.ReplaceAllString(input, "${1}REDACTED")
+}
+
+var (
+	uuidPattern      = regexp.MustCompile(`(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	timestampPattern = regexp.MustCompile(`(?i)[0-9]{4}-[0-9]{2}-[0-9]{2}t[0-9]{2}:[0-9]{2}(:[0-9]{2}(\.[0-9]+)?)?(z|[+-][0-9]{2}:[0-9]{2})?`)
+	randomPattern    = regexp.MustCompile(`[0-9]{8,}`)
+)
+
+// ignoredQueryParameters are the (lower-cased) names of query string parameters which are ignored when matching a
+// request, since they vary between runs - such as the signature and validity period of a Shared Access Signature
+var ignoredQueryParameters = map[string]struct{}{
+	"se":  {},
+	"sig": {},
+	"skt": {},
+	"ske": {},
+	"st":  {},
+}
+
+// requestKey returns the key used to match a request to a recorded interaction, which ignores the case of the URL,
+// the order of the query string parameters and any values which vary between runs - UUIDs, timestamps (for example
+// the time period for metrics, or a value from `timestamp()`) and numbers of 8 or more digits (for example from
+// `acceptance.RandTimeInt`).
+//
+// The random values within `acceptance.TestData` are recorded in the cassette, so the same values are used when replaying - however
+// other random values (such as those from the `random` provider, or `acceptance.RandString`) can't be matched, and so tests using
+// them can't be replayed.
+func requestKey(method string, input string) string {
+	u, err := url.Parse(input)
+	if err != nil {
+		return strings.ToUpper(method) + " " + normalizeRequestValue(input)
+	}
+
+	query := u.Query()
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if _, ok := ignoredQueryParameters[strings.ToLower(k)]; ok {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parameters := make([]string, 0, len(keys))
+	for _, k := range keys {
+		values := query[k]
+		sort.Strings(values)
+		parameters = append(parameters, k+"="+strings.Join(values, ","))
+	}
+
+	key := strings.ToUpper(method) + " " + u.Host + strings.TrimSuffix(u.Path, "/")
+	if len(parameters) > 0 {
+		key += "?" + strings.Join(parameters, "&")
+	}
+
+	return normalizeRequestValue(key)
+}
+
+func normalizeRequestValue(input string) string {
+	output := strings.ToLower(input)
+	output = uuidPattern.ReplaceAllString(output, "{uuid}")
+	output = timestampPattern.ReplaceAllString(output, "{timestamp}")
+	output = randomPattern.ReplaceAllString(output, "{random}")
+	return output
+}
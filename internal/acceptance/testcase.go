@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/helpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/types"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
@@ -170,6 +171,13 @@ func (td TestData) runAcceptanceTest(t *testing.T, testCase resource.TestCase) {
 	testCase.ExternalProviders = td.externalProviders()
 	testCase.ProviderFactories = td.providers()
 
+	// only a single test is recorded/replayed at once within a process (see `recording.Start`), and since the recording
+	// has already been started the test can't wait for others to complete by calling `t.Parallel()`
+	if recording.Enabled() {
+		resource.Test(t, testCase)
+		return
+	}
+
 	resource.ParallelTest(t, testCase)
 }
 
@@ -183,11 +191,11 @@ func (td TestData) runAcceptanceSequentialTest(t *testing.T, testCase resource.T
 func (td TestData) providers() map[string]func() (*schema.Provider, error) {
 	return map[string]func() (*schema.Provider, error){
 		"azurerm": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
+			azurerm := provider.TestAzureProviderWithClientBuilder(recording.ConfigureClientBuilder)
 			return azurerm, nil
 		},
 		"azurerm-alt": func() (*schema.Provider, error) { //nolint:unparam
-			azurerm := provider.TestAzureProvider()
+			azurerm := provider.TestAzureProviderWithClientBuilder(recording.ConfigureClientBuilder)
 			return azurerm, nil
 		},
 	}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/hashicorp/go-azure-sdk/sdk/auth"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/recording"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
@@ -71,6 +72,7 @@ func Build() (*clients.Client, error) {
 			StorageUseAzureAD: false,
 			SubscriptionID:    os.Getenv("ARM_SUBSCRIPTION_ID"),
 		}
+		recording.ConfigureClientBuilder(&clientBuilder)
 
 		client, err := clients.Build(ctx, clientBuilder)
 		if err != nil {
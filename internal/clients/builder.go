@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 )
 
@@ -26,12 +27,24 @@ type ClientBuilder struct {
 	CustomCorrelationRequestID  string
 	DisableCorrelationRequestID bool
 	DisableTerraformPartnerID   bool
+	HTTPTrace                   common.HTTPTraceOptions
+	LockBackend                 locks.BackendOptions
 	MetadataHost                string
 	PartnerID                   string
 	RegisteredResourceProviders resourceproviders.ResourceProviders
+	RequestThrottling           common.RequestThrottlingOptions
+	RetryPolicy                 common.RetryPolicyOptions
 	StorageUseAzureAD           bool
 	SubscriptionID              string
 	TerraformVersion            string
+
+	// AdditionalMiddleware is a list of HTTP middleware which (in addition to the default middleware) is configured
+	// for every client, for example to record and replay the requests made during the acceptance tests
+	AdditionalMiddleware []common.HTTPMiddleware
+
+	// Authorizer (when set) is used to authenticate requests to every API, rather than building authorizers from the
+	// AuthConfig - for example when the requests are being replayed and no credentials are available
+	Authorizer auth.Authorizer
 }
 
 const azureStackEnvironmentError = `
@@ -51,25 +64,32 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf(azureStackEnvironmentError)
 	}
 
+	newAuthorizer := func(api environments.Api) (auth.Authorizer, error) {
+		if builder.Authorizer != nil {
+			return builder.Authorizer, nil
+		}
+		return auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+	}
+
 	var resourceManagerAuth, storageAuth, synapseAuth, batchManagementAuth, keyVaultAuth auth.Authorizer
 
-	resourceManagerAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ResourceManager)
+	resourceManagerAuth, err = newAuthorizer(builder.AuthConfig.Environment.ResourceManager)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Resource Manager API: %+v", err)
 	}
 
-	storageAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Storage)
+	storageAuth, err = newAuthorizer(builder.AuthConfig.Environment.Storage)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Storage API: %+v", err)
 	}
 
-	keyVaultAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.KeyVault)
+	keyVaultAuth, err = newAuthorizer(builder.AuthConfig.Environment.KeyVault)
 	if err != nil {
 		return nil, fmt.Errorf("unable to build authorizer for Key Vault API: %+v", err)
 	}
 
 	if builder.AuthConfig.Environment.Synapse.Available() {
-		synapseAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Synapse)
+		synapseAuth, err = newAuthorizer(builder.AuthConfig.Environment.Synapse)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Synapse API: %+v", err)
 		}
@@ -78,7 +98,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 	}
 
 	if builder.AuthConfig.Environment.Batch.Available() {
-		batchManagementAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.Batch)
+		batchManagementAuth, err = newAuthorizer(builder.AuthConfig.Environment.Batch)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Batch Management API: %+v", err)
 		}
@@ -88,7 +108,7 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 
 	// Helper for obtaining endpoint-specific tokens
 	authorizerFunc := common.ApiAuthorizerFunc(func(api environments.Api) (auth.Authorizer, error) {
-		authorizer, err := auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, api)
+		authorizer, err := newAuthorizer(api)
 		if err != nil {
 			return nil, fmt.Errorf("building custom authorizer for API %q: %+v", api.Name(), err)
 		}
@@ -96,14 +116,19 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return authorizer, nil
 	})
 
-	account, err := NewResourceManagerAccount(ctx, *builder.AuthConfig, builder.SubscriptionID, builder.RegisteredResourceProviders)
+	graphAuth, err := newAuthorizer(builder.AuthConfig.Environment.MicrosoftGraph)
+	if err != nil {
+		return nil, fmt.Errorf("unable to build authorizer for Microsoft Graph API: %+v", err)
+	}
+
+	account, err := newResourceManagerAccountFromAuthorizer(ctx, *builder.AuthConfig, graphAuth, builder.SubscriptionID, builder.RegisteredResourceProviders)
 	if err != nil {
 		return nil, fmt.Errorf("building account: %+v", err)
 	}
 
 	var managedHSMAuth auth.Authorizer
 	if builder.AuthConfig.Environment.ManagedHSM.Available() {
-		managedHSMAuth, err = auth.NewAuthorizerFromCredentials(ctx, *builder.AuthConfig, builder.AuthConfig.Environment.ManagedHSM)
+		managedHSMAuth, err = newAuthorizer(builder.AuthConfig.Environment.ManagedHSM)
 		if err != nil {
 			return nil, fmt.Errorf("unable to build authorizer for Managed HSM API: %+v", err)
 		}
@@ -116,8 +141,19 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		return nil, fmt.Errorf("unable to determine resource manager endpoint for the current environment")
 	}
 
+	httpTracer, err := common.NewHTTPTracer(builder.HTTPTrace)
+	if err != nil {
+		return nil, fmt.Errorf("configuring HTTP tracing: %+v", err)
+	}
+
+	lockBackend, err := locks.NewBackend(builder.LockBackend, storageAuth)
+	if err != nil {
+		return nil, fmt.Errorf("configuring the lock backend: %+v", err)
+	}
+
 	client := Client{
-		Account: account,
+		Account:     account,
+		LockBackend: lockBackend,
 	}
 
 	o := &common.ClientOptions{
@@ -153,6 +189,12 @@ func Build(ctx context.Context, builder ClientBuilder) (*Client, error) {
 		StorageUseAzureAD:           builder.StorageUseAzureAD,
 
 		ResourceManagerEndpoint: *resourceManagerEndpoint,
+
+		RequestThrottling: builder.RequestThrottling,
+		HTTPTracer:        httpTracer,
+		RetryPolicy:       common.NewRetryPolicy(builder.RetryPolicy),
+
+		AdditionalMiddleware: builder.AdditionalMiddleware,
 	}
 
 	if err := client.Build(ctx, o); err != nil {
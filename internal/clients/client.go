@@ -26,6 +26,7 @@ import (
 	workloads_v2023_04_01 "github.com/hashicorp/go-azure-sdk/resource-manager/workloads/2023-04-01"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	aadb2c "github.com/hashicorp/terraform-provider-azurerm/internal/services/aadb2c/client"
 	advisor "github.com/hashicorp/terraform-provider-azurerm/internal/services/advisor/client"
 	analysisServices "github.com/hashicorp/terraform-provider-azurerm/internal/services/analysisservices/client"
@@ -144,6 +145,7 @@ import (
 	voiceServices "github.com/hashicorp/terraform-provider-azurerm/internal/services/voiceservices/client"
 	web "github.com/hashicorp/terraform-provider-azurerm/internal/services/web/client"
 	workloads "github.com/hashicorp/terraform-provider-azurerm/internal/services/workloads/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 )
 
 type Client struct {
@@ -155,6 +157,17 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// LockBackend is the Backend used to coordinate locks with other processes, which is nil when locks are only
+	// coordinated within this process - see locks.WithBackend
+	LockBackend locks.Backend
+
+	// ProviderTags is the Tags configuration specified in the Provider block (`default_tags` and `ignore_tags`)
+	ProviderTags tags.ProviderConfiguration
+
+	// ResourceProviderRegistrationWarnings is configured by the Provider block, and determines whether a warning is
+	// surfaced during the plan for resources which require a Resource Provider that isn't registered
+	ResourceProviderRegistrationWarnings bool
+
 	AadB2c                            *aadb2c_v2021_04_01_preview.Client
 	Advisor                           *advisor.Client
 	AnalysisServices                  *analysisservices_v2017_08_01.Client
@@ -278,6 +291,11 @@ type Client struct {
 
 // NOTE: it should be possible for this method to become Private once the top level Client's removed
 
+// ProviderTagsConfiguration returns the Tags configuration specified in the Provider block
+func (client *Client) ProviderTagsConfiguration() tags.ProviderConfiguration {
+	return client.ProviderTags
+}
+
 func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error {
 	autorest.Count429AsRetry = false
 	// Disable the Azure SDK for Go's validation since it's unhelpful for our use-case
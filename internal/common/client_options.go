@@ -31,6 +31,15 @@ type Authorizers struct {
 
 type ApiAuthorizerFunc func(api environments.Api) (auth.Authorizer, error)
 
+// HTTPMiddleware is implemented by middleware which can be configured for both the go-azure-sdk and go-autorest clients
+type HTTPMiddleware interface {
+	RequestMiddleware() client.RequestMiddleware
+	ResponseMiddleware() client.ResponseMiddleware
+
+	PrepareDecorator() autorest.PrepareDecorator
+	RespondDecorator() autorest.RespondDecorator
+}
+
 type ClientOptions struct {
 	Authorizers *Authorizers
 	AuthConfig  *auth.Credentials
@@ -50,6 +59,19 @@ type ClientOptions struct {
 
 	ResourceManagerEndpoint string
 
+	RequestThrottling RequestThrottlingOptions
+
+	// HTTPTracer (when set) writes a trace of every request to a file
+	HTTPTracer *HTTPTracer
+
+	// RetryPolicy (when set) resends requests which failed with a transient error - this is only supported for the
+	// go-azure-sdk clients, since the go-autorest clients are being removed
+	RetryPolicy *RetryPolicy
+
+	// AdditionalMiddleware is configured after all of the other middleware, such that it can change where the
+	// request is sent without affecting the logs and traces of the request
+	AdditionalMiddleware []HTTPMiddleware
+
 	// Legacy authorizers for go-autorest
 	BatchManagementAuthorizer autorest.Authorizer
 	KeyVaultAuthorizer        autorest.Authorizer
@@ -74,8 +96,32 @@ func (o ClientOptions) Configure(c client.BaseClient, authorizer auth.Authorizer
 		c.AppendRequestMiddleware(correlationRequestIDMiddleware(id))
 	}
 
+	if o.RequestThrottling.Enabled {
+		throttler := newRequestThrottler(o.RequestThrottling, o.ResourceManagerEndpoint)
+		c.AppendRequestMiddleware(throttler.requestMiddleware())
+		c.AppendResponseMiddleware(throttler.responseMiddleware())
+	}
+
+	if o.HTTPTracer != nil {
+		c.AppendRequestMiddleware(o.HTTPTracer.requestMiddleware())
+		c.AppendResponseMiddleware(o.HTTPTracer.responseMiddleware())
+	}
+
 	c.AppendRequestMiddleware(requestLoggerMiddleware("AzureRM"))
 	c.AppendResponseMiddleware(responseLoggerMiddleware("AzureRM"))
+
+	for _, m := range o.AdditionalMiddleware {
+		c.AppendRequestMiddleware(m.RequestMiddleware())
+		c.AppendResponseMiddleware(m.ResponseMiddleware())
+	}
+
+	// the request body is buffered once the request has been finalised, so that it can be resent as-is - and requests
+	// are retried after every other response middleware, since each attempt is resent using this client (and as such
+	// passes through each middleware itself)
+	if o.RetryPolicy != nil {
+		c.AppendRequestMiddleware(o.RetryPolicy.requestMiddleware())
+		c.AppendResponseMiddleware(o.RetryPolicy.responseMiddleware(c))
+	}
 }
 
 // ConfigureClient sets up an autorest.Client using an autorest.Authorizer
@@ -85,12 +131,42 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 	c.Authorizer = authorizer
 	c.Sender = sender.BuildSender("AzureRM")
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
+
+	requestInspectors := make([]autorest.PrepareDecorator, 0)
+	responseInspectors := make([]autorest.RespondDecorator, 0)
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
 		if id == "" {
 			id = correlationRequestID()
 		}
-		c.RequestInspector = withCorrelationRequestID(id)
+		requestInspectors = append(requestInspectors, withCorrelationRequestID(id))
+	}
+
+	if o.RequestThrottling.Enabled {
+		throttler := newRequestThrottler(o.RequestThrottling, o.ResourceManagerEndpoint)
+		requestInspectors = append(requestInspectors, throttler.prepareDecorator())
+		responseInspectors = append(responseInspectors, throttler.respondDecorator())
+	}
+
+	if o.HTTPTracer != nil {
+		requestInspectors = append(requestInspectors, o.HTTPTracer.prepareDecorator())
+		responseInspectors = append(responseInspectors, o.HTTPTracer.respondDecorator())
+	}
+
+	for _, m := range o.AdditionalMiddleware {
+		requestInspectors = append(requestInspectors, m.PrepareDecorator())
+		responseInspectors = append(responseInspectors, m.RespondDecorator())
+	}
+
+	if len(requestInspectors) > 0 {
+		c.RequestInspector = func(p autorest.Preparer) autorest.Preparer {
+			return autorest.DecoratePreparer(p, requestInspectors...)
+		}
+	}
+	if len(responseInspectors) > 0 {
+		c.ResponseInspector = func(r autorest.Responder) autorest.Responder {
+			return autorest.DecorateResponder(r, responseInspectors...)
+		}
 	}
 }
 
@@ -0,0 +1,310 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// The HTTP Tracer writes a structured record of every request sent to Resource Manager and the Data Plane APIs
+// (including the method, URL, status, latency, correlation ID and the redacted headers/body) to a file - either
+// as a HTTP Archive (HAR) or as OpenTelemetry (OTLP JSON) spans - which, unlike the request/response logging
+// middleware, can be loaded into existing tooling when investigating an issue.
+
+const (
+	HTTPTraceFormatHAR  = "har"
+	HTTPTraceFormatOTLP = "otlp"
+
+	// httpTraceMaxBodySize is the maximum size of a request/response body which is included in the trace
+	httpTraceMaxBodySize = 64 * 1024
+
+	headerServiceRequestID = "x-ms-request-id"
+)
+
+type HTTPTraceOptions struct {
+	// Path is the path to the file which the trace should be written to, when empty tracing is disabled
+	Path string
+
+	// Format is the format which the trace should be written in, either `har` or `otlp`
+	Format string
+}
+
+// HTTPTracer writes a trace of every HTTP request to a file, using either the HAR or OTLP format
+type HTTPTracer struct {
+	lock   sync.Mutex
+	file   *os.File
+	format string
+
+	// harOffset is the offset at which the next HAR entry should be written, which overwrites the closing brackets
+	// of the document - ensuring that the file is valid JSON after every entry
+	harOffset  int64
+	harEntries bool
+
+	// otlpTraceId is the Trace ID used for spans whose request doesn't contain a Correlation Request ID
+	otlpTraceId string
+}
+
+var (
+	httpTracersLock sync.Mutex
+	httpTracers     = make(map[string]*HTTPTracer)
+)
+
+// NewHTTPTracer returns a HTTPTracer which writes to the file specified in `options`, which is shared between all of
+// the clients (and all instances of the Provider within this process) tracing to the same file.
+func NewHTTPTracer(options HTTPTraceOptions) (*HTTPTracer, error) {
+	if options.Path == "" {
+		return nil, nil
+	}
+
+	format := options.Format
+	if format == "" {
+		format = HTTPTraceFormatHAR
+	}
+	if format != HTTPTraceFormatHAR && format != HTTPTraceFormatOTLP {
+		return nil, fmt.Errorf("unsupported HTTP trace format %q, expected %q or %q", format, HTTPTraceFormatHAR, HTTPTraceFormatOTLP)
+	}
+
+	path, err := filepath.Abs(options.Path)
+	if err != nil {
+		return nil, fmt.Errorf("determining the absolute path for the HTTP trace file %q: %+v", options.Path, err)
+	}
+
+	httpTracersLock.Lock()
+	defer httpTracersLock.Unlock()
+
+	if tracer, ok := httpTracers[path]; ok {
+		if tracer.format != format {
+			return nil, fmt.Errorf("the HTTP trace file %q is already being written using the %q format", path, tracer.format)
+		}
+		return tracer, nil
+	}
+
+	tracer := &HTTPTracer{
+		format: format,
+	}
+	switch format {
+	case HTTPTraceFormatHAR:
+		err = tracer.openHAR(path)
+	case HTTPTraceFormatOTLP:
+		err = tracer.openOTLP(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening the HTTP trace file %q: %+v", path, err)
+	}
+
+	log.Printf("[DEBUG] Writing a HTTP trace in the %q format to %q", format, path)
+	httpTracers[path] = tracer
+	return tracer, nil
+}
+
+type httpTraceContextKey struct{}
+
+type httpTraceRequest struct {
+	started       time.Time
+	body          []byte
+	bodyTruncated bool
+}
+
+type httpTraceEntry struct {
+	started  time.Time
+	duration time.Duration
+
+	method string
+	url    string
+
+	requestHeaders  http.Header
+	requestBody     httpTraceBody
+	responseHeaders http.Header
+	responseBody    httpTraceBody
+
+	statusCode int
+	statusText string
+
+	correlationRequestId string
+	serviceRequestId     string
+}
+
+type httpTraceBody struct {
+	mimeType string
+	size     int64
+	text     string
+}
+
+// requestMiddleware returns a RequestMiddleware which records the start time and body of the request
+func (t *HTTPTracer) requestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		return t.startRequest(request), nil
+	}
+}
+
+// responseMiddleware returns a ResponseMiddleware which writes the request and response to the trace
+func (t *HTTPTracer) responseMiddleware() client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		t.finishRequest(request, response)
+		return response, nil
+	}
+}
+
+// prepareDecorator returns the go-autorest equivalent of requestMiddleware
+func (t *HTTPTracer) prepareDecorator() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			return t.startRequest(r), nil
+		})
+	}
+}
+
+// respondDecorator returns the go-autorest equivalent of responseMiddleware
+func (t *HTTPTracer) respondDecorator() autorest.RespondDecorator {
+	return func(r autorest.Responder) autorest.Responder {
+		return autorest.ResponderFunc(func(resp *http.Response) error {
+			if resp != nil && resp.Request != nil {
+				t.finishRequest(resp.Request, resp)
+			}
+			return r.Respond(resp)
+		})
+	}
+}
+
+func (t *HTTPTracer) startRequest(request *http.Request) *http.Request {
+	traceRequest := httpTraceRequest{
+		started: time.Now(),
+	}
+
+	if request.Body != nil && request.Body != http.NoBody {
+		var body io.ReadCloser
+		traceRequest.body, traceRequest.bodyTruncated, body = captureHTTPTraceBody(request.Body)
+		request.Body = body
+	}
+
+	return request.WithContext(context.WithValue(request.Context(), httpTraceContextKey{}, traceRequest))
+}
+
+func (t *HTTPTracer) finishRequest(request *http.Request, response *http.Response) {
+	if request == nil || response == nil {
+		return
+	}
+
+	traceRequest, ok := request.Context().Value(httpTraceContextKey{}).(httpTraceRequest)
+	if !ok {
+		return
+	}
+
+	entry := httpTraceEntry{
+		started:  traceRequest.started,
+		duration: time.Since(traceRequest.started),
+
+		method: request.Method,
+		url:    redactHTTPTraceURL(request.URL),
+
+		requestHeaders:  redactHTTPTraceHeaders(request.Header),
+		responseHeaders: redactHTTPTraceHeaders(response.Header),
+
+		statusCode: response.StatusCode,
+		statusText: http.StatusText(response.StatusCode),
+
+		correlationRequestId: request.Header.Get(HeaderCorrelationRequestID),
+		serviceRequestId:     response.Header.Get(headerServiceRequestID),
+	}
+
+	entry.requestBody = newHTTPTraceBody(request.Header.Get("Content-Type"), request.ContentLength, traceRequest.body, traceRequest.bodyTruncated)
+	if isHTTPTraceDataPlaneContent(request.URL, request.Header) {
+		entry.requestBody.text = omitHTTPTraceDataPlaneContent(traceRequest.body)
+	}
+
+	if response.Body != nil && response.Body != http.NoBody {
+		captured, truncated, body := captureHTTPTraceBody(response.Body)
+		response.Body = body
+		entry.responseBody = newHTTPTraceBody(response.Header.Get("Content-Type"), response.ContentLength, captured, truncated)
+		if response.StatusCode < 300 && isHTTPTraceDataPlaneContent(nil, response.Header) {
+			entry.responseBody.text = omitHTTPTraceDataPlaneContent(captured)
+		}
+	}
+
+	var err error
+	switch t.format {
+	case HTTPTraceFormatHAR:
+		err = t.writeHAREntry(entry)
+	case HTTPTraceFormatOTLP:
+		err = t.writeOTLPSpan(entry)
+	}
+	if err != nil {
+		log.Printf("[WARN] writing %s request to %s to the HTTP trace: %+v", entry.method, entry.url, err)
+	}
+}
+
+// captureHTTPTraceBody reads up to httpTraceMaxBodySize bytes of the body, returning the bytes read, whether the body
+// has been truncated and a replacement body which returns the complete body (without buffering the remainder in memory)
+func captureHTTPTraceBody(body io.ReadCloser) ([]byte, bool, io.ReadCloser) {
+	captured, err := io.ReadAll(io.LimitReader(body, httpTraceMaxBodySize+1))
+	truncated := len(captured) > httpTraceMaxBodySize
+
+	replacement := struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(captured), body),
+		Closer: body,
+	}
+	if err != nil {
+		// surface the error when the body is read by the client
+		replacement.Reader = io.MultiReader(bytes.NewReader(captured), errReader{err: err})
+	}
+
+	if truncated {
+		captured = captured[:httpTraceMaxBodySize]
+	}
+
+	return captured, truncated, replacement
+}
+
+type errReader struct {
+	err error
+}
+
+func (e errReader) Read([]byte) (int, error) {
+	return 0, e.err
+}
+
+func omitHTTPTraceDataPlaneContent(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("(%d bytes of Storage Data Plane content omitted)", len(body))
+}
+
+func newHTTPTraceBody(contentType string, contentLength int64, body []byte, truncated bool) httpTraceBody {
+	output := httpTraceBody{
+		mimeType: contentType,
+		size:     contentLength,
+	}
+	if output.size < 0 && !truncated {
+		output.size = int64(len(body))
+	}
+
+	if len(body) > 0 {
+		output.text = redactHTTPTraceBody(contentType, body)
+		if truncated {
+			output.text = fmt.Sprintf("%s... (truncated after %d bytes)", output.text, httpTraceMaxBodySize)
+		}
+	}
+
+	return output
+}
@@ -0,0 +1,483 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/version"
+)
+
+// HTTP Archive (HAR) 1.2: http://www.softwareishard.com/blog/har-12-spec/
+//
+// Since a HAR file is a single JSON document, each entry is written over the closing brackets of the document (which
+// are then re-written) - meaning that the file is valid after each entry and can be appended to by subsequent runs.
+
+const harTrailer = "\n]}}\n"
+
+type harLog struct {
+	Log harLogContent `json:"log"`
+}
+
+type harLogContent struct {
+	Version string        `json:"version"`
+	Creator harCreator    `json:"creator"`
+	Entries []interface{} `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+
+	// custom fields must be prefixed with an underscore
+	CorrelationRequestId string `json:"_correlationRequestId,omitempty"`
+	ServiceRequestId     string `json:"_serviceRequestId,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int64          `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int64  `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+func (t *HTTPTracer) openHAR(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	if info.Size() == 0 {
+		header, err := json.Marshal(harLog{
+			Log: harLogContent{
+				Version: "1.2",
+				Creator: harCreator{
+					Name:    "terraform-provider-azurerm",
+					Version: version.ProviderVersion,
+				},
+				Entries: []interface{}{},
+			},
+		})
+		if err != nil {
+			file.Close()
+			return err
+		}
+
+		// strip the empty entries and closing brackets, so that entries can be appended
+		header = bytes.TrimSuffix(header, []byte("]}}"))
+		if _, err := file.Write(header); err != nil {
+			file.Close()
+			return err
+		}
+		if _, err := file.WriteString(harTrailer); err != nil {
+			file.Close()
+			return err
+		}
+
+		t.file = file
+		t.harOffset = int64(len(header))
+		return nil
+	}
+
+	// otherwise this is an existing file which we're appending to, providing it's one we've written
+	if info.Size() < int64(len(harTrailer)) {
+		file.Close()
+		return fmt.Errorf("the existing file is not a HAR file written by the provider")
+	}
+	trailer := make([]byte, len(harTrailer))
+	if _, err := file.ReadAt(trailer, info.Size()-int64(len(harTrailer))); err != nil && err != io.EOF {
+		file.Close()
+		return err
+	}
+	if string(trailer) != harTrailer {
+		file.Close()
+		return fmt.Errorf("the existing file is not a HAR file written by the provider")
+	}
+
+	var existing harLog
+	if err := json.NewDecoder(io.NewSectionReader(file, 0, info.Size())).Decode(&existing); err != nil {
+		file.Close()
+		return fmt.Errorf("parsing the existing HAR file: %+v", err)
+	}
+
+	t.file = file
+	t.harOffset = info.Size() - int64(len(harTrailer))
+	t.harEntries = len(existing.Log.Entries) > 0
+	return nil
+}
+
+func (t *HTTPTracer) writeHAREntry(entry httpTraceEntry) error {
+	milliseconds := float64(entry.duration) / float64(time.Millisecond)
+
+	output := harEntry{
+		StartedDateTime: entry.started.Format("2006-01-02T15:04:05.000Z07:00"),
+		Time:            milliseconds,
+		Request: harRequest{
+			Method:      entry.method,
+			URL:         entry.url,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(entry.requestHeaders),
+			QueryString: harQueryString(entry.url),
+			HeadersSize: -1,
+			BodySize:    entry.requestBody.size,
+		},
+		Response: harResponse{
+			Status:      entry.statusCode,
+			StatusText:  entry.statusText,
+			HTTPVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(entry.responseHeaders),
+			Content: harContent{
+				Size:     entry.responseBody.size,
+				MimeType: entry.responseBody.mimeType,
+				Text:     entry.responseBody.text,
+			},
+			RedirectURL: "",
+			HeadersSize: -1,
+			BodySize:    entry.responseBody.size,
+		},
+		Timings: harTimings{
+			Send:    0,
+			Wait:    milliseconds,
+			Receive: 0,
+		},
+		CorrelationRequestId: entry.correlationRequestId,
+		ServiceRequestId:     entry.serviceRequestId,
+	}
+	if entry.requestBody.text != "" {
+		output.Request.PostData = &harPostData{
+			MimeType: entry.requestBody.mimeType,
+			Text:     entry.requestBody.text,
+		}
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	buf := bytes.Buffer{}
+	if t.harEntries {
+		buf.WriteString(",")
+	}
+	buf.WriteString("\n")
+	buf.Write(data)
+	entryLength := int64(buf.Len())
+	buf.WriteString(harTrailer)
+
+	if _, err := t.file.WriteAt(buf.Bytes(), t.harOffset); err != nil {
+		return err
+	}
+
+	t.harOffset += entryLength
+	t.harEntries = true
+	return nil
+}
+
+func harHeaders(input http.Header) []harNameValue {
+	output := make([]harNameValue, 0)
+	for k, values := range input {
+		for _, v := range values {
+			output = append(output, harNameValue{Name: k, Value: v})
+		}
+	}
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].Name < output[j].Name
+	})
+	return output
+}
+
+func harQueryString(input string) []harNameValue {
+	output := make([]harNameValue, 0)
+	u, err := url.Parse(input)
+	if err != nil {
+		return output
+	}
+	for k, values := range u.Query() {
+		for _, v := range values {
+			output = append(output, harNameValue{Name: k, Value: v})
+		}
+	}
+	sort.Slice(output, func(i, j int) bool {
+		return output[i].Name < output[j].Name
+	})
+	return output
+}
+
+// OpenTelemetry (OTLP JSON): https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+//
+// Each span is written as a separate `ExportTraceServiceRequest` on a new line, which matches the output of the
+// OpenTelemetry Collector's File Exporter and so can be replayed into any OTLP compatible backend.
+
+const (
+	otlpSpanKindClient  = 3
+	otlpStatusCodeOk    = 1
+	otlpStatusCodeError = 2
+)
+
+type otlpExportTraceServiceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpStatus struct {
+	Code int `json:"code"`
+}
+
+type otlpAttribute struct {
+	Key   string             `json:"key"`
+	Value otlpAttributeValue `json:"value"`
+}
+
+type otlpAttributeValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+func (t *HTTPTracer) openOTLP(path string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+
+	traceId, err := randomHex(16)
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	t.file = file
+	t.otlpTraceId = traceId
+	return nil
+}
+
+func (t *HTTPTracer) writeOTLPSpan(entry httpTraceEntry) error {
+	spanId, err := randomHex(8)
+	if err != nil {
+		return err
+	}
+
+	// requests sharing a Correlation Request ID are part of the same trace
+	traceId := t.otlpTraceId
+	if v := strings.ReplaceAll(entry.correlationRequestId, "-", ""); len(v) == 32 {
+		if _, err := hex.DecodeString(v); err == nil {
+			traceId = strings.ToLower(v)
+		}
+	}
+
+	status := otlpStatusCodeOk
+	if entry.statusCode >= 400 {
+		status = otlpStatusCodeError
+	}
+
+	attributes := []otlpAttribute{
+		otlpStringAttribute("http.request.method", entry.method),
+		otlpStringAttribute("url.full", entry.url),
+		otlpIntAttribute("http.response.status_code", int64(entry.statusCode)),
+	}
+	if u, err := url.Parse(entry.url); err == nil {
+		attributes = append(attributes, otlpStringAttribute("server.address", u.Hostname()))
+	}
+	if entry.correlationRequestId != "" {
+		attributes = append(attributes, otlpStringAttribute("az.correlation_request_id", entry.correlationRequestId))
+	}
+	if entry.serviceRequestId != "" {
+		attributes = append(attributes, otlpStringAttribute("az.service_request_id", entry.serviceRequestId))
+	}
+	attributes = append(attributes, otlpHeaderAttributes("http.request.header", entry.requestHeaders)...)
+	attributes = append(attributes, otlpHeaderAttributes("http.response.header", entry.responseHeaders)...)
+	if entry.requestBody.text != "" {
+		attributes = append(attributes, otlpStringAttribute("http.request.body", entry.requestBody.text))
+	}
+	if entry.responseBody.text != "" {
+		attributes = append(attributes, otlpStringAttribute("http.response.body", entry.responseBody.text))
+	}
+
+	output := otlpExportTraceServiceRequest{
+		ResourceSpans: []otlpResourceSpans{
+			{
+				Resource: otlpResource{
+					Attributes: []otlpAttribute{
+						otlpStringAttribute("service.name", "terraform-provider-azurerm"),
+						otlpStringAttribute("service.version", version.ProviderVersion),
+					},
+				},
+				ScopeSpans: []otlpScopeSpans{
+					{
+						Scope: otlpScope{
+							Name:    "github.com/hashicorp/terraform-provider-azurerm/internal/common",
+							Version: version.ProviderVersion,
+						},
+						Spans: []otlpSpan{
+							{
+								TraceId:           traceId,
+								SpanId:            spanId,
+								Name:              entry.method,
+								Kind:              otlpSpanKindClient,
+								StartTimeUnixNano: strconv.FormatInt(entry.started.UnixNano(), 10),
+								EndTimeUnixNano:   strconv.FormatInt(entry.started.Add(entry.duration).UnixNano(), 10),
+								Attributes:        attributes,
+								Status: otlpStatus{
+									Code: status,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	data, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	_, err = t.file.Write(append(data, '\n'))
+	return err
+}
+
+func otlpStringAttribute(key, value string) otlpAttribute {
+	return otlpAttribute{
+		Key: key,
+		Value: otlpAttributeValue{
+			StringValue: &value,
+		},
+	}
+}
+
+func otlpIntAttribute(key string, value int64) otlpAttribute {
+	// int64 values are encoded as strings in the JSON representation of Protocol Buffers
+	v := strconv.FormatInt(value, 10)
+	return otlpAttribute{
+		Key: key,
+		Value: otlpAttributeValue{
+			IntValue: &v,
+		},
+	}
+}
+
+func otlpHeaderAttributes(prefix string, input http.Header) []otlpAttribute {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	output := make([]otlpAttribute, 0, len(keys))
+	for _, k := range keys {
+		output = append(output, otlpStringAttribute(fmt.Sprintf("%s.%s", prefix, strings.ToLower(k)), strings.Join(input[k], ", ")))
+	}
+	return output
+}
+
+func randomHex(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating random bytes: %+v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const httpTraceRedacted = "REDACTED"
+
+// httpTraceRedactedHeaders are the (lower-cased) names of headers whose values are never written to the trace
+var httpTraceRedactedHeaders = map[string]struct{}{
+	"api-key":                        {},
+	"authorization":                  {},
+	"cookie":                         {},
+	"ocp-apim-subscription-key":      {},
+	"proxy-authorization":            {},
+	"set-cookie":                     {},
+	"x-api-key":                      {},
+	"x-functions-key":                {},
+	"x-ms-authorization-auxiliary":   {},
+	"x-ms-copy-source-authorization": {},
+	"x-ms-encryption-key":            {},
+}
+
+// httpTraceURLHeaders are the (lower-cased) names of headers whose values are URLs, such as the source of a copy
+// which can contain a Shared Access Signature - and so are written to the trace with the query string redacted
+var httpTraceURLHeaders = map[string]struct{}{
+	"x-ms-copy-source":   {},
+	"x-ms-rename-source": {},
+}
+
+// httpTraceRedactedQueryParameters are the (lower-cased) names of query string parameters whose values are never
+// written to the trace, such as the signature of a Shared Access Signature
+var httpTraceRedactedQueryParameters = map[string]struct{}{
+	"access_token": {},
+	"api-key":      {},
+	"code":         {},
+	"sig":          {},
+	"token":        {},
+}
+
+// httpTraceRedactedFieldPattern matches the names of the fields within a request/response body whose values are
+// never written to the trace - this intentionally errs on the side of caution, for example `value` is used both for
+// the values of Key Vault Secrets and Storage Account Keys.
+var httpTraceRedactedFieldPattern = regexp.MustCompile(`(?i)(password|secret|token|connectionstring|connection_string|accesskey|accountkey|primarykey|secondarykey|sharedkey|instrumentationkey|assertion|protectedsettings|customdata|commandtoexecute|^keys?$|^key[0-9]+$|^value$|^(d|dp|dq|p|q|qi|k|key_hsm)$)`)
+
+// httpTraceRedactedObjectFieldPattern matches the names of the fields within a request/response body whose values are
+// never written to the trace even when they're objects, such as the Protected Settings of a Virtual Machine Extension
+// (which can contain any keys)
+var httpTraceRedactedObjectFieldPattern = regexp.MustCompile(`(?i)^protectedsettings`)
+
+// httpTraceDataPlaneUploadQueryParameters are the values of the `comp` and `action` query string parameters used when
+// uploading content to the Storage Data Plane API
+var httpTraceDataPlaneUploadQueryParameters = map[string]struct{}{
+	"append":      {},
+	"appendblock": {},
+	"block":       {},
+	"page":        {},
+	"range":       {},
+}
+
+// httpTraceRedactedXMLElementPattern matches XML elements whose values are never written to the trace, such as the
+// value of a User Delegation Key returned from the Storage Data Plane API
+var httpTraceRedactedXMLElementPattern = regexp.MustCompile(`(?is)<(Value|Password|Secret|Key|SignedOid)>.*?</(Value|Password|Secret|Key|SignedOid)>`)
+
+func redactHTTPTraceHeaders(input http.Header) http.Header {
+	output := make(http.Header, len(input))
+	for k, v := range input {
+		if _, ok := httpTraceRedactedHeaders[strings.ToLower(k)]; ok {
+			output[k] = []string{httpTraceRedacted}
+			continue
+		}
+		if _, ok := httpTraceURLHeaders[strings.ToLower(k)]; ok {
+			values := make([]string, 0, len(v))
+			for _, value := range v {
+				u, err := url.Parse(value)
+				if err != nil {
+					values = append(values, httpTraceRedacted)
+					continue
+				}
+				values = append(values, redactHTTPTraceURL(u))
+			}
+			output[k] = values
+			continue
+		}
+		output[k] = append([]string{}, v...)
+	}
+	return output
+}
+
+func redactHTTPTraceURL(input *url.URL) string {
+	if input == nil {
+		return ""
+	}
+
+	u := *input
+	u.User = nil
+	if u.RawQuery != "" {
+		query := u.Query()
+		for k := range query {
+			if _, ok := httpTraceRedactedQueryParameters[strings.ToLower(k)]; ok {
+				query[k] = []string{httpTraceRedacted}
+			}
+		}
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String()
+}
+
+// isHTTPTraceDataPlaneContent returns whether the body of the request/response is content uploaded to or downloaded
+// from the Storage Data Plane API (for example the contents of a Storage Blob), which is never written to the trace
+// regardless of its content type
+func isHTTPTraceDataPlaneContent(u *url.URL, header http.Header) bool {
+	for _, k := range []string{"x-ms-blob-type", "x-ms-type", "x-ms-write"} {
+		if header.Get(k) != "" {
+			return true
+		}
+	}
+	if u == nil {
+		return false
+	}
+	query := u.Query()
+	for _, k := range []string{"comp", "action"} {
+		if _, ok := httpTraceDataPlaneUploadQueryParameters[strings.ToLower(query.Get(k))]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHTTPTraceBody returns the body as a string with any sensitive values redacted, bodies which are neither
+// JSON, XML, form-encoded or plain text (for example the contents of a Storage Blob) are omitted entirely.
+func redactHTTPTraceBody(contentType string, body []byte) string {
+	mimeType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case strings.Contains(mimeType, "json"):
+		var v interface{}
+		if err := json.Unmarshal(body, &v); err != nil {
+			// the body may have been truncated, but since it can't be redacted it can't be included
+			return fmt.Sprintf("(%d bytes omitted since the JSON body could not be parsed for redaction)", len(body))
+		}
+		redacted, err := json.Marshal(redactHTTPTraceJSONValue(v))
+		if err != nil {
+			return fmt.Sprintf("(%d bytes omitted since the JSON body could not be redacted)", len(body))
+		}
+		return string(redacted)
+
+	case mimeType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return fmt.Sprintf("(%d bytes omitted since the form body could not be parsed for redaction)", len(body))
+		}
+		for k := range values {
+			if httpTraceRedactedFieldPattern.MatchString(k) {
+				values[k] = []string{httpTraceRedacted}
+			}
+		}
+		return values.Encode()
+
+	case strings.Contains(mimeType, "xml"):
+		return httpTraceRedactedXMLElementPattern.ReplaceAllStringFunc(string(body), func(element string) string {
+			name := element[1:strings.Index(element, ">")]
+			return fmt.Sprintf("<%s>%s</%s>", name, httpTraceRedacted, name)
+		})
+
+	case strings.HasPrefix(mimeType, "text/"):
+		return string(body)
+	}
+
+	return fmt.Sprintf("(%d bytes of %q omitted)", len(body), contentType)
+}
+
+func redactHTTPTraceJSONValue(input interface{}) interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			switch val.(type) {
+			case map[string]interface{}, []interface{}:
+				if httpTraceRedactedObjectFieldPattern.MatchString(key) {
+					v[key] = httpTraceRedacted
+					continue
+				}
+				v[key] = redactHTTPTraceJSONValue(val)
+			default:
+				if val != nil && httpTraceRedactedFieldPattern.MatchString(key) {
+					v[key] = httpTraceRedacted
+				}
+			}
+		}
+		return v
+
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redactHTTPTraceJSONValue(val)
+		}
+		return v
+	}
+
+	return input
+}
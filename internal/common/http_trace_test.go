@@ -0,0 +1,336 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRedactHTTPTraceHeaders(t *testing.T) {
+	input := http.Header{
+		"Authorization":                []string{"Bearer abc123"},
+		"X-Ms-Authorization-Auxiliary": []string{"Bearer def456"},
+		"X-Ms-Encryption-Key":          []string{"c2VjcmV0"},
+		"X-Ms-Copy-Source":             []string{"https://example.blob.core.windows.net/container/blob?sv=2023-11-03&sig=c2VjcmV0"},
+		"Content-Type":                 []string{"application/json"},
+	}
+
+	actual := redactHTTPTraceHeaders(input)
+	for _, k := range []string{"Authorization", "X-Ms-Authorization-Auxiliary", "X-Ms-Encryption-Key"} {
+		if v := actual.Get(k); v != httpTraceRedacted {
+			t.Fatalf("expected the header %q to be redacted but got %q", k, v)
+		}
+	}
+	if v, expected := actual.Get("X-Ms-Copy-Source"), "https://example.blob.core.windows.net/container/blob?sig=REDACTED&sv=2023-11-03"; v != expected {
+		t.Fatalf("expected the header `X-Ms-Copy-Source` to be %q but got %q", expected, v)
+	}
+	if v := actual.Get("Content-Type"); v != "application/json" {
+		t.Fatalf("expected the header `Content-Type` to be %q but got %q", "application/json", v)
+	}
+
+	// the original headers are still sent
+	if v := input.Get("Authorization"); v != "Bearer abc123" {
+		t.Fatalf("expected the original `Authorization` header to be unchanged but got %q", v)
+	}
+}
+
+func TestRedactHTTPTraceURL(t *testing.T) {
+	u, _ := url.Parse("https://example.blob.core.windows.net/container/blob?sv=2023-11-03&sig=c2VjcmV0&sp=r")
+
+	actual := redactHTTPTraceURL(u)
+	expected := "https://example.blob.core.windows.net/container/blob?sig=REDACTED&sp=r&sv=2023-11-03"
+	if actual != expected {
+		t.Fatalf("expected %q but got %q", expected, actual)
+	}
+}
+
+func TestRedactHTTPTraceBody(t *testing.T) {
+	testData := []struct {
+		ContentType string
+		Input       string
+		Expected    string
+	}{
+		{
+			ContentType: "application/json; charset=utf-8",
+			Input:       `{"location":"westeurope","properties":{"administratorLogin":"sqladmin","administratorLoginPassword":"P@ssw0rd1234!"}}`,
+			Expected:    `{"location":"westeurope","properties":{"administratorLogin":"sqladmin","administratorLoginPassword":"REDACTED"}}`,
+		},
+		{
+			ContentType: "application/json",
+			Input:       `{"keys":[{"keyName":"key1","permissions":"FULL","value":"c2VjcmV0"}]}`,
+			Expected:    `{"keys":[{"keyName":"key1","permissions":"FULL","value":"REDACTED"}]}`,
+		},
+		{
+			ContentType: "application/json",
+			Input:       `{"value":[{"name":"example"}],"nextLink":null}`,
+			Expected:    `{"nextLink":null,"value":[{"name":"example"}]}`,
+		},
+		{
+			ContentType: "application/json",
+			Input:       `{"properties":{"osProfile":{"customData":"c2VjcmV0"}}}`,
+			Expected:    `{"properties":{"osProfile":{"customData":"REDACTED"}}}`,
+		},
+		{
+			ContentType: "application/json",
+			Input:       `{"properties":{"protectedSettings":{"commandToExecute":"echo secret","storageAccountName":"example"},"settings":{"commandToExecute":"echo secret"}}}`,
+			Expected:    `{"properties":{"protectedSettings":"REDACTED","settings":{"commandToExecute":"REDACTED"}}}`,
+		},
+		{
+			// a truncated body can't be parsed and so can't be included
+			ContentType: "application/json",
+			Input:       `{"value":"c2Vj`,
+			Expected:    `(14 bytes omitted since the JSON body could not be parsed for redaction)`,
+		},
+		{
+			ContentType: "application/xml",
+			Input:       `<UserDelegationKey><SignedTid>tenant</SignedTid><Value>c2VjcmV0</Value></UserDelegationKey>`,
+			Expected:    `<UserDelegationKey><SignedTid>tenant</SignedTid><Value>REDACTED</Value></UserDelegationKey>`,
+		},
+		{
+			ContentType: "application/x-www-form-urlencoded",
+			Input:       `client_id=abc&client_secret=def&grant_type=client_credentials`,
+			Expected:    `client_id=abc&client_secret=REDACTED&grant_type=client_credentials`,
+		},
+		{
+			ContentType: "application/octet-stream",
+			Input:       `some binary content`,
+			Expected:    `(19 bytes of "application/octet-stream" omitted)`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual := redactHTTPTraceBody(v.ContentType, []byte(v.Input))
+		if actual != v.Expected {
+			t.Fatalf("expected %q but got %q", v.Expected, actual)
+		}
+	}
+}
+
+func TestIsHTTPTraceDataPlaneContent(t *testing.T) {
+	testData := []struct {
+		Name     string
+		URL      string
+		Header   http.Header
+		Expected bool
+	}{
+		{
+			Name:     "Put Blob",
+			URL:      "https://example.blob.core.windows.net/container/blob",
+			Header:   http.Header{"X-Ms-Blob-Type": []string{"BlockBlob"}, "Content-Type": []string{"text/plain"}},
+			Expected: true,
+		},
+		{
+			Name:     "Put Block",
+			URL:      "https://example.blob.core.windows.net/container/blob?comp=block&blockid=AAAA",
+			Header:   http.Header{"Content-Type": []string{"text/plain"}},
+			Expected: true,
+		},
+		{
+			Name:     "Put Range",
+			URL:      "https://example.file.core.windows.net/share/file?comp=range",
+			Header:   http.Header{"X-Ms-Write": []string{"update"}},
+			Expected: true,
+		},
+		{
+			Name:     "Set Blob Metadata",
+			URL:      "https://example.blob.core.windows.net/container/blob?comp=metadata",
+			Header:   http.Header{"X-Ms-Meta-Hello": []string{"world"}},
+			Expected: false,
+		},
+		{
+			Name:     "Resource Manager",
+			URL:      "https://management.azure.com/subscriptions/00000000-0000-0000-0000-000000000000?api-version=2022-12-01",
+			Header:   http.Header{"Content-Type": []string{"application/json"}},
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		u, _ := url.Parse(v.URL)
+		if actual := isHTTPTraceDataPlaneContent(u, v.Header); actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestCaptureHTTPTraceBodyPreservesBody(t *testing.T) {
+	input := strings.Repeat("a", httpTraceMaxBodySize+100)
+
+	captured, truncated, body := captureHTTPTraceBody(io.NopCloser(strings.NewReader(input)))
+	if !truncated {
+		t.Fatalf("expected the body to be truncated")
+	}
+	if len(captured) != httpTraceMaxBodySize {
+		t.Fatalf("expected %d bytes to be captured but got %d", httpTraceMaxBodySize, len(captured))
+	}
+
+	actual, err := io.ReadAll(body)
+	if err != nil {
+		t.Fatalf("reading body: %+v", err)
+	}
+	if string(actual) != input {
+		t.Fatalf("expected the complete body to be returned")
+	}
+}
+
+func TestHTTPTracerHAR(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.har")
+
+	tracer, err := NewHTTPTracer(HTTPTraceOptions{Path: path, Format: HTTPTraceFormatHAR})
+	if err != nil {
+		t.Fatalf("building tracer: %+v", err)
+	}
+
+	sendTracedRequest(t, tracer, http.MethodPut, `{"properties":{"password":"P@ssw0rd1234!"}}`)
+	sendTracedRequest(t, tracer, http.MethodGet, "")
+
+	var actual harLog
+	readHTTPTraceJSON(t, path, &actual)
+	if len(actual.Log.Entries) != 2 {
+		t.Fatalf("expected 2 entries but got %d", len(actual.Log.Entries))
+	}
+
+	// a new tracer (e.g. a subsequent run) appends to the existing file
+	delete(httpTracers, path)
+	tracer, err = NewHTTPTracer(HTTPTraceOptions{Path: path, Format: HTTPTraceFormatHAR})
+	if err != nil {
+		t.Fatalf("building tracer: %+v", err)
+	}
+	sendTracedRequest(t, tracer, http.MethodDelete, "")
+
+	readHTTPTraceJSON(t, path, &actual)
+	if len(actual.Log.Entries) != 3 {
+		t.Fatalf("expected 3 entries but got %d", len(actual.Log.Entries))
+	}
+
+	contents, _ := os.ReadFile(path)
+	if bytes.Contains(contents, []byte("P@ssw0rd1234!")) || bytes.Contains(contents, []byte("Bearer abc123")) {
+		t.Fatalf("expected sensitive values to be redacted from the trace")
+	}
+	if !bytes.Contains(contents, []byte(`"_correlationRequestId":"7f5a6223-f475-4a9c-b9d5-12575aa6b11b"`)) {
+		t.Fatalf("expected the trace to include the correlation request id")
+	}
+}
+
+func TestHTTPTracerOTLP(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+
+	tracer, err := NewHTTPTracer(HTTPTraceOptions{Path: path, Format: HTTPTraceFormatOTLP})
+	if err != nil {
+		t.Fatalf("building tracer: %+v", err)
+	}
+
+	sendTracedRequest(t, tracer, http.MethodPut, `{"properties":{"password":"P@ssw0rd1234!"}}`)
+	sendTracedRequest(t, tracer, http.MethodGet, "")
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("opening %q: %+v", path, err)
+	}
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		lines++
+
+		var actual otlpExportTraceServiceRequest
+		if err := json.Unmarshal(scanner.Bytes(), &actual); err != nil {
+			t.Fatalf("parsing line %d: %+v", lines, err)
+		}
+
+		span := actual.ResourceSpans[0].ScopeSpans[0].Spans[0]
+		if span.TraceId != "7f5a6223f4754a9cb9d512575aa6b11b" {
+			t.Fatalf("expected the Trace ID to be derived from the correlation request id but got %q", span.TraceId)
+		}
+		if len(span.SpanId) != 16 {
+			t.Fatalf("expected a 16 character Span ID but got %q", span.SpanId)
+		}
+		if strings.Contains(scanner.Text(), "P@ssw0rd1234!") {
+			t.Fatalf("expected sensitive values to be redacted from the trace")
+		}
+	}
+
+	if lines != 2 {
+		t.Fatalf("expected 2 spans but got %d", lines)
+	}
+}
+
+func TestHTTPTracerUnsupportedFormat(t *testing.T) {
+	if _, err := NewHTTPTracer(HTTPTraceOptions{Path: filepath.Join(t.TempDir(), "trace"), Format: "xml"}); err == nil {
+		t.Fatalf("expected an error for an unsupported format")
+	}
+}
+
+func sendTracedRequest(t *testing.T, tracer *HTTPTracer, method, body string) {
+	u, _ := url.Parse("https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example")
+	request := (&http.Request{
+		Method: method,
+		URL:    u,
+		Header: http.Header{
+			"Authorization":               []string{"Bearer abc123"},
+			"Content-Type":                []string{"application/json"},
+			"X-Ms-Correlation-Request-Id": []string{"7f5a6223-f475-4a9c-b9d5-12575aa6b11b"},
+		},
+	}).WithContext(context.Background())
+	if body != "" {
+		request.Body = io.NopCloser(strings.NewReader(body))
+		request.ContentLength = int64(len(body))
+	}
+
+	request, err := tracer.requestMiddleware()(request)
+	if err != nil {
+		t.Fatalf("request middleware: %+v", err)
+	}
+
+	if body != "" {
+		sent, _ := io.ReadAll(request.Body)
+		if string(sent) != body {
+			t.Fatalf("expected the request body %q to be sent but got %q", body, string(sent))
+		}
+	}
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			"Content-Type":    []string{"application/json"},
+			"X-Ms-Request-Id": []string{"abc"},
+		},
+		Body: io.NopCloser(strings.NewReader(`{"name":"example"}`)),
+	}
+	response, err = tracer.responseMiddleware()(request, response)
+	if err != nil {
+		t.Fatalf("response middleware: %+v", err)
+	}
+
+	received, _ := io.ReadAll(response.Body)
+	if string(received) != `{"name":"example"}` {
+		t.Fatalf("expected the response body to be preserved but got %q", string(received))
+	}
+}
+
+func readHTTPTraceJSON(t *testing.T, path string, v interface{}) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %q: %+v", path, err)
+	}
+	if err := json.Unmarshal(contents, v); err != nil {
+		t.Fatalf("parsing %q: %+v\n\n%s", path, err, string(contents))
+	}
+}
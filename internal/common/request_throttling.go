@@ -0,0 +1,427 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+)
+
+// Azure Resource Manager throttles requests using a token bucket per Subscription (and per Resource Provider),
+// returning the number of requests remaining in the `x-ms-ratelimit-remaining-*` response headers - and a HTTP 429
+// once the bucket has been exhausted. When provisioning a large number of resources concurrently it's common for
+// the bucket to be exhausted, at which point every retry counts against the (empty) budget too.
+//
+// The request throttler instead tracks these headers across all of the clients for a Subscription and paces requests
+// before the budget is exhausted, rather than relying on retrying requests once they've been throttled.
+//
+// Requests which are throttled are retried by the SDK (and go-autorest) without passing through the middleware again,
+// so a token is taken when the connection for each attempt is obtained rather than once per request - however only the
+// headers from the final response are observed.
+
+const (
+	headerRateLimitRemainingSubscriptionReads   = "x-ms-ratelimit-remaining-subscription-reads"
+	headerRateLimitRemainingSubscriptionWrites  = "x-ms-ratelimit-remaining-subscription-writes"
+	headerRateLimitRemainingSubscriptionDeletes = "x-ms-ratelimit-remaining-subscription-deletes"
+	headerRateLimitRemainingResourceReads       = "x-ms-ratelimit-remaining-subscription-resource-entities-read"
+	headerRateLimitRemainingResourceRequests    = "x-ms-ratelimit-remaining-subscription-resource-requests"
+	headerRetryAfter                            = "Retry-After"
+)
+
+const (
+	DefaultRequestThrottlingReadsPerSecond  = 25
+	DefaultRequestThrottlingWritesPerSecond = 10
+
+	// requestThrottlingBurstSeconds is the number of seconds worth of requests which can be sent in a burst,
+	// which matches the size of the buckets used by Resource Manager (e.g. 250 reads at 25 reads/second)
+	requestThrottlingBurstSeconds = 10
+)
+
+type RequestThrottlingOptions struct {
+	// Enabled specifies whether requests sent to Resource Manager should be paced
+	Enabled bool
+
+	// ReadsPerSecond is the rate at which the budget for read (GET/HEAD) requests is replenished
+	ReadsPerSecond int
+
+	// WritesPerSecond is the rate at which the budget for write (PUT/PATCH/POST) and delete requests is replenished
+	WritesPerSecond int
+}
+
+type requestCategory string
+
+const (
+	requestCategoryRead   requestCategory = "read"
+	requestCategoryWrite  requestCategory = "write"
+	requestCategoryDelete requestCategory = "delete"
+)
+
+// sharedTokenBuckets contains the token buckets for every Subscription and Resource Provider, which are
+// intentionally shared across all clients (and all instances of the Provider within this process using the same
+// configuration) since Resource Manager tracks the budget per Subscription rather than per client.
+var sharedTokenBuckets = newTokenBuckets(time.Now)
+
+type requestThrottler struct {
+	buckets             *tokenBuckets
+	options             RequestThrottlingOptions
+	resourceManagerHost string
+}
+
+func newRequestThrottler(options RequestThrottlingOptions, resourceManagerEndpoint string) requestThrottler {
+	if options.ReadsPerSecond <= 0 {
+		options.ReadsPerSecond = DefaultRequestThrottlingReadsPerSecond
+	}
+	if options.WritesPerSecond <= 0 {
+		options.WritesPerSecond = DefaultRequestThrottlingWritesPerSecond
+	}
+
+	host := resourceManagerEndpoint
+	if u, err := url.Parse(resourceManagerEndpoint); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	return requestThrottler{
+		buckets:             sharedTokenBuckets,
+		options:             options,
+		resourceManagerHost: host,
+	}
+}
+
+// requestMiddleware returns a RequestMiddleware which delays each attempt to send the request until there's budget
+// available to send it
+func (t requestThrottler) requestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		return t.beforeRequest(request), nil
+	}
+}
+
+// responseMiddleware returns a ResponseMiddleware which updates the budget using the headers returned by Resource Manager
+func (t requestThrottler) responseMiddleware() client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		t.afterResponse(request, response)
+		return response, nil
+	}
+}
+
+// prepareDecorator returns the go-autorest equivalent of requestMiddleware
+func (t requestThrottler) prepareDecorator() autorest.PrepareDecorator {
+	return func(p autorest.Preparer) autorest.Preparer {
+		return autorest.PreparerFunc(func(r *http.Request) (*http.Request, error) {
+			r, err := p.Prepare(r)
+			if err != nil {
+				return r, err
+			}
+			return t.beforeRequest(r), nil
+		})
+	}
+}
+
+// respondDecorator returns the go-autorest equivalent of responseMiddleware
+func (t requestThrottler) respondDecorator() autorest.RespondDecorator {
+	return func(r autorest.Responder) autorest.Responder {
+		return autorest.ResponderFunc(func(resp *http.Response) error {
+			if resp != nil && resp.Request != nil {
+				t.afterResponse(resp.Request, resp)
+			}
+			return r.Respond(resp)
+		})
+	}
+}
+
+// beforeRequest returns a copy of the request which waits for budget to be available whenever a connection is obtained
+// to send it, which happens for each attempt to send the request
+func (t requestThrottler) beforeRequest(request *http.Request) *http.Request {
+	scope := t.scopeForRequest(request)
+	if scope == nil {
+		return request
+	}
+
+	ctx := request.Context()
+	trace := &httptrace.ClientTrace{
+		GetConn: func(string) {
+			t.wait(ctx, request.Method, request.URL.Path, *scope)
+		},
+	}
+	return request.WithContext(httptrace.WithClientTrace(ctx, trace))
+}
+
+// wait takes a token from the budget for the scope, waiting until the token is available - if the context is cancelled
+// whilst waiting the request is sent immediately, and fails since the context has been cancelled
+func (t requestThrottler) wait(ctx context.Context, method, path string, scope throttlingScope) {
+	capacity, refillRate := t.budgetFor(scope.category)
+	wait := t.buckets.reserve(scope.subscriptionKey(), capacity, refillRate)
+	if scope.resourceProvider != "" {
+		if rpWait := t.buckets.reserve(scope.resourceProviderKey(), capacity, refillRate); rpWait > wait {
+			wait = rpWait
+		}
+	}
+
+	if wait <= 0 {
+		return
+	}
+
+	log.Printf("[DEBUG] AzureRM Request Throttling: delaying %s request to %s by %s", method, path, wait)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func (t requestThrottler) afterResponse(request *http.Request, response *http.Response) {
+	if response == nil {
+		return
+	}
+
+	scope := t.scopeForRequest(request)
+	if scope == nil {
+		return
+	}
+
+	capacity, refillRate := t.budgetFor(scope.category)
+
+	subscriptionHeader := headerRateLimitRemainingSubscriptionReads
+	resourceProviderHeader := headerRateLimitRemainingResourceReads
+	switch scope.category {
+	case requestCategoryWrite:
+		subscriptionHeader = headerRateLimitRemainingSubscriptionWrites
+		resourceProviderHeader = headerRateLimitRemainingResourceRequests
+	case requestCategoryDelete:
+		subscriptionHeader = headerRateLimitRemainingSubscriptionDeletes
+		resourceProviderHeader = headerRateLimitRemainingResourceRequests
+	}
+
+	if remaining, ok := parseRateLimitRemaining(response.Header.Get(subscriptionHeader)); ok {
+		t.buckets.observe(scope.subscriptionKey(), capacity, refillRate, remaining)
+	}
+	if scope.resourceProvider != "" {
+		if remaining, ok := parseRateLimitRemaining(response.Header.Get(resourceProviderHeader)); ok {
+			t.buckets.observe(scope.resourceProviderKey(), capacity, refillRate, remaining)
+		}
+	}
+
+	if response.StatusCode == http.StatusTooManyRequests {
+		retryAfter := parseRetryAfter(response.Header.Get(headerRetryAfter), t.buckets.now())
+		if retryAfter <= 0 {
+			// Resource Manager should always return a Retry-After header, but if not we'll wait until the bucket is half full
+			retryAfter = time.Duration(float64(time.Second) * capacity / 2 / refillRate)
+		}
+
+		log.Printf("[DEBUG] AzureRM Request Throttling: %s request to %s was throttled, pausing %s requests for %s", request.Method, request.URL.Path, scope.category, retryAfter)
+		t.buckets.block(scope.subscriptionKey(), capacity, refillRate, retryAfter)
+		if scope.resourceProvider != "" {
+			t.buckets.block(scope.resourceProviderKey(), capacity, refillRate, retryAfter)
+		}
+	}
+}
+
+func (t requestThrottler) budgetFor(category requestCategory) (capacity float64, refillRate float64) {
+	refillRate = float64(t.options.WritesPerSecond)
+	if category == requestCategoryRead {
+		refillRate = float64(t.options.ReadsPerSecond)
+	}
+	return refillRate * requestThrottlingBurstSeconds, refillRate
+}
+
+type throttlingScope struct {
+	subscriptionId   string
+	resourceProvider string
+	category         requestCategory
+}
+
+func (s throttlingScope) subscriptionKey() string {
+	return fmt.Sprintf("%s/%s", s.subscriptionId, s.category)
+}
+
+func (s throttlingScope) resourceProviderKey() string {
+	return fmt.Sprintf("%s/%s/%s", s.subscriptionId, s.resourceProvider, s.category)
+}
+
+// scopeForRequest returns the Subscription and Resource Provider which the budget for this request is tracked against,
+// or nil if this request isn't sent to Resource Manager (e.g. data plane requests) or isn't scoped to a Subscription.
+func (t requestThrottler) scopeForRequest(request *http.Request) *throttlingScope {
+	if request == nil || request.URL == nil || !strings.EqualFold(request.URL.Host, t.resourceManagerHost) {
+		return nil
+	}
+
+	segments := strings.Split(strings.Trim(request.URL.Path, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") || segments[1] == "" {
+		return nil
+	}
+
+	scope := throttlingScope{
+		subscriptionId: strings.ToLower(segments[1]),
+		category:       requestCategoryWrite,
+	}
+
+	// nested resources (e.g. Role Assignments scoped to a Virtual Network) are handled by the last Resource Provider in the ID
+	for i := len(segments) - 2; i >= 2; i-- {
+		if strings.EqualFold(segments[i], "providers") {
+			scope.resourceProvider = strings.ToLower(segments[i+1])
+			break
+		}
+	}
+
+	switch request.Method {
+	case http.MethodGet, http.MethodHead:
+		scope.category = requestCategoryRead
+	case http.MethodDelete:
+		scope.category = requestCategoryDelete
+	}
+
+	return &scope
+}
+
+func parseRateLimitRemaining(input string) (float64, bool) {
+	if input == "" {
+		return 0, false
+	}
+
+	v, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || v < 0 {
+		return 0, false
+	}
+
+	return float64(v), true
+}
+
+func parseRetryAfter(input string, now time.Time) time.Duration {
+	if input == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(strings.TrimSpace(input)); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(input); err == nil {
+		return t.Sub(now)
+	}
+
+	return 0
+}
+
+type tokenBuckets struct {
+	lock    sync.Mutex
+	buckets map[tokenBucketKey]*tokenBucket
+	now     func() time.Time
+}
+
+// tokenBucketKey identifies a bucket by both the scope and the configuration of the bucket, so that instances of the
+// Provider configured with different rates don't share a bucket sized for another configuration
+type tokenBucketKey struct {
+	key        string
+	capacity   float64
+	refillRate float64
+}
+
+func newTokenBuckets(now func() time.Time) *tokenBuckets {
+	return &tokenBuckets{
+		buckets: make(map[tokenBucketKey]*tokenBucket),
+		now:     now,
+	}
+}
+
+// reserve takes a token from the bucket identified by `key`, returning how long the caller must wait before sending the request
+func (b *tokenBuckets) reserve(key string, capacity, refillRate float64) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.bucket(key, capacity, refillRate).reserve(b.now())
+}
+
+// observe updates the bucket identified by `key` using the number of requests which Resource Manager reports as remaining
+func (b *tokenBuckets) observe(key string, capacity, refillRate, remaining float64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.bucket(key, capacity, refillRate).observe(b.now(), remaining)
+}
+
+// block prevents any requests from being sent using the bucket identified by `key` for the specified duration
+func (b *tokenBuckets) block(key string, capacity, refillRate float64, duration time.Duration) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.now()
+	bucket := b.bucket(key, capacity, refillRate)
+	if until := now.Add(duration); until.After(bucket.blockedUntil) {
+		bucket.blockedUntil = until
+	}
+}
+
+func (b *tokenBuckets) bucket(key string, capacity, refillRate float64) *tokenBucket {
+	bucketKey := tokenBucketKey{
+		key:        key,
+		capacity:   capacity,
+		refillRate: refillRate,
+	}
+	bucket, ok := b.buckets[bucketKey]
+	if !ok {
+		bucket = &tokenBucket{
+			capacity:    capacity,
+			tokens:      capacity,
+			refillRate:  refillRate,
+			lastUpdated: b.now(),
+		}
+		b.buckets[bucketKey] = bucket
+	}
+	return bucket
+}
+
+type tokenBucket struct {
+	capacity     float64
+	tokens       float64
+	refillRate   float64
+	lastUpdated  time.Time
+	blockedUntil time.Time
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.lastUpdated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.refillRate)
+		b.lastUpdated = now
+	}
+}
+
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+
+	// the token is taken immediately (allowing the balance to go negative) so that concurrent requests queue up
+	// behind one another, rather than all being sent at the same moment once the bucket refills
+	b.tokens--
+
+	wait := time.Duration(0)
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.refillRate * float64(time.Second))
+	}
+	if blocked := b.blockedUntil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+
+	return wait
+}
+
+func (b *tokenBucket) observe(now time.Time, remaining float64) {
+	b.refill(now)
+
+	// Resource Manager is the source of truth, since the budget is shared with any other clients using this Subscription
+	if remaining < b.tokens {
+		b.tokens = remaining
+	}
+}
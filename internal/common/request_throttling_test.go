@@ -0,0 +1,255 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRequestThrottlingScope(t *testing.T) {
+	testData := []struct {
+		Method   string
+		Url      string
+		Expected *throttlingScope
+	}{
+		{
+			// data plane requests aren't throttled
+			Method:   http.MethodGet,
+			Url:      "https://example.vault.azure.net/secrets/example",
+			Expected: nil,
+		},
+		{
+			// tenant level requests aren't throttled
+			Method:   http.MethodGet,
+			Url:      "https://management.azure.com/providers/Microsoft.Management/managementGroups/example",
+			Expected: nil,
+		},
+		{
+			Method: http.MethodGet,
+			Url:    "https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example",
+			Expected: &throttlingScope{
+				subscriptionId: "12345678-1234-9876-4563-123456789012",
+				category:       requestCategoryRead,
+			},
+		},
+		{
+			Method: http.MethodPut,
+			Url:    "https://MANAGEMENT.azure.com/Subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example",
+			Expected: &throttlingScope{
+				subscriptionId:   "12345678-1234-9876-4563-123456789012",
+				resourceProvider: "microsoft.network",
+				category:         requestCategoryWrite,
+			},
+		},
+		{
+			Method: http.MethodPost,
+			Url:    "https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example/listKeys",
+			Expected: &throttlingScope{
+				subscriptionId:   "12345678-1234-9876-4563-123456789012",
+				resourceProvider: "microsoft.storage",
+				category:         requestCategoryWrite,
+			},
+		},
+		{
+			Method: http.MethodDelete,
+			Url:    "https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example/providers/Microsoft.Authorization/roleAssignments/example",
+			Expected: &throttlingScope{
+				subscriptionId:   "12345678-1234-9876-4563-123456789012",
+				resourceProvider: "microsoft.authorization",
+				category:         requestCategoryDelete,
+			},
+		},
+	}
+
+	throttler := newRequestThrottler(RequestThrottlingOptions{Enabled: true}, "https://management.azure.com/")
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %s %s", v.Method, v.Url)
+
+		u, err := url.Parse(v.Url)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", v.Url, err)
+		}
+
+		actual := throttler.scopeForRequest(&http.Request{Method: v.Method, URL: u})
+		if v.Expected == nil {
+			if actual != nil {
+				t.Fatalf("expected no scope but got %+v", *actual)
+			}
+			continue
+		}
+
+		if actual == nil {
+			t.Fatalf("expected %+v but got no scope", *v.Expected)
+		}
+
+		if *actual != *v.Expected {
+			t.Fatalf("expected %+v but got %+v", *v.Expected, *actual)
+		}
+	}
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Now()
+	buckets := newTokenBuckets(func() time.Time { return now })
+
+	// the bucket starts full, so the first 10 requests are sent immediately
+	for i := 0; i < 10; i++ {
+		if wait := buckets.reserve("example", 10, 5); wait != 0 {
+			t.Fatalf("expected request %d to be sent immediately but got a wait of %s", i, wait)
+		}
+	}
+
+	// subsequent requests queue up behind one another at the refill rate
+	if wait := buckets.reserve("example", 10, 5); wait != 200*time.Millisecond {
+		t.Fatalf("expected a wait of 200ms but got %s", wait)
+	}
+	if wait := buckets.reserve("example", 10, 5); wait != 400*time.Millisecond {
+		t.Fatalf("expected a wait of 400ms but got %s", wait)
+	}
+
+	// once the bucket has refilled requests are sent immediately again
+	now = now.Add(2 * time.Second)
+	if wait := buckets.reserve("example", 10, 5); wait != 0 {
+		t.Fatalf("expected the request to be sent immediately but got a wait of %s", wait)
+	}
+}
+
+func TestTokenBucketObserve(t *testing.T) {
+	now := time.Now()
+	buckets := newTokenBuckets(func() time.Time { return now })
+
+	// Resource Manager reports that the budget is exhausted (e.g. by another client), so the next request should wait
+	buckets.observe("example", 10, 5, 0)
+	if wait := buckets.reserve("example", 10, 5); wait != 200*time.Millisecond {
+		t.Fatalf("expected a wait of 200ms but got %s", wait)
+	}
+
+	// a larger remaining budget shouldn't increase the number of tokens available locally
+	buckets.observe("example", 10, 5, 100)
+	if wait := buckets.reserve("example", 10, 5); wait != 400*time.Millisecond {
+		t.Fatalf("expected a wait of 400ms but got %s", wait)
+	}
+}
+
+func TestTokenBucketBlock(t *testing.T) {
+	now := time.Now()
+	buckets := newTokenBuckets(func() time.Time { return now })
+
+	buckets.block("example", 10, 5, 30*time.Second)
+	if wait := buckets.reserve("example", 10, 5); wait != 30*time.Second {
+		t.Fatalf("expected a wait of 30s but got %s", wait)
+	}
+
+	now = now.Add(30 * time.Second)
+	if wait := buckets.reserve("example", 10, 5); wait != 0 {
+		t.Fatalf("expected the request to be sent immediately but got a wait of %s", wait)
+	}
+}
+
+func TestRequestThrottlingResponseHeaders(t *testing.T) {
+	now := time.Now()
+	throttler := newRequestThrottler(RequestThrottlingOptions{Enabled: true, ReadsPerSecond: 1, WritesPerSecond: 1}, "https://management.azure.com/")
+	throttler.buckets = newTokenBuckets(func() time.Time { return now })
+
+	u, _ := url.Parse("https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example")
+	request := (&http.Request{Method: http.MethodGet, URL: u}).WithContext(context.Background())
+
+	request = throttler.beforeRequest(request)
+
+	throttler.afterResponse(request, &http.Response{
+		StatusCode: http.StatusOK,
+		Header: http.Header{
+			http.CanonicalHeaderKey(headerRateLimitRemainingSubscriptionReads): []string{"0"},
+		},
+	})
+
+	scope := throttler.scopeForRequest(request)
+	if wait := throttler.buckets.reserve(scope.subscriptionKey(), 10, 1); wait != time.Second {
+		t.Fatalf("expected a wait of 1s but got %s", wait)
+	}
+
+	// a different Subscription has its own budget
+	other := throttlingScope{subscriptionId: "11111111-1234-9876-4563-123456789012", category: requestCategoryRead}
+	if wait := throttler.buckets.reserve(other.subscriptionKey(), 10, 1); wait != 0 {
+		t.Fatalf("expected the request to be sent immediately but got a wait of %s", wait)
+	}
+}
+
+func TestTokenBucketsConfiguration(t *testing.T) {
+	now := time.Now()
+	buckets := newTokenBuckets(func() time.Time { return now })
+
+	buckets.observe("example", 10, 5, 0)
+	if wait := buckets.reserve("example", 10, 5); wait != 200*time.Millisecond {
+		t.Fatalf("expected a wait of 200ms but got %s", wait)
+	}
+
+	// a Provider configured with a different rate uses its own bucket for the same Subscription
+	if wait := buckets.reserve("example", 100, 10); wait != 0 {
+		t.Fatalf("expected the request to be sent immediately but got a wait of %s", wait)
+	}
+}
+
+func TestRequestThrottlingEachAttempt(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	throttler := newRequestThrottler(RequestThrottlingOptions{Enabled: true, ReadsPerSecond: 100}, server.URL)
+	throttler.buckets = newTokenBuckets(func() time.Time { return now })
+
+	request, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL+"/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example", nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	request = throttler.beforeRequest(request)
+
+	// the request is sent twice (as when it's retried by the SDK) without passing through the middleware again
+	for i := 0; i < 2; i++ {
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+		response.Body.Close()
+	}
+	if attempts != 2 {
+		t.Fatalf("expected 2 attempts but got %d", attempts)
+	}
+
+	// a token has been taken for each attempt
+	scope := throttler.scopeForRequest(request)
+	buckets := throttler.buckets.buckets
+	bucket, ok := buckets[tokenBucketKey{key: scope.subscriptionKey(), capacity: 1000, refillRate: 100}]
+	if !ok {
+		t.Fatalf("expected a bucket for the Subscription but got %+v", buckets)
+	}
+	if bucket.tokens != 998 {
+		t.Fatalf("expected 998 tokens to remain but got %f", bucket.tokens)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	testData := map[string]time.Duration{
+		"":                              0,
+		"invalid":                       0,
+		"17":                            17 * time.Second,
+		"Mon, 01 Jan 2024 00:01:00 GMT": time.Minute,
+	}
+
+	for input, expected := range testData {
+		if actual := parseRetryAfter(input, now); actual != expected {
+			t.Fatalf("expected %q to be parsed as %s but got %s", input, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,502 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// Azure Resource Manager returns a number of errors which are transient - for example a HTTP 409 when another
+// operation is in progress on a parent resource, a HTTP 404 when reading a resource which has only just been created,
+// or a HTTP 400 when assigning a role to a principal which hasn't replicated yet. Rather than each resource retrying
+// these errors itself, the retry policy classifies each response and resends requests which failed with a well-known
+// transient error, backing off between attempts.
+//
+// Requests are resent using the same client as the original request, so that each attempt is authorized with a current
+// access token and passes through the same pipeline (retrying throttled requests, request throttling, tracing and
+// recording) as the original request.
+
+const (
+	DefaultRetryPolicyMaxRetries                = 12
+	DefaultRetryPolicyMinBackoff                = 5 * time.Second
+	DefaultRetryPolicyMaxBackoff                = time.Minute
+	DefaultRetryPolicyMaxDuration               = 5 * time.Minute
+	DefaultRetryPolicyEventualConsistencyWindow = 5 * time.Minute
+)
+
+// TransientError describes an error code returned by Azure which is known to be transient
+type TransientError struct {
+	// Code is the error code returned in the response body, which is compared case-insensitively
+	Code string
+
+	// StatusCodes optionally limits this to responses with one of these HTTP status codes
+	StatusCodes []int
+}
+
+// DefaultTransientErrors are the error codes which are retried for every request
+var DefaultTransientErrors = []TransientError{
+	{
+		// another operation on this (or a parent/dependent) resource is in progress
+		Code:        "AnotherOperationInProgress",
+		StatusCodes: []int{http.StatusConflict},
+	},
+	{
+		Code:        "OperationPreempted",
+		StatusCodes: []int{http.StatusConflict},
+	},
+	{
+		Code: "RetryableError",
+	},
+	{
+		Code: "RetryableErrorDueToAnotherOperation",
+	},
+	{
+		// a resource referenced in the request (e.g. a Subnet) is still being provisioned
+		Code: "ReferencedResourceNotProvisioned",
+	},
+	{
+		// the principal for a role assignment hasn't replicated to Resource Manager yet
+		Code:        "PrincipalNotFound",
+		StatusCodes: []int{http.StatusBadRequest},
+	},
+}
+
+type RetryPolicyOptions struct {
+	// Disabled specifies that transient errors shouldn't be retried
+	Disabled bool
+
+	// MaxRetries is the maximum number of times a request is resent, which is also bounded by the deadline of the request
+	MaxRetries int
+
+	// MinBackoff and MaxBackoff bound the (exponential) delay between attempts, when no Retry-After header is returned
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// MaxDuration bounds the total time spent waiting between the attempts for a request, since resources may also
+	// retry the request themselves
+	MaxDuration time.Duration
+
+	// TransientErrors are the error codes which should be retried, defaulting to DefaultTransientErrors
+	TransientErrors []TransientError
+
+	// EventualConsistencyWindow is how long after a resource is created that a HTTP 404 reading it is retried - this
+	// only applies to the first read of the resource following its creation
+	EventualConsistencyWindow time.Duration
+}
+
+// RetryPolicyOverride allows a resource to change which errors are retried for the requests it sends
+type RetryPolicyOverride struct {
+	// Disabled specifies that transient errors shouldn't be retried for requests sent by this resource
+	Disabled bool
+
+	// AdditionalTransientErrors are error codes which should also be retried for requests sent by this resource
+	AdditionalTransientErrors []TransientError
+
+	// IgnoredErrorCodes are error codes which shouldn't be retried for requests sent by this resource, for example
+	// when the resource handles the error itself
+	IgnoredErrorCodes []string
+
+	// IgnoreEventualConsistency specifies that a HTTP 404 reading a resource which has just been created shouldn't be
+	// retried for requests sent by this resource
+	IgnoreEventualConsistency bool
+
+	// MaxRetries (when set) overrides the maximum number of times a request sent by this resource is resent
+	MaxRetries *int
+}
+
+type retryPolicyOverrideKey struct{}
+
+// WithRetryPolicyOverride returns a context which applies the override to any request sent using it
+func WithRetryPolicyOverride(ctx context.Context, override *RetryPolicyOverride) context.Context {
+	if override == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, retryPolicyOverrideKey{}, override)
+}
+
+type retryPolicyAttemptKey struct{}
+
+// isRetryPolicyAttempt returns whether the request is being resent by the retry policy, in which case the attempt is
+// returned to the retry policy which is resending it rather than being retried itself
+func isRetryPolicyAttempt(ctx context.Context) bool {
+	v, ok := ctx.Value(retryPolicyAttemptKey{}).(bool)
+	return ok && v
+}
+
+func retryPolicyOverrideFromContext(ctx context.Context) *RetryPolicyOverride {
+	if v, ok := ctx.Value(retryPolicyOverrideKey{}).(*RetryPolicyOverride); ok {
+		return v
+	}
+	return nil
+}
+
+// sharedCreatedResources tracks the resources created by every client, since a resource is typically created and
+// then read using different clients (e.g. a Create function followed by the Read function)
+var sharedCreatedResources = newCreatedResources(time.Now)
+
+// RetryPolicy resends requests to the go-azure-sdk clients which failed with a transient error
+type RetryPolicy struct {
+	options RetryPolicyOptions
+	created *createdResources
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryPolicy returns a RetryPolicy for the options, or nil if the retry policy is disabled
+func NewRetryPolicy(options RetryPolicyOptions) *RetryPolicy {
+	if options.Disabled {
+		return nil
+	}
+
+	if options.MaxRetries <= 0 {
+		options.MaxRetries = DefaultRetryPolicyMaxRetries
+	}
+	if options.MinBackoff <= 0 {
+		options.MinBackoff = DefaultRetryPolicyMinBackoff
+	}
+	if options.MaxBackoff < options.MinBackoff {
+		options.MaxBackoff = DefaultRetryPolicyMaxBackoff
+	}
+	if options.MaxDuration <= 0 {
+		options.MaxDuration = DefaultRetryPolicyMaxDuration
+	}
+	if options.TransientErrors == nil {
+		options.TransientErrors = DefaultTransientErrors
+	}
+	if options.EventualConsistencyWindow <= 0 {
+		options.EventualConsistencyWindow = DefaultRetryPolicyEventualConsistencyWindow
+	}
+
+	return &RetryPolicy{
+		options: options,
+		created: sharedCreatedResources,
+		sleep:   sleepWithContext,
+	}
+}
+
+// requestMiddleware returns a RequestMiddleware which buffers the request body, so that the request can be resent
+func (p *RetryPolicy) requestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		if request.Body == nil || request.Body == http.NoBody || request.GetBody != nil {
+			return request, nil
+		}
+
+		body, err := io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading the request body: %+v", err)
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		request.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+
+		return request, nil
+	}
+}
+
+// responseMiddleware returns a ResponseMiddleware which resends the request using the client whilst it fails with a
+// transient error
+func (p *RetryPolicy) responseMiddleware(c client.BaseClient) client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		if isRetryPolicyAttempt(request.Context()) {
+			return response, nil
+		}
+
+		override := retryPolicyOverrideFromContext(request.Context())
+		if override != nil && override.Disabled {
+			p.created.observe(request, response)
+			return response, nil
+		}
+
+		if request.Method == http.MethodGet {
+			// only the first read of a resource which has just been created is retried when it returns a HTTP 404
+			defer p.created.forget(request)
+		}
+
+		maxRetries := p.options.MaxRetries
+		if override != nil && override.MaxRetries != nil {
+			maxRetries = *override.MaxRetries
+		}
+
+		waited := time.Duration(0)
+		for attempt := 1; ; attempt++ {
+			p.created.observe(request, response)
+
+			reason := p.classify(request, response, override)
+			if reason == "" || attempt > maxRetries {
+				return response, nil
+			}
+
+			wait := p.backoff(attempt, response)
+			if deadline, ok := request.Context().Deadline(); ok && time.Until(deadline) < wait {
+				// there isn't time for another attempt, so the error is returned to the caller instead
+				return response, nil
+			}
+			if waited+wait > p.options.MaxDuration {
+				log.Printf("[DEBUG] AzureRM Retry Policy: %s request to %s failed with %s, not retrying since the retries have taken %s", request.Method, request.URL.Path, reason, waited)
+				return response, nil
+			}
+			waited += wait
+
+			retry, err := cloneRequestForRetry(request)
+			if err != nil {
+				return response, nil
+			}
+
+			log.Printf("[DEBUG] AzureRM Retry Policy: %s request to %s failed with %s, retrying in %s (attempt %d of %d)", request.Method, request.URL.Path, reason, wait, attempt, maxRetries)
+			if response.Body != nil {
+				_, _ = io.Copy(io.Discard, response.Body)
+				response.Body.Close()
+			}
+
+			if err := p.sleep(request.Context(), wait); err != nil {
+				return nil, fmt.Errorf("waiting to retry %s request to %s which failed with %s: %+v", request.Method, request.URL.Path, reason, err)
+			}
+
+			// the status code of each attempt is checked here, rather than by the client
+			resp, err := c.Execute(retry.Context(), &client.Request{
+				ValidStatusFunc: func(*http.Response, *odata.OData) bool {
+					return true
+				},
+				Client:  c,
+				Request: retry,
+			})
+			if resp == nil || resp.Response == nil {
+				return nil, fmt.Errorf("retrying %s request to %s which failed with %s: %+v", request.Method, request.URL.Path, reason, err)
+			}
+			response = resp.Response
+		}
+	}
+}
+
+// classify returns a description of the transient error which the response failed with, or an empty string if the
+// request shouldn't be retried
+func (p *RetryPolicy) classify(request *http.Request, response *http.Response, override *RetryPolicyOverride) string {
+	if response == nil || response.StatusCode < http.StatusBadRequest {
+		return ""
+	}
+
+	if response.StatusCode == http.StatusNotFound && request.Method == http.MethodGet {
+		if (override == nil || !override.IgnoreEventualConsistency) && p.created.recentlyCreated(request, p.options.EventualConsistencyWindow) {
+			return "HTTP 404 reading a resource which has just been created"
+		}
+	}
+
+	transientErrors := p.options.TransientErrors
+	if override != nil && len(override.AdditionalTransientErrors) > 0 {
+		transientErrors = append(append([]TransientError{}, transientErrors...), override.AdditionalTransientErrors...)
+	}
+
+	for _, code := range responseErrorCodes(response) {
+		if override != nil && containsErrorCode(override.IgnoredErrorCodes, code) {
+			continue
+		}
+
+		for _, transientError := range transientErrors {
+			if !strings.EqualFold(transientError.Code, code) {
+				continue
+			}
+			if len(transientError.StatusCodes) > 0 && !containsStatusCode(transientError.StatusCodes, response.StatusCode) {
+				continue
+			}
+
+			return fmt.Sprintf("HTTP %d %s", response.StatusCode, transientError.Code)
+		}
+	}
+
+	return ""
+}
+
+// backoff returns how long to wait before the next attempt, using the Retry-After header when it's returned
+func (p *RetryPolicy) backoff(attempt int, response *http.Response) time.Duration {
+	if response != nil {
+		if retryAfter := parseRetryAfter(response.Header.Get(headerRetryAfter), time.Now()); retryAfter > 0 {
+			return retryAfter
+		}
+	}
+
+	wait := p.options.MinBackoff
+	for i := 1; i < attempt && wait < p.options.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > p.options.MaxBackoff {
+		wait = p.options.MaxBackoff
+	}
+
+	// jitter avoids concurrent requests which failed for the same reason being resent at the same moment
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// responseErrorCodes returns the error codes contained in the response body, including any nested details
+func responseErrorCodes(response *http.Response) []string {
+	if response.Body == nil || response.Body == http.NoBody {
+		return nil
+	}
+
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	var payload struct {
+		transientErrorDetail
+		Error *transientErrorDetail `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil
+	}
+
+	codes := payload.codes()
+	if payload.Error != nil {
+		codes = append(codes, payload.Error.codes()...)
+	}
+	return codes
+}
+
+type transientErrorDetail struct {
+	Code    string                 `json:"code"`
+	Details []transientErrorDetail `json:"details"`
+}
+
+func (d transientErrorDetail) codes() []string {
+	codes := make([]string, 0)
+	if d.Code != "" {
+		codes = append(codes, d.Code)
+	}
+	for _, v := range d.Details {
+		codes = append(codes, v.codes()...)
+	}
+	return codes
+}
+
+func containsErrorCode(codes []string, code string) bool {
+	for _, v := range codes {
+		if strings.EqualFold(v, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsStatusCode(statusCodes []int, statusCode int) bool {
+	for _, v := range statusCodes {
+		if v == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneRequestForRetry returns a copy of the request which can be resent - the Authorization header is removed, since
+// the client authorizes each attempt using a current access token
+func cloneRequestForRetry(request *http.Request) (*http.Request, error) {
+	retry := request.Clone(context.WithValue(request.Context(), retryPolicyAttemptKey{}, true))
+	retry.Header.Del("Authorization")
+	if request.Body != nil && request.Body != http.NoBody {
+		if request.GetBody == nil {
+			return nil, fmt.Errorf("the request body can't be resent")
+		}
+		body, err := request.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return retry, nil
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// createdResources tracks when resources were created, so that a HTTP 404 reading a resource which has just been
+// created can be distinguished from a resource which has been deleted
+type createdResources struct {
+	lock      sync.Mutex
+	resources map[string]time.Time
+	now       func() time.Time
+}
+
+func newCreatedResources(now func() time.Time) *createdResources {
+	return &createdResources{
+		resources: make(map[string]time.Time),
+		now:       now,
+	}
+}
+
+func (c *createdResources) observe(request *http.Request, response *http.Response) {
+	if request == nil || request.URL == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := createdResourceKey(request)
+	switch request.Method {
+	case http.MethodPut:
+		if response != nil && response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+			c.resources[key] = c.now()
+		}
+	case http.MethodDelete:
+		// a HTTP 404 is expected once a resource has been deleted
+		delete(c.resources, key)
+	}
+}
+
+// forget stops tracking the resource once it's been read, so that a HTTP 404 from subsequent reads (for example once
+// the resource has been deleted outside of Terraform, or when checking whether the resource exists) isn't retried
+func (c *createdResources) forget(request *http.Request) {
+	if request == nil || request.URL == nil {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.resources, createdResourceKey(request))
+}
+
+func (c *createdResources) recentlyCreated(request *http.Request, window time.Duration) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	key := createdResourceKey(request)
+	created, ok := c.resources[key]
+	if !ok {
+		return false
+	}
+	if c.now().Sub(created) > window {
+		delete(c.resources, key)
+		return false
+	}
+	return true
+}
+
+func createdResourceKey(request *http.Request) string {
+	return strings.ToLower(request.URL.Host + request.URL.Path)
+}
@@ -0,0 +1,338 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"golang.org/x/oauth2"
+)
+
+func TestRetryPolicyClassify(t *testing.T) {
+	testData := []struct {
+		Name       string
+		StatusCode int
+		Body       string
+		Override   *RetryPolicyOverride
+		Expected   bool
+	}{
+		{
+			Name:       "success",
+			StatusCode: http.StatusOK,
+			Body:       `{"error":{"code":"AnotherOperationInProgress"}}`,
+			Expected:   false,
+		},
+		{
+			Name:       "another operation in progress",
+			StatusCode: http.StatusConflict,
+			Body:       `{"error":{"code":"AnotherOperationInProgress","message":"Another operation on this or dependent resource is in progress."}}`,
+			Expected:   true,
+		},
+		{
+			Name:       "another operation in progress with an unexpected status code",
+			StatusCode: http.StatusBadRequest,
+			Body:       `{"error":{"code":"AnotherOperationInProgress"}}`,
+			Expected:   false,
+		},
+		{
+			Name:       "nested error code",
+			StatusCode: http.StatusBadRequest,
+			Body:       `{"error":{"code":"InvalidRequest","details":[{"code":"ReferencedResourceNotProvisioned"}]}}`,
+			Expected:   true,
+		},
+		{
+			Name:       "top level error code",
+			StatusCode: http.StatusBadRequest,
+			Body:       `{"code":"PrincipalNotFound","message":"Principal 00000000000000000000000000000000 does not exist in the directory."}`,
+			Expected:   true,
+		},
+		{
+			Name:       "error code is case insensitive",
+			StatusCode: http.StatusConflict,
+			Body:       `{"error":{"code":"retryableError"}}`,
+			Expected:   true,
+		},
+		{
+			Name:       "unrelated error",
+			StatusCode: http.StatusConflict,
+			Body:       `{"error":{"code":"Conflict"}}`,
+			Expected:   false,
+		},
+		{
+			Name:       "not json",
+			StatusCode: http.StatusConflict,
+			Body:       `AnotherOperationInProgress`,
+			Expected:   false,
+		},
+		{
+			Name:       "additional error code",
+			StatusCode: http.StatusConflict,
+			Body:       `{"error":{"code":"Conflict"}}`,
+			Override: &RetryPolicyOverride{
+				AdditionalTransientErrors: []TransientError{
+					{
+						Code:        "Conflict",
+						StatusCodes: []int{http.StatusConflict},
+					},
+				},
+			},
+			Expected: true,
+		},
+		{
+			Name:       "ignored error code",
+			StatusCode: http.StatusBadRequest,
+			Body:       `{"code":"PrincipalNotFound"}`,
+			Override: &RetryPolicyOverride{
+				IgnoredErrorCodes: []string{"PrincipalNotFound"},
+			},
+			Expected: false,
+		},
+	}
+
+	policy := NewRetryPolicy(RetryPolicyOptions{})
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		request := httptest.NewRequest(http.MethodPut, "https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example", nil)
+		response := &http.Response{
+			StatusCode: v.StatusCode,
+			Body:       io.NopCloser(strings.NewReader(v.Body)),
+		}
+
+		actual := policy.classify(request, response, v.Override) != ""
+		if actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+
+		// the body must still be readable once it's been classified
+		body, err := io.ReadAll(response.Body)
+		if err != nil || string(body) != v.Body {
+			t.Fatalf("expected the response body to be preserved but got %q (%+v)", string(body), err)
+		}
+	}
+}
+
+func TestRetryPolicyEventualConsistency(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := NewRetryPolicy(RetryPolicyOptions{
+		EventualConsistencyWindow: time.Minute,
+	})
+	policy.created = newCreatedResources(func() time.Time {
+		return now
+	})
+
+	resourceUrl := "https://management.azure.com/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example"
+	notFound := func() *http.Response {
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader(`{"error":{"code":"ResourceGroupNotFound"}}`)),
+		}
+	}
+
+	get := httptest.NewRequest(http.MethodGet, resourceUrl, nil)
+	if policy.classify(get, notFound(), nil) != "" {
+		t.Fatalf("expected a HTTP 404 for a resource which hasn't been created not to be retried")
+	}
+
+	policy.created.observe(httptest.NewRequest(http.MethodPut, resourceUrl, nil), &http.Response{StatusCode: http.StatusCreated})
+	if policy.classify(get, notFound(), nil) == "" {
+		t.Fatalf("expected a HTTP 404 for a resource which has just been created to be retried")
+	}
+	if policy.classify(get, notFound(), &RetryPolicyOverride{IgnoreEventualConsistency: true}) != "" {
+		t.Fatalf("expected a HTTP 404 not to be retried when the resource ignores eventual consistency")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if policy.classify(get, notFound(), nil) != "" {
+		t.Fatalf("expected a HTTP 404 for a resource created outside of the window not to be retried")
+	}
+
+	policy.created.observe(httptest.NewRequest(http.MethodPut, resourceUrl, nil), &http.Response{StatusCode: http.StatusOK})
+	policy.created.forget(get)
+	if policy.classify(get, notFound(), nil) != "" {
+		t.Fatalf("expected a HTTP 404 for a resource which has already been read not to be retried")
+	}
+
+	policy.created.observe(httptest.NewRequest(http.MethodPut, resourceUrl, nil), &http.Response{StatusCode: http.StatusOK})
+	policy.created.observe(httptest.NewRequest(http.MethodDelete, resourceUrl, nil), &http.Response{StatusCode: http.StatusAccepted})
+	if policy.classify(get, notFound(), nil) != "" {
+		t.Fatalf("expected a HTTP 404 for a resource which has been deleted not to be retried")
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := NewRetryPolicy(RetryPolicyOptions{
+		MinBackoff: 2 * time.Second,
+		MaxBackoff: 10 * time.Second,
+	})
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		wait := policy.backoff(attempt, nil)
+		if wait < time.Second || wait > 10*time.Second {
+			t.Fatalf("expected the backoff for attempt %d to be between 1s and 10s but got %s", attempt, wait)
+		}
+	}
+
+	response := &http.Response{
+		Header: http.Header{
+			headerRetryAfter: []string{"30"},
+		},
+	}
+	if wait := policy.backoff(1, response); wait != 30*time.Second {
+		t.Fatalf("expected the Retry-After header to be used but got %s", wait)
+	}
+}
+
+type testRetryPolicyAuthorizer struct {
+	tokens int
+}
+
+func (a *testRetryPolicyAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	a.tokens++
+	return &oauth2.Token{
+		AccessToken: fmt.Sprintf("token-%d", a.tokens),
+		TokenType:   "Bearer",
+	}, nil
+}
+
+func (a *testRetryPolicyAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return nil, nil
+}
+
+func TestRetryPolicyResendsRequest(t *testing.T) {
+	attempts := 0
+	authorizationHeaders := make(map[string]struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		authorizationHeaders[r.Header.Get("Authorization")] = struct{}{}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"name":"example"}` {
+			t.Errorf("expected the request body to be resent but got %q", string(body))
+		}
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":{"code":"AnotherOperationInProgress"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	policy := NewRetryPolicy(RetryPolicyOptions{})
+	policy.created = newCreatedResources(time.Now)
+	waits := make([]time.Duration, 0)
+	policy.sleep = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	// every attempt passes through the client's middleware
+	requestMiddlewareCalls := 0
+	c := client.NewClient(server.URL, "Example", "2020-01-01")
+	c.SetAuthorizer(&testRetryPolicyAuthorizer{})
+	c.AppendRequestMiddleware(func(request *http.Request) (*http.Request, error) {
+		requestMiddlewareCalls++
+		return request, nil
+	})
+	c.AppendRequestMiddleware(policy.requestMiddleware())
+	c.AppendResponseMiddleware(policy.responseMiddleware(c))
+
+	send := func(ctx context.Context) (*client.Response, error) {
+		request, err := c.NewRequest(ctx, client.RequestOptions{
+			ContentType:         "application/json; charset=utf-8",
+			ExpectedStatusCodes: []int{http.StatusOK},
+			HttpMethod:          http.MethodPut,
+			Path:                "/example",
+		})
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		if err := request.Marshal(map[string]string{"name": "example"}); err != nil {
+			t.Fatalf("marshaling request: %+v", err)
+		}
+		return c.Execute(ctx, request)
+	}
+
+	response, err := send(context.Background())
+	if err != nil {
+		t.Fatalf("retrying request: %+v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected the final response to be returned but got HTTP %d", response.StatusCode)
+	}
+	if attempts != 3 || len(waits) != 2 {
+		t.Fatalf("expected the request to be sent 3 times with 2 waits but got %d and %d", attempts, len(waits))
+	}
+	if requestMiddlewareCalls != 3 {
+		t.Fatalf("expected each attempt to pass through the request middleware but got %d calls", requestMiddlewareCalls)
+	}
+	if len(authorizationHeaders) != 3 {
+		t.Fatalf("expected each attempt to be authorized with a new access token but got %d distinct tokens", len(authorizationHeaders))
+	}
+
+	// the override from the context limits the number of retries
+	attempts = 0
+	response, err = send(WithRetryPolicyOverride(context.Background(), &RetryPolicyOverride{
+		MaxRetries: pointer.To(1),
+	}))
+	if err == nil {
+		t.Fatalf("expected an error to be returned once the retries are exhausted")
+	}
+	if response.StatusCode != http.StatusConflict || attempts != 2 {
+		t.Fatalf("expected the request to be sent twice and the error returned but got HTTP %d after %d attempts", response.StatusCode, attempts)
+	}
+}
+
+func TestRetryPolicyMaxDuration(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set(headerRetryAfter, "60")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":{"code":"AnotherOperationInProgress"}}`))
+	}))
+	defer server.Close()
+
+	policy := NewRetryPolicy(RetryPolicyOptions{
+		MaxDuration: 150 * time.Second,
+	})
+	policy.created = newCreatedResources(time.Now)
+	policy.sleep = func(_ context.Context, _ time.Duration) error {
+		return nil
+	}
+
+	c := client.NewClient(server.URL, "Example", "2020-01-01")
+	c.SetAuthorizer(&testRetryPolicyAuthorizer{})
+	c.AppendRequestMiddleware(policy.requestMiddleware())
+	c.AppendResponseMiddleware(policy.responseMiddleware(c))
+
+	request, err := c.NewRequest(context.Background(), client.RequestOptions{
+		ContentType:         "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{http.StatusOK},
+		HttpMethod:          http.MethodGet,
+		Path:                "/example",
+	})
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	if _, err := c.Execute(context.Background(), request); err == nil {
+		t.Fatalf("expected an error to be returned once the retries have taken the maximum duration")
+	}
+
+	// two waits of 60s fit within the maximum duration, a third doesn't
+	if attempts != 3 {
+		t.Fatalf("expected the request to be sent 3 times but got %d", attempts)
+	}
+}
@@ -23,3 +23,38 @@ func EnhancedValidationEnabled() bool {
 
 	return strings.EqualFold(value, "true")
 }
+
+// EnhancedValidationNameAvailabilityEnabled returns whether Enhanced Validation should check that the
+// globally unique names for new resources (for example Storage Accounts and Key Vaults) are available.
+//
+// This functionality calls out to the CheckNameAvailability API for each of these resources during
+// the plan, so that a conflicting name is surfaced before any resources are created during the apply.
+//
+// This is opt-in, and can be enabled by setting the Environment Variable
+// `ARM_PROVIDER_ENHANCED_VALIDATION_NAME_AVAILABILITY` to `true` - however is disabled when Enhanced
+// Validation is disabled.
+func EnhancedValidationNameAvailabilityEnabled() bool {
+	if !EnhancedValidationEnabled() {
+		return false
+	}
+
+	return strings.EqualFold(os.Getenv("ARM_PROVIDER_ENHANCED_VALIDATION_NAME_AVAILABILITY"), "true")
+}
+
+// EnhancedValidationSkusEnabled returns whether Enhanced Validation should check the Virtual Machine sizes
+// and Managed Disk SKUs used by new resources are available in the specified Location (and Availability Zones),
+// and that there's sufficient regional vCPU quota for them.
+//
+// This functionality calls out to the Resource SKUs and Compute Usages APIs during the plan (caching the
+// results for each Subscription and Location), so that these are surfaced before any resources are created
+// during the apply.
+//
+// This is opt-in, and can be enabled by setting the Environment Variable
+// `ARM_PROVIDER_ENHANCED_VALIDATION_SKUS` to `true` - however is disabled when Enhanced Validation is disabled.
+func EnhancedValidationSkusEnabled() bool {
+	if !EnhancedValidationEnabled() {
+		return false
+	}
+
+	return strings.EqualFold(os.Getenv("ARM_PROVIDER_ENHANCED_VALIDATION_SKUS"), "true")
+}
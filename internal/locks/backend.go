@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+)
+
+const (
+	// BackendTypeLocal only coordinates locks within the current provider process
+	BackendTypeLocal = "local"
+
+	// BackendTypeFile coordinates locks between provider processes using lock files within a shared directory
+	BackendTypeFile = "file"
+
+	// BackendTypeAzureBlob coordinates locks between provider processes using leases on blobs within a container
+	BackendTypeAzureBlob = "azure_blob"
+)
+
+// Backend coordinates locks between provider processes (for example, two workspaces which both add subnets to the
+// same virtual network) - and is used in addition to the locks held within the current provider process, such that
+// a Backend is only ever asked to lock a key once within each process.
+type Backend interface {
+	// Lock blocks until the lock for the key is acquired, returning an error if the context is cancelled first
+	Lock(ctx context.Context, key string) error
+
+	// Unlock releases the lock for the key
+	Unlock(key string) error
+}
+
+// BackendOptions configures the Backend used to coordinate locks between provider processes
+type BackendOptions struct {
+	// Type is the type of Backend, one of BackendTypeLocal, BackendTypeFile or BackendTypeAzureBlob
+	Type string
+
+	// FileDirectory is the directory used for lock files when Type is BackendTypeFile
+	FileDirectory string
+
+	// BlobContainerURL is the URL of the container used for lock blobs when Type is BackendTypeAzureBlob
+	BlobContainerURL string
+
+	// BlobAccessKey is the Storage Account access key used to authenticate to the container, when not specified the
+	// Storage authorizer for the Provider is used instead
+	BlobAccessKey string
+}
+
+// NewBackend returns the Backend for the options, or nil when locks only need to be coordinated within this process
+func NewBackend(options BackendOptions, storageAuthorizer auth.Authorizer) (Backend, error) {
+	switch options.Type {
+	case "", BackendTypeLocal:
+		return nil, nil
+
+	case BackendTypeFile:
+		if options.FileDirectory == "" {
+			return nil, fmt.Errorf("a directory must be specified when using the %q lock backend", BackendTypeFile)
+		}
+		return NewFileBackend(options.FileDirectory)
+
+	case BackendTypeAzureBlob:
+		if options.BlobContainerURL == "" {
+			return nil, fmt.Errorf("a container URL must be specified when using the %q lock backend", BackendTypeAzureBlob)
+		}
+		client, err := NewBlobLeaseClient(options.BlobContainerURL, options.BlobAccessKey, storageAuthorizer)
+		if err != nil {
+			return nil, fmt.Errorf("building the blob client for the %q lock backend: %+v", BackendTypeAzureBlob, err)
+		}
+		return NewBlobBackend(client), nil
+	}
+
+	return nil, fmt.Errorf("unsupported lock backend %q", options.Type)
+}
+
+type backendContextKey struct{}
+
+// WithBackend returns a copy of the context which also coordinates the locks acquired using it with the Backend - the
+// Backend is configured per instance of the Provider, so is passed along with the context for each operation rather
+// than being held globally. A nil Backend means that locks are only coordinated within this process.
+func WithBackend(ctx context.Context, b Backend) context.Context {
+	return context.WithValue(ctx, backendContextKey{}, b)
+}
+
+func backendFromContext(ctx context.Context) Backend {
+	if b, ok := ctx.Value(backendContextKey{}).(Backend); ok {
+		return b
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Backend = &BlobBackend{}
+
+// ErrLeaseAlreadyPresent is returned by a BlobLeaseClient when the blob is already leased by another process
+var ErrLeaseAlreadyPresent = errors.New("the blob is already leased")
+
+// ErrLeaseLost is returned when the lease on a blob is no longer held by this process, either because it couldn't be
+// renewed before it expired or because the blob has since been leased by another process
+var ErrLeaseLost = errors.New("the lease on the blob was lost")
+
+// BlobLeaseClient is the subset of the Blob Storage API used by the BlobBackend, which allows the BlobBackend to be used
+// with a Storage Account, the Azurite emulator, or an in-memory implementation in tests.
+type BlobLeaseClient interface {
+	// EnsureBlob creates the (empty) blob if it doesn't already exist
+	EnsureBlob(ctx context.Context, name string) error
+
+	// AcquireLease acquires a lease on the blob for the duration, returning ErrLeaseAlreadyPresent if the blob is
+	// already leased
+	AcquireLease(ctx context.Context, name string, duration time.Duration) (leaseId string, err error)
+
+	// RenewLease renews the lease on the blob, returning ErrLeaseLost if the lease is no longer held
+	RenewLease(ctx context.Context, name string, leaseId string) error
+
+	// ReleaseLease releases the lease on the blob
+	ReleaseLease(ctx context.Context, name string, leaseId string) error
+}
+
+// BlobBackend coordinates locks between provider processes on different machines using a lease on a blob for each key
+// within a container. Whilst a lock is held the lease is renewed periodically, so that the lease for a process which
+// exits without releasing it expires.
+type BlobBackend struct {
+	client BlobLeaseClient
+
+	// leaseDuration is the duration of each lease, which must be between 15 and 60 seconds
+	leaseDuration time.Duration
+
+	// pollInterval is how often to retry acquiring a lease which is held by another process
+	pollInterval time.Duration
+
+	lock sync.Mutex
+	held map[string]*blobLease
+}
+
+type blobLease struct {
+	leaseId   string
+	heartbeat *heartbeat
+}
+
+// NewBlobBackend returns a BlobBackend using the client
+func NewBlobBackend(client BlobLeaseClient) *BlobBackend {
+	return &BlobBackend{
+		client:        client,
+		leaseDuration: 45 * time.Second,
+		pollInterval:  5 * time.Second,
+		held:          make(map[string]*blobLease),
+	}
+}
+
+func (b *BlobBackend) Lock(ctx context.Context, key string) error {
+	name := b.blobName(key)
+	if err := b.client.EnsureBlob(ctx, name); err != nil {
+		return fmt.Errorf("creating the lock blob %q: %+v", name, err)
+	}
+
+	for {
+		leaseId, err := b.client.AcquireLease(ctx, name, b.leaseDuration)
+		if err == nil {
+			b.lock.Lock()
+			b.held[key] = &blobLease{
+				leaseId: leaseId,
+				heartbeat: startHeartbeat(b.leaseDuration/3, b.leaseDuration, func() error {
+					ctx, cancel := context.WithTimeout(context.Background(), b.leaseDuration/3)
+					defer cancel()
+					return b.client.RenewLease(ctx, name, leaseId)
+				}),
+			}
+			b.lock.Unlock()
+			return nil
+		}
+		if !errors.Is(err, ErrLeaseAlreadyPresent) {
+			return fmt.Errorf("acquiring a lease on the lock blob %q: %+v", name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the lease on the lock blob %q to be released: %+v", name, ctx.Err())
+		case <-time.After(b.pollInterval):
+		}
+	}
+}
+
+func (b *BlobBackend) Unlock(key string) error {
+	b.lock.Lock()
+	lease, ok := b.held[key]
+	delete(b.held, key)
+	b.lock.Unlock()
+
+	if !ok {
+		return fmt.Errorf("the lock %q isn't held by this process", key)
+	}
+	lease.heartbeat.Stop()
+
+	name := b.blobName(key)
+	if err := lease.heartbeat.Err(); err != nil {
+		// the lease may now be held by another process, so it mustn't be released
+		return fmt.Errorf("the lock %q wasn't held for the whole operation, so it may have run at the same time as another process: %w", key, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.leaseDuration)
+	defer cancel()
+
+	if err := b.client.ReleaseLease(ctx, name, lease.leaseId); err != nil {
+		return fmt.Errorf("releasing the lease on the lock blob %q: %+v", name, err)
+	}
+
+	return nil
+}
+
+// blobName returns the name of the blob for the key, which is hashed since keys can contain any character - Resource
+// IDs are case-insensitive, so the key is lower-cased first
+func (b *BlobBackend) blobName(key string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(key)))
+	return hex.EncodeToString(hash[:]) + ".lock"
+}
+
+// heartbeat periodically renews a lock held by a Backend until it's stopped, or until the lock is lost
+type heartbeat struct {
+	stop chan struct{}
+	done chan struct{}
+
+	// err is the reason the lock was lost, which is only safe to read once the heartbeat is done
+	err error
+}
+
+// startHeartbeat calls renew every interval - the lock is lost when renew returns ErrLeaseLost, or when it hasn't
+// succeeded for the expiry (at which point the lock has expired), and isn't renewed any further
+func startHeartbeat(interval time.Duration, expiry time.Duration, renew func() error) *heartbeat {
+	h := &heartbeat{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	go func() {
+		defer close(h.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		renewed := time.Now()
+		for {
+			select {
+			case <-h.stop:
+				return
+			case <-ticker.C:
+				err := renew()
+				if err == nil {
+					renewed = time.Now()
+					continue
+				}
+
+				if !errors.Is(err, ErrLeaseLost) && time.Since(renewed) < expiry {
+					log.Printf("[WARN] renewing the lease on a lock blob: %+v", err)
+					continue
+				}
+
+				if !errors.Is(err, ErrLeaseLost) {
+					err = fmt.Errorf("%w since it couldn't be renewed for %s: %+v", ErrLeaseLost, time.Since(renewed).Round(time.Second), err)
+				}
+				log.Printf("[ERROR] %+v", err)
+				h.err = err
+				return
+			}
+		}
+	}()
+
+	return h
+}
+
+// Stop stops renewing the lock, and waits for any renewal in progress to complete
+func (h *heartbeat) Stop() {
+	close(h.stop)
+	<-h.done
+}
+
+// Err returns the reason the lock was lost, or nil if it was held until the heartbeat was stopped - Stop must be
+// called first
+func (h *heartbeat) Err() error {
+	return h.err
+}
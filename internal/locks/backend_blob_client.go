@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+var _ BlobLeaseClient = blobLeaseClient{}
+
+// blobLeaseClient implements BlobLeaseClient using the Blob Storage API
+type blobLeaseClient struct {
+	client        *blobs.Client
+	containerName string
+}
+
+// NewBlobLeaseClient returns a BlobLeaseClient for the container at the URL - which is either in the format
+// `https://{account}.blob.core.windows.net/{container}`, or `http://127.0.0.1:10000/{account}/{container}` when
+// using the Azurite emulator. When an access key is specified this is used to authenticate, otherwise the authorizer.
+func NewBlobLeaseClient(containerUrl string, accessKey string, authorizer auth.Authorizer) (BlobLeaseClient, error) {
+	u, err := url.Parse(containerUrl)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("expected the container URL to be in the format `https://{account}.blob.core.windows.net/{container}` but got %q", containerUrl)
+	}
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	containerName := segments[len(segments)-1]
+	if containerName == "" || len(segments) > 2 {
+		return nil, fmt.Errorf("expected the container URL to be in the format `https://{account}.blob.core.windows.net/{container}` but got %q", containerUrl)
+	}
+
+	// the account name is the first segment of the path when using the emulator, and otherwise the subdomain
+	accountName := strings.Split(u.Host, ".")[0]
+	baseUri := fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+	if len(segments) == 2 {
+		accountName = segments[0]
+		baseUri = fmt.Sprintf("%s/%s", baseUri, accountName)
+	}
+
+	client, err := blobs.NewWithBaseUri(baseUri)
+	if err != nil {
+		return nil, fmt.Errorf("building the Blobs client: %+v", err)
+	}
+
+	if accessKey != "" {
+		authorizer, err = auth.NewSharedKeyAuthorizer(accountName, accessKey, auth.SharedKey)
+		if err != nil {
+			return nil, fmt.Errorf("building the Shared Key authorizer: %+v", err)
+		}
+	}
+	if authorizer == nil {
+		return nil, fmt.Errorf("either an access key or an authorizer must be specified")
+	}
+	client.Client.SetAuthorizer(authorizer)
+
+	return blobLeaseClient{
+		client:        client,
+		containerName: containerName,
+	}, nil
+}
+
+func (c blobLeaseClient) EnsureBlob(ctx context.Context, name string) error {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+
+	existing, err := c.client.GetProperties(ctx, c.containerName, name, blobs.GetPropertiesInput{})
+	if err == nil {
+		return nil
+	}
+	if !response.WasNotFound(existing.HttpResponse) {
+		return fmt.Errorf("retrieving the blob: %+v", err)
+	}
+
+	resp, err := c.client.PutBlockBlob(ctx, c.containerName, name, blobs.PutBlockBlobInput{
+		Content: pointer.To([]byte{}),
+	})
+	if err != nil {
+		// another process may have created (and leased) the blob in the meantime
+		if resp.HttpResponse != nil && resp.HttpResponse.StatusCode == http.StatusPreconditionFailed {
+			return nil
+		}
+		return fmt.Errorf("creating the blob: %+v", err)
+	}
+
+	return nil
+}
+
+func (c blobLeaseClient) AcquireLease(ctx context.Context, name string, duration time.Duration) (string, error) {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.client.AcquireLease(ctx, c.containerName, name, blobs.AcquireLeaseInput{
+		LeaseDuration: int(duration.Seconds()),
+	})
+	if err != nil {
+		if response.WasConflict(resp.HttpResponse) {
+			return "", ErrLeaseAlreadyPresent
+		}
+		return "", err
+	}
+
+	return resp.LeaseID, nil
+}
+
+func (c blobLeaseClient) RenewLease(ctx context.Context, name string, leaseId string) error {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.client.RenewLease(ctx, c.containerName, name, blobs.RenewLeaseInput{
+		LeaseID: leaseId,
+	})
+	if err != nil {
+		// the lease has expired and the blob has since been leased by another process
+		if response.WasConflict(resp.HttpResponse) {
+			return fmt.Errorf("%w: %+v", ErrLeaseLost, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (c blobLeaseClient) ReleaseLease(ctx context.Context, name string, leaseId string) error {
+	ctx, cancel := withRequestTimeout(ctx)
+	defer cancel()
+
+	_, err := c.client.ReleaseLease(ctx, c.containerName, name, blobs.ReleaseLeaseInput{
+		LeaseID: leaseId,
+	})
+	return err
+}
+
+// withRequestTimeout bounds each request, since the Storage clients require a context with a deadline and the
+// context used to wait for a lock may not have one
+func withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, time.Minute)
+}
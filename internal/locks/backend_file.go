@@ -0,0 +1,112 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var _ Backend = &FileBackend{}
+
+// FileBackend coordinates locks between provider processes on the same machine (for example, a shared runner) using
+// an advisory lock on a file for each key within a directory. Since these are released by the operating system when
+// a process exits, a process which exits without releasing a lock can't block other processes.
+type FileBackend struct {
+	directory string
+
+	// pollInterval is how often to check whether a lock has been released
+	pollInterval time.Duration
+
+	lock sync.Mutex
+	held map[string]*os.File
+}
+
+// NewFileBackend returns a FileBackend using lock files within the directory, which is created if it doesn't exist
+func NewFileBackend(directory string) (*FileBackend, error) {
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return nil, fmt.Errorf("creating the lock directory %q: %+v", directory, err)
+	}
+
+	return &FileBackend{
+		directory:    directory,
+		pollInterval: time.Second,
+		held:         make(map[string]*os.File),
+	}, nil
+}
+
+func (b *FileBackend) Lock(ctx context.Context, key string) error {
+	path := b.path(key)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening the lock file %q: %+v", path, err)
+	}
+
+	for {
+		locked, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return fmt.Errorf("locking the lock file %q: %+v", path, err)
+		}
+		if locked {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			f.Close()
+			return fmt.Errorf("waiting for the lock file %q to be released: %+v", path, ctx.Err())
+		case <-time.After(b.pollInterval):
+		}
+	}
+
+	// the key is written to the lock file to help identify which lock a file is for, since the file name is hashed
+	hostname, _ := os.Hostname()
+	if err := f.Truncate(0); err == nil {
+		_, _ = f.WriteAt([]byte(fmt.Sprintf("%s\nheld by process %d on %q since %s\n", key, os.Getpid(), hostname, time.Now().Format(time.RFC3339))), 0)
+	}
+
+	b.lock.Lock()
+	b.held[key] = f
+	b.lock.Unlock()
+
+	return nil
+}
+
+func (b *FileBackend) Unlock(key string) error {
+	b.lock.Lock()
+	f, ok := b.held[key]
+	delete(b.held, key)
+	b.lock.Unlock()
+
+	if !ok {
+		return fmt.Errorf("the lock %q isn't held by this process", key)
+	}
+
+	// the lock file is intentionally left in place, since removing it would allow another process to lock a new file
+	// at the same path whilst a third process is waiting on the old one
+	unlockErr := unlockFile(f)
+	if err := f.Close(); err != nil && unlockErr == nil {
+		unlockErr = err
+	}
+	if unlockErr != nil {
+		return fmt.Errorf("unlocking the lock file %q: %+v", f.Name(), unlockErr)
+	}
+
+	return nil
+}
+
+// path returns the path of the lock file for the key, which is hashed since keys can contain any character - Resource
+// IDs are case-insensitive, so the key is lower-cased first
+func (b *FileBackend) path(key string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(key)))
+	return filepath.Join(b.directory, hex.EncodeToString(hash[:])+".lock")
+}
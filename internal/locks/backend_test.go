@@ -0,0 +1,260 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileBackend(t *testing.T) {
+	directory := t.TempDir()
+
+	// each backend represents a separate provider process sharing the directory
+	first, err := NewFileBackend(directory)
+	if err != nil {
+		t.Fatalf("building backend: %+v", err)
+	}
+	first.pollInterval = 10 * time.Millisecond
+	second, err := NewFileBackend(directory)
+	if err != nil {
+		t.Fatalf("building backend: %+v", err)
+	}
+	second.pollInterval = 10 * time.Millisecond
+
+	testBackend(t, first, second)
+}
+
+func TestBlobBackend(t *testing.T) {
+	client := &fakeBlobLeaseClient{
+		leases: make(map[string]string),
+	}
+
+	first := NewBlobBackend(client)
+	first.pollInterval = 10 * time.Millisecond
+	second := NewBlobBackend(client)
+	second.pollInterval = 10 * time.Millisecond
+
+	testBackend(t, first, second)
+}
+
+func TestBlobBackendLeaseLost(t *testing.T) {
+	client := &fakeBlobLeaseClient{
+		leases: make(map[string]string),
+	}
+
+	backend := NewBlobBackend(client)
+	backend.leaseDuration = 30 * time.Millisecond
+	if err := backend.Lock(context.Background(), "virtualNetwork.example"); err != nil {
+		t.Fatalf("acquiring the lock: %+v", err)
+	}
+
+	// the lease expires and is acquired by another process
+	client.lock.Lock()
+	client.leases[backend.blobName("virtualNetwork.example")] = "another-process"
+	client.lock.Unlock()
+	time.Sleep(100 * time.Millisecond)
+
+	err := backend.Unlock("virtualNetwork.example")
+	if !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("expected the lost lease to be reported when releasing the lock but got %+v", err)
+	}
+	if client.leases[backend.blobName("virtualNetwork.example")] != "another-process" {
+		t.Fatalf("expected the lease held by another process not to be released")
+	}
+}
+
+func TestBlobBackendLeaseNotRenewed(t *testing.T) {
+	client := &fakeBlobLeaseClient{
+		leases:    make(map[string]string),
+		renewFail: true,
+	}
+
+	backend := NewBlobBackend(client)
+	backend.leaseDuration = 30 * time.Millisecond
+	if err := backend.Lock(context.Background(), "virtualNetwork.example"); err != nil {
+		t.Fatalf("acquiring the lock: %+v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if err := backend.Unlock("virtualNetwork.example"); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("expected a lease which couldn't be renewed before it expired to be reported as lost but got %+v", err)
+	}
+}
+
+func testBackend(t *testing.T, first Backend, second Backend) {
+	if err := first.Lock(context.Background(), "virtualNetwork.example"); err != nil {
+		t.Fatalf("acquiring the lock: %+v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := second.Lock(ctx, "virtualNetwork.example"); err == nil {
+		t.Fatalf("expected an error acquiring a lock held by another process")
+	}
+
+	// Resource IDs are case-insensitive, so the same key in a different casing is the same lock
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := second.Lock(ctx, "VirtualNetwork.Example"); err == nil {
+		t.Fatalf("expected an error acquiring a lock held by another process in a different casing")
+	}
+
+	if err := second.Lock(context.Background(), "virtualNetwork.other"); err != nil {
+		t.Fatalf("acquiring a different lock: %+v", err)
+	}
+	if err := second.Unlock("virtualNetwork.other"); err != nil {
+		t.Fatalf("releasing the lock: %+v", err)
+	}
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		if err := first.Unlock("virtualNetwork.example"); err != nil {
+			t.Errorf("releasing the lock: %+v", err)
+		}
+	}()
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := second.Lock(ctx, "virtualNetwork.example"); err != nil {
+		t.Fatalf("expected the lock to be acquired once released but got %+v", err)
+	}
+	if err := second.Unlock("virtualNetwork.example"); err != nil {
+		t.Fatalf("releasing the lock: %+v", err)
+	}
+
+	if err := second.Unlock("virtualNetwork.example"); err == nil {
+		t.Fatalf("expected an error releasing a lock which isn't held")
+	}
+}
+
+func TestMutexKVWithBackend(t *testing.T) {
+	backend := &fakeBackend{
+		fail: true,
+	}
+	m := newMutexKV()
+	ctx := WithBackend(context.Background(), backend)
+
+	if err := m.LockWithContext(ctx, "example"); err == nil {
+		t.Fatalf("expected an error when the backend fails")
+	}
+	if len(m.Holders()) != 0 {
+		t.Fatalf("expected the lock within this process to be released when the backend fails")
+	}
+
+	backend.fail = false
+	if err := m.LockWithContext(ctx, "example"); err != nil {
+		t.Fatalf("acquiring the lock: %+v", err)
+	}
+	if backend.locks != 1 {
+		t.Fatalf("expected the lock to be acquired from the backend")
+	}
+
+	// the lock is released from the backend which it was acquired from
+	m.Unlock("example")
+	if backend.unlocks != 1 {
+		t.Fatalf("expected the lock to be released from the backend")
+	}
+
+	// locks acquired without a backend in the context are only held within this process
+	m.Lock("example")
+	m.Unlock("example")
+	if backend.locks != 1 || backend.unlocks != 1 {
+		t.Fatalf("expected the backend not to be used without it being in the context")
+	}
+}
+
+func TestLocksOnlyCoordinateIDsWithBackend(t *testing.T) {
+	backend := &fakeBackend{}
+	ctx := WithBackend(context.Background(), backend)
+
+	if err := ByNameWithContext(ctx, "example", "virtualNetwork"); err != nil {
+		t.Fatalf("acquiring the lock: %+v", err)
+	}
+	UnlockByName("example", "virtualNetwork")
+	if backend.locks != 0 {
+		t.Fatalf("expected a lock by name to only be held within this process")
+	}
+
+	id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example"
+	if err := ByIDWithContext(ctx, id); err != nil {
+		t.Fatalf("acquiring the lock: %+v", err)
+	}
+	UnlockByID(id)
+	if backend.locks != 1 || backend.unlocks != 1 {
+		t.Fatalf("expected a lock by ID to be coordinated with the backend")
+	}
+}
+
+type fakeBackend struct {
+	fail    bool
+	locks   int
+	unlocks int
+}
+
+func (b *fakeBackend) Lock(_ context.Context, _ string) error {
+	if b.fail {
+		return errors.New("unavailable")
+	}
+	b.locks++
+	return nil
+}
+
+func (b *fakeBackend) Unlock(_ string) error {
+	b.unlocks++
+	return nil
+}
+
+// fakeBlobLeaseClient is an in-memory BlobLeaseClient
+type fakeBlobLeaseClient struct {
+	lock      sync.Mutex
+	count     int
+	leases    map[string]string
+	renewFail bool
+}
+
+func (c *fakeBlobLeaseClient) EnsureBlob(_ context.Context, _ string) error {
+	return nil
+}
+
+func (c *fakeBlobLeaseClient) AcquireLease(_ context.Context, name string, _ time.Duration) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, leased := c.leases[name]; leased {
+		return "", ErrLeaseAlreadyPresent
+	}
+
+	c.count++
+	c.leases[name] = fmt.Sprintf("lease-%d", c.count)
+	return c.leases[name], nil
+}
+
+func (c *fakeBlobLeaseClient) RenewLease(_ context.Context, name string, leaseId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.renewFail {
+		return errors.New("unavailable")
+	}
+	if c.leases[name] != leaseId {
+		return fmt.Errorf("%w: the lease %q isn't held", ErrLeaseLost, leaseId)
+	}
+	return nil
+}
+
+func (c *fakeBlobLeaseClient) ReleaseLease(_ context.Context, name string, leaseId string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.leases[name] != leaseId {
+		return fmt.Errorf("the lease %q isn't held", leaseId)
+	}
+	delete(c.leases, name)
+	return nil
+}
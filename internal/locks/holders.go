@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// DumpHolders writes the callers which are currently holding locks (and for how long) to the writer, which is
+// useful to diagnose an apply which appears to have hung
+func DumpHolders(w io.Writer) {
+	holders := armMutexKV.Holders()
+	if len(holders) == 0 {
+		fmt.Fprintln(w, "[WARN] No locks are currently held")
+		return
+	}
+
+	fmt.Fprintf(w, "[WARN] %d lock(s) are currently held:\n", len(holders))
+	for _, v := range holders {
+		fmt.Fprintf(w, "[WARN]   %q held by %s for %s\n", v.Key, v.Caller, time.Since(v.Since).Round(time.Second))
+	}
+}
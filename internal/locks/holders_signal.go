@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build !windows
+
+package locks
+
+import (
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// DumpHoldersOnSignal writes the current lock holders to the log when the process receives SIGQUIT, before
+// continuing with the default behaviour of dumping the goroutines and exiting
+func DumpHoldersOnSignal() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGQUIT)
+
+	go func() {
+		<-signals
+		DumpHolders(log.Writer())
+
+		signal.Reset(syscall.SIGQUIT)
+		if err := syscall.Kill(os.Getpid(), syscall.SIGQUIT); err != nil {
+			log.Printf("[DEBUG] re-raising SIGQUIT: %+v", err)
+		}
+	}()
+}
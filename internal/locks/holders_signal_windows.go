@@ -0,0 +1,9 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build windows
+
+package locks
+
+// DumpHoldersOnSignal is a no-op on Windows, which doesn't support SIGQUIT
+func DumpHoldersOnSignal() {}
@@ -3,31 +3,57 @@
 
 package locks
 
+import (
+	"context"
+)
+
 // armMutexKV is the instance of MutexKV for ARM resources
 var armMutexKV = newMutexKV()
 
-func ByID(id string) {
-	armMutexKV.Lock(id)
+// ByIDWithContext locks the ID, returning an error if the context is cancelled (for example, when the
+// timeout for the operation is reached) before the lock is acquired. Since a Resource ID is unique, the
+// lock is also coordinated with other processes when the context contains a Backend
+func ByIDWithContext(ctx context.Context, id string) error {
+	return armMutexKV.LockWithContext(ctx, id)
 }
 
-// handle the case of using the same name for different kinds of resources
-func ByName(name string, resourceType string) {
-	updatedName := resourceType + "." + name
-	armMutexKV.Lock(updatedName)
+// MultipleByIDWithContext locks each of the IDs, in a canonical order to avoid deadlocks - returning an
+// error (and releasing any locks which were acquired) if the context is cancelled before all of the locks
+// are acquired
+func MultipleByIDWithContext(ctx context.Context, ids *[]string) error {
+	return armMutexKV.LockMultipleWithContext(ctx, *ids)
 }
 
-func MultipleByName(names *[]string, resourceType string) {
-	newSlice := removeDuplicatesFromStringArray(*names)
+// ByNameWithContext locks the name for the resource type, returning an error if the context is cancelled
+// (for example, when the timeout for the operation is reached) before the lock is acquired
+func ByNameWithContext(ctx context.Context, name string, resourceType string) error {
+	// handle the case of using the same name for different kinds of resources
+	updatedName := resourceType + "." + name
+	return armMutexKV.LockWithContext(withinProcess(ctx), updatedName)
+}
 
-	for _, name := range newSlice {
-		ByName(name, resourceType)
+// MultipleByNameWithContext locks each of the names for the resource type, in a canonical order to avoid
+// deadlocks - returning an error (and releasing any locks which were acquired) if the context is cancelled
+// before all of the locks are acquired
+func MultipleByNameWithContext(ctx context.Context, names *[]string, resourceType string) error {
+	keys := make([]string, 0, len(*names))
+	for _, name := range *names {
+		keys = append(keys, resourceType+"."+name)
 	}
+
+	return armMutexKV.LockMultipleWithContext(withinProcess(ctx), keys)
 }
 
 func UnlockByID(id string) {
 	armMutexKV.Unlock(id)
 }
 
+func UnlockMultipleByID(ids *[]string) {
+	for _, id := range removeDuplicatesFromStringArray(*ids) {
+		UnlockByID(id)
+	}
+}
+
 func UnlockByName(name string, resourceType string) {
 	updatedName := resourceType + "." + name
 	armMutexKV.Unlock(updatedName)
@@ -40,3 +66,10 @@ func UnlockMultipleByName(names *[]string, resourceType string) {
 		UnlockByName(name, resourceType)
 	}
 }
+
+// withinProcess returns a copy of the context which only coordinates locks within this process - a name is
+// only unique within its parent resource, so locks by name would otherwise serialize unrelated resources (for
+// example, in other Resource Groups or Subscriptions) which happen to share a name across processes
+func withinProcess(ctx context.Context) context.Context {
+	return WithBackend(ctx, nil)
+}
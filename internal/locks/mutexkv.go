@@ -4,8 +4,15 @@
 package locks
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 )
 
 // mutexKV is a simple key/value store for arbitrary mutexes. It can be used to
@@ -13,39 +20,193 @@ import (
 // keys they must serialize on.
 type mutexKV struct {
 	lock  sync.Mutex
-	store map[string]*sync.Mutex
+	store map[string]*keyedMutex
 }
 
-// Locks the mutex for the given key. Caller is responsible for calling Unlock
+// keyedMutex is a mutex which can be acquired with a context - the mutex is held
+// whilst the channel contains a value
+type keyedMutex struct {
+	ch     chan struct{}
+	holder *lockHolder
+
+	// backend is the Backend which the lock was also acquired from, if any
+	backend Backend
+}
+
+// lockHolder describes the caller which is currently holding a lock
+type lockHolder struct {
+	Key    string
+	Caller string
+	Since  time.Time
+}
+
+// Locks the mutex for the given key within this process. Caller is responsible for calling Unlock
 // for the same key
 func (m *mutexKV) Lock(key string) {
+	// a context without a deadline or a Backend can't fail, so this can't return an error
+	_ = m.LockWithContext(context.Background(), key)
+}
+
+// LockWithContext locks the mutex for the given key, returning an error if the context is cancelled
+// (for example, when the timeout for the operation is reached) before the lock is acquired. When the
+// context contains a Backend the lock is also acquired from the Backend. Caller is responsible for
+// calling Unlock for the same key when no error is returned
+func (m *mutexKV) LockWithContext(ctx context.Context, key string) error {
 	log.Printf("[DEBUG] Locking %q", key)
-	m.get(key).Lock()
-	log.Printf("[DEBUG] Locked %q", key)
+	start := time.Now()
+	mutex := m.get(key)
+
+	select {
+	case mutex.ch <- struct{}{}:
+	case <-ctx.Done():
+		return fmt.Errorf("waited %s to acquire the lock %q%s: %+v", time.Since(start).Round(time.Second), key, m.describeHolder(key), ctx.Err())
+	}
+
+	b := backendFromContext(ctx)
+	if b != nil {
+		if err := b.Lock(ctx, key); err != nil {
+			<-mutex.ch
+			return fmt.Errorf("waited %s to acquire the lock %q from the lock backend: %+v", time.Since(start).Round(time.Second), key, err)
+		}
+	}
+
+	m.setHolder(key, &lockHolder{
+		Key:    key,
+		Caller: caller(),
+		Since:  time.Now(),
+	}, b)
+	log.Printf("[DEBUG] Locked %q after waiting %s", key, time.Since(start))
+	return nil
+}
+
+// LockMultipleWithContext locks the mutexes for each of the given keys in a canonical (sorted) order, such that two
+// callers locking an overlapping set of keys can't deadlock. If any lock can't be acquired, the locks which have been
+// acquired are released and an error is returned.
+func (m *mutexKV) LockMultipleWithContext(ctx context.Context, keys []string) error {
+	sorted := removeDuplicatesFromStringArray(keys)
+	sort.Strings(sorted)
+
+	for i, key := range sorted {
+		if err := m.LockWithContext(ctx, key); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				m.Unlock(sorted[j])
+			}
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
 func (m *mutexKV) Unlock(key string) {
 	log.Printf("[DEBUG] Unlocking %q", key)
-	m.get(key).Unlock()
+	mutex := m.get(key)
+	holder, b := m.setHolder(key, nil, nil)
+	if holder != nil {
+		log.Printf("[DEBUG] Held %q for %s", key, time.Since(holder.Since))
+	}
+
+	if b != nil {
+		// this can't fail the operation, so the lock will instead expire from the backend
+		if err := b.Unlock(key); err != nil {
+			if errors.Is(err, ErrLeaseLost) {
+				log.Printf("[ERROR] releasing the lock %q from the lock backend: %+v", key, err)
+			} else {
+				log.Printf("[WARN] releasing the lock %q from the lock backend: %+v", key, err)
+			}
+		}
+	}
+
+	select {
+	case <-mutex.ch:
+	default:
+		panic(fmt.Sprintf("unlocking %q which isn't locked", key))
+	}
 	log.Printf("[DEBUG] Unlocked %q", key)
 }
 
+// Holders returns the callers which are currently holding locks, ordered by when the lock was acquired
+func (m *mutexKV) Holders() []lockHolder {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	holders := make([]lockHolder, 0)
+	for _, v := range m.store {
+		if v.holder != nil {
+			holders = append(holders, *v.holder)
+		}
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		return holders[i].Since.Before(holders[j].Since)
+	})
+
+	return holders
+}
+
 // Returns a mutex for the given key, no guarantee of its lock status
-func (m *mutexKV) get(key string) *sync.Mutex {
+func (m *mutexKV) get(key string) *keyedMutex {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	mutex, ok := m.store[key]
 	if !ok {
-		mutex = &sync.Mutex{}
+		mutex = &keyedMutex{
+			ch: make(chan struct{}, 1),
+		}
 		m.store[key] = mutex
 	}
 	return mutex
 }
 
+// setHolder updates the holder of the lock for the given key (and the Backend which the lock was acquired from),
+// returning the previous holder and Backend
+func (m *mutexKV) setHolder(key string, holder *lockHolder, backend Backend) (*lockHolder, Backend) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	mutex, ok := m.store[key]
+	if !ok {
+		return nil, nil
+	}
+
+	existingHolder, existingBackend := mutex.holder, mutex.backend
+	mutex.holder = holder
+	mutex.backend = backend
+	return existingHolder, existingBackend
+}
+
+func (m *mutexKV) describeHolder(key string) string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if mutex, ok := m.store[key]; ok && mutex.holder != nil {
+		return fmt.Sprintf(" (held by %s for %s)", mutex.holder.Caller, time.Since(mutex.holder.Since).Round(time.Second))
+	}
+
+	return ""
+}
+
+// caller returns the location of the first caller outside of this package, which is used to identify lock holders
+func caller() string {
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, "/internal/locks.") || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line)
+		}
+		if !more {
+			break
+		}
+	}
+
+	return "unknown"
+}
+
 // newMutexKV returns a properly initialized mutexKV
 func newMutexKV() *mutexKV {
 	return &mutexKV{
-		store: make(map[string]*sync.Mutex),
+		store: make(map[string]*keyedMutex),
 	}
 }
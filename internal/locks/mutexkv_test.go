@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMutexKVLockWithContextTimesOut(t *testing.T) {
+	m := newMutexKV()
+	m.Lock("example")
+	defer m.Unlock("example")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := m.LockWithContext(ctx, "example")
+	if err == nil {
+		t.Fatalf("expected an error when the lock is held until the context is cancelled")
+	}
+	if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		t.Fatalf("expected the context to have exceeded its deadline but got %+v", ctx.Err())
+	}
+	if !strings.Contains(err.Error(), "TestMutexKVLockWithContextTimesOut") {
+		t.Fatalf("expected the error to contain the holder of the lock but got %q", err.Error())
+	}
+}
+
+func TestMutexKVLockWithContextWaitsForUnlock(t *testing.T) {
+	m := newMutexKV()
+	m.Lock("example")
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		m.Unlock("example")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := m.LockWithContext(ctx, "example"); err != nil {
+		t.Fatalf("expected the lock to be acquired once released but got %+v", err)
+	}
+	m.Unlock("example")
+}
+
+func TestMutexKVLockMultipleWithContextOrdering(t *testing.T) {
+	m := newMutexKV()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// locking overlapping keys in opposing orders would deadlock without a canonical ordering
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := 0; i < 100; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			keys := []string{"a", "b", "c"}
+			if err := m.LockMultipleWithContext(ctx, keys); err != nil {
+				errs <- err
+				return
+			}
+			for _, k := range keys {
+				m.Unlock(k)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			keys := []string{"c", "b", "a", "a"}
+			if err := m.LockMultipleWithContext(ctx, keys); err != nil {
+				errs <- err
+				return
+			}
+			for _, k := range []string{"a", "b", "c"} {
+				m.Unlock(k)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Fatalf("expected the locks to be acquired but got %+v", err)
+	}
+}
+
+func TestMutexKVLockMultipleWithContextReleasesOnError(t *testing.T) {
+	m := newMutexKV()
+	m.Lock("b")
+	defer m.Unlock("b")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := m.LockMultipleWithContext(ctx, []string{"a", "b"}); err == nil {
+		t.Fatalf("expected an error when one of the locks is held")
+	}
+
+	if holders := m.Holders(); len(holders) != 1 || holders[0].Key != "b" {
+		t.Fatalf("expected only the lock for `b` to be held but got %+v", holders)
+	}
+}
+
+func TestDumpHolders(t *testing.T) {
+	if err := ByNameWithContext(context.Background(), "example", "testResource"); err != nil {
+		t.Fatalf("acquiring the lock: %+v", err)
+	}
+	defer UnlockByName("example", "testResource")
+
+	var buf bytes.Buffer
+	DumpHolders(&buf)
+
+	if !strings.Contains(buf.String(), `"testResource.example" held by`) || !strings.Contains(buf.String(), "TestDumpHolders") {
+		t.Fatalf("expected the holder of the lock to be dumped but got %q", buf.String())
+	}
+}
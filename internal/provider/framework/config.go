@@ -5,7 +5,9 @@ package framework
 
 import (
 	"context"
+	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
@@ -13,9 +15,12 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	providerfeatures "github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 )
 
 type ProviderConfig struct {
@@ -126,6 +131,68 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 	p.clientBuilder.DisableTerraformPartnerID = getEnvBoolOrDefault(data.DisableTerraformPartnerId, "ARM_DISABLE_TERRAFORM_PARTNER_ID", false)
 	p.clientBuilder.StorageUseAzureAD = getEnvBoolOrDefault(data.StorageUseAzureAD, "ARM_STORAGE_USE_AZUREAD", false)
 
+	p.clientBuilder.HTTPTrace = common.HTTPTraceOptions{
+		Path:   getEnvStringIfValueAbsent(data.HTTPTraceFile, "ARM_HTTP_TRACE_FILE"),
+		Format: getEnvStringOrDefault(data.HTTPTraceFormat, "ARM_HTTP_TRACE_FORMAT", common.HTTPTraceFormatHAR),
+	}
+
+	requestThrottlingEnabled := data.RequestThrottlingEnabled.ValueBool()
+	if data.RequestThrottlingEnabled.IsNull() || data.RequestThrottlingEnabled.IsUnknown() {
+		v := os.Getenv("ARM_REQUEST_THROTTLING_ENABLED")
+		requestThrottlingEnabled = strings.EqualFold(v, "true") || v == "1"
+	}
+
+	readsPerSecond, err := getEnvInt64OrDefault(data.RequestThrottlingReadsPerSecond, "ARM_REQUEST_THROTTLING_READS_PER_SECOND", common.DefaultRequestThrottlingReadsPerSecond)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("configuring request throttling", err.Error()))
+		return
+	}
+
+	writesPerSecond, err := getEnvInt64OrDefault(data.RequestThrottlingWritesPerSecond, "ARM_REQUEST_THROTTLING_WRITES_PER_SECOND", common.DefaultRequestThrottlingWritesPerSecond)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("configuring request throttling", err.Error()))
+		return
+	}
+
+	if readsPerSecond < 1 || writesPerSecond < 1 {
+		diags.Append(diag.NewErrorDiagnostic("configuring request throttling", "`request_throttling_reads_per_second` and `request_throttling_writes_per_second` must be at least 1"))
+		return
+	}
+
+	p.clientBuilder.RequestThrottling = common.RequestThrottlingOptions{
+		Enabled:         requestThrottlingEnabled,
+		ReadsPerSecond:  int(readsPerSecond),
+		WritesPerSecond: int(writesPerSecond),
+	}
+
+	maxRetries, err := getEnvInt64OrDefault(data.RetryTransientErrorsMaxRetries, "ARM_RETRY_TRANSIENT_ERRORS_MAX_RETRIES", common.DefaultRetryPolicyMaxRetries)
+	if err != nil {
+		diags.Append(diag.NewErrorDiagnostic("configuring the retry policy", err.Error()))
+		return
+	}
+	if maxRetries < 1 {
+		diags.Append(diag.NewErrorDiagnostic("configuring the retry policy", "`retry_transient_errors_max_retries` must be at least 1"))
+		return
+	}
+
+	retryTransientErrorsEnabled := data.RetryTransientErrorsEnabled.ValueBool()
+	if data.RetryTransientErrorsEnabled.IsNull() || data.RetryTransientErrorsEnabled.IsUnknown() {
+		v := os.Getenv("ARM_RETRY_TRANSIENT_ERRORS_ENABLED")
+		retryTransientErrorsEnabled = strings.EqualFold(v, "true") || v == "1"
+	}
+
+	p.clientBuilder.RetryPolicy = common.RetryPolicyOptions{
+		Disabled:   !retryTransientErrorsEnabled,
+		MaxRetries: int(maxRetries),
+	}
+
+	p.clientBuilder.LockBackend = locks.BackendOptions{
+		Type:             getEnvStringOrDefault(data.LockBackend, "ARM_LOCK_BACKEND", locks.BackendTypeLocal),
+		FileDirectory:    getEnvStringIfValueAbsent(data.LockFileDirectory, "ARM_LOCK_FILE_DIRECTORY"),
+		BlobContainerURL: getEnvStringIfValueAbsent(data.LockBlobContainerURL, "ARM_LOCK_BLOB_CONTAINER_URL"),
+		BlobAccessKey:    getEnvStringIfValueAbsent(data.LockBlobAccessKey, "ARM_LOCK_BLOB_ACCESS_KEY"),
+	}
+
 	f := providerfeatures.UserFeatures{}
 
 	// features is required, but we'll play safe here
@@ -485,7 +552,7 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 		return
 	}
 
-	client.StopContext = ctx
+	client.StopContext = locks.WithBackend(ctx, client.LockBackend)
 
 	resourceProviderRegistrationSet := getEnvStringOrDefault(data.ResourceProviderRegistrations, "ARM_RESOURCE_PROVIDER_REGISTRATIONS", resourceproviders.ProviderRegistrationsCore)
 	if !providerfeatures.FivePointOhBeta() {
@@ -526,5 +593,42 @@ func (p *ProviderConfig) Load(ctx context.Context, data *ProviderModel, tfVersio
 		return
 	}
 
+	client.ResourceProviderRegistrationWarnings = getEnvBoolOrDefault(data.ResourceProviderRegistrationWarnings, "ARM_RESOURCE_PROVIDER_REGISTRATION_WARNINGS", false)
+	if client.ResourceProviderRegistrationWarnings {
+		if err := resourceproviders.CacheSupportedProviders(ctx2, client.Resource.ResourceProvidersClient, subId); err != nil {
+			log.Printf("[WARN] retrieving the registration state of the Resource Providers: %+v. Resource Provider registration warnings will be unavailable", err)
+		}
+	}
+
+	tagsConfig := tags.ProviderConfiguration{
+		DefaultTags:       make(map[string]string),
+		IgnoreKeys:        make([]string, 0),
+		IgnoreKeyPrefixes: make([]string, 0),
+	}
+	if !data.DefaultTags.IsNull() && !data.DefaultTags.IsUnknown() {
+		var defaultTagsList []DefaultTags
+		diags.Append(data.DefaultTags.ElementsAs(ctx, &defaultTagsList, true)...)
+		if len(defaultTagsList) > 0 {
+			diags.Append(defaultTagsList[0].Tags.ElementsAs(ctx, &tagsConfig.DefaultTags, false)...)
+		}
+	}
+	if !data.IgnoreTags.IsNull() && !data.IgnoreTags.IsUnknown() {
+		var ignoreTagsList []IgnoreTags
+		diags.Append(data.IgnoreTags.ElementsAs(ctx, &ignoreTagsList, true)...)
+		if len(ignoreTagsList) > 0 {
+			if !ignoreTagsList[0].Keys.IsNull() {
+				diags.Append(ignoreTagsList[0].Keys.ElementsAs(ctx, &tagsConfig.IgnoreKeys, false)...)
+			}
+			if !ignoreTagsList[0].KeyPrefixes.IsNull() {
+				diags.Append(ignoreTagsList[0].KeyPrefixes.ElementsAs(ctx, &tagsConfig.IgnoreKeyPrefixes, false)...)
+			}
+		}
+	}
+	if diags.HasError() {
+		return
+	}
+	tagsConfig.Client = client.Resource.TagsClient
+	client.ProviderTags = tagsConfig
+
 	p.Client = client
 }
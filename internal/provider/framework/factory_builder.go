@@ -43,7 +43,11 @@ func ProtoV5ProviderServerFactory(ctx context.Context) (func() tfprotov5.Provide
 		return nil, nil, err
 	}
 
-	return muxServer.ProviderServer, v2Provider, nil
+	providerServer := func() tfprotov5.ProviderServer {
+		return withResourceProviderRegistrationWarnings(muxServer.ProviderServer(), v2Provider.Meta)
+	}
+
+	return providerServer, v2Provider, nil
 }
 
 func V5ProviderWithoutPluginSDK() func() tfprotov5.ProviderServer {
@@ -8,6 +8,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -172,6 +173,28 @@ func getEnvBoolOrDefault(val types.Bool, envVar string, def bool) bool {
 	return val.ValueBool()
 }
 
+// getEnvInt64OrDefault takes a Framework Int64Value and a corresponding Environment Variable name and returns
+// one of the following in priority order:
+// 1 - the Int64 value set in the Int64Value if this is not Null / Unknown.
+// 2 - the integer representation of the os.GetEnv() value of the Environment Variable provided, if set.
+// 3 - the default value `def` in all other cases.
+func getEnvInt64OrDefault(val types.Int64, envVar string, def int64) (int64, error) {
+	if val.IsNull() || val.IsUnknown() {
+		v := os.Getenv(envVar)
+		if v == "" {
+			return def, nil
+		}
+
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parsing the value of the Environment Variable %q as an integer: %+v", envVar, err)
+		}
+		return i, nil
+	}
+
+	return val.ValueInt64(), nil
+}
+
 // getEnvListOfStringsIfAbsent returns a []string for the types.List, or the contents of the supplied Environment
 // Variable `envVar` if set. If the separator is an empty string, then "," will be used as a default.
 func getEnvListOfStringsIfAbsent(val types.List, envVar string, separator string) []string {
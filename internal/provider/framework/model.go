@@ -9,35 +9,49 @@ import (
 )
 
 type ProviderModel struct {
-	SubscriptionId                types.String `tfsdk:"subscription_id"`
-	ClientId                      types.String `tfsdk:"client_id"`
-	ClientIdFilePath              types.String `tfsdk:"client_id_file_path"`
-	TenantId                      types.String `tfsdk:"tenant_id"`
-	AuxiliaryTenantIds            types.List   `tfsdk:"auxiliary_tenant_ids"`
-	Environment                   types.String `tfsdk:"environment"`
-	MetaDataHost                  types.String `tfsdk:"metadata_host"`
-	ClientCertificate             types.String `tfsdk:"client_certificate"`
-	ClientCertificatePath         types.String `tfsdk:"client_certificate_path"`
-	ClientCertificatePassword     types.String `tfsdk:"client_certificate_password"`
-	ClientSecret                  types.String `tfsdk:"client_secret"`
-	ClientSecretFilePath          types.String `tfsdk:"client_secret_file_path"`
-	OIDCRequestToken              types.String `tfsdk:"oidc_request_token"`
-	OIDCRequestURL                types.String `tfsdk:"oidc_request_url"`
-	OIDCToken                     types.String `tfsdk:"oidc_token"`
-	OIDCTokenFilePath             types.String `tfsdk:"oidc_token_file_path"`
-	UseOIDC                       types.Bool   `tfsdk:"use_oidc"`
-	UseMSI                        types.Bool   `tfsdk:"use_msi"`
-	MSIEndpoint                   types.String `tfsdk:"msi_endpoint"`
-	UseCLI                        types.Bool   `tfsdk:"use_cli"`
-	UseAKSWorkloadIdentity        types.Bool   `tfsdk:"use_aks_workload_identity"`
-	PartnerId                     types.String `tfsdk:"partner_id"`
-	DisableCorrelationRequestId   types.Bool   `tfsdk:"disable_correlation_request_id"`
-	DisableTerraformPartnerId     types.Bool   `tfsdk:"disable_terraform_partner_id"`
-	StorageUseAzureAD             types.Bool   `tfsdk:"storage_use_azuread"`
-	Features                      types.List   `tfsdk:"features"`
-	SkipProviderRegistration      types.Bool   `tfsdk:"skip_provider_registration"` // TODO - Remove in 5.0
-	ResourceProviderRegistrations types.String `tfsdk:"resource_provider_registrations"`
-	ResourceProvidersToRegister   types.List   `tfsdk:"resource_providers_to_register"`
+	SubscriptionId                       types.String `tfsdk:"subscription_id"`
+	ClientId                             types.String `tfsdk:"client_id"`
+	ClientIdFilePath                     types.String `tfsdk:"client_id_file_path"`
+	TenantId                             types.String `tfsdk:"tenant_id"`
+	AuxiliaryTenantIds                   types.List   `tfsdk:"auxiliary_tenant_ids"`
+	Environment                          types.String `tfsdk:"environment"`
+	MetaDataHost                         types.String `tfsdk:"metadata_host"`
+	ClientCertificate                    types.String `tfsdk:"client_certificate"`
+	ClientCertificatePath                types.String `tfsdk:"client_certificate_path"`
+	ClientCertificatePassword            types.String `tfsdk:"client_certificate_password"`
+	ClientSecret                         types.String `tfsdk:"client_secret"`
+	ClientSecretFilePath                 types.String `tfsdk:"client_secret_file_path"`
+	OIDCRequestToken                     types.String `tfsdk:"oidc_request_token"`
+	OIDCRequestURL                       types.String `tfsdk:"oidc_request_url"`
+	OIDCToken                            types.String `tfsdk:"oidc_token"`
+	OIDCTokenFilePath                    types.String `tfsdk:"oidc_token_file_path"`
+	UseOIDC                              types.Bool   `tfsdk:"use_oidc"`
+	UseMSI                               types.Bool   `tfsdk:"use_msi"`
+	MSIEndpoint                          types.String `tfsdk:"msi_endpoint"`
+	UseCLI                               types.Bool   `tfsdk:"use_cli"`
+	UseAKSWorkloadIdentity               types.Bool   `tfsdk:"use_aks_workload_identity"`
+	PartnerId                            types.String `tfsdk:"partner_id"`
+	DisableCorrelationRequestId          types.Bool   `tfsdk:"disable_correlation_request_id"`
+	DisableTerraformPartnerId            types.Bool   `tfsdk:"disable_terraform_partner_id"`
+	StorageUseAzureAD                    types.Bool   `tfsdk:"storage_use_azuread"`
+	HTTPTraceFile                        types.String `tfsdk:"http_trace_file"`
+	HTTPTraceFormat                      types.String `tfsdk:"http_trace_format"`
+	RequestThrottlingEnabled             types.Bool   `tfsdk:"request_throttling_enabled"`
+	RequestThrottlingReadsPerSecond      types.Int64  `tfsdk:"request_throttling_reads_per_second"`
+	RequestThrottlingWritesPerSecond     types.Int64  `tfsdk:"request_throttling_writes_per_second"`
+	RetryTransientErrorsEnabled          types.Bool   `tfsdk:"retry_transient_errors_enabled"`
+	RetryTransientErrorsMaxRetries       types.Int64  `tfsdk:"retry_transient_errors_max_retries"`
+	LockBackend                          types.String `tfsdk:"lock_backend"`
+	LockFileDirectory                    types.String `tfsdk:"lock_file_directory"`
+	LockBlobContainerURL                 types.String `tfsdk:"lock_blob_container_url"`
+	LockBlobAccessKey                    types.String `tfsdk:"lock_blob_access_key"`
+	Features                             types.List   `tfsdk:"features"`
+	DefaultTags                          types.List   `tfsdk:"default_tags"`
+	IgnoreTags                           types.List   `tfsdk:"ignore_tags"`
+	SkipProviderRegistration             types.Bool   `tfsdk:"skip_provider_registration"` // TODO - Remove in 5.0
+	ResourceProviderRegistrations        types.String `tfsdk:"resource_provider_registrations"`
+	ResourceProvidersToRegister          types.List   `tfsdk:"resource_providers_to_register"`
+	ResourceProviderRegistrationWarnings types.Bool   `tfsdk:"resource_provider_registration_warnings"`
 }
 
 type Features struct {
@@ -245,3 +259,12 @@ type RecoveryServiceVaults struct {
 var RecoveryServiceVaultsAttributes = map[string]attr.Type{
 	"recover_soft_deleted_backup_protected_vm": types.BoolType,
 }
+
+type DefaultTags struct {
+	Tags types.Map `tfsdk:"tags"`
+}
+
+type IgnoreTags struct {
+	Keys        types.Set `tfsdk:"keys"`
+	KeyPrefixes types.Set `tfsdk:"key_prefixes"`
+}
@@ -15,8 +15,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	pluginsdkprovider "github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk/frameworkhelpers"
 )
 
@@ -30,10 +34,16 @@ var _ provider.Provider = &azureRmFrameworkProvider{}
 var _ provider.ProviderWithFunctions = &azureRmFrameworkProvider{}
 
 func (p *azureRmFrameworkProvider) Functions(_ context.Context) []func() function.Function {
-	return []func() function.Function{
+	functions := []func() function.Function{
+		providerfunction.NewBuildResourceIDFunction,
 		providerfunction.NewNormaliseResourceIDFunction,
 		providerfunction.NewParseResourceIDFunction,
+		providerfunction.NewSubnetContainsFunction,
+		providerfunction.NewSubnetPlanFunction,
+		providerfunction.NewSubnetUsableHostsFunction,
 	}
+
+	return append(functions, providerfunction.NewBuildTypedResourceIDFunctions()...)
 }
 
 func NewFrameworkProvider(primary interface{ Meta() interface{} }) provider.Provider {
@@ -183,6 +193,75 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 				Description: "This will disable the Terraform Partner ID which is used if a custom `partner_id` isn't specified.",
 			},
 
+			"http_trace_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to a file which a trace of every HTTP request sent by the AzureRM Provider (with any sensitive values redacted) should be written to.",
+			},
+
+			"http_trace_format": schema.StringAttribute{
+				Optional:    true,
+				Description: "The format which the trace of HTTP requests specified in `http_trace_file` should be written in. Possible values are `har` and `otlp`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						common.HTTPTraceFormatHAR,
+						common.HTTPTraceFormatOTLP,
+					),
+				},
+			},
+
+			"request_throttling_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Should the AzureRM Provider pace requests to Azure Resource Manager using the rate limits returned by the API, rather than retrying requests once they've been throttled?",
+			},
+
+			"request_throttling_reads_per_second": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of read requests per second, per Subscription, which the AzureRM Provider should pace requests to when `request_throttling_enabled` is set.",
+			},
+
+			"request_throttling_writes_per_second": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The number of write and delete requests per second, per Subscription, which the AzureRM Provider should pace requests to when `request_throttling_enabled` is set.",
+			},
+
+			"retry_transient_errors_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Should the AzureRM Provider retry requests to Azure Resource Manager which fail with a well-known transient error?",
+			},
+
+			"retry_transient_errors_max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of times a request which fails with a well-known transient error should be retried when `retry_transient_errors_enabled` is set.",
+			},
+
+			"lock_backend": schema.StringAttribute{
+				Optional:    true,
+				Description: "The backend which should be used to coordinate locks on parent resources (such as Virtual Networks) with other instances of the AzureRM Provider. Possible values are `local`, `file` and `azure_blob`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						locks.BackendTypeLocal,
+						locks.BackendTypeFile,
+						locks.BackendTypeAzureBlob,
+					),
+				},
+			},
+
+			"lock_file_directory": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to a directory shared by each instance of the AzureRM Provider which lock files should be created in when `lock_backend` is set to `file`.",
+			},
+
+			"lock_blob_container_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of a Storage Container which lock blobs should be created in when `lock_backend` is set to `azure_blob`.",
+			},
+
+			"lock_blob_access_key": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The Access Key for the Storage Account containing the `lock_blob_container_url` Storage Container. When not specified, the credentials used by the AzureRM Provider are used instead.",
+			},
+
 			// Advanced feature flags
 			"skip_provider_registration": schema.BoolAttribute{
 				Optional:           true,
@@ -221,6 +300,11 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 					},
 				},
 			},
+
+			"resource_provider_registration_warnings": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Should the AzureRM Provider surface a warning during the plan for resources which require a Resource Provider that isn't registered in the subscription?",
+			},
 		},
 
 		Blocks: map[string]schema.Block{
@@ -457,6 +541,41 @@ func (p *azureRmFrameworkProvider) Schema(_ context.Context, _ provider.SchemaRe
 					},
 				},
 			},
+
+			"default_tags": schema.ListNestedBlock{
+				Description: "Tags which should be assigned to every taggable resource managed by the AzureRM Provider.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"tags": schema.MapAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+						},
+					},
+				},
+			},
+
+			"ignore_tags": schema.ListNestedBlock{
+				Description: "Tags which should be ignored when reading the Tags for every taggable resource managed by the AzureRM Provider, for example those assigned by Azure Policy.",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"keys": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+
+						"key_prefixes": schema.SetAttribute{
+							ElementType: types.StringType,
+							Optional:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -476,18 +595,35 @@ func (p *azureRmFrameworkProvider) Configure(ctx context.Context, request provid
 		response.DataSourceData = v
 	} else {
 		p.Load(ctx, &data, request.TerraformVersion, &response.Diagnostics)
-
-		response.DataSourceData = &p.ProviderConfig
-		response.ResourceData = &p.ProviderConfig
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		// the Client is shared in the same way as the Meta of the Plugin SDK Provider, so that Resources can be
+		// configured consistently regardless of whether the Providers are muxed
+		response.DataSourceData = p.Client
+		response.ResourceData = p.Client
 	}
 }
 
 func (p *azureRmFrameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	// We do not currently support any Native framework Data Sources
-	return nil
+	dataSources := make([]func() datasource.DataSource, 0)
+	for _, service := range pluginsdkprovider.SupportedTypedServices() {
+		if v, ok := service.(sdk.TypedServiceRegistrationWithFrameworkResources); ok {
+			dataSources = append(dataSources, v.FrameworkDataSources()...)
+		}
+	}
+
+	return dataSources
 }
 
 func (p *azureRmFrameworkProvider) Resources(_ context.Context) []func() resource.Resource {
-	// We do not currently support any Native framework Resources
-	return nil
+	resources := make([]func() resource.Resource, 0)
+	for _, service := range pluginsdkprovider.SupportedTypedServices() {
+		if v, ok := service.(sdk.TypedServiceRegistrationWithFrameworkResources); ok {
+			resources = append(resources, v.FrameworkResources()...)
+		}
+	}
+
+	return resources
 }
@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package framework
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+)
+
+var _ tfprotov5.ProviderServer = resourceProviderRegistrationWarningsServer{}
+
+// resourceProviderRegistrationWarningsServer wraps the (muxed) Provider Server to surface a warning during the plan
+// for each resource/data source in the configuration which requires a Resource Provider that isn't registered.
+//
+// This is done at the protocol level since the Plugin SDK doesn't support returning warnings during the plan.
+type resourceProviderRegistrationWarningsServer struct {
+	tfprotov5.ProviderServer
+
+	// meta returns the Client of the Provider once it's been configured, which determines whether the warnings
+	// are enabled for this instance of the Provider
+	meta func() interface{}
+}
+
+func withResourceProviderRegistrationWarnings(server tfprotov5.ProviderServer, meta func() interface{}) tfprotov5.ProviderServer {
+	return resourceProviderRegistrationWarningsServer{
+		ProviderServer: server,
+		meta:           meta,
+	}
+}
+
+func (s resourceProviderRegistrationWarningsServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	// the Resource Provider isn't required to delete a resource
+	if req.ProposedNewState != nil {
+		if isNull, err := req.ProposedNewState.IsNull(); err == nil && !isNull {
+			resp.Diagnostics = s.appendResourceProviderRegistrationWarning(resp.Diagnostics, req.TypeName)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s resourceProviderRegistrationWarningsServer) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
+	resp, err := s.ProviderServer.ReadDataSource(ctx, req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	resp.Diagnostics = s.appendResourceProviderRegistrationWarning(resp.Diagnostics, req.TypeName)
+	return resp, nil
+}
+
+func (s resourceProviderRegistrationWarningsServer) appendResourceProviderRegistrationWarning(diagnostics []*tfprotov5.Diagnostic, typeName string) []*tfprotov5.Diagnostic {
+	client, ok := s.meta().(*clients.Client)
+	if !ok || client == nil || !client.ResourceProviderRegistrationWarnings {
+		return diagnostics
+	}
+
+	resourceProvider, unregistered := resourceproviders.UnregisteredForResourceType(typeName)
+	if !unregistered {
+		return diagnostics
+	}
+
+	summary, detail := resourceproviders.RegistrationWarning(typeName, resourceProvider)
+	return append(diagnostics, &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
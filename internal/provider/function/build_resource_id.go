@@ -0,0 +1,253 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/recaser"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type BuildResourceIDFunction struct{}
+
+var _ function.Function = BuildResourceIDFunction{}
+
+func NewBuildResourceIDFunction() function.Function {
+	return &BuildResourceIDFunction{}
+}
+
+func (a BuildResourceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "build_resource_id"
+}
+
+func (a BuildResourceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "build_resource_id",
+		Description:         "Builds an Azure Resource Manager ID in the correct casing for Terraform from the resource type and the values for each segment",
+		MarkdownDescription: "Builds an Azure Resource Manager ID in the correct casing for Terraform from the resource type and the values for each segment",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "resource_type",
+				Description:         "The full resource type, for example `Microsoft.Network/virtualNetworks/subnets`",
+				MarkdownDescription: "The full resource type, for example `Microsoft.Network/virtualNetworks/subnets`",
+			},
+			function.MapParameter{
+				Name:                "segments",
+				Description:         "The values for each of the user specified segments of the Resource ID, for example `subscription_id`, `resource_group_name` and `virtual_network_name`",
+				MarkdownDescription: "The values for each of the user specified segments of the Resource ID, for example `subscription_id`, `resource_group_name` and `virtual_network_name`",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (a BuildResourceIDFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var resourceType string
+	var segments map[string]string
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &resourceType, &segments))
+
+	if response.Error != nil {
+		return
+	}
+
+	if resourceType == "" {
+		response.Error = function.NewArgumentFuncError(0, "Got empty resource type")
+		return
+	}
+
+	idType, err := resourceIdTypeForSegments(resourceType, segments)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	values := make(map[string]string)
+	for _, segment := range userSpecifiedSegments(idType) {
+		for k, v := range segments {
+			if normaliseSegmentName(k) == normaliseSegmentName(segment.Name) {
+				values[segment.Name] = v
+			}
+		}
+	}
+
+	result, err := buildResourceId(idType, values)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
+
+// resourceIdTypeForSegments returns the registered Resource ID type with the specified full resource type (as output
+// by `parse_resource_id`) whose user specified segments match the keys of `segments`
+func resourceIdTypeForSegments(resourceType string, segments map[string]string) (resourceids.ResourceId, error) {
+	provided := make([]string, 0, len(segments))
+	for k := range segments {
+		provided = append(provided, normaliseSegmentName(k))
+	}
+	sort.Strings(provided)
+
+	candidates := make([]resourceids.ResourceId, 0)
+	for _, id := range recaser.KnownResourceIds() {
+		if strings.EqualFold(fullResourceType(id), resourceType) {
+			candidates = append(candidates, id)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("the resource type %q is not currently supported by the provider", resourceType)
+	}
+
+	// sort the candidates so that the error message is consistent
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ID() < candidates[j].ID()
+	})
+
+	expected := make([]string, 0)
+	for _, id := range candidates {
+		names := make([]string, 0)
+		for _, segment := range userSpecifiedSegments(id) {
+			names = append(names, normaliseSegmentName(segment.Name))
+		}
+		sort.Strings(names)
+
+		if strings.Join(names, ",") == strings.Join(provided, ",") {
+			return id, nil
+		}
+
+		snakeCased := make([]string, 0)
+		for _, segment := range userSpecifiedSegments(id) {
+			snakeCased = append(snakeCased, fmt.Sprintf("`%s`", convertToSnakeCase(segment.Name)))
+		}
+		expected = append(expected, strings.Join(snakeCased, ", "))
+	}
+
+	return nil, fmt.Errorf("the segments specified for the resource type %q are not valid, expected one of: [%s]", resourceType, strings.Join(expected, "], ["))
+}
+
+// fullResourceType returns the full resource type for the Resource ID type, for example `Microsoft.Network/virtualNetworks/subnets`
+func fullResourceType(id resourceids.ResourceId) string {
+	fullType := ""
+	hasProvider := false
+	lastStaticSegment := ""
+	for _, segment := range id.Segments() {
+		switch segment.Type {
+		case resourceids.ResourceProviderSegmentType:
+			// extension resources (e.g. a role assignment on a virtual network) are of the type of the last provider
+			fullType = pointer.From(segment.FixedValue)
+			hasProvider = true
+
+		case resourceids.StaticSegmentType:
+			value := pointer.From(segment.FixedValue)
+			lastStaticSegment = value
+			if hasProvider && !strings.EqualFold(value, "providers") {
+				fullType = fmt.Sprintf("%s/%s", fullType, value)
+			}
+		}
+	}
+
+	// resource groups and subscriptions don't have a provider segment
+	if !hasProvider && lastStaticSegment != "" {
+		return fmt.Sprintf("Microsoft.Resources/%s", lastStaticSegment)
+	}
+
+	return fullType
+}
+
+func userSpecifiedSegments(id resourceids.ResourceId) []resourceids.Segment {
+	output := make([]resourceids.Segment, 0)
+	for _, segment := range id.Segments() {
+		switch segment.Type {
+		case resourceids.StaticSegmentType, resourceids.ResourceProviderSegmentType:
+			continue
+		}
+		output = append(output, segment)
+	}
+	return output
+}
+
+// buildResourceId validates the values for each of the user specified segments of the Resource ID type, and returns
+// the Resource ID in the correct casing
+func buildResourceId(idType resourceids.ResourceId, values map[string]string) (string, error) {
+	// the registered Resource ID types are shared, so a new instance is used to build the ID
+	idType = reflect.New(reflect.TypeOf(idType).Elem()).Interface().(resourceids.ResourceId)
+
+	components := make([]string, 0)
+	for _, segment := range idType.Segments() {
+		switch segment.Type {
+		case resourceids.StaticSegmentType, resourceids.ResourceProviderSegmentType:
+			components = append(components, pointer.From(segment.FixedValue))
+			continue
+		}
+
+		name := convertToSnakeCase(segment.Name)
+		value, ok := values[segment.Name]
+		if !ok || value == "" {
+			return "", fmt.Errorf("a value must be specified for `%s`", name)
+		}
+
+		switch segment.Type {
+		case resourceids.ScopeSegmentType:
+			if !strings.HasPrefix(value, "/") {
+				return "", fmt.Errorf("`%s` must be a Resource ID starting with `/` but got %q", name, value)
+			}
+			components = append(components, strings.Trim(recaser.ReCase(value), "/"))
+			continue
+
+		case resourceids.SubscriptionIdSegmentType:
+			if _, err := uuid.ParseUUID(value); err != nil {
+				return "", fmt.Errorf("`%s` must be a UUID but got %q", name, value)
+			}
+
+		case resourceids.ConstantSegmentType:
+			found := false
+			for _, v := range pointer.From(segment.PossibleValues) {
+				if strings.EqualFold(v, value) {
+					value = v
+					found = true
+					break
+				}
+			}
+			if !found {
+				return "", fmt.Errorf("`%s` must be one of [%s] but got %q", name, strings.Join(pointer.From(segment.PossibleValues), ", "), value)
+			}
+		}
+
+		if strings.Contains(value, "/") {
+			return "", fmt.Errorf("`%s` cannot contain `/` but got %q", name, value)
+		}
+		components = append(components, value)
+	}
+
+	// the ID is parsed to validate it and to return it in the canonical format
+	id := fmt.Sprintf("/%s", strings.Join(components, "/"))
+	parser := resourceids.NewParserFromResourceIdType(idType)
+	parsed, err := parser.Parse(id, true)
+	if err != nil {
+		return "", fmt.Errorf("building Resource ID: %+v", err)
+	}
+
+	if err := idType.FromParseResult(*parsed); err != nil {
+		return "", fmt.Errorf("building Resource ID: %+v", err)
+	}
+
+	return idType.ID(), nil
+}
+
+// normaliseSegmentName allows the segments to be specified in either snake case (e.g. `resource_group_name`), as is
+// conventional in Terraform, or camel case (e.g. `resourceGroupName`) as used in the Resource ID definitions
+func normaliseSegmentName(input string) string {
+	return strings.ToLower(strings.ReplaceAll(input, "_", ""))
+}
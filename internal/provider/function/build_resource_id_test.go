@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+func TestProviderFunctionBuildResourceID_basic(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "azurerm" {
+  features {}
+}
+
+output "subnet" {
+  value = provider::azurerm::build_resource_id("Microsoft.Network/virtualNetworks/subnets", {
+    subscription_id      = "12345678-1234-9876-4563-123456789012"
+    resource_group_name  = "resGroup1"
+    virtual_network_name = "network1"
+    subnet_name          = "subnet1"
+  })
+}
+
+output "resource_group" {
+  value = provider::azurerm::build_resource_id("microsoft.resources/resourcegroups", {
+    subscriptionId    = "12345678-1234-9876-4563-123456789012"
+    resourceGroupName = "resGroup1"
+  })
+}
+`,
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("subnet", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1"),
+					acceptance.TestCheckOutput("resource_group", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionBuildResourceID_invalidSegments(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "azurerm" {
+  features {}
+}
+
+output "test" {
+  value = provider::azurerm::build_resource_id("Microsoft.Network/virtualNetworks/subnets", {
+    subscription_id     = "12345678-1234-9876-4563-123456789012"
+    resource_group_name = "resGroup1"
+    subnet_name         = "subnet1"
+  })
+}
+`,
+				ExpectError: regexp.MustCompile("the segments specified for the resource type"),
+			},
+			{
+				Config: `
+provider "azurerm" {
+  features {}
+}
+
+output "test" {
+  value = provider::azurerm::build_resource_id("Microsoft.Resources/resourceGroups", {
+    subscription_id     = "not-a-uuid"
+    resource_group_name = "resGroup1"
+  })
+}
+`,
+				ExpectError: regexp.MustCompile("must be a UUID"),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionBuildTypedResourceID_subnet(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "azurerm" {
+  features {}
+}
+
+output "test" {
+  value = provider::azurerm::build_subnet_id("12345678-1234-9876-4563-123456789012", "resGroup1", "network1", "subnet1")
+}
+`,
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("test", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/resGroup1/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1"),
+				),
+			},
+		},
+	})
+}
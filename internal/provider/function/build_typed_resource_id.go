@@ -0,0 +1,248 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/recaser"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// BuildTypedResourceIDFunction builds a specific type of Resource ID (e.g. `build_subnet_id`) with a parameter for each
+// of the user specified segments - which unlike `build_resource_id` allows Terraform to validate the arguments.
+type BuildTypedResourceIDFunction struct {
+	name   string
+	idType resourceids.ResourceId
+}
+
+var _ function.Function = BuildTypedResourceIDFunction{}
+
+// NewBuildTypedResourceIDFunctions returns a `build_*_id` function for each of the Resource ID types registered by the
+// APIs used by the Provider, including the common Resource ID types
+func NewBuildTypedResourceIDFunctions() []func() function.Function {
+	commonIds := make(map[string]struct{})
+	for _, id := range commonids.CommonIds() {
+		commonIds[strings.ToLower(id.ID())] = struct{}{}
+	}
+
+	candidates := make([]typedResourceIdCandidate, 0)
+	for key, id := range recaser.KnownResourceIds() {
+		_, common := commonIds[key]
+		candidates = append(candidates, newTypedResourceIdCandidate(id, common))
+	}
+
+	names, err := typedResourceIdFunctionNames(candidates)
+	if err != nil {
+		panic(fmt.Sprintf("building the typed Resource ID functions: %+v", err))
+	}
+
+	keys := make([]string, 0, len(names))
+	for k := range names {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	output := make([]func() function.Function, 0, len(keys))
+	for _, k := range keys {
+		f := BuildTypedResourceIDFunction{
+			name:   k,
+			idType: names[k],
+		}
+		output = append(output, func() function.Function {
+			return &f
+		})
+	}
+
+	return output
+}
+
+// typedResourceIdCandidate is a Resource ID type along with the possible names for its function, in order of preference
+type typedResourceIdCandidate struct {
+	idType resourceids.ResourceId
+	common bool
+	names  []string
+}
+
+// newTypedResourceIdCandidate returns the possible names for the function for the Resource ID type, which are
+// qualified by the Resource Provider (e.g. `build_network_connection_id`) and then by the resource types within it
+// (e.g. `build_network_connections_connection_id`) when the shorter name is used by more than one Resource ID type
+func newTypedResourceIdCandidate(id resourceids.ResourceId, common bool) typedResourceIdCandidate {
+	typeName := convertToSnakeCase(strings.TrimSuffix(reflect.TypeOf(id).Elem().Name(), "Id"))
+
+	namespace := "Microsoft.Resources"
+	resourceTypes := make([]string, 0)
+	for _, segment := range id.Segments() {
+		switch segment.Type {
+		case resourceids.ResourceProviderSegmentType:
+			namespace = pointer.From(segment.FixedValue)
+			resourceTypes = make([]string, 0)
+
+		case resourceids.StaticSegmentType:
+			if value := pointer.From(segment.FixedValue); !strings.EqualFold(value, "providers") {
+				resourceTypes = append(resourceTypes, convertToSnakeCase(value))
+			}
+		}
+	}
+	namespace = strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(namespace, "Microsoft."), ".", "_"))
+
+	return typedResourceIdCandidate{
+		idType: id,
+		common: common,
+		names: []string{
+			fmt.Sprintf("build_%s_id", typeName),
+			fmt.Sprintf("build_%s_%s_id", namespace, typeName),
+			fmt.Sprintf("build_%s_%s_%s_id", namespace, strings.Join(resourceTypes, "_"), typeName),
+		},
+	}
+}
+
+// typedResourceIdFunctionNames assigns a unique function name to each of the candidates - where a name is used by more
+// than one candidate each of them uses its next name, except for a common Resource ID type which keeps the shorter
+// name. An error is returned when a name is still used by more than one candidate once the names are exhausted.
+func typedResourceIdFunctionNames(candidates []typedResourceIdCandidate) (map[string]resourceids.ResourceId, error) {
+	levels := make([]int, len(candidates))
+	for {
+		byName := make(map[string][]int)
+		for i, candidate := range candidates {
+			name := candidate.names[levels[i]]
+			byName[name] = append(byName[name], i)
+		}
+
+		names := make([]string, 0, len(byName))
+		for name := range byName {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		changed := false
+		for _, name := range names {
+			indexes := byName[name]
+			if len(indexes) == 1 {
+				continue
+			}
+
+			commonCount := 0
+			for _, i := range indexes {
+				if candidates[i].common {
+					commonCount++
+				}
+			}
+
+			for _, i := range indexes {
+				if candidates[i].common && commonCount == 1 {
+					continue
+				}
+
+				if levels[i]+1 >= len(candidates[i].names) {
+					ids := make([]string, 0, len(indexes))
+					for _, j := range indexes {
+						ids = append(ids, candidates[j].idType.ID())
+					}
+					sort.Strings(ids)
+					return nil, fmt.Errorf("the function name %q is used by more than one Resource ID type: [%s]", name, strings.Join(ids, ", "))
+				}
+
+				levels[i]++
+				changed = true
+			}
+		}
+
+		if !changed {
+			break
+		}
+	}
+
+	output := make(map[string]resourceids.ResourceId, len(candidates))
+	for i, candidate := range candidates {
+		output[candidate.names[levels[i]]] = candidate.idType
+	}
+	return output, nil
+}
+
+func (a BuildTypedResourceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = a.name
+}
+
+func (a BuildTypedResourceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	parameters := make([]function.Parameter, 0)
+	for _, segment := range userSpecifiedSegments(a.idType) {
+		description := fmt.Sprintf("The value for the `%s` segment, for example `%s`", segment.Name, segment.ExampleValue)
+		parameters = append(parameters, function.StringParameter{
+			Name:                convertToSnakeCase(segment.Name),
+			Description:         description,
+			MarkdownDescription: description,
+		})
+	}
+
+	description := fmt.Sprintf("Builds a %s in the correct casing for Terraform from the values for each segment", strings.TrimSuffix(reflect.TypeOf(a.idType).Elem().Name(), "Id")+" ID")
+	response.Definition = function.Definition{
+		Summary:             a.name,
+		Description:         description,
+		MarkdownDescription: description,
+		Parameters:          parameters,
+		Return:              function.StringReturn{},
+	}
+}
+
+func (a BuildTypedResourceIDFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	segments := userSpecifiedSegments(a.idType)
+
+	arguments := make([]string, len(segments))
+	targets := make([]interface{}, len(segments))
+	for i := range arguments {
+		targets[i] = &arguments[i]
+	}
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, targets...))
+
+	if response.Error != nil {
+		return
+	}
+
+	values := make(map[string]string)
+	for i, segment := range segments {
+		values[segment.Name] = arguments[i]
+	}
+
+	result, err := buildResourceId(a.idType, values)
+	if err != nil {
+		response.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
+
+// snakeCaseReplacer rewrites the words in Resource ID types which can't be split on the case of each character
+var snakeCaseReplacer = strings.NewReplacer(
+	"VMware", "Vmware",
+	"WANP2SVPN", "WanP2sVpn",
+)
+
+// convertToSnakeCase converts the name of a segment (e.g. `resourceGroupName`) or Resource ID type to snake case (e.g.
+// `resource_group_name`), treating consecutive upper case characters as a single word (e.g. `VPNConnection`)
+func convertToSnakeCase(input string) string {
+	runes := []rune(snakeCaseReplacer.Replace(input))
+	output := make([]rune, 0, len(runes))
+	for i, char := range runes {
+		if i > 0 && unicode.IsUpper(char) {
+			previousIsUpper := unicode.IsUpper(runes[i-1])
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !previousIsUpper || nextIsLower {
+				output = append(output, '_')
+			}
+		}
+		output = append(output, unicode.ToLower(char))
+	}
+	return string(output)
+}
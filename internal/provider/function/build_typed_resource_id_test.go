@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/automation/2023-11-01/connection"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/devcenter/2023-04-01/networkconnections"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/virtualnetworkgatewayconnections"
+)
+
+func TestTypedResourceIdFunctionNames(t *testing.T) {
+	candidates := []typedResourceIdCandidate{
+		newTypedResourceIdCandidate(&commonids.SubnetId{}, true),
+		newTypedResourceIdCandidate(&connection.ConnectionId{}, false),
+		newTypedResourceIdCandidate(&virtualnetworkgatewayconnections.ConnectionId{}, false),
+		newTypedResourceIdCandidate(&networkconnections.NetworkConnectionId{}, false),
+	}
+
+	names, err := typedResourceIdFunctionNames(candidates)
+	if err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+
+	expected := map[string]resourceids.ResourceId{
+		// the name is unique, so isn't qualified
+		"build_subnet_id": &commonids.SubnetId{},
+
+		// the name is used by more than one type, so is qualified by the Resource Provider
+		"build_automation_connection_id": &connection.ConnectionId{},
+
+		// the name qualified by the Resource Provider is used by `build_network_connection_id` below, so is also
+		// qualified by the resource types
+		"build_network_connections_connection_id": &virtualnetworkgatewayconnections.ConnectionId{},
+		"build_devcenter_network_connection_id":   &networkconnections.NetworkConnectionId{},
+	}
+	if len(names) != len(expected) {
+		t.Fatalf("expected %d names but got %d: %+v", len(expected), len(names), names)
+	}
+	for name, id := range expected {
+		actual, ok := names[name]
+		if !ok {
+			t.Fatalf("expected a function named %q but got %+v", name, names)
+		}
+		if actual.ID() != id.ID() {
+			t.Fatalf("expected the function %q to build %q but got %q", name, id.ID(), actual.ID())
+		}
+	}
+}
+
+func TestTypedResourceIdFunctionNamesCommonIdKeepsName(t *testing.T) {
+	generated := newTypedResourceIdCandidate(&commonids.SubnetId{}, false)
+	generated.idType = &virtualnetworkgatewayconnections.ConnectionId{}
+
+	names, err := typedResourceIdFunctionNames([]typedResourceIdCandidate{
+		newTypedResourceIdCandidate(&commonids.SubnetId{}, true),
+		generated,
+	})
+	if err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+
+	if id := names["build_subnet_id"]; id == nil || id.ID() != (&commonids.SubnetId{}).ID() {
+		t.Fatalf("expected `build_subnet_id` to build a Subnet ID but got %+v", names)
+	}
+	if _, ok := names["build_network_subnet_id"]; !ok {
+		t.Fatalf("expected the generated type to be qualified by the Resource Provider but got %+v", names)
+	}
+}
+
+func TestTypedResourceIdFunctionNamesDuplicate(t *testing.T) {
+	duplicate := newTypedResourceIdCandidate(&commonids.SubnetId{}, false)
+	duplicate.idType = &virtualnetworkgatewayconnections.ConnectionId{}
+
+	_, err := typedResourceIdFunctionNames([]typedResourceIdCandidate{
+		newTypedResourceIdCandidate(&commonids.SubnetId{}, false),
+		duplicate,
+	})
+	if err == nil || !strings.Contains(err.Error(), "is used by more than one Resource ID type") {
+		t.Fatalf("expected an error for the duplicate name but got %+v", err)
+	}
+}
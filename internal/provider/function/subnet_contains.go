@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+type SubnetContainsFunction struct{}
+
+var _ function.Function = SubnetContainsFunction{}
+
+func NewSubnetContainsFunction() function.Function {
+	return &SubnetContainsFunction{}
+}
+
+func (a SubnetContainsFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "subnet_contains"
+}
+
+func (a SubnetContainsFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "subnet_contains",
+		Description:         "Returns whether an IP address, or every address in a CIDR, is within the specified CIDR",
+		MarkdownDescription: "Returns whether an IP address, or every address in a CIDR, is within the specified CIDR",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				Description:         "The CIDR to check, for example the address space of a Virtual Network `10.0.0.0/16`",
+				MarkdownDescription: "The CIDR to check, for example the address space of a Virtual Network `10.0.0.0/16`",
+			},
+			function.StringParameter{
+				Name:                "address",
+				Description:         "The IP address (for example `10.0.1.4`) or CIDR (for example `10.0.1.0/24`) which should be within `cidr`",
+				MarkdownDescription: "The IP address (for example `10.0.1.4`) or CIDR (for example `10.0.1.0/24`) which should be within `cidr`",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (a SubnetContainsFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var cidr, address string
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &cidr, &address))
+
+	if response.Error != nil {
+		return
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(0, fmt.Sprintf("parsing %q as a CIDR: %+v", cidr, err))
+		return
+	}
+
+	var result bool
+	if strings.Contains(address, "/") {
+		_, other, err := net.ParseCIDR(address)
+		if err != nil {
+			response.Error = function.NewArgumentFuncError(1, fmt.Sprintf("parsing %q as a CIDR: %+v", address, err))
+			return
+		}
+
+		ones, bits := network.Mask.Size()
+		otherOnes, otherBits := other.Mask.Size()
+		result = bits == otherBits && otherOnes >= ones && network.Contains(other.IP)
+	} else {
+		ip := net.ParseIP(address)
+		if ip == nil {
+			response.Error = function.NewArgumentFuncError(1, fmt.Sprintf("%q is not a valid IP address or CIDR", address))
+			return
+		}
+
+		result = network.Contains(ip)
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+func TestProviderFunctionSubnetContains_basic(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "azurerm" {
+  features {}
+}
+
+output "address" {
+  value = provider::azurerm::subnet_contains("10.0.0.0/16", "10.0.1.4")
+}
+
+output "cidr" {
+  value = provider::azurerm::subnet_contains("10.0.0.0/16", "10.0.1.0/24")
+}
+
+output "overlapping_cidr" {
+  value = provider::azurerm::subnet_contains("10.0.1.0/24", "10.0.0.0/16")
+}
+
+output "outside" {
+  value = provider::azurerm::subnet_contains("10.0.0.0/16", "10.1.0.4")
+}
+`,
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("address", "true"),
+					acceptance.TestCheckOutput("cidr", "true"),
+					acceptance.TestCheckOutput("overlapping_cidr", "false"),
+					acceptance.TestCheckOutput("outside", "false"),
+				),
+			},
+		},
+	})
+}
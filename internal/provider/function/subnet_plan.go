@@ -0,0 +1,182 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+)
+
+type SubnetPlanFunction struct{}
+
+var _ function.Function = SubnetPlanFunction{}
+
+type subnetPlanRequest struct {
+	Name         string `tfsdk:"name"`
+	PrefixLength int64  `tfsdk:"prefix_length"`
+}
+
+var subnetPlanRequestTypes = map[string]attr.Type{
+	"name":          types.StringType,
+	"prefix_length": types.Int64Type,
+}
+
+func NewSubnetPlanFunction() function.Function {
+	return &SubnetPlanFunction{}
+}
+
+func (a SubnetPlanFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "subnet_plan"
+}
+
+func (a SubnetPlanFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "subnet_plan",
+		Description:         "Allocates non-overlapping IPv4 subnets of the requested sizes from the address space of a Virtual Network, returning a map of the subnet name to its address prefix",
+		MarkdownDescription: "Allocates non-overlapping IPv4 subnets of the requested sizes from the address space of a Virtual Network, returning a map of the subnet name to its address prefix",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "address_space",
+				Description:         "The address space of the Virtual Network, for example `[\"10.0.0.0/16\"]`",
+				MarkdownDescription: "The address space of the Virtual Network, for example `[\"10.0.0.0/16\"]`",
+				ElementType:         types.StringType,
+			},
+			function.ListParameter{
+				Name:                "subnets",
+				Description:         "The subnets to allocate, in order, each of which is an object containing the `name` and `prefix_length` of the subnet",
+				MarkdownDescription: "The subnets to allocate, in order, each of which is an object containing the `name` and `prefix_length` of the subnet",
+				ElementType: types.ObjectType{
+					AttrTypes: subnetPlanRequestTypes,
+				},
+			},
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (a SubnetPlanFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var addressSpace []string
+	var subnets []subnetPlanRequest
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &addressSpace, &subnets))
+
+	if response.Error != nil {
+		return
+	}
+
+	if len(addressSpace) == 0 {
+		response.Error = function.NewArgumentFuncError(0, "at least one address space must be specified")
+		return
+	}
+
+	spaces := make([]*addressSpaceAllocator, 0, len(addressSpace))
+	for _, cidr := range addressSpace {
+		space, err := newAddressSpaceAllocator(cidr)
+		if err != nil {
+			response.Error = function.NewArgumentFuncError(0, err.Error())
+			return
+		}
+		spaces = append(spaces, space)
+	}
+
+	result, err := planSubnets(spaces, subnets)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, result))
+}
+
+// planSubnets allocates each subnet, in order, from the first address space with sufficient free space after the
+// previously allocated subnet - which means that appending a subnet doesn't change those already allocated
+func planSubnets(spaces []*addressSpaceAllocator, subnets []subnetPlanRequest) (map[string]string, error) {
+	result := make(map[string]string)
+	for _, subnet := range subnets {
+		if subnet.Name == "" {
+			return nil, fmt.Errorf("the `name` of each subnet must be specified")
+		}
+		if _, exists := result[subnet.Name]; exists {
+			return nil, fmt.Errorf("the subnet %q is specified more than once", subnet.Name)
+		}
+		if subnet.PrefixLength < 2 || subnet.PrefixLength > 29 {
+			return nil, fmt.Errorf("the `prefix_length` of the subnet %q must be between 2 and 29 but got %d", subnet.Name, subnet.PrefixLength)
+		}
+		if err := validate.SubnetPrefixLengthForName(subnet.Name, int(subnet.PrefixLength)); err != nil {
+			return nil, err
+		}
+
+		allocated := ""
+		for _, space := range spaces {
+			if cidr, ok := space.allocate(int(subnet.PrefixLength)); ok {
+				allocated = cidr
+				break
+			}
+		}
+		if allocated == "" {
+			return nil, fmt.Errorf("there is insufficient space remaining in the address space [%s] to allocate the subnet %q with a prefix length of %d", joinAddressSpaces(spaces), subnet.Name, subnet.PrefixLength)
+		}
+
+		result[subnet.Name] = allocated
+	}
+
+	return result, nil
+}
+
+// addressSpaceAllocator allocates subnets sequentially from an IPv4 address space
+type addressSpaceAllocator struct {
+	cidr string
+	next uint64
+	end  uint64
+}
+
+func newAddressSpaceAllocator(cidr string) (*addressSpaceAllocator, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return nil, fmt.Errorf("the address space must be an IPv4 CIDR, for example 10.0.0.0/16, but got %q", cidr)
+	}
+	if !ip.Equal(network.IP) {
+		return nil, fmt.Errorf("the address space must start with the network address %q but got %q", network.String(), cidr)
+	}
+
+	start := uint64(binary.BigEndian.Uint32(network.IP.To4()))
+	return &addressSpaceAllocator{
+		cidr: cidr,
+		next: start,
+		end:  start + uint64(subnetSize(network)),
+	}, nil
+}
+
+// allocate returns the next subnet with the prefix length which is available in the address space, aligned to its size
+func (a *addressSpaceAllocator) allocate(prefixLength int) (string, bool) {
+	size := uint64(1) << (32 - prefixLength)
+	start := (a.next + size - 1) / size * size
+	if start+size > a.end {
+		return "", false
+	}
+
+	a.next = start + size
+
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, uint32(start))
+	return fmt.Sprintf("%s/%d", ip.String(), prefixLength), true
+}
+
+func joinAddressSpaces(spaces []*addressSpaceAllocator) string {
+	cidrs := make([]string, 0, len(spaces))
+	for _, space := range spaces {
+		cidrs = append(cidrs, space.cidr)
+	}
+	return strings.Join(cidrs, ", ")
+}
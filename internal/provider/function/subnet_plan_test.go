@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider/framework"
+)
+
+func TestProviderFunctionSubnetPlan_basic(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "azurerm" {
+  features {}
+}
+
+locals {
+  subnets = provider::azurerm::subnet_plan(["10.0.0.0/16"], [
+    { name = "workload", prefix_length = 24 },
+    { name = "GatewaySubnet", prefix_length = 27 },
+    { name = "AzureBastionSubnet", prefix_length = 26 },
+  ])
+}
+
+output "workload" {
+  value = local.subnets["workload"]
+}
+
+output "gateway" {
+  value = local.subnets["GatewaySubnet"]
+}
+
+output "bastion" {
+  value = local.subnets["AzureBastionSubnet"]
+}
+`,
+				Check: acceptance.ComposeTestCheckFunc(
+					acceptance.TestCheckOutput("workload", "10.0.0.0/24"),
+					acceptance.TestCheckOutput("gateway", "10.0.1.0/27"),
+					acceptance.TestCheckOutput("bastion", "10.0.1.64/26"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderFunctionSubnetPlan_invalid(t *testing.T) {
+	if !features.FourPointOhBeta() {
+		t.Skipf("skipping test due to missing feature flag")
+	}
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(version.Must(version.NewVersion("1.8.0-beta1"))),
+		},
+		ProtoV5ProviderFactories: framework.ProtoV5ProviderFactoriesInit(context.Background(), "azurerm"),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "azurerm" {
+  features {}
+}
+
+output "test" {
+  value = provider::azurerm::subnet_plan(["10.0.0.0/24"], [
+    { name = "first", prefix_length = 25 },
+    { name = "second", prefix_length = 24 },
+  ])
+}
+`,
+				ExpectError: regexp.MustCompile("there is insufficient space remaining"),
+			},
+			{
+				Config: `
+provider "azurerm" {
+  features {}
+}
+
+output "test" {
+  value = provider::azurerm::subnet_plan(["10.0.0.0/24"], [
+    { name = "AzureFirewallSubnet", prefix_length = 27 },
+  ])
+}
+`,
+				ExpectError: regexp.MustCompile("must have a prefix length of 26 or less"),
+			},
+		},
+	})
+}
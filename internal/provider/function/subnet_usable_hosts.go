@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package function
+
+import (
+	"context"
+	"net"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+)
+
+type SubnetUsableHostsFunction struct{}
+
+var _ function.Function = SubnetUsableHostsFunction{}
+
+func NewSubnetUsableHostsFunction() function.Function {
+	return &SubnetUsableHostsFunction{}
+}
+
+func (a SubnetUsableHostsFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "subnet_usable_hosts"
+}
+
+func (a SubnetUsableHostsFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "subnet_usable_hosts",
+		Description:         "Returns the number of addresses in an IPv4 subnet which can be assigned to resources, excluding the 5 addresses reserved by Azure",
+		MarkdownDescription: "Returns the number of addresses in an IPv4 subnet which can be assigned to resources, excluding the 5 addresses reserved by Azure",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				Description:         "The address prefix of the subnet, for example `10.0.1.0/24`",
+				MarkdownDescription: "The address prefix of the subnet, for example `10.0.1.0/24`",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (a SubnetUsableHostsFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var cidr string
+
+	response.Error = function.ConcatFuncErrors(request.Arguments.Get(ctx, &cidr))
+
+	if response.Error != nil {
+		return
+	}
+
+	network, err := parseSubnetCIDR(cidr)
+	if err != nil {
+		response.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Result.Set(ctx, subnetSize(network)-validate.SubnetReservedAddresses))
+}
+
+// parseSubnetCIDR parses an IPv4 CIDR which is valid as the address prefix of an Azure subnet
+func parseSubnetCIDR(cidr string) (*net.IPNet, error) {
+	if _, errs := validate.SubnetCIDR(cidr, "cidr"); len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	return network, nil
+}
+
+// subnetSize returns the total number of addresses in an IPv4 network
+func subnetSize(network *net.IPNet) int64 {
+	ones, bits := network.Mask.Size()
+	return int64(1) << (bits - ones)
+}
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// withLockBackend passes the lock Backend for the Provider instance along with the context given to the resource's
+// context-aware functions (which are used by Typed resources) - Untyped resources instead derive their context from
+// the StopContext on the Client, which already contains the Backend.
+func withLockBackend(resource *pluginsdk.Resource) *pluginsdk.Resource {
+	resource.CreateContext = wrapContextFuncWithLockBackend(resource.CreateContext)
+	resource.ReadContext = wrapContextFuncWithLockBackend(resource.ReadContext)
+	resource.UpdateContext = wrapContextFuncWithLockBackend(resource.UpdateContext)
+	resource.DeleteContext = wrapContextFuncWithLockBackend(resource.DeleteContext)
+	resource.CreateWithoutTimeout = wrapContextFuncWithLockBackend(resource.CreateWithoutTimeout)
+	resource.ReadWithoutTimeout = wrapContextFuncWithLockBackend(resource.ReadWithoutTimeout)
+	resource.UpdateWithoutTimeout = wrapContextFuncWithLockBackend(resource.UpdateWithoutTimeout)
+	resource.DeleteWithoutTimeout = wrapContextFuncWithLockBackend(resource.DeleteWithoutTimeout)
+	return resource
+}
+
+func wrapContextFuncWithLockBackend(in func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	if in == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		if client, ok := meta.(*clients.Client); ok {
+			ctx = locks.WithBackend(ctx, client.LockBackend)
+		}
+		return in(ctx, d, meta)
+	}
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"log"
 	"os"
 	"strings"
 	"time"
@@ -17,9 +18,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
@@ -31,6 +35,14 @@ func TestAzureProvider() *schema.Provider {
 	return azureProvider(true)
 }
 
+// TestAzureProviderWithClientBuilder returns the Provider used in the Acceptance Tests, with `configure` being called
+// to customise the ClientBuilder (for example to record or replay the requests made) before the Clients are built
+func TestAzureProviderWithClientBuilder(configure func(builder *clients.ClientBuilder)) *schema.Provider {
+	p := azureProvider(true)
+	p.ConfigureContextFunc = providerConfigureWithClientBuilder(p, configure)
+	return p
+}
+
 func ValidatePartnerID(i interface{}, k string) ([]string, []error) {
 	// ValidatePartnerID checks if partner_id is any of the following:
 	//  * a valid UUID - will add "pid-" prefix to the ID if it is not already present
@@ -139,6 +151,12 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 		}
 	}
 
+	// apply the Tags configuration from the Provider block (`default_tags` and `ignore_tags`) to taggable resources,
+	// both Typed and Untyped - and pass the lock Backend for this instance of the Provider along to each resource
+	for k, v := range resources {
+		resources[k] = withLockBackend(tags.WithProviderTags(v))
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -319,8 +337,102 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				Description: "This will disable the Terraform Partner ID which is used if a custom `partner_id` isn't specified.",
 			},
 
+			"request_throttling_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_REQUEST_THROTTLING_ENABLED", false),
+				Description: "Should the AzureRM Provider pace requests to Azure Resource Manager using the rate limits returned by the API, rather than retrying requests once they've been throttled?",
+			},
+
+			"request_throttling_reads_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_REQUEST_THROTTLING_READS_PER_SECOND", common.DefaultRequestThrottlingReadsPerSecond),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of read requests per second, per Subscription, which the AzureRM Provider should pace requests to when `request_throttling_enabled` is set.",
+			},
+
+			"request_throttling_writes_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_REQUEST_THROTTLING_WRITES_PER_SECOND", common.DefaultRequestThrottlingWritesPerSecond),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The number of write and delete requests per second, per Subscription, which the AzureRM Provider should pace requests to when `request_throttling_enabled` is set.",
+			},
+
+			"retry_transient_errors_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_RETRY_TRANSIENT_ERRORS_ENABLED", false),
+				Description: "Should the AzureRM Provider retry requests to Azure Resource Manager which fail with a well-known transient error?",
+			},
+
+			"retry_transient_errors_max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("ARM_RETRY_TRANSIENT_ERRORS_MAX_RETRIES", common.DefaultRetryPolicyMaxRetries),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of times a request which fails with a well-known transient error should be retried when `retry_transient_errors_enabled` is set.",
+			},
+
+			"lock_backend": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_LOCK_BACKEND", locks.BackendTypeLocal),
+				Description: "The backend which should be used to coordinate locks on parent resources (such as Virtual Networks) with other instances of the AzureRM Provider. Possible values are `local`, `file` and `azure_blob`.",
+				ValidateFunc: validation.StringInSlice([]string{
+					locks.BackendTypeLocal,
+					locks.BackendTypeFile,
+					locks.BackendTypeAzureBlob,
+				}, false),
+			},
+
+			"lock_file_directory": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_LOCK_FILE_DIRECTORY", ""),
+				Description: "The path to a directory shared by each instance of the AzureRM Provider which lock files should be created in when `lock_backend` is set to `file`.",
+			},
+
+			"lock_blob_container_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_LOCK_BLOB_CONTAINER_URL", ""),
+				Description: "The URL of a Storage Container which lock blobs should be created in when `lock_backend` is set to `azure_blob`.",
+			},
+
+			"lock_blob_access_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_LOCK_BLOB_ACCESS_KEY", ""),
+				Description: "The Access Key for the Storage Account containing the `lock_blob_container_url` Storage Container. When not specified, the credentials used by the AzureRM Provider are used instead.",
+			},
+
+			"http_trace_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_HTTP_TRACE_FILE", ""),
+				Description: "The path to a file which a trace of every HTTP request sent by the AzureRM Provider (with any sensitive values redacted) should be written to.",
+			},
+
+			"http_trace_format": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_HTTP_TRACE_FORMAT", common.HTTPTraceFormatHAR),
+				Description: "The format which the trace of HTTP requests specified in `http_trace_file` should be written in. Possible values are `har` and `otlp`.",
+				ValidateFunc: validation.StringInSlice([]string{
+					common.HTTPTraceFormatHAR,
+					common.HTTPTraceFormatOTLP,
+				}, false),
+			},
+
 			"features": schemaFeatures(supportLegacyTestSuite),
 
+			"default_tags": schemaDefaultTags(),
+
+			"ignore_tags": schemaIgnoreTags(),
+
 			// Advanced feature flags
 			"resource_provider_registrations": {
 				Type:        schema.TypeString,
@@ -346,6 +458,13 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				},
 			},
 
+			"resource_provider_registration_warnings": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_RESOURCE_PROVIDER_REGISTRATION_WARNINGS", false),
+				Description: "Should the AzureRM Provider surface a warning during the plan for resources which require a Resource Provider that isn't registered in the subscription?",
+			},
+
 			// TODO: Remove `skip_provider_registration` in v5.0
 			"skip_provider_registration": {
 				Type:        schema.TypeBool,
@@ -384,6 +503,10 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 // To configure behavioral aspects of the provider, use the buildClient function instead.
 // This separation allows us to robustly test different authentication scenarios.
 func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
+	return providerConfigureWithClientBuilder(p, nil)
+}
+
+func providerConfigureWithClientBuilder(p *schema.Provider, configureClientBuilder func(builder *clients.ClientBuilder)) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		subscriptionId := d.Get("subscription_id").(string)
 		if subscriptionId == "" {
@@ -482,13 +605,13 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			EnableAuthenticationUsingGitHubOIDC:        enableOidc,
 		}
 
-		return buildClient(ctx, p, d, authConfig)
+		return buildClient(ctx, p, d, authConfig, configureClientBuilder)
 	}
 }
 
 // buildClient is used to configure behavioral aspects of the provider. To configure the
 // cloud environment and authentication-related settings, use the providerConfigure function.
-func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials) (*clients.Client, diag.Diagnostics) {
+func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials, configureClientBuilder func(builder *clients.ClientBuilder)) (*clients.Client, diag.Diagnostics) {
 	// TODO: This hardcoded default is for v3.x, where `resource_provider_registrations` is not defined. Remove this hardcoded default in v4.0
 	providerRegistrations := resourceproviders.ProviderRegistrationsLegacy
 	if features.FourPointOhBeta() {
@@ -521,18 +644,41 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
 		DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
 		Features:                    expandFeatures(d.Get("features").([]interface{})),
+		HTTPTrace: common.HTTPTraceOptions{
+			Path:   d.Get("http_trace_file").(string),
+			Format: d.Get("http_trace_format").(string),
+		},
+		LockBackend: locks.BackendOptions{
+			Type:             d.Get("lock_backend").(string),
+			FileDirectory:    d.Get("lock_file_directory").(string),
+			BlobContainerURL: d.Get("lock_blob_container_url").(string),
+			BlobAccessKey:    d.Get("lock_blob_access_key").(string),
+		},
 		MetadataHost:                d.Get("metadata_host").(string),
 		PartnerID:                   d.Get("partner_id").(string),
 		RegisteredResourceProviders: requiredResourceProviders,
-		StorageUseAzureAD:           d.Get("storage_use_azuread").(bool),
-		SubscriptionID:              d.Get("subscription_id").(string),
-		TerraformVersion:            p.TerraformVersion,
+		RequestThrottling: common.RequestThrottlingOptions{
+			Enabled:         d.Get("request_throttling_enabled").(bool),
+			ReadsPerSecond:  d.Get("request_throttling_reads_per_second").(int),
+			WritesPerSecond: d.Get("request_throttling_writes_per_second").(int),
+		},
+		RetryPolicy: common.RetryPolicyOptions{
+			Disabled:   !d.Get("retry_transient_errors_enabled").(bool),
+			MaxRetries: d.Get("retry_transient_errors_max_retries").(int),
+		},
+		StorageUseAzureAD: d.Get("storage_use_azuread").(bool),
+		SubscriptionID:    d.Get("subscription_id").(string),
+		TerraformVersion:  p.TerraformVersion,
 
 		// this field is intentionally not exposed in the provider block, since it's only used for
 		// platform level tracing
 		CustomCorrelationRequestID: os.Getenv("ARM_CORRELATION_REQUEST_ID"),
 	}
 
+	if configureClientBuilder != nil {
+		configureClientBuilder(&clientBuilder)
+	}
+
 	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golangci-lint
 	stopCtx, ok := schema.StopContext(ctx) //nolint:staticcheck
 	if !ok {
@@ -544,7 +690,7 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		return nil, diag.FromErr(err)
 	}
 
-	client.StopContext = stopCtx
+	client.StopContext = locks.WithBackend(stopCtx, client.LockBackend)
 
 	subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
 
@@ -556,5 +702,15 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 
 	}
 
+	client.ResourceProviderRegistrationWarnings = d.Get("resource_provider_registration_warnings").(bool)
+	if client.ResourceProviderRegistrationWarnings {
+		if err := resourceproviders.CacheSupportedProviders(ctx2, client.Resource.ResourceProvidersClient, subscriptionId); err != nil {
+			log.Printf("[WARN] retrieving the registration state of the Resource Providers: %+v. Resource Provider registration warnings will be unavailable", err)
+		}
+	}
+
+	client.ProviderTags = expandProviderTags(d.Get("default_tags").([]interface{}), d.Get("ignore_tags").([]interface{}))
+	client.ProviderTags.Client = client.Resource.TagsClient
+
 	return client, nil
 }
@@ -99,6 +99,39 @@ func schemaContainsSensitiveFieldsNotMarkedAsSensitive(input map[string]*plugins
 	return nil
 }
 
+func TestResourcesWithWriteOnlyFieldsBehaveConsistently(t *testing.T) {
+	provider := TestAzureProvider()
+
+	// intentionally sorting these so the output is consistent
+	resourceNames := make([]string, 0)
+	for resourceName := range provider.ResourcesMap {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+
+	for _, resourceName := range resourceNames {
+		resource := provider.ResourcesMap[resourceName]
+		for fieldName, field := range resource.Schema {
+			if !strings.HasSuffix(fieldName, "_wo") {
+				continue
+			}
+
+			if !field.Sensitive || field.DiffSuppressFunc == nil || field.Computed {
+				t.Fatalf("the write-only field %q in the Resource %q should be Sensitive, non-Computed and use the DiffSuppressFunc `pluginsdk.SuppressWriteOnlyDiff`", fieldName, resourceName)
+			}
+
+			versionField, ok := resource.Schema[pluginsdk.WriteOnlyVersionKey(fieldName)]
+			if !ok {
+				t.Fatalf("the write-only field %q in the Resource %q has no companion field %q", fieldName, resourceName, pluginsdk.WriteOnlyVersionKey(fieldName))
+			}
+
+			if versionField.Type != pluginsdk.TypeInt || versionField.Sensitive {
+				t.Fatalf("the field %q in the Resource %q should be a non-Sensitive Integer", pluginsdk.WriteOnlyVersionKey(fieldName), resourceName)
+			}
+		}
+	}
+}
+
 func TestDataSourcesShouldNotSupportImport(t *testing.T) {
 	provider := TestAzureProvider()
 
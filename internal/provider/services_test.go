@@ -4,12 +4,16 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	providerfunction "github.com/hashicorp/terraform-provider-azurerm/internal/provider/function"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -120,6 +124,17 @@ func TestResourcesAreNamedConsistently(t *testing.T) {
 	}
 }
 
+func TestResourcesHaveResourceProviders(t *testing.T) {
+	// the Resource Provider required by each Resource is used to surface the Resource Providers which need to be
+	// registered (see the `azurerm_resource_provider_registrations` Data Source) - so when adding a new group of
+	// resources `resourceProvidersForResourceTypes` within `internal/resourceproviders` needs to be updated
+	for resourceType := range AzureProvider().ResourcesMap {
+		if _, ok := resourceproviders.ForResourceType(resourceType); !ok {
+			t.Errorf("unable to determine the Resource Provider required by the Resource %q", resourceType)
+		}
+	}
+}
+
 func validateResourceTypeName(resourceType string) error {
 	if strings.ToLower(resourceType) != resourceType {
 		return fmt.Errorf("the resource type must be all lower-case")
@@ -207,3 +222,23 @@ func TestTypedResourcesUsePointersForOptionalProperties(t *testing.T) {
 		t.Fatalf("schema properties found with incorrect types - `Optional` should be pointers, `Required` should not be pointers")
 	}
 }
+
+func TestBuildTypedResourceIDFunctionsHaveUniqueNames(t *testing.T) {
+	// the Resource ID types are registered by each of the APIs used by the Provider, so this is checked here rather
+	// than within the `function` package
+	names := make(map[string]struct{})
+	for _, f := range providerfunction.NewBuildTypedResourceIDFunctions() {
+		resp := function.MetadataResponse{}
+		f().Metadata(context.Background(), function.MetadataRequest{}, &resp)
+		if _, ok := names[resp.Name]; ok {
+			t.Fatalf("the function name %q is used more than once", resp.Name)
+		}
+		names[resp.Name] = struct{}{}
+	}
+
+	for _, name := range []string{"build_subnet_id", "build_resource_group_id"} {
+		if _, ok := names[name]; !ok {
+			t.Fatalf("expected a function named %q", name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaDefaultTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Tags which should be assigned to every taggable resource managed by the AzureRM Provider.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"tags": {
+					Type:         pluginsdk.TypeMap,
+					Required:     true,
+					ValidateFunc: tags.Validate,
+					Elem: &pluginsdk.Schema{
+						Type: pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func schemaIgnoreTags() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:        pluginsdk.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Tags which should be ignored when reading the Tags for every taggable resource managed by the AzureRM Provider, for example those assigned by Azure Policy.",
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"keys": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"key_prefixes": {
+					Type:     pluginsdk.TypeSet,
+					Optional: true,
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+			},
+		},
+	}
+}
+
+func expandProviderTags(defaultTags []interface{}, ignoreTags []interface{}) tags.ProviderConfiguration {
+	output := tags.ProviderConfiguration{
+		DefaultTags:       make(map[string]string),
+		IgnoreKeys:        make([]string, 0),
+		IgnoreKeyPrefixes: make([]string, 0),
+	}
+
+	if len(defaultTags) > 0 && defaultTags[0] != nil {
+		raw := defaultTags[0].(map[string]interface{})
+		for k, v := range tags.Expand(raw["tags"].(map[string]interface{})) {
+			output.DefaultTags[k] = *v
+		}
+	}
+
+	if len(ignoreTags) > 0 && ignoreTags[0] != nil {
+		raw := ignoreTags[0].(map[string]interface{})
+		for _, v := range raw["keys"].(*pluginsdk.Set).List() {
+			output.IgnoreKeys = append(output.IgnoreKeys, v.(string))
+		}
+		for _, v := range raw["key_prefixes"].(*pluginsdk.Set).List() {
+			output.IgnoreKeyPrefixes = append(output.IgnoreKeyPrefixes, v.(string))
+		}
+	}
+
+	return output
+}
@@ -72,3 +72,42 @@ func populateCache(ctx context.Context, client *providers.ProvidersClient, subsc
 	cachedResourceProviders = &providerNames
 	return nil
 }
+
+// IsRegistered returns whether the specified Resource Provider is registered in the Subscription, according to the
+// cache - the second return value is false when this isn't known (e.g. the cache hasn't been populated, or the
+// Resource Provider isn't available in this Azure Environment).
+func IsRegistered(resourceProvider string) (registered bool, known bool) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	// Resource Providers are returned from the API in differing cases (e.g. `microsoft.insights`)
+	for name := range registeredResourceProviders {
+		if strings.EqualFold(name, resourceProvider) {
+			return true, true
+		}
+	}
+	for name := range unregisteredResourceProviders {
+		if strings.EqualFold(name, resourceProvider) {
+			return false, true
+		}
+	}
+
+	return false, false
+}
+
+// markAsRegistered updates the cache once the specified Resource Provider has been registered
+func markAsRegistered(resourceProvider string) {
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if registeredResourceProviders == nil || unregisteredResourceProviders == nil {
+		return
+	}
+
+	for name := range unregisteredResourceProviders {
+		if strings.EqualFold(name, resourceProvider) {
+			delete(unregisteredResourceProviders, name)
+		}
+	}
+	registeredResourceProviders[resourceProvider] = struct{}{}
+}
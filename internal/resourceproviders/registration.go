@@ -101,6 +101,7 @@ func registerWithSubscription(ctx context.Context, client *providers.ProvidersCl
 	}
 
 	log.Printf("[DEBUG] %s is registered.", providerId)
+	markAsRegistered(providerName)
 
 	return nil
 }
@@ -0,0 +1,265 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"strings"
+)
+
+// resourceProvidersForResourceTypes maps the prefix of a Terraform Resource Type to the Resource Provider which
+// is required to use it - where the longest matching prefix takes precedence, allowing the exceptions within
+// a group of resources (e.g. `azurerm_kubernetes_cluster_extension`) to be specified.
+//
+// NOTE: this is used to surface which Resource Providers require registration, so when adding a new group of
+// resources with a different prefix (or from a different Resource Provider) this should be updated.
+var resourceProvidersForResourceTypes = map[string]string{
+	"azurerm_aadb2c": "Microsoft.AzureActiveDirectory",
+	"azurerm_active_directory_domain_service":                 "Microsoft.AAD",
+	"azurerm_advanced_threat_protection":                      "Microsoft.Security",
+	"azurerm_advisor":                                         "Microsoft.Advisor",
+	"azurerm_ai_services":                                     "Microsoft.CognitiveServices",
+	"azurerm_analysis_services":                               "Microsoft.AnalysisServices",
+	"azurerm_api_connection":                                  "Microsoft.Web",
+	"azurerm_api_management":                                  "Microsoft.ApiManagement",
+	"azurerm_app_configuration":                               "Microsoft.AppConfiguration",
+	"azurerm_app_service":                                     "Microsoft.Web",
+	"azurerm_app_service_certificate_order":                   "Microsoft.CertificateRegistration",
+	"azurerm_app_service_connection":                          "Microsoft.ServiceLinker",
+	"azurerm_application_gateway":                             "Microsoft.Network",
+	"azurerm_application_insights":                            "microsoft.insights",
+	"azurerm_application_load_balancer":                       "Microsoft.ServiceNetworking",
+	"azurerm_application_security_group":                      "Microsoft.Network",
+	"azurerm_arc_kubernetes":                                  "Microsoft.KubernetesConfiguration",
+	"azurerm_arc_kubernetes_cluster":                          "Microsoft.Kubernetes",
+	"azurerm_arc_kubernetes_cluster_extension":                "Microsoft.KubernetesConfiguration",
+	"azurerm_arc_kubernetes_provisioned_cluster":              "Microsoft.Kubernetes",
+	"azurerm_arc_machine":                                     "Microsoft.HybridCompute",
+	"azurerm_arc_machine_automanage_configuration_assignment": "Microsoft.AutoManage",
+	"azurerm_arc_private_link_scope":                          "Microsoft.HybridCompute",
+	"azurerm_arc_resource_bridge":                             "Microsoft.ResourceConnector",
+	"azurerm_attestation":                                     "Microsoft.Attestation",
+	"azurerm_automanage":                                      "Microsoft.AutoManage",
+	"azurerm_automation":                                      "Microsoft.Automation",
+	"azurerm_availability_set":                                "Microsoft.Compute",
+	"azurerm_backup":                                          "Microsoft.RecoveryServices",
+	"azurerm_bastion_host":                                    "Microsoft.Network",
+	"azurerm_batch":                                           "Microsoft.Batch",
+	"azurerm_billing":                                         "Microsoft.CostManagement",
+	"azurerm_blueprint":                                       "Microsoft.Blueprint",
+	"azurerm_bot":                                             "Microsoft.BotService",
+	"azurerm_bot_healthbot":                                   "Microsoft.HealthBot",
+	"azurerm_capacity_reservation":                            "Microsoft.Compute",
+	"azurerm_cdn":                                             "Microsoft.Cdn",
+	"azurerm_cdn_frontdoor_firewall_policy":                   "Microsoft.Network",
+	"azurerm_chaos_studio":                                    "Microsoft.Chaos",
+	"azurerm_cognitive":                                       "Microsoft.CognitiveServices",
+	"azurerm_communication":                                   "Microsoft.Communication",
+	"azurerm_confidential_ledger":                             "Microsoft.ConfidentialLedger",
+	"azurerm_consumption":                                     "Microsoft.Consumption",
+	"azurerm_container_app":                                   "Microsoft.App",
+	"azurerm_container_connected_registry":                    "Microsoft.ContainerRegistry",
+	"azurerm_container_group":                                 "Microsoft.ContainerInstance",
+	"azurerm_container_registry":                              "Microsoft.ContainerRegistry",
+	"azurerm_cosmosdb":                                        "Microsoft.DocumentDB",
+	"azurerm_cosmosdb_postgresql":                             "Microsoft.DBforPostgreSQL",
+	"azurerm_cost":                                            "Microsoft.CostManagement",
+	"azurerm_custom_ip_prefix":                                "Microsoft.Network",
+	"azurerm_custom_provider":                                 "Microsoft.CustomProviders",
+	"azurerm_dashboard":                                       "Microsoft.Portal",
+	"azurerm_dashboard_grafana":                               "Microsoft.Dashboard",
+	"azurerm_data_factory":                                    "Microsoft.DataFactory",
+	"azurerm_data_protection":                                 "Microsoft.DataProtection",
+	"azurerm_data_share":                                      "Microsoft.DataShare",
+	"azurerm_database_migration":                              "Microsoft.DataMigration",
+	"azurerm_databox_edge":                                    "Microsoft.DataBoxEdge",
+	"azurerm_databricks":                                      "Microsoft.Databricks",
+	"azurerm_datadog":                                         "Microsoft.Datadog",
+	"azurerm_dedicated_hardware_security_module":              "Microsoft.HardwareSecurityModules",
+	"azurerm_dedicated_host":                                  "Microsoft.Compute",
+	"azurerm_dev_center":                                      "Microsoft.DevCenter",
+	"azurerm_dev_test":                                        "Microsoft.DevTestLab",
+	"azurerm_digital_twins":                                   "Microsoft.DigitalTwins",
+	"azurerm_disk":                                            "Microsoft.Compute",
+	"azurerm_dns":                                             "Microsoft.Network",
+	"azurerm_elastic_cloud":                                   "Microsoft.Elastic",
+	"azurerm_elastic_san":                                     "Microsoft.ElasticSan",
+	"azurerm_email_communication":                             "Microsoft.Communication",
+	"azurerm_eventgrid":                                       "Microsoft.EventGrid",
+	"azurerm_eventhub":                                        "Microsoft.EventHub",
+	"azurerm_express_route":                                   "Microsoft.Network",
+	"azurerm_extended":                                        "Microsoft.ExtendedLocation",
+	"azurerm_federated_identity_credential":                   "Microsoft.ManagedIdentity",
+	"azurerm_firewall":                                        "Microsoft.Network",
+	"azurerm_fluid_relay":                                     "Microsoft.FluidRelay",
+	"azurerm_frontdoor":                                       "Microsoft.Network",
+	"azurerm_function_app":                                    "Microsoft.Web",
+	"azurerm_function_app_connection":                         "Microsoft.ServiceLinker",
+	"azurerm_gallery_application":                             "Microsoft.Compute",
+	"azurerm_graph_account":                                   "Microsoft.GraphServices",
+	"azurerm_graph_services":                                  "Microsoft.GraphServices",
+	"azurerm_hdinsight":                                       "Microsoft.HDInsight",
+	"azurerm_healthbot":                                       "Microsoft.HealthBot",
+	"azurerm_healthcare":                                      "Microsoft.HealthcareApis",
+	"azurerm_hpc_cache":                                       "Microsoft.StorageCache",
+	"azurerm_image":                                           "Microsoft.Compute",
+	"azurerm_images":                                          "Microsoft.Compute",
+	"azurerm_iot_security":                                    "Microsoft.Security",
+	"azurerm_iotcentral":                                      "Microsoft.IoTCentral",
+	"azurerm_iothub":                                          "Microsoft.Devices",
+	"azurerm_iothub_device_update":                            "Microsoft.DeviceUpdate",
+	"azurerm_ip_group":                                        "Microsoft.Network",
+	"azurerm_ip_groups":                                       "Microsoft.Network",
+	"azurerm_key_vault":                                       "Microsoft.KeyVault",
+	"azurerm_kubernetes":                                      "Microsoft.ContainerService",
+	"azurerm_kubernetes_cluster_extension":                    "Microsoft.KubernetesConfiguration",
+	"azurerm_kubernetes_flux_configuration":                   "Microsoft.KubernetesConfiguration",
+	"azurerm_kusto":                                           "Microsoft.Kusto",
+	"azurerm_lb":                                              "Microsoft.Network",
+	"azurerm_lighthouse":                                      "Microsoft.ManagedServices",
+	"azurerm_linux_function_app":                              "Microsoft.Web",
+	"azurerm_linux_virtual_machine":                           "Microsoft.Compute",
+	"azurerm_linux_web_app":                                   "Microsoft.Web",
+	"azurerm_load_test":                                       "Microsoft.LoadTestService",
+	"azurerm_local_network_gateway":                           "Microsoft.Network",
+	"azurerm_log_analytics":                                   "Microsoft.OperationalInsights",
+	"azurerm_log_analytics_solution":                          "Microsoft.OperationsManagement",
+	"azurerm_logic_app":                                       "Microsoft.Logic",
+	"azurerm_logic_app_standard":                              "Microsoft.Web",
+	"azurerm_machine_learning":                                "Microsoft.MachineLearningServices",
+	"azurerm_maintenance":                                     "Microsoft.Maintenance",
+	"azurerm_managed_api":                                     "Microsoft.Web",
+	"azurerm_managed_application":                             "Microsoft.Solutions",
+	"azurerm_managed_disk":                                    "Microsoft.Compute",
+	"azurerm_managed_lustre":                                  "Microsoft.StorageCache",
+	"azurerm_management_group":                                "Microsoft.Management",
+	"azurerm_management_group_policy":                         "Microsoft.Authorization",
+	"azurerm_management_group_policy_remediation":             "Microsoft.PolicyInsights",
+	"azurerm_management_group_template_deployment":            "Microsoft.Resources",
+	"azurerm_management_lock":                                 "Microsoft.Authorization",
+	"azurerm_maps":                                            "Microsoft.Maps",
+	"azurerm_marketplace_agreement":                           "Microsoft.MarketplaceOrdering",
+	"azurerm_marketplace_role_assignment":                     "Microsoft.Marketplace",
+	"azurerm_mobile_network":                                  "Microsoft.MobileNetwork",
+	"azurerm_monitor":                                         "microsoft.insights",
+	"azurerm_monitor_aad_diagnostic_setting":                  "Microsoft.AADIAM",
+	"azurerm_monitor_alert_processing_rule":                   "Microsoft.AlertsManagement",
+	"azurerm_monitor_alert_prometheus_rule_group":             "Microsoft.AlertsManagement",
+	"azurerm_monitor_smart_detector_alert_rule":               "Microsoft.AlertsManagement",
+	"azurerm_monitor_workspace":                               "Microsoft.Monitor",
+	"azurerm_mssql":                                           "Microsoft.Sql",
+	"azurerm_mssql_virtual_machine":                           "Microsoft.SqlVirtualMachine",
+	"azurerm_mysql":                                           "Microsoft.DBforMySQL",
+	"azurerm_nat_gateway":                                     "Microsoft.Network",
+	"azurerm_netapp":                                          "Microsoft.NetApp",
+	"azurerm_network":                                         "Microsoft.Network",
+	"azurerm_network_function":                                "Microsoft.NetworkFunction",
+	"azurerm_new_relic":                                       "NewRelic.Observability",
+	"azurerm_nginx":                                           "Nginx.NginxPlus",
+	"azurerm_notification_hub":                                "Microsoft.NotificationHubs",
+	"azurerm_orbital":                                         "Microsoft.Orbital",
+	"azurerm_orchestrated_virtual_machine_scale_set":          "Microsoft.Compute",
+	"azurerm_palo_alto":                                       "PaloAltoNetworks.Cloudngfw",
+	"azurerm_palo_alto_network_virtual_appliance":             "Microsoft.Network",
+	"azurerm_pim":                                             "Microsoft.Authorization",
+	"azurerm_platform_image":                                  "Microsoft.Compute",
+	"azurerm_point_to_site_vpn_gateway":                       "Microsoft.Network",
+	"azurerm_policy":                                          "Microsoft.Authorization",
+	"azurerm_policy_virtual_machine_configuration_assignment": "Microsoft.GuestConfiguration",
+	"azurerm_portal":                                          "Microsoft.Portal",
+	"azurerm_postgresql":                                      "Microsoft.DBforPostgreSQL",
+	"azurerm_powerbi":                                         "Microsoft.PowerBIDedicated",
+	"azurerm_private":                                         "Microsoft.Network",
+	"azurerm_proximity_placement_group":                       "Microsoft.Compute",
+	"azurerm_public_ip":                                       "Microsoft.Network",
+	"azurerm_public_ips":                                      "Microsoft.Network",
+	"azurerm_public_maintenance_configurations":               "Microsoft.Maintenance",
+	"azurerm_purview":                                         "Microsoft.Purview",
+	"azurerm_recovery_services":                               "Microsoft.RecoveryServices",
+	"azurerm_redhat_openshift":                                "Microsoft.RedHatOpenShift",
+	"azurerm_redis":                                           "Microsoft.Cache",
+	"azurerm_relay":                                           "Microsoft.Relay",
+	"azurerm_resource":                                        "Microsoft.Resources",
+	"azurerm_resource_group_cost_management":                  "Microsoft.CostManagement",
+	"azurerm_resource_group_policy":                           "Microsoft.Authorization",
+	"azurerm_resource_group_policy_remediation":               "Microsoft.PolicyInsights",
+	"azurerm_resource_management_private_link":                "Microsoft.Authorization",
+	"azurerm_resource_policy":                                 "Microsoft.Authorization",
+	"azurerm_resource_policy_remediation":                     "Microsoft.PolicyInsights",
+	"azurerm_resources":                                       "Microsoft.Resources",
+	"azurerm_restore_point_collection":                        "Microsoft.Compute",
+	"azurerm_role":                                            "Microsoft.Authorization",
+	"azurerm_route":                                           "Microsoft.Network",
+	"azurerm_search":                                          "Microsoft.Search",
+	"azurerm_security_center":                                 "Microsoft.Security",
+	"azurerm_sentinel":                                        "Microsoft.SecurityInsights",
+	"azurerm_service_fabric":                                  "Microsoft.ServiceFabric",
+	"azurerm_service_plan":                                    "Microsoft.Web",
+	"azurerm_servicebus":                                      "Microsoft.ServiceBus",
+	"azurerm_shared_image":                                    "Microsoft.Compute",
+	"azurerm_signalr":                                         "Microsoft.SignalRService",
+	"azurerm_site_recovery":                                   "Microsoft.RecoveryServices",
+	"azurerm_snapshot":                                        "Microsoft.Compute",
+	"azurerm_source_control_token":                            "Microsoft.Web",
+	"azurerm_spatial_anchors":                                 "Microsoft.MixedReality",
+	"azurerm_spring_cloud":                                    "Microsoft.AppPlatform",
+	"azurerm_spring_cloud_connection":                         "Microsoft.ServiceLinker",
+	"azurerm_ssh_public_key":                                  "Microsoft.Compute",
+	"azurerm_stack_hci":                                       "Microsoft.AzureStackHCI",
+	"azurerm_static_site":                                     "Microsoft.Web",
+	"azurerm_static_web_app":                                  "Microsoft.Web",
+	"azurerm_storage":                                         "Microsoft.Storage",
+	"azurerm_storage_mover":                                   "Microsoft.StorageMover",
+	"azurerm_storage_sync":                                    "Microsoft.StorageSync",
+	"azurerm_stream_analytics":                                "Microsoft.StreamAnalytics",
+	"azurerm_subnet":                                          "Microsoft.Network",
+	"azurerm_subscription":                                    "Microsoft.Subscription",
+	"azurerm_subscription_cost_management":                    "Microsoft.CostManagement",
+	"azurerm_subscription_policy":                             "Microsoft.Authorization",
+	"azurerm_subscription_policy_remediation":                 "Microsoft.PolicyInsights",
+	"azurerm_subscription_template_deployment":                "Microsoft.Resources",
+	"azurerm_synapse":                                         "Microsoft.Synapse",
+	"azurerm_system_center_virtual_machine_manager":           "Microsoft.ScVmm",
+	"azurerm_template_spec_version":                           "Microsoft.Resources",
+	"azurerm_tenant_template_deployment":                      "Microsoft.Resources",
+	"azurerm_traffic_manager":                                 "Microsoft.Network",
+	"azurerm_user_assigned_identity":                          "Microsoft.ManagedIdentity",
+	"azurerm_virtual_desktop":                                 "Microsoft.DesktopVirtualization",
+	"azurerm_virtual_hub":                                     "Microsoft.Network",
+	"azurerm_virtual_machine":                                 "Microsoft.Compute",
+	"azurerm_virtual_machine_automanage_configuration_assignment": "Microsoft.AutoManage",
+	"azurerm_virtual_machine_packet_capture":                      "Microsoft.Network",
+	"azurerm_virtual_machine_scale_set_packet_capture":            "Microsoft.Network",
+	"azurerm_virtual_network":                                     "Microsoft.Network",
+	"azurerm_virtual_wan":                                         "Microsoft.Network",
+	"azurerm_vmware":                                              "Microsoft.AVS",
+	"azurerm_voice_services":                                      "Microsoft.VoiceServices",
+	"azurerm_vpn":                                                 "Microsoft.Network",
+	"azurerm_web_app":                                             "Microsoft.Web",
+	"azurerm_web_application_firewall_policy":                     "Microsoft.Network",
+	"azurerm_web_pubsub":                                          "Microsoft.SignalRService",
+	"azurerm_windows_function_app":                                "Microsoft.Web",
+	"azurerm_windows_virtual_machine":                             "Microsoft.Compute",
+	"azurerm_windows_web_app":                                     "Microsoft.Web",
+	"azurerm_workloads":                                           "Microsoft.Workloads",
+}
+
+// ForResourceType returns the Resource Provider which is required to use the specified Terraform Resource
+// (or Data Source) Type, if this is known.
+func ForResourceType(resourceType string) (string, bool) {
+	longestPrefix := ""
+	for prefix := range resourceProvidersForResourceTypes {
+		if resourceType != prefix && !strings.HasPrefix(resourceType, prefix+"_") {
+			continue
+		}
+		if len(prefix) > len(longestPrefix) {
+			longestPrefix = prefix
+		}
+	}
+
+	if longestPrefix == "" {
+		return "", false
+	}
+
+	return resourceProvidersForResourceTypes[longestPrefix], true
+}
@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"testing"
+)
+
+func TestForResourceType(t *testing.T) {
+	testCases := []struct {
+		resourceType     string
+		resourceProvider string
+		known            bool
+	}{
+		{
+			resourceType:     "azurerm_storage_account",
+			resourceProvider: "Microsoft.Storage",
+			known:            true,
+		},
+		{
+			// the longest matching prefix should be used
+			resourceType:     "azurerm_storage_mover_project",
+			resourceProvider: "Microsoft.StorageMover",
+			known:            true,
+		},
+		{
+			resourceType:     "azurerm_network_function_collector",
+			resourceProvider: "Microsoft.NetworkFunction",
+			known:            true,
+		},
+		{
+			resourceType:     "azurerm_monitor_action_group",
+			resourceProvider: "microsoft.insights",
+			known:            true,
+		},
+		{
+			// prefixes only match on a word boundary
+			resourceType: "azurerm_storagefoo",
+			known:        false,
+		},
+		{
+			resourceType: "azurerm_client_config",
+			known:        false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Logf("[DEBUG] Testing %q", testCase.resourceType)
+
+		resourceProvider, known := ForResourceType(testCase.resourceType)
+		if known != testCase.known {
+			t.Fatalf("expected known to be %t but got %t", testCase.known, known)
+		}
+		if resourceProvider != testCase.resourceProvider {
+			t.Fatalf("expected %q but got %q", testCase.resourceProvider, resourceProvider)
+		}
+	}
+}
+
+func TestUnregisteredForResourceType(t *testing.T) {
+	registeredResourceProviders = map[string]struct{}{
+		"Microsoft.Storage": {},
+	}
+	unregisteredResourceProviders = map[string]struct{}{
+		"Microsoft.StorageMover": {},
+		"microsoft.insights":     {},
+	}
+	defer func() {
+		registeredResourceProviders = nil
+		unregisteredResourceProviders = nil
+	}()
+
+	if _, unregistered := UnregisteredForResourceType("azurerm_storage_account"); unregistered {
+		t.Fatalf("expected `azurerm_storage_account` to be registered")
+	}
+	if resourceProvider, unregistered := UnregisteredForResourceType("azurerm_storage_mover"); !unregistered || resourceProvider != "Microsoft.StorageMover" {
+		t.Fatalf("expected `azurerm_storage_mover` to require the unregistered Resource Provider `Microsoft.StorageMover` but got %q", resourceProvider)
+	}
+	if _, unregistered := UnregisteredForResourceType("azurerm_monitor_action_group"); !unregistered {
+		t.Fatalf("expected `azurerm_monitor_action_group` to require an unregistered Resource Provider")
+	}
+	// Resource Providers which aren't in the cache are unknown, so shouldn't be surfaced
+	if _, unregistered := UnregisteredForResourceType("azurerm_kubernetes_cluster"); unregistered {
+		t.Fatalf("expected `azurerm_kubernetes_cluster` not to be surfaced as unregistered")
+	}
+
+	markAsRegistered("microsoft.insights")
+	if _, unregistered := UnregisteredForResourceType("azurerm_monitor_action_group"); unregistered {
+		t.Fatalf("expected `azurerm_monitor_action_group` to be registered once the Resource Provider is registered")
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceproviders
+
+import (
+	"fmt"
+)
+
+// UnregisteredForResourceType returns the Resource Provider required by the specified Terraform Resource (or Data
+// Source) Type when this is known to be unregistered in the Subscription.
+func UnregisteredForResourceType(resourceType string) (string, bool) {
+	resourceProvider, ok := ForResourceType(resourceType)
+	if !ok {
+		return "", false
+	}
+
+	registered, known := IsRegistered(resourceProvider)
+	if !known || registered {
+		return "", false
+	}
+
+	return resourceProvider, true
+}
+
+// RegistrationWarning returns the summary and detail of the warning for a Terraform Resource (or Data Source)
+// Type which requires a Resource Provider that isn't registered
+func RegistrationWarning(resourceType string, resourceProvider string) (string, string) {
+	summary := fmt.Sprintf("The Resource Provider %q required by `%s` isn't registered", resourceProvider, resourceType)
+	detail := fmt.Sprintf(`The Resource Provider %[1]q, which is required by %[2]q, isn't registered in the Subscription.
+
+Provisioning this resource is likely to fail with a "MissingSubscriptionRegistration" error. To resolve this,
+either register the Resource Provider out-of-band (for example using "az provider register --namespace %[1]s"),
+use the "azurerm_resource_provider_registration" resource, or include it in the "resource_providers_to_register"
+property in the Provider block.`, resourceProvider, resourceType)
+
+	return summary, detail
+}
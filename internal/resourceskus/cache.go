@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+)
+
+// cachedSkus and cachedUsages are keyed by the Subscription ID and Location, and only contain the
+// entries which have been requested - since these are only retrieved when they're needed during a plan
+var cachedSkus = make(map[string]*cacheEntry[[]skus.ResourceSku])
+var cachedUsages = make(map[string]*cacheEntry[[]Usage])
+
+// cacheLock guards the maps above, but isn't held whilst the values are retrieved from the API - instead
+// concurrent requests for the same entry wait for the request which is retrieving it
+var cacheLock = &sync.Mutex{}
+
+// cacheEntry also caches any error from retrieving the value, to avoid calling the API for each
+// resource in the Location when it's unavailable (e.g. due to missing permissions) - unless the
+// context was cancelled, in which case it's retried next time
+type cacheEntry[T any] struct {
+	// done is closed once the value has been retrieved
+	done chan struct{}
+
+	value T
+	err   error
+
+	// cancelled specifies that the context was cancelled whilst retrieving the value, in which case the entry
+	// has been removed from the cache
+	cancelled bool
+}
+
+func cacheKey(subscriptionId commonids.SubscriptionId, locationName string) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(subscriptionId.SubscriptionId), location.Normalize(locationName))
+}
+
+// CacheSkus attempts to retrieve the Compute Resource SKUs available within the specified Location
+// from the Resource Manager API and caches them, for use in enhanced validation
+func CacheSkus(ctx context.Context, client *skus.SkusClient, subscriptionId commonids.SubscriptionId, locationName string) ([]skus.ResourceSku, error) {
+	return cached(ctx, &cachedSkus, cacheKey(subscriptionId, locationName), func() ([]skus.ResourceSku, error) {
+		options := skus.ResourceSkusListOperationOptions{
+			Filter:                   pointer.To(fmt.Sprintf("location eq '%s'", location.Normalize(locationName))),
+			IncludeExtendedLocations: pointer.To("false"),
+		}
+		resp, err := client.ResourceSkusListComplete(ctx, subscriptionId, options)
+		if err != nil {
+			return nil, fmt.Errorf("listing Resource SKUs for %s in %q: %+v", subscriptionId, locationName, err)
+		}
+		return resp.Items, nil
+	})
+}
+
+// CacheUsages attempts to retrieve the Compute Usages (and the associated Quota Limits) within the specified
+// Location from the Resource Manager API and caches them, for use in enhanced validation
+func CacheUsages(ctx context.Context, client *UsagesClient, subscriptionId commonids.SubscriptionId, locationName string) ([]Usage, error) {
+	return cached(ctx, &cachedUsages, cacheKey(subscriptionId, locationName), func() ([]Usage, error) {
+		usages, err := client.List(ctx, subscriptionId, locationName)
+		if err != nil {
+			return nil, fmt.Errorf("listing Compute Usages for %s in %q: %+v", subscriptionId, locationName, err)
+		}
+		return pointer.From(usages), nil
+	})
+}
+
+// cached returns the value for the key from the cache, calling `retrieve` to populate it when it's not cached - and
+// waiting for it to be populated when another request is already retrieving it
+func cached[T any](ctx context.Context, cache *map[string]*cacheEntry[T], key string, retrieve func() (T, error)) (T, error) {
+	for {
+		cacheLock.Lock()
+		entry, ok := (*cache)[key]
+		if !ok {
+			entry = &cacheEntry[T]{
+				done: make(chan struct{}),
+			}
+			(*cache)[key] = entry
+		}
+		cacheLock.Unlock()
+
+		if !ok {
+			entry.value, entry.err = retrieve()
+			if ctx.Err() != nil {
+				entry.cancelled = true
+				cacheLock.Lock()
+				if (*cache)[key] == entry {
+					delete(*cache, key)
+				}
+				cacheLock.Unlock()
+			}
+			close(entry.done)
+			return entry.value, entry.err
+		}
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			var empty T
+			return empty, ctx.Err()
+		}
+
+		// the request which was retrieving the value was cancelled, so retry using this context
+		if entry.cancelled {
+			continue
+		}
+		return entry.value, entry.err
+	}
+}
+
+func ClearCache() {
+	cacheLock.Lock()
+	cachedSkus = make(map[string]*cacheEntry[[]skus.ResourceSku])
+	cachedUsages = make(map[string]*cacheEntry[[]Usage])
+	cacheLock.Unlock()
+}
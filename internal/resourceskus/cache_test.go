@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCachedRetrievesOnce(t *testing.T) {
+	cache := make(map[string]*cacheEntry[[]string])
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	retrieve := func() ([]string, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return []string{"Standard_D2s_v3"}, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([][]string, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			v, err := cached(context.Background(), &cache, "example", retrieve)
+			if err != nil {
+				t.Errorf("expected no error but got %+v", err)
+			}
+			results[i] = v
+		}(i)
+	}
+
+	// the lock isn't held whilst the value is retrieved, so other entries can be retrieved in the meantime
+	<-started
+	if v, err := cached(context.Background(), &cache, "other", func() ([]string, error) { return []string{"Premium_LRS"}, nil }); err != nil || len(v) != 1 {
+		t.Fatalf("expected the other entry to be retrieved but got %+v / %+v", v, err)
+	}
+
+	close(release)
+	wg.Wait()
+
+	if calls != 1 {
+		t.Fatalf("expected the value to be retrieved once but got %d", calls)
+	}
+	for i, v := range results {
+		if len(v) != 1 || v[0] != "Standard_D2s_v3" {
+			t.Fatalf("expected result %d to be the cached value but got %+v", i, v)
+		}
+	}
+}
+
+func TestCachedErrors(t *testing.T) {
+	cache := make(map[string]*cacheEntry[[]string])
+
+	calls := 0
+	retrieve := func() ([]string, error) {
+		calls++
+		return nil, fmt.Errorf("forbidden")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := cached(context.Background(), &cache, "example", retrieve); err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the error to be cached but the value was retrieved %d times", calls)
+	}
+}
+
+func TestCachedCancelled(t *testing.T) {
+	cache := make(map[string]*cacheEntry[[]string])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := cached(ctx, &cache, "example", func() ([]string, error) { return nil, ctx.Err() }); err == nil {
+		t.Fatalf("expected an error but didn't get one")
+	}
+
+	// the value is retrieved again, since the context was cancelled
+	v, err := cached(context.Background(), &cache, "example", func() ([]string, error) { return []string{"Standard_D2s_v3"}, nil })
+	if err != nil || len(v) != 1 {
+		t.Fatalf("expected the value to be retrieved again but got %+v / %+v", v, err)
+	}
+}
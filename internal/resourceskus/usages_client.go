@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+// NOTE: the Compute Usage API isn't available in the vendored version of the SDK, so this is a minimal
+// client for the List operation - which can be replaced once it's available.
+
+const usagesApiVersion = "2021-07-01"
+
+type UsagesClient struct {
+	Client *resourcemanager.Client
+}
+
+func NewUsagesClientWithBaseURI(sdkApi environments.Api) (*UsagesClient, error) {
+	client, err := resourcemanager.NewResourceManagerClient(sdkApi, "usages", usagesApiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("instantiating UsagesClient: %+v", err)
+	}
+
+	return &UsagesClient{
+		Client: client,
+	}, nil
+}
+
+type Usage struct {
+	CurrentValue int64     `json:"currentValue"`
+	Limit        int64     `json:"limit"`
+	Name         UsageName `json:"name"`
+	Unit         string    `json:"unit"`
+}
+
+type UsageName struct {
+	LocalizedValue *string `json:"localizedValue,omitempty"`
+	Value          *string `json:"value,omitempty"`
+}
+
+type usagesListPager struct {
+	NextLink *odata.Link `json:"nextLink"`
+}
+
+func (p *usagesListPager) NextPageLink() *odata.Link {
+	defer func() {
+		p.NextLink = nil
+	}()
+
+	return p.NextLink
+}
+
+// List returns the Compute Usages (and the associated Limits) for the Subscription within the specified Location
+func (c UsagesClient) List(ctx context.Context, id commonids.SubscriptionId, locationName string) (*[]Usage, error) {
+	opts := client.RequestOptions{
+		ContentType: "application/json; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod: http.MethodGet,
+		Pager:      &usagesListPager{},
+		Path:       fmt.Sprintf("%s/providers/Microsoft.Compute/locations/%s/usages", id.ID(), location.Normalize(locationName)),
+	}
+
+	req, err := c.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+
+	resp, err := req.ExecutePaged(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %+v", err)
+	}
+
+	var values struct {
+		Values *[]Usage `json:"value"`
+	}
+	if err := resp.Unmarshal(&values); err != nil {
+		return nil, fmt.Errorf("unmarshaling response: %+v", err)
+	}
+
+	return values.Values, nil
+}
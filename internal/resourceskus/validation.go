@@ -0,0 +1,294 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+const (
+	ResourceTypeDisks           = "disks"
+	ResourceTypeVirtualMachines = "virtualMachines"
+)
+
+var resourceTypeDescriptions = map[string]string{
+	ResourceTypeDisks:           "Managed Disk SKU",
+	ResourceTypeVirtualMachines: "Virtual Machine size",
+}
+
+// totalRegionalVCPUsUsage is the name of the Usage for the Total Regional vCPUs, which applies in addition to
+// the quota for each family of Virtual Machine sizes
+const totalRegionalVCPUsUsage = "cores"
+
+type Clients struct {
+	SkusClient   *skus.SkusClient
+	UsagesClient *UsagesClient
+}
+
+type Request struct {
+	// ResourceType is the type of Resource SKU, either ResourceTypeDisks or ResourceTypeVirtualMachines
+	ResourceType string
+
+	// Name is the name of the SKU, for example `Standard_D2s_v3` or `Premium_LRS`
+	Name string
+
+	Location string
+
+	// Zones are the Availability Zones which the SKU is being provisioned into, if any
+	Zones []string
+
+	// AdditionalInstances is the number of additional instances of a Virtual Machine size being provisioned,
+	// which is used to check the regional vCPU quota. The quota isn't checked when this is zero.
+	//
+	// NOTE: the quota is checked for each resource individually against the current usage - so the vCPUs
+	// required by other resources being created/scaled out in the same plan aren't taken into account
+	AdditionalInstances int64
+
+	// PreviousName is the name of the Virtual Machine size being replaced when resizing existing instances, in which
+	// case the quota is checked for the difference in vCPUs between the sizes rather than the vCPUs of the new size
+	PreviousName string
+}
+
+// Validate checks that the Resource SKU in the Request is available to this Subscription in the specified
+// Location (and Availability Zones) - and that there's sufficient regional vCPU quota for any additional
+// instances of a Virtual Machine size.
+//
+// NOTE: this is best-effort - when Enhanced Validation for SKUs is disabled, or the Resource SKUs/Usages
+// can't be retrieved (for example due to permissions) a warning is logged and the validation is skipped.
+func Validate(ctx context.Context, clients Clients, subscriptionId commonids.SubscriptionId, request Request) error {
+	if !features.EnhancedValidationSkusEnabled() || clients.SkusClient == nil {
+		return nil
+	}
+	if request.Name == "" || request.Location == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	available, err := CacheSkus(ctx, clients.SkusClient, subscriptionId, request.Location)
+	if err != nil {
+		log.Printf("[WARN] unable to validate the %s %q: %+v", resourceTypeDescriptions[request.ResourceType], request.Name, err)
+		return nil
+	}
+
+	sku, err := validateSku(available, request)
+	if err != nil {
+		return err
+	}
+
+	if sku == nil || request.AdditionalInstances <= 0 || request.ResourceType != ResourceTypeVirtualMachines || clients.UsagesClient == nil {
+		return nil
+	}
+
+	var previous *skus.ResourceSku
+	if request.PreviousName != "" {
+		if previous = findSku(available, request.ResourceType, request.PreviousName); previous == nil {
+			log.Printf("[WARN] unable to validate the vCPU quota for the %s %q since the previous size %q wasn't found", resourceTypeDescriptions[request.ResourceType], request.Name, request.PreviousName)
+			return nil
+		}
+	}
+
+	usages, err := CacheUsages(ctx, clients.UsagesClient, subscriptionId, request.Location)
+	if err != nil {
+		log.Printf("[WARN] unable to validate the vCPU quota for the %s %q: %+v", resourceTypeDescriptions[request.ResourceType], request.Name, err)
+		return nil
+	}
+
+	return validateQuota(*sku, previous, usages, request)
+}
+
+// findSku returns the first Resource SKU of the Resource Type with the specified name, or nil if it's not found
+func findSku(available []skus.ResourceSku, resourceType string, name string) *skus.ResourceSku {
+	for _, sku := range available {
+		if strings.EqualFold(pointer.From(sku.ResourceType), resourceType) && strings.EqualFold(pointer.From(sku.Name), name) {
+			return pointer.To(sku)
+		}
+	}
+	return nil
+}
+
+// validateSku returns the matching Resource SKU when it's available in the Location and Zones for the Request
+func validateSku(available []skus.ResourceSku, request Request) (*skus.ResourceSku, error) {
+	// the API returning no SKUs at all means that we can't say anything useful
+	if len(available) == 0 {
+		return nil, nil
+	}
+
+	description := resourceTypeDescriptions[request.ResourceType]
+	locationName := location.Normalize(request.Location)
+
+	var restricted *skus.ResourceSku
+	var restriction *skus.ResourceSkuRestrictions
+	for _, sku := range available {
+		if !strings.EqualFold(pointer.From(sku.ResourceType), request.ResourceType) || !strings.EqualFold(pointer.From(sku.Name), request.Name) {
+			continue
+		}
+
+		// Managed Disks return a SKU per size (e.g. P10) which share a name - any of these being unrestricted is sufficient
+		if r := locationRestriction(sku, locationName); r != nil {
+			if restricted == nil {
+				restricted = pointer.To(sku)
+				restriction = r
+			}
+			continue
+		}
+
+		if err := validateZones(sku, request, description, locationName); err != nil {
+			return nil, err
+		}
+
+		return pointer.To(sku), nil
+	}
+
+	if restricted != nil {
+		return nil, fmt.Errorf("the %s %q isn't available to this Subscription in the location %q (reason: %s)", description, request.Name, locationName, pointer.From(restriction.ReasonCode))
+	}
+
+	return nil, fmt.Errorf("the %s %q isn't available in the location %q", description, request.Name, locationName)
+}
+
+func locationRestriction(sku skus.ResourceSku, locationName string) *skus.ResourceSkuRestrictions {
+	for _, restriction := range pointer.From(sku.Restrictions) {
+		if pointer.From(restriction.Type) != skus.ResourceSkuRestrictionsTypeLocation {
+			continue
+		}
+
+		locations := pointer.From(restriction.Values)
+		if restriction.RestrictionInfo != nil && restriction.RestrictionInfo.Locations != nil {
+			locations = *restriction.RestrictionInfo.Locations
+		}
+		for _, v := range locations {
+			if location.Normalize(v) == locationName {
+				return pointer.To(restriction)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateZones(sku skus.ResourceSku, request Request, description string, locationName string) error {
+	if len(request.Zones) == 0 {
+		return nil
+	}
+
+	supported := make(map[string]struct{})
+	for _, info := range pointer.From(sku.LocationInfo) {
+		if location.Normalize(pointer.From(info.Location)) != locationName {
+			continue
+		}
+		for _, zone := range pointer.From(info.Zones) {
+			supported[zone] = struct{}{}
+		}
+	}
+
+	for _, restriction := range pointer.From(sku.Restrictions) {
+		if pointer.From(restriction.Type) != skus.ResourceSkuRestrictionsTypeZone || restriction.RestrictionInfo == nil {
+			continue
+		}
+		for _, zone := range pointer.From(restriction.RestrictionInfo.Zones) {
+			delete(supported, zone)
+		}
+	}
+
+	if len(supported) == 0 {
+		return fmt.Errorf("the %s %q doesn't support Availability Zones in the location %q", description, request.Name, locationName)
+	}
+
+	unavailable := make([]string, 0)
+	for _, zone := range request.Zones {
+		if _, ok := supported[zone]; !ok {
+			unavailable = append(unavailable, zone)
+		}
+	}
+	if len(unavailable) > 0 {
+		zones := make([]string, 0)
+		for zone := range supported {
+			zones = append(zones, zone)
+		}
+		sort.Strings(zones)
+
+		return fmt.Errorf("the %s %q isn't available in the Availability Zone(s) %s in the location %q - the available zones are %s", description, request.Name, strings.Join(unavailable, ", "), locationName, strings.Join(zones, ", "))
+	}
+
+	return nil
+}
+
+// validateQuota checks that the regional vCPU quota (both for the family of the Virtual Machine size and the Total
+// Regional vCPUs) has enough capacity remaining for the additional instances in the Request, ignoring any other
+// Requests in the same plan. When instances are being resized from the previous size only the additional vCPUs are
+// required, which for the quota of the family is only when both sizes are within the same family.
+func validateQuota(sku skus.ResourceSku, previous *skus.ResourceSku, usages []Usage, request Request) error {
+	vCPUs := skuVCPUs(sku)
+	if vCPUs == 0 {
+		return nil
+	}
+	previousVCPUs := int64(0)
+	if previous != nil {
+		if previousVCPUs = skuVCPUs(*previous); previousVCPUs == 0 {
+			return nil
+		}
+	}
+
+	for _, name := range []string{pointer.From(sku.Family), totalRegionalVCPUsUsage} {
+		if name == "" {
+			continue
+		}
+
+		required := vCPUs * request.AdditionalInstances
+		if previous != nil && (name == totalRegionalVCPUsUsage || strings.EqualFold(pointer.From(previous.Family), name)) {
+			required -= previousVCPUs * request.AdditionalInstances
+		}
+		if required <= 0 {
+			continue
+		}
+
+		for _, usage := range usages {
+			if !strings.EqualFold(pointer.From(usage.Name.Value), name) {
+				continue
+			}
+
+			remaining := usage.Limit - usage.CurrentValue
+			if required > remaining {
+				quotaName := pointer.From(usage.Name.LocalizedValue)
+				if quotaName == "" {
+					quotaName = name
+				}
+				if previous != nil {
+					return fmt.Errorf("resizing %d instance(s) from the %s %q to %q requires an additional %d vCPUs but only %d of the %d vCPUs for the quota %q are available in the location %q - a quota increase can be requested through the Azure Portal", request.AdditionalInstances, resourceTypeDescriptions[request.ResourceType], request.PreviousName, request.Name, required, remaining, usage.Limit, quotaName, location.Normalize(request.Location))
+				}
+				return fmt.Errorf("%d instance(s) of the %s %q require %d vCPUs but only %d of the %d vCPUs for the quota %q are available in the location %q - a quota increase can be requested through the Azure Portal", request.AdditionalInstances, resourceTypeDescriptions[request.ResourceType], request.Name, required, remaining, usage.Limit, quotaName, location.Normalize(request.Location))
+			}
+		}
+	}
+
+	return nil
+}
+
+// skuVCPUs returns the number of vCPUs of the Virtual Machine size, or zero when this isn't known
+func skuVCPUs(sku skus.ResourceSku) int64 {
+	for _, capability := range pointer.From(sku.Capabilities) {
+		if strings.EqualFold(pointer.From(capability.Name), "vCPUs") {
+			v, err := strconv.ParseInt(pointer.From(capability.Value), 10, 64)
+			if err != nil {
+				return 0
+			}
+			return v
+		}
+	}
+	return 0
+}
@@ -0,0 +1,337 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourceskus
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2021-07-01/skus"
+)
+
+func testResourceSkus() []skus.ResourceSku {
+	return []skus.ResourceSku{
+		{
+			Name:         pointer.To("Standard_D2s_v3"),
+			ResourceType: pointer.To(ResourceTypeVirtualMachines),
+			Family:       pointer.To("standardDSv3Family"),
+			Capabilities: &[]skus.ResourceSkuCapabilities{
+				{
+					Name:  pointer.To("vCPUs"),
+					Value: pointer.To("2"),
+				},
+			},
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("westeurope"),
+					Zones:    pointer.To(zones.Schema{"1", "2", "3"}),
+				},
+			},
+			Restrictions: &[]skus.ResourceSkuRestrictions{
+				{
+					Type:       pointer.To(skus.ResourceSkuRestrictionsTypeZone),
+					ReasonCode: pointer.To(skus.ResourceSkuRestrictionsReasonCodeNotAvailableForSubscription),
+					RestrictionInfo: &skus.ResourceSkuRestrictionInfo{
+						Locations: pointer.To([]string{"westeurope"}),
+						Zones:     pointer.To(zones.Schema{"3"}),
+					},
+				},
+			},
+		},
+		{
+			Name:         pointer.To("Standard_M416ms_v2"),
+			ResourceType: pointer.To(ResourceTypeVirtualMachines),
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("westeurope"),
+				},
+			},
+			Restrictions: &[]skus.ResourceSkuRestrictions{
+				{
+					Type:       pointer.To(skus.ResourceSkuRestrictionsTypeLocation),
+					ReasonCode: pointer.To(skus.ResourceSkuRestrictionsReasonCodeNotAvailableForSubscription),
+					Values:     pointer.To([]string{"westeurope"}),
+					RestrictionInfo: &skus.ResourceSkuRestrictionInfo{
+						Locations: pointer.To([]string{"westeurope"}),
+					},
+				},
+			},
+		},
+		{
+			Name:         pointer.To("Premium_LRS"),
+			ResourceType: pointer.To(ResourceTypeDisks),
+			Size:         pointer.To("P1"),
+			LocationInfo: &[]skus.ResourceSkuLocationInfo{
+				{
+					Location: pointer.To("westeurope"),
+					Zones:    pointer.To(zones.Schema{"1", "2", "3"}),
+				},
+			},
+		},
+	}
+}
+
+func TestValidateSku(t *testing.T) {
+	testData := []struct {
+		Name        string
+		Request     Request
+		ExpectError bool
+	}{
+		{
+			Name: "available",
+			Request: Request{
+				ResourceType: ResourceTypeVirtualMachines,
+				Name:         "Standard_D2s_v3",
+				Location:     "West Europe",
+			},
+		},
+		{
+			Name: "name is case insensitive",
+			Request: Request{
+				ResourceType: ResourceTypeVirtualMachines,
+				Name:         "standard_d2s_v3",
+				Location:     "westeurope",
+			},
+		},
+		{
+			Name: "not available in the location",
+			Request: Request{
+				ResourceType: ResourceTypeVirtualMachines,
+				Name:         "Standard_A0",
+				Location:     "westeurope",
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "restricted for the subscription",
+			Request: Request{
+				ResourceType: ResourceTypeVirtualMachines,
+				Name:         "Standard_M416ms_v2",
+				Location:     "westeurope",
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "available zone",
+			Request: Request{
+				ResourceType: ResourceTypeVirtualMachines,
+				Name:         "Standard_D2s_v3",
+				Location:     "westeurope",
+				Zones:        []string{"1", "2"},
+			},
+		},
+		{
+			Name: "restricted zone",
+			Request: Request{
+				ResourceType: ResourceTypeVirtualMachines,
+				Name:         "Standard_D2s_v3",
+				Location:     "westeurope",
+				Zones:        []string{"3"},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "zones unsupported",
+			Request: Request{
+				ResourceType: ResourceTypeVirtualMachines,
+				Name:         "Standard_M416ms_v2",
+				Location:     "westeurope",
+				Zones:        []string{"1"},
+			},
+			ExpectError: true,
+		},
+		{
+			Name: "disk sku",
+			Request: Request{
+				ResourceType: ResourceTypeDisks,
+				Name:         "Premium_LRS",
+				Location:     "westeurope",
+				Zones:        []string{"1"},
+			},
+		},
+		{
+			Name: "disk sku with a virtual machine resource type",
+			Request: Request{
+				ResourceType: ResourceTypeVirtualMachines,
+				Name:         "Premium_LRS",
+				Location:     "westeurope",
+			},
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		sku, err := validateSku(testResourceSkus(), v.Request)
+		if v.ExpectError {
+			if err == nil {
+				t.Fatalf("expected an error but didn't get one")
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+		if sku == nil {
+			t.Fatalf("expected the matching sku to be returned")
+		}
+	}
+
+	// no SKUs being returned means there's nothing to validate against
+	if _, err := validateSku(nil, Request{ResourceType: ResourceTypeVirtualMachines, Name: "Standard_A0", Location: "westeurope"}); err != nil {
+		t.Fatalf("expected no error when no skus are available but got %+v", err)
+	}
+}
+
+func TestValidateQuota(t *testing.T) {
+	usages := []Usage{
+		{
+			CurrentValue: 90,
+			Limit:        100,
+			Name: UsageName{
+				LocalizedValue: pointer.To("Total Regional vCPUs"),
+				Value:          pointer.To("cores"),
+			},
+		},
+		{
+			CurrentValue: 4,
+			Limit:        10,
+			Name: UsageName{
+				LocalizedValue: pointer.To("Standard DSv3 Family vCPUs"),
+				Value:          pointer.To("standardDSv3Family"),
+			},
+		},
+	}
+
+	testData := []struct {
+		Name        string
+		Instances   int64
+		ExpectError bool
+	}{
+		{
+			Name:      "within quota",
+			Instances: 3,
+		},
+		{
+			Name:        "exceeds the family quota",
+			Instances:   4,
+			ExpectError: true,
+		},
+	}
+
+	sku := testResourceSkus()[0]
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateQuota(sku, nil, usages, Request{
+			ResourceType:        ResourceTypeVirtualMachines,
+			Name:                "Standard_D2s_v3",
+			Location:            "westeurope",
+			AdditionalInstances: v.Instances,
+		})
+		if v.ExpectError != (err != nil) {
+			t.Fatalf("expected an error to be %t but got %+v", v.ExpectError, err)
+		}
+	}
+
+	// the total regional vCPUs also apply
+	usages[1].Limit = 1000
+	err := validateQuota(sku, nil, usages, Request{
+		ResourceType:        ResourceTypeVirtualMachines,
+		Name:                "Standard_D2s_v3",
+		Location:            "westeurope",
+		AdditionalInstances: 6,
+	})
+	if err == nil {
+		t.Fatalf("expected an error when exceeding the total regional vCPUs")
+	}
+}
+
+func TestValidateQuotaResize(t *testing.T) {
+	usages := []Usage{
+		{
+			CurrentValue: 90,
+			Limit:        100,
+			Name: UsageName{
+				LocalizedValue: pointer.To("Total Regional vCPUs"),
+				Value:          pointer.To("cores"),
+			},
+		},
+		{
+			CurrentValue: 4,
+			Limit:        10,
+			Name: UsageName{
+				LocalizedValue: pointer.To("Standard DSv3 Family vCPUs"),
+				Value:          pointer.To("standardDSv3Family"),
+			},
+		},
+	}
+	size := func(name, family, vCPUs string) skus.ResourceSku {
+		return skus.ResourceSku{
+			Name:         pointer.To(name),
+			ResourceType: pointer.To(ResourceTypeVirtualMachines),
+			Family:       pointer.To(family),
+			Capabilities: &[]skus.ResourceSkuCapabilities{
+				{
+					Name:  pointer.To("vCPUs"),
+					Value: pointer.To(vCPUs),
+				},
+			},
+		}
+	}
+
+	testData := []struct {
+		Name        string
+		Previous    skus.ResourceSku
+		New         skus.ResourceSku
+		ExpectError bool
+	}{
+		{
+			Name:     "within the family quota",
+			Previous: size("Standard_D2s_v3", "standardDSv3Family", "2"),
+			New:      size("Standard_D8s_v3", "standardDSv3Family", "8"),
+		},
+		{
+			Name:        "exceeds the family quota",
+			Previous:    size("Standard_D2s_v3", "standardDSv3Family", "2"),
+			New:         size("Standard_D16s_v3", "standardDSv3Family", "16"),
+			ExpectError: true,
+		},
+		{
+			Name:     "to a smaller size",
+			Previous: size("Standard_D64s_v3", "standardDSv3Family", "64"),
+			New:      size("Standard_D32s_v3", "standardDSv3Family", "32"),
+		},
+		{
+			// the vCPUs of the previous size are in another family, so all of the vCPUs of the new size are required
+			Name:        "exceeds the family quota from another family",
+			Previous:    size("Standard_E8s_v3", "standardESv3Family", "8"),
+			New:         size("Standard_D8s_v3", "standardDSv3Family", "8"),
+			ExpectError: true,
+		},
+		{
+			Name:        "exceeds the total regional vCPUs",
+			Previous:    size("Standard_E4s_v3", "standardESv3Family", "4"),
+			New:         size("Standard_D16s_v3", "standardDDSv4Family", "16"),
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateQuota(v.New, pointer.To(v.Previous), usages, Request{
+			ResourceType:        ResourceTypeVirtualMachines,
+			Name:                pointer.From(v.New.Name),
+			Location:            "westeurope",
+			AdditionalInstances: 1,
+			PreviousName:        pointer.From(v.Previous.Name),
+		})
+		if v.ExpectError != (err != nil) {
+			t.Fatalf("expected an error to be %t but got %+v", v.ExpectError, err)
+		}
+	}
+}
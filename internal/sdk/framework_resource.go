@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+// TypedServiceRegistrationWithFrameworkResources is a superset of TypedServiceRegistration allowing
+// a Service to register Resources and Data Sources which are implemented natively using the
+// Terraform Plugin Framework, rather than on top of the Plugin SDK.
+//
+// NOTE: this is intentionally an optional interface since the majority of Services only contain
+// Resources built on the Plugin SDK
+type TypedServiceRegistrationWithFrameworkResources interface {
+	TypedServiceRegistration
+
+	// FrameworkDataSources returns a list of Plugin Framework Data Sources supported by this Service
+	FrameworkDataSources() []func() datasource.DataSource
+
+	// FrameworkResources returns a list of Plugin Framework Resources supported by this Service
+	FrameworkResources() []func() resource.Resource
+}
+
+// FrameworkMetadata contains the Client and Provider configuration shared with the Plugin SDK
+// Resources, and is populated when the Plugin Framework configures the Resource or Data Source
+type FrameworkMetadata struct {
+	// Client is a reference to the Azure Providers Client - providing a typed reference to this object
+	Client *clients.Client
+
+	// SubscriptionId is the Subscription ID which the Provider is configured to use
+	SubscriptionId string
+
+	// Features are the Features configured in the `features` block of the Provider
+	Features features.UserFeatures
+}
+
+// Defaults populates the FrameworkMetadata from the ProviderData - which is the Meta of the Plugin SDK
+// Provider, since both Providers are served together and share a single Client.
+//
+// The ProviderData is nil until the Provider has been configured (for example, when validating the
+// configuration), in which case the FrameworkMetadata is left unpopulated.
+func (m *FrameworkMetadata) Defaults(providerData interface{}, diags *diag.Diagnostics) {
+	if providerData == nil {
+		return
+	}
+
+	client, ok := providerData.(*clients.Client)
+	if !ok {
+		diags.AddError("Client Provider Data Error", fmt.Sprintf("expected the Provider Data to be a *clients.Client but got %T", providerData))
+		return
+	}
+
+	m.Client = client
+	if client.Account != nil {
+		m.SubscriptionId = client.Account.SubscriptionId
+	}
+	m.Features = client.Features
+}
+
+// FrameworkResourceMetadata is intended to be embedded within Resources implemented using the
+// Plugin Framework, so that the Client is available within each of the CRUD functions.
+type FrameworkResourceMetadata struct {
+	FrameworkMetadata
+}
+
+// Configure implements resource.ResourceWithConfigure
+func (m *FrameworkResourceMetadata) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	m.Defaults(request.ProviderData, &response.Diagnostics)
+}
+
+// MarkAsGone removes the Resource from the State, for use in Read when the Resource no longer exists
+func (m *FrameworkResourceMetadata) MarkAsGone(ctx context.Context, id resourceids.Id, response *resource.ReadResponse) {
+	log.Printf("[DEBUG] %s was not found - removing from state", id)
+	response.State.RemoveResource(ctx)
+}
+
+// FrameworkDataSourceMetadata is intended to be embedded within Data Sources implemented using the
+// Plugin Framework, so that the Client is available within the Read function.
+type FrameworkDataSourceMetadata struct {
+	FrameworkMetadata
+}
+
+// Configure implements datasource.DataSourceWithConfigure
+func (m *FrameworkDataSourceMetadata) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	m.Defaults(request.ProviderData, &response.Diagnostics)
+}
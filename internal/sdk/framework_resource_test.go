@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+func TestFrameworkResourceMetadata_Configure(t *testing.T) {
+	client := &clients.Client{
+		Account: &clients.ResourceManagerAccount{
+			SubscriptionId: "12345678-1234-9876-4563-123456789012",
+		},
+	}
+
+	metadata := FrameworkResourceMetadata{}
+	response := resource.ConfigureResponse{}
+	metadata.Configure(context.Background(), resource.ConfigureRequest{ProviderData: client}, &response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %+v", response.Diagnostics)
+	}
+
+	if metadata.Client != client {
+		t.Fatalf("expected the Client to be populated from the Provider Data")
+	}
+	if metadata.SubscriptionId != "12345678-1234-9876-4563-123456789012" {
+		t.Fatalf("expected the Subscription ID to be populated but got %q", metadata.SubscriptionId)
+	}
+}
+
+func TestFrameworkDataSourceMetadata_ConfigureUnconfiguredProvider(t *testing.T) {
+	metadata := FrameworkDataSourceMetadata{}
+	response := datasource.ConfigureResponse{}
+	metadata.Configure(context.Background(), datasource.ConfigureRequest{}, &response)
+	if response.Diagnostics.HasError() {
+		t.Fatalf("expected no error when the Provider hasn't been configured but got: %+v", response.Diagnostics)
+	}
+
+	if metadata.Client != nil {
+		t.Fatalf("expected the Client to be nil when the Provider hasn't been configured")
+	}
+}
+
+func TestFrameworkDataSourceMetadata_ConfigureUnexpectedProviderData(t *testing.T) {
+	metadata := FrameworkDataSourceMetadata{}
+	response := datasource.ConfigureResponse{}
+	metadata.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: "unexpected"}, &response)
+	if !response.Diagnostics.HasError() {
+		t.Fatalf("expected an error when the Provider Data isn't a Client")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// NameAvailability is the result of checking whether a globally unique name is available
+type NameAvailability struct {
+	// Available specifies whether the name can be used
+	Available bool
+
+	// Message optionally describes why the name can't be used
+	Message string
+}
+
+// NameAvailabilityFunc checks whether a globally unique name is available, using the API for the resource type
+type NameAvailabilityFunc func(ctx context.Context, client *clients.Client, name string) (*NameAvailability, error)
+
+// ValidateNameAvailability checks during the plan that the globally unique name specified in `field` is available
+// for a new resource - so that a name which is already taken is surfaced before any other resources are created
+// during the apply. This is only checked when the Enhanced Validation for Name Availability feature is enabled.
+//
+// Any `dependentFields` which are used by the check (for example the App Service Plan for a Web App) must also be
+// known for the name to be checked, since otherwise the check may be made against the wrong scope.
+//
+// Since this is best-effort (for example the credentials in use may not have permission to call the API) an error
+// checking the availability of the name is logged rather than returned.
+func ValidateNameAvailability(ctx context.Context, diff *pluginsdk.ResourceDiff, client *clients.Client, field string, check NameAvailabilityFunc, dependentFields ...string) error {
+	if !features.EnhancedValidationNameAvailabilityEnabled() || diff == nil || client == nil {
+		return nil
+	}
+
+	// the name is ForceNew, so only needs to be checked when the resource is being (re)created
+	if diff.Id() != "" && !diff.HasChange(field) {
+		return nil
+	}
+
+	// the name may not be known until apply, for example when it's generated by another resource
+	if !diff.NewValueKnown(field) {
+		return nil
+	}
+	for _, dependentField := range dependentFields {
+		if !diff.NewValueKnown(dependentField) {
+			return nil
+		}
+	}
+
+	name, ok := diff.Get(field).(string)
+	if !ok || name == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	result, err := check(ctx, client, name)
+	if err != nil {
+		log.Printf("[WARN] checking the availability of the name %q specified for `%s`: %+v", name, field, err)
+		return nil
+	}
+
+	if result != nil && !result.Available {
+		if result.Message != "" {
+			return fmt.Errorf("the name %q specified for `%s` isn't available: %s", name, field, result.Message)
+		}
+		return fmt.Errorf("the name %q specified for `%s` isn't available", name, field)
+	}
+
+	return nil
+}
+
+// ValidateNameAvailabilityDiff returns a CustomizeDiffFunc which calls ValidateNameAvailability, for use in
+// Untyped Resources
+func ValidateNameAvailabilityDiff(field string, check NameAvailabilityFunc, dependentFields ...string) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *pluginsdk.ResourceDiff, meta interface{}) error {
+		client, _ := meta.(*clients.Client)
+		return ValidateNameAvailability(ctx, diff, client, field, check, dependentFields...)
+	}
+}
+
+// ValidateNameAvailability calls ValidateNameAvailability using the ResourceDiff, for use in the CustomizeDiff
+// function of Typed Resources
+func (rmd ResourceMetaData) ValidateNameAvailability(ctx context.Context, field string, check NameAvailabilityFunc, dependentFields ...string) error {
+	return ValidateNameAvailability(ctx, rmd.ResourceDiff, rmd.Client, field, check, dependentFields...)
+}
@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestValidateNameAvailability(t *testing.T) {
+	testData := []struct {
+		Name          string
+		FeatureFlag   string
+		State         *terraform.InstanceState
+		Config        map[string]interface{}
+		Availability  *NameAvailability
+		CheckError    error
+		Dependent     bool
+		ExpectChecked bool
+		ExpectError   bool
+	}{
+		{
+			Name:          "feature disabled",
+			FeatureFlag:   "",
+			Config:        map[string]interface{}{"name": "taken"},
+			Availability:  &NameAvailability{Available: false},
+			ExpectChecked: false,
+		},
+		{
+			Name:          "name available",
+			FeatureFlag:   "true",
+			Config:        map[string]interface{}{"name": "example"},
+			Availability:  &NameAvailability{Available: true},
+			ExpectChecked: true,
+		},
+		{
+			Name:          "name unavailable",
+			FeatureFlag:   "true",
+			Config:        map[string]interface{}{"name": "taken"},
+			Availability:  &NameAvailability{Available: false, Message: "The name is already in use."},
+			ExpectChecked: true,
+			ExpectError:   true,
+		},
+		{
+			Name:          "error checking the name is ignored",
+			FeatureFlag:   "true",
+			Config:        map[string]interface{}{"name": "example"},
+			CheckError:    fmt.Errorf("forbidden"),
+			ExpectChecked: true,
+		},
+		{
+			Name:        "existing resource",
+			FeatureFlag: "true",
+			State: &terraform.InstanceState{
+				ID: "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example",
+				Attributes: map[string]string{
+					"id":   "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example",
+					"name": "taken",
+					"tags": "first",
+				},
+			},
+			Config:        map[string]interface{}{"name": "taken", "tags": "second"},
+			Availability:  &NameAvailability{Available: false},
+			ExpectChecked: false,
+		},
+		{
+			Name:          "unknown dependent field",
+			FeatureFlag:   "true",
+			Config:        map[string]interface{}{"name": "example", "service_plan_id": "74D93920-ED26-11E3-AC10-0800200C9A66"},
+			Availability:  &NameAvailability{Available: false},
+			Dependent:     true,
+			ExpectChecked: false,
+		},
+		{
+			Name:          "known dependent field",
+			FeatureFlag:   "true",
+			Config:        map[string]interface{}{"name": "example", "service_plan_id": "example"},
+			Availability:  &NameAvailability{Available: true},
+			Dependent:     true,
+			ExpectChecked: true,
+		},
+		{
+			Name:          "unknown name",
+			FeatureFlag:   "true",
+			Config:        map[string]interface{}{"name": "74D93920-ED26-11E3-AC10-0800200C9A66"},
+			Availability:  &NameAvailability{Available: false},
+			ExpectChecked: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+		t.Setenv("ARM_PROVIDER_ENHANCED_VALIDATION", "true")
+		t.Setenv("ARM_PROVIDER_ENHANCED_VALIDATION_NAME_AVAILABILITY", v.FeatureFlag)
+
+		checked := false
+		dependentFields := make([]string, 0)
+		if v.Dependent {
+			dependentFields = append(dependentFields, "service_plan_id")
+		}
+		resource := &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"name": {
+					Type:     pluginsdk.TypeString,
+					Required: true,
+					ForceNew: true,
+				},
+				"tags": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+				},
+				"service_plan_id": {
+					Type:     pluginsdk.TypeString,
+					Optional: true,
+				},
+			},
+			CustomizeDiff: ValidateNameAvailabilityDiff("name", func(ctx context.Context, client *clients.Client, name string) (*NameAvailability, error) {
+				checked = true
+				return v.Availability, v.CheckError
+			}, dependentFields...),
+		}
+
+		_, err := resource.Diff(context.TODO(), v.State, terraform.NewResourceConfigRaw(v.Config), &clients.Client{})
+		if checked != v.ExpectChecked {
+			t.Fatalf("expected the name to be checked to be %t but got %t", v.ExpectChecked, checked)
+		}
+		if v.ExpectError {
+			if err == nil || !strings.Contains(err.Error(), "`name`") {
+				t.Fatalf("expected an error referencing `name` but got %+v", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+	}
+}
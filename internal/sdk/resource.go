@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -171,6 +172,15 @@ type ResourceFunc struct {
 	// Timeout is the default timeout, which can be overridden by users
 	// for this method - in-turn used for the Azure API
 	Timeout time.Duration
+
+	// RetryPolicy optionally changes which transient errors are retried for the requests sent by this method,
+	// for example to retry an additional error code which is transient for this resource
+	RetryPolicy *common.RetryPolicyOverride
+}
+
+// run calls Func, applying the RetryPolicy (if any) to the requests sent using the context
+func (rf ResourceFunc) run(ctx context.Context, metaData ResourceMetaData) error {
+	return rf.Func(common.WithRetryPolicyOverride(ctx, rf.RetryPolicy), metaData)
 }
 
 type ResourceMetaData struct {
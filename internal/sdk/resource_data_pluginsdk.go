@@ -4,6 +4,7 @@
 package sdk
 
 import (
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
@@ -46,6 +47,12 @@ func (p *PluginSdkResourceData) GetFromConfig(key string) interface{} {
 	return nil
 }
 
+// GetRawConfig returns the raw configuration, which is used to retrieve the values of write-only fields
+// NOTE: this is only available in Create and Update functions.
+func (p *PluginSdkResourceData) GetRawConfig() cty.Value {
+	return p.resourceData.GetRawConfig()
+}
+
 func (p *PluginSdkResourceData) GetFromState(key string) interface{} {
 	// p.resourceData.GetRawState() ?
 	return nil
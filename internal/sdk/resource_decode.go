@@ -5,8 +5,10 @@ package sdk
 
 import (
 	"fmt"
+	"math/big"
 	"reflect"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -47,6 +49,12 @@ type stateRetriever interface {
 	GetOkExists(key string) (interface{}, bool)
 }
 
+// rawConfigRetriever is implemented by the stateRetrievers which expose the raw configuration, which is
+// needed to decode write-only fields since the values of these are never present in the plan or state
+type rawConfigRetriever interface {
+	GetRawConfig() cty.Value
+}
+
 func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debugLogger Logger) error {
 	if reflect.TypeOf(input).Kind() != reflect.Ptr {
 		return fmt.Errorf("need a pointer")
@@ -63,6 +71,17 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 		}
 
 		if structTags != nil {
+			if structTags.writeOnly {
+				value, exists, err := getWriteOnlyValue(stateRetriever, structTags.hclPath, field.Type)
+				if err != nil {
+					return fmt.Errorf("retrieving write-only value for %q: %+v", field.Name, err)
+				}
+				if exists {
+					reflect.ValueOf(input).Elem().Field(i).Set(value)
+				}
+				continue
+			}
+
 			tfschemaValue, valExists := stateRetriever.GetOkExists(structTags.hclPath)
 			if !valExists {
 				continue
@@ -79,6 +98,75 @@ func decodeReflectedType(input interface{}, stateRetriever stateRetriever, debug
 	return nil
 }
 
+// getWriteOnlyValue retrieves the value for the top-level write-only field hclPath from the raw configuration and
+// converts it into the type of the field. Only this attribute is read from the raw configuration, so unknown values
+// for other arguments don't prevent the write-only value from being decoded.
+func getWriteOnlyValue(stateRetriever stateRetriever, hclPath string, fieldType reflect.Type) (reflect.Value, bool, error) {
+	retriever, ok := stateRetriever.(rawConfigRetriever)
+	if !ok {
+		return reflect.Value{}, false, fmt.Errorf("the raw configuration is not available")
+	}
+
+	config := retriever.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() || !config.Type().IsObjectType() || !config.Type().HasAttribute(hclPath) {
+		return reflect.Value{}, false, nil
+	}
+
+	// the value can be unknown during the plan, in which case there's nothing to decode yet
+	v := config.GetAttr(hclPath)
+	if v.IsNull() || !v.IsKnown() {
+		return reflect.Value{}, false, nil
+	}
+
+	valueType := fieldType
+	if fieldType.Kind() == reflect.Pointer {
+		valueType = fieldType.Elem()
+	}
+
+	value := reflect.New(valueType).Elem()
+	switch valueType.Kind() {
+	case reflect.String:
+		if !v.Type().Equals(cty.String) {
+			return reflect.Value{}, false, fmt.Errorf("expected a string but got %s", v.Type().FriendlyName())
+		}
+		value.SetString(v.AsString())
+
+	case reflect.Bool:
+		if !v.Type().Equals(cty.Bool) {
+			return reflect.Value{}, false, fmt.Errorf("expected a bool but got %s", v.Type().FriendlyName())
+		}
+		value.SetBool(v.True())
+
+	case reflect.Int, reflect.Int64:
+		if !v.Type().Equals(cty.Number) {
+			return reflect.Value{}, false, fmt.Errorf("expected a number but got %s", v.Type().FriendlyName())
+		}
+		i, accuracy := v.AsBigFloat().Int64()
+		if accuracy != big.Exact || value.OverflowInt(i) {
+			return reflect.Value{}, false, fmt.Errorf("the value %s can't be represented as an %s", v.AsBigFloat().String(), valueType.Kind())
+		}
+		value.SetInt(i)
+
+	case reflect.Float64:
+		if !v.Type().Equals(cty.Number) {
+			return reflect.Value{}, false, fmt.Errorf("expected a number but got %s", v.Type().FriendlyName())
+		}
+		f, _ := v.AsBigFloat().Float64()
+		value.SetFloat(f)
+
+	default:
+		return reflect.Value{}, false, fmt.Errorf("write-only fields of type %s are not supported", fieldType)
+	}
+
+	if fieldType.Kind() == reflect.Pointer {
+		ptr := reflect.New(valueType)
+		ptr.Elem().Set(value)
+		return ptr, true, nil
+	}
+
+	return value, true, nil
+}
+
 func setValue(input, tfschemaValue interface{}, index int, fieldName string, debugLogger Logger) (errOut error) {
 	debugLogger.Infof("setting value for %q..", fieldName)
 	defer func() {
@@ -8,10 +8,12 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-cty/cty"
 )
 
 type decodeTestData struct {
 	State       map[string]interface{}
+	RawConfig   cty.Value
 	Input       interface{}
 	Expected    interface{}
 	ExpectError bool
@@ -431,6 +433,131 @@ func TestDecode_TopLevelFieldsComputed(t *testing.T) {
 	}.test(t)
 }
 
+func TestDecode_TopLevelFieldsWriteOnly(t *testing.T) {
+	type SimpleType struct {
+		Name            string   `tfschema:"name"`
+		Password        string   `tfschema:"password_wo,writeOnly"`
+		PasswordVersion int64    `tfschema:"password_wo_version"`
+		Number          *int64   `tfschema:"number_wo,writeOnly"`
+		Price           *float64 `tfschema:"price_wo,writeOnly"`
+		Enabled         bool     `tfschema:"enabled_wo,writeOnly"`
+		Omitted         string   `tfschema:"omitted_wo,writeOnly"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"name":                "example",
+			"password_wo":         "",
+			"password_wo_version": 2,
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"name":                cty.StringVal("example"),
+			"password_wo":         cty.StringVal("Pa55w0rd!"),
+			"password_wo_version": cty.NumberIntVal(2),
+			"number_wo":           cty.NumberIntVal(42),
+			"price_wo":            cty.NumberFloatVal(129.99),
+			"enabled_wo":          cty.True,
+			"omitted_wo":          cty.NullVal(cty.String),
+		}),
+		Input: &SimpleType{},
+		Expected: &SimpleType{
+			Name:            "example",
+			Password:        "Pa55w0rd!",
+			PasswordVersion: 2,
+			Number:          pointer.To(int64(42)),
+			Price:           pointer.To(129.99),
+			Enabled:         true,
+		},
+		ExpectError: false,
+	}.test(t)
+}
+
+func TestDecode_TopLevelFieldsWriteOnlyIntegers(t *testing.T) {
+	type SimpleType struct {
+		Name        string `tfschema:"name"`
+		Count       int    `tfschema:"count_wo,writeOnly"`
+		CountPtr    *int   `tfschema:"count_ptr_wo,writeOnly"`
+		Capacity    int64  `tfschema:"capacity_wo,writeOnly"`
+		CapacityPtr *int64 `tfschema:"capacity_ptr_wo,writeOnly"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"name": "example",
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"name":            cty.StringVal("example"),
+			"count_wo":        cty.NumberIntVal(3),
+			"count_ptr_wo":    cty.NumberIntVal(4),
+			"capacity_wo":     cty.NumberIntVal(5),
+			"capacity_ptr_wo": cty.NumberIntVal(6),
+		}),
+		Input: &SimpleType{},
+		Expected: &SimpleType{
+			Name:        "example",
+			Count:       3,
+			CountPtr:    pointer.To(4),
+			Capacity:    5,
+			CapacityPtr: pointer.To(int64(6)),
+		},
+		ExpectError: false,
+	}.test(t)
+}
+
+func TestDecode_TopLevelFieldsWriteOnlyPartiallyKnownConfig(t *testing.T) {
+	type SimpleType struct {
+		Name     string `tfschema:"name"`
+		Password string `tfschema:"password_wo,writeOnly"`
+		Secret   string `tfschema:"secret_wo,writeOnly"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"name": "example",
+		},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"name":        cty.UnknownVal(cty.String),
+			"password_wo": cty.StringVal("Pa55w0rd!"),
+			"secret_wo":   cty.UnknownVal(cty.String),
+		}),
+		Input: &SimpleType{},
+		Expected: &SimpleType{
+			Name:     "example",
+			Password: "Pa55w0rd!",
+		},
+		ExpectError: false,
+	}.test(t)
+}
+
+func TestDecode_TopLevelFieldsWriteOnlyFractionalInteger(t *testing.T) {
+	type SimpleType struct {
+		Count int64 `tfschema:"count_wo,writeOnly"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{},
+		RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"count_wo": cty.NumberFloatVal(1.5),
+		}),
+		Input:       &SimpleType{},
+		ExpectError: true,
+	}.test(t)
+}
+
+func TestDecode_TopLevelFieldsWriteOnlyNoConfig(t *testing.T) {
+	type SimpleType struct {
+		Name     string `tfschema:"name"`
+		Password string `tfschema:"password_wo,writeOnly"`
+	}
+	decodeTestData{
+		State: map[string]interface{}{
+			"name":        "example",
+			"password_wo": "should-not-be-used",
+		},
+		Input: &SimpleType{},
+		Expected: &SimpleType{
+			Name: "example",
+		},
+		ExpectError: false,
+	}.test(t)
+}
+
 func TestResourceDecode_NestedOneLevelDeepEmpty(t *testing.T) {
 	type Inner struct {
 		Value string `tfschema:"value"`
@@ -1057,12 +1184,18 @@ func (testData decodeTestData) test(t *testing.T) {
 
 func (testData decodeTestData) stateWrapper() testDataGetter {
 	return testDataGetter{
-		values: testData.State,
+		values:    testData.State,
+		rawConfig: testData.RawConfig,
 	}
 }
 
 type testDataGetter struct {
-	values map[string]interface{}
+	values    map[string]interface{}
+	rawConfig cty.Value
+}
+
+func (td testDataGetter) GetRawConfig() cty.Value {
+	return td.rawConfig
 }
 
 func (td testDataGetter) Get(key string) interface{} {
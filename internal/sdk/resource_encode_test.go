@@ -382,6 +382,25 @@ func TestResourceEncode_TopLevelOmitted(t *testing.T) {
 	}.test(t)
 }
 
+func TestResourceEncode_TopLevelWriteOnly(t *testing.T) {
+	type SimpleType struct {
+		Name            string `tfschema:"name"`
+		Password        string `tfschema:"password_wo,writeOnly"`
+		PasswordVersion int64  `tfschema:"password_wo_version"`
+	}
+	encodeTestData{
+		Input: &SimpleType{
+			Name:            "example",
+			Password:        "Pa55w0rd!",
+			PasswordVersion: 3,
+		},
+		Expected: map[string]interface{}{
+			"name":                "example",
+			"password_wo_version": int64(3),
+		},
+	}.test(t)
+}
+
 func TestResourceEncode_TopLevelComputed(t *testing.T) {
 	type SimpleType struct {
 		ComputedString        string             `tfschema:"computed_string" computed:"true"`
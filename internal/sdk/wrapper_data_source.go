@@ -48,7 +48,7 @@ func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 		Schema: *resourceSchema,
 		ReadContext: dw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, dw.logger)
-			return dw.dataSource.Read().Func(ctx, metaData)
+			return dw.dataSource.Read().run(ctx, metaData)
 		}),
 		Timeouts: &schema.ResourceTimeout{
 			Read: d(dw.dataSource.Read().Timeout),
@@ -52,24 +52,24 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 
 		CreateContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
-			err := rw.resource.Create().Func(ctx, metaData)
+			err := rw.resource.Create().run(ctx, metaData)
 			if err != nil {
 				return err
 			}
 			// NOTE: whilst this may look like we should use the Read
 			// functions timeout here, we're still /technically/ in the
 			// Create function so reusing that timeout should be sufficient
-			return rw.resource.Read().Func(ctx, metaData)
+			return rw.resource.Read().run(ctx, metaData)
 		}),
 
 		// looks like these could be reused, easiest if they're not
 		ReadContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
-			return rw.resource.Read().Func(ctx, metaData)
+			return rw.resource.Read().run(ctx, metaData)
 		}),
 		DeleteContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
-			return rw.resource.Delete().Func(ctx, metaData)
+			return rw.resource.Delete().run(ctx, metaData)
 		}),
 
 		Timeouts: &schema.ResourceTimeout{
@@ -118,14 +118,14 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		resource.UpdateContext = rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
 
-			err := v.Update().Func(ctx, metaData)
+			err := v.Update().run(ctx, metaData)
 			if err != nil {
 				return err
 			}
 			// whilst this may look like we should use the Update timeout here
 			// we're still "technically" in the update method, so reusing the
 			// Update's timeout should be fine
-			return rw.resource.Read().Func(ctx, metaData)
+			return rw.resource.Read().run(ctx, metaData)
 		})
 		resource.Timeouts.Update = d(v.Update().Timeout)
 	}
@@ -142,7 +142,7 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 				serializationDebugLogger: NullLogger{},
 			}
 
-			return v.CustomizeDiff().Func(ctx, metaData)
+			return v.CustomizeDiff().run(ctx, metaData)
 		}
 	}
 
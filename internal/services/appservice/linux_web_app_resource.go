@@ -74,6 +74,8 @@ var _ sdk.ResourceWithUpdate = LinuxWebAppResource{}
 
 var _ sdk.ResourceWithCustomImporter = LinuxWebAppResource{}
 
+var _ sdk.ResourceWithCustomizeDiff = LinuxWebAppResource{}
+
 var _ sdk.ResourceWithStateMigration = LinuxWebAppResource{}
 
 func (r LinuxWebAppResource) Arguments() map[string]*pluginsdk.Schema {
@@ -1009,6 +1011,16 @@ func (r LinuxWebAppResource) Update() sdk.ResourceFunc {
 	}
 }
 
+func (r LinuxWebAppResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			servicePlanId := metadata.ResourceDiff.Get("service_plan_id").(string)
+			return metadata.ValidateNameAvailability(ctx, "name", checkWebAppNameAvailability(servicePlanId), "service_plan_id")
+		},
+	}
+}
+
 func (r LinuxWebAppResource) CustomImporter() sdk.ResourceRunFunc {
 	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
 		client := metadata.Client.AppService.WebAppsClient
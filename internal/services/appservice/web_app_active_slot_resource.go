@@ -100,7 +100,9 @@ func (r WebAppActiveSlotResource) Create() sdk.ResourceFunc {
 				PreserveVnet: activeSlot.OverwriteNetworking,
 			}
 
-			locks.ByID(appId.ID())
+			if err := locks.ByIDWithContext(ctx, appId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(appId.ID())
 
 			if _, err := client.SwapSlotWithProduction(ctx, appId, csmSlotEntity); err != nil {
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package appservice
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/web/2023-01-01/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+// checkWebAppNameAvailability returns a NameAvailabilityFunc which checks the name of a Web App hosted on the App
+// Service Plan. The check is skipped for App Service Plans within an App Service Environment, since the name is
+// instead checked against the domain of the App Service Environment during the apply.
+func checkWebAppNameAvailability(servicePlanId string) sdk.NameAvailabilityFunc {
+	return func(ctx context.Context, client *clients.Client, name string) (*sdk.NameAvailability, error) {
+		if servicePlanId != "" {
+			id, err := commonids.ParseAppServicePlanID(servicePlanId)
+			if err != nil {
+				return nil, err
+			}
+
+			servicePlan, err := client.AppService.ServicePlanClient.Get(ctx, *id)
+			if err != nil {
+				return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if model := servicePlan.Model; model != nil && model.Properties != nil && model.Properties.HostingEnvironmentProfile != nil {
+				return &sdk.NameAvailability{
+					Available: true,
+				}, nil
+			}
+		}
+
+		subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+		input := resourceproviders.ResourceNameAvailabilityRequest{
+			Name: name,
+			Type: resourceproviders.CheckNameResourceTypesMicrosoftPointWebSites,
+		}
+		resp, err := client.AppService.ResourceProvidersClient.CheckNameAvailability(ctx, subscriptionId, input)
+		if err != nil {
+			return nil, fmt.Errorf("checking the availability of the Web App name %q: %+v", name, err)
+		}
+		if resp.Model == nil || resp.Model.NameAvailable == nil {
+			return nil, fmt.Errorf("checking the availability of the Web App name %q: `model` was nil", name)
+		}
+
+		return &sdk.NameAvailability{
+			Available: *resp.Model.NameAvailable,
+			Message:   pointer.From(resp.Model.Message),
+		}, nil
+	}
+}
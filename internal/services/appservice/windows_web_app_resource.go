@@ -72,6 +72,8 @@ type WindowsWebAppModel struct {
 
 var _ sdk.ResourceWithCustomImporter = WindowsWebAppResource{}
 
+var _ sdk.ResourceWithCustomizeDiff = WindowsWebAppResource{}
+
 var _ sdk.ResourceWithStateMigration = WindowsWebAppResource{}
 
 func (r WindowsWebAppResource) Arguments() map[string]*pluginsdk.Schema {
@@ -1062,6 +1064,16 @@ func (r WindowsWebAppResource) Update() sdk.ResourceFunc {
 	}
 }
 
+func (r WindowsWebAppResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			servicePlanId := metadata.ResourceDiff.Get("service_plan_id").(string)
+			return metadata.ValidateNameAvailability(ctx, "name", checkWebAppNameAvailability(servicePlanId), "service_plan_id")
+		},
+	}
+}
+
 func (r WindowsWebAppResource) CustomImporter() sdk.ResourceRunFunc {
 	return func(ctx context.Context, metadata sdk.ResourceMetaData) error {
 		client := metadata.Client.AppService.WebAppsClient
@@ -18,6 +18,7 @@ import (
 	"github.com/hashicorp/go-uuid"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/authorization/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -217,6 +218,12 @@ func (br roleAssignmentBaseResource) createFunc(resourceName, scope string) sdk.
 			return nil
 		},
 
+		// the creation of the Role Assignment is retried whilst the Principal replicates, so that this is bounded by
+		// the timeout of the resource rather than the number of retries
+		RetryPolicy: &common.RetryPolicyOverride{
+			IgnoredErrorCodes: []string{"PrincipalNotFound"},
+		},
+
 		Timeout: 30 * time.Minute,
 	}
 }
@@ -27,7 +27,6 @@ import (
 	managedHsmHelpers "github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/helpers"
 	managedHsmParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/parse"
 	managedHsmValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/validate"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/set"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
@@ -307,19 +306,22 @@ func (AzureAIServicesResource) Create() sdk.ResourceFunc {
 			networkACLs, subnetIds := expandAzureAIServicesNetworkACLs(model.NetworkACLs)
 
 			// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-			virtualNetworkNames := make([]string, 0)
+			virtualNetworkIds := make([]string, 0)
 			for _, v := range subnetIds {
 				subnetId, err := commonids.ParseSubnetID(v)
 				if err != nil {
 					return err
 				}
-				if !utils.SliceContainsValue(virtualNetworkNames, subnetId.VirtualNetworkName) {
-					virtualNetworkNames = append(virtualNetworkNames, subnetId.VirtualNetworkName)
+				virtualNetworkId := commonids.NewVirtualNetworkID(subnetId.SubscriptionId, subnetId.ResourceGroupName, subnetId.VirtualNetworkName).ID()
+				if !utils.SliceContainsValue(virtualNetworkIds, virtualNetworkId) {
+					virtualNetworkIds = append(virtualNetworkIds, virtualNetworkId)
 				}
 			}
 
-			locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-			defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+			if err := locks.MultipleByIDWithContext(ctx, &virtualNetworkIds); err != nil {
+				return err
+			}
+			defer locks.UnlockMultipleByID(&virtualNetworkIds)
 
 			props := cognitiveservicesaccounts.Account{
 				Kind:     pointer.To("AIServices"),
@@ -472,23 +474,29 @@ func (AzureAIServicesResource) Update() sdk.ResourceFunc {
 			props := resp.Model
 			if metadata.ResourceData.HasChange("network_acls") {
 				networkACLs, subnetIds := expandAzureAIServicesNetworkACLs(model.NetworkACLs)
-				locks.MultipleByName(&subnetIds, network.VirtualNetworkResourceName)
-				defer locks.UnlockMultipleByName(&subnetIds, network.VirtualNetworkResourceName)
 
-				// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-				virtualNetworkNames := make([]string, 0)
+				// lock on the Virtual Network ID's (and then the Subnet ID's) since modifications in the networking stack are exclusive
+				virtualNetworkIds := make([]string, 0)
 				for _, v := range subnetIds {
 					subnetId, err := commonids.ParseSubnetIDInsensitively(v)
 					if err != nil {
 						return err
 					}
-					if !utils.SliceContainsValue(virtualNetworkNames, subnetId.VirtualNetworkName) {
-						virtualNetworkNames = append(virtualNetworkNames, subnetId.VirtualNetworkName)
+					virtualNetworkId := commonids.NewVirtualNetworkID(subnetId.SubscriptionId, subnetId.ResourceGroupName, subnetId.VirtualNetworkName).ID()
+					if !utils.SliceContainsValue(virtualNetworkIds, virtualNetworkId) {
+						virtualNetworkIds = append(virtualNetworkIds, virtualNetworkId)
 					}
 				}
 
-				locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-				defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+				if err := locks.MultipleByIDWithContext(ctx, &virtualNetworkIds); err != nil {
+					return err
+				}
+				defer locks.UnlockMultipleByID(&virtualNetworkIds)
+
+				if err := locks.MultipleByIDWithContext(ctx, &subnetIds); err != nil {
+					return err
+				}
+				defer locks.UnlockMultipleByID(&subnetIds)
 
 				props.Properties.NetworkAcls = networkACLs
 			}
@@ -101,6 +101,25 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				Sensitive:        true,
 				DiffSuppressFunc: adminPasswordDiffSuppressFunc,
 				ValidateFunc:     computeValidate.LinuxAdminPassword,
+				ConflictsWith:    []string{"admin_password_wo"},
+			},
+
+			"admin_password_wo": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: pluginsdk.SuppressWriteOnlyDiff,
+				ValidateFunc:     computeValidate.LinuxAdminPassword,
+				ConflictsWith:    []string{"admin_password"},
+				RequiredWith:     []string{"admin_password_wo_version"},
+			},
+
+			"admin_password_wo_version": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"admin_password_wo"},
 			},
 
 			"admin_ssh_key": SSHKeysSchema(true),
@@ -417,6 +436,10 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			validateVirtualMachineSizeDiff,
+		),
 	}
 }
 
@@ -428,7 +451,9 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 
 	id := virtualmachines.NewVirtualMachineID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.VirtualMachineName, VirtualMachineResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualMachineName, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualMachineName, VirtualMachineResourceName)
 
 	resp, err := client.Get(ctx, id, virtualmachines.DefaultGetOperationOptions())
@@ -760,12 +785,18 @@ func resourceLinuxVirtualMachineCreate(d *pluginsdk.ResourceData, meta interface
 	}
 
 	// "Authentication using either SSH or by user name and password must be enabled in Linux profile." Target="linuxConfiguration"
-	adminPassword := d.Get("admin_password").(string)
+	adminPassword, err := pluginsdk.GetWriteOnlyString(d, "admin_password_wo")
+	if err != nil {
+		return err
+	}
+	if adminPassword == "" {
+		adminPassword = d.Get("admin_password").(string)
+	}
 	if disablePasswordAuthentication && len(sshKeys) == 0 {
 		return fmt.Errorf("at least one `admin_ssh_key` must be specified when `disable_password_authentication` is set to `true`")
 	} else if !disablePasswordAuthentication {
 		if adminPassword == "" {
-			return fmt.Errorf("an `admin_password` must be specified if `disable_password_authentication` is set to `false`")
+			return fmt.Errorf("one of `admin_password` or `admin_password_wo` must be specified if `disable_password_authentication` is set to `false`")
 		}
 
 		params.Properties.OsProfile.AdminPassword = pointer.To(adminPassword)
@@ -1053,7 +1084,9 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByName(id.VirtualMachineName, VirtualMachineResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualMachineName, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualMachineName, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Linux %s", id)
@@ -1659,7 +1692,9 @@ func resourceLinuxVirtualMachineDelete(d *pluginsdk.ResourceData, meta interface
 		return err
 	}
 
-	locks.ByName(id.VirtualMachineName, VirtualMachineResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualMachineName, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualMachineName, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Linux %s", id)
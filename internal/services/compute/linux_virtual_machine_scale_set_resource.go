@@ -82,6 +82,7 @@ func resourceLinuxVirtualMachineScaleSet() *pluginsdk.Resource {
 
 				return false
 			}),
+			validateVirtualMachineScaleSetSkuDiff,
 		),
 	}
 }
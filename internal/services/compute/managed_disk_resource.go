@@ -313,6 +313,7 @@ func resourceManagedDisk() *pluginsdk.Resource {
 				}
 				return len(old.([]interface{})) > 0 && len(new.([]interface{})) == 0
 			}),
+			validateManagedDiskSkuDiff,
 		),
 	}
 }
@@ -832,7 +833,9 @@ func resourceManagedDiskUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 		// check instanceView State
 
-		locks.ByName(name, VirtualMachineResourceName)
+		if err := locks.ByNameWithContext(ctx, name, VirtualMachineResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(name, VirtualMachineResourceName)
 
 		vm, err := virtualMachinesClient.Get(ctx, *virtualMachineId, virtualmachines.DefaultGetOperationOptions())
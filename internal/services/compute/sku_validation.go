@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute
+
+import (
+	"context"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachines"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-07-01/virtualmachinescalesets"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceskus"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// these CustomizeDiff functions check the Virtual Machine sizes and Managed Disk SKUs are available (and that
+// there's sufficient vCPU quota) during the plan when Enhanced Validation for SKUs is enabled
+
+func validateVirtualMachineSizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !features.EnhancedValidationSkusEnabled() {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("size", "location", "zone") {
+		return nil
+	}
+	if !d.NewValueKnown("size") || !d.NewValueKnown("location") || !d.NewValueKnown("zone") {
+		return nil
+	}
+
+	request := resourceskus.Request{
+		ResourceType: resourceskus.ResourceTypeVirtualMachines,
+		Name:         d.Get("size").(string),
+		Location:     d.Get("location").(string),
+	}
+	if zone := d.Get("zone").(string); zone != "" {
+		request.Zones = []string{zone}
+	}
+
+	// Spot Virtual Machines use a separate quota, and resizing an existing Virtual Machine only requires the difference
+	// in vCPUs between the previous and the new size
+	if d.Get("priority").(string) != string(virtualmachines.VirtualMachinePriorityTypesSpot) {
+		request.AdditionalInstances = 1
+		if d.Id() != "" {
+			previous, _ := d.GetChange("size")
+			request.PreviousName = previous.(string)
+		}
+	}
+
+	client := meta.(*clients.Client)
+	return resourceskus.Validate(ctx, resourceSkuClients(client), commonids.NewSubscriptionID(client.Account.SubscriptionId), request)
+}
+
+func validateVirtualMachineScaleSetSkuDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !features.EnhancedValidationSkusEnabled() {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("sku", "instances", "location", "zones") {
+		return nil
+	}
+	if !d.NewValueKnown("sku") || !d.NewValueKnown("instances") || !d.NewValueKnown("location") || !d.NewValueKnown("zones") {
+		return nil
+	}
+
+	// the sizes for a Scale Set using a Mixed SKU are defined in the Flexible Orchestration profile
+	sku := d.Get("sku").(string)
+	if sku == "Mix" {
+		return nil
+	}
+
+	request := resourceskus.Request{
+		ResourceType: resourceskus.ResourceTypeVirtualMachines,
+		Name:         sku,
+		Location:     d.Get("location").(string),
+		Zones:        zones.ExpandUntyped(d.Get("zones").(*pluginsdk.Set).List()),
+	}
+
+	if d.Get("priority").(string) != string(virtualmachinescalesets.VirtualMachinePriorityTypesSpot) {
+		oldInstances, newInstances := d.GetChange("instances")
+		switch {
+		case d.Id() == "":
+			request.AdditionalInstances = int64(newInstances.(int))
+		case !d.HasChange("sku") && newInstances.(int) > oldInstances.(int):
+			request.AdditionalInstances = int64(newInstances.(int) - oldInstances.(int))
+		}
+	}
+
+	client := meta.(*clients.Client)
+	return resourceskus.Validate(ctx, resourceSkuClients(client), commonids.NewSubscriptionID(client.Account.SubscriptionId), request)
+}
+
+func validateManagedDiskSkuDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !features.EnhancedValidationSkusEnabled() {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("storage_account_type", "location", "zone") {
+		return nil
+	}
+	if !d.NewValueKnown("storage_account_type") || !d.NewValueKnown("location") || !d.NewValueKnown("zone") {
+		return nil
+	}
+
+	request := resourceskus.Request{
+		ResourceType: resourceskus.ResourceTypeDisks,
+		Name:         d.Get("storage_account_type").(string),
+		Location:     d.Get("location").(string),
+	}
+	if zone := d.Get("zone").(string); zone != "" {
+		request.Zones = []string{zone}
+	}
+
+	client := meta.(*clients.Client)
+	return resourceskus.Validate(ctx, resourceSkuClients(client), commonids.NewSubscriptionID(client.Account.SubscriptionId), request)
+}
+
+func resourceSkuClients(client *clients.Client) resourceskus.Clients {
+	return resourceskus.Clients{
+		SkusClient:   client.Compute.SkusClient,
+		UsagesClient: client.Compute.UsagesClient,
+	}
+}
@@ -434,6 +434,10 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 				Computed: true,
 			},
 		},
+
+		CustomizeDiff: pluginsdk.CustomDiffWithAll(
+			validateVirtualMachineSizeDiff,
+		),
 	}
 }
 
@@ -445,7 +449,9 @@ func resourceWindowsVirtualMachineCreate(d *pluginsdk.ResourceData, meta interfa
 
 	id := virtualmachines.NewVirtualMachineID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.VirtualMachineName, VirtualMachineResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualMachineName, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualMachineName, VirtualMachineResourceName)
 
 	resp, err := client.Get(ctx, id, virtualmachines.DefaultGetOperationOptions())
@@ -1083,7 +1089,9 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		return err
 	}
 
-	locks.ByName(id.VirtualMachineName, VirtualMachineResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualMachineName, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualMachineName, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Windows %s", id)
@@ -1714,7 +1722,9 @@ func resourceWindowsVirtualMachineDelete(d *pluginsdk.ResourceData, meta interfa
 		return err
 	}
 
-	locks.ByName(id.VirtualMachineName, VirtualMachineResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualMachineName, VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualMachineName, VirtualMachineResourceName)
 
 	log.Printf("[DEBUG] Retrieving Windows %s", id)
@@ -81,6 +81,7 @@ func resourceWindowsVirtualMachineScaleSet() *pluginsdk.Resource {
 
 				return false
 			}),
+			validateVirtualMachineScaleSetSkuDiff,
 		),
 	}
 }
@@ -108,7 +108,9 @@ func (a ContainerAppCustomDomainResource) Create() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(containerAppId.ID())
+			if err := locks.ByIDWithContext(ctx, containerAppId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(containerAppId.ID())
 
 			id := parse.NewContainerAppCustomDomainId(containerAppId.SubscriptionId, containerAppId.ResourceGroupName, containerAppId.ContainerAppName, model.Name)
@@ -281,7 +283,9 @@ func (a ContainerAppCustomDomainResource) Delete() sdk.ResourceFunc {
 						// attempt to lock the cert if we have the ID
 						certificateId := pointer.From(v.CertificateId)
 						if certificateId != "" {
-							locks.ByID(certificateId)
+							if err := locks.ByIDWithContext(ctx, certificateId); err != nil {
+								return err
+							}
 							defer locks.UnlockByID(certificateId)
 						}
 					}
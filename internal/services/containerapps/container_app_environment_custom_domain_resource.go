@@ -102,7 +102,9 @@ func (r ContainerAppEnvironmentCustomDomainResource) Create() sdk.ResourceFunc {
 			}
 
 			// Prevent parallel create of the same resource
-			locks.ByID(id.ID())
+			if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			existing, err := client.Get(ctx, *id)
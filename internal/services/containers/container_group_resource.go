@@ -816,7 +816,9 @@ func resourceContainerGroupCreate(d *pluginsdk.ResourceData, meta interface{}) e
 				return fmt.Errorf(`parsing subnet id %q: %v`, item.Id, err)
 			}
 
-			locks.ByID(subnet.ID())
+			if err := locks.ByIDWithContext(ctx, subnet.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(subnet.ID())
 		}
 	}
@@ -1085,7 +1087,9 @@ func resourceContainerGroupDelete(d *pluginsdk.ResourceData, meta interface{}) e
 					return fmt.Errorf(`parsing subnet id %q: %v`, item.Id, err)
 				}
 
-				locks.ByID(subnet.ID())
+				if err := locks.ByIDWithContext(ctx, subnet.ID()); err != nil {
+					return err
+				}
 				defer locks.UnlockByID(subnet.ID())
 			}
 		}
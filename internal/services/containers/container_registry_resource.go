@@ -26,6 +26,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/migration"
 	containerValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
@@ -158,7 +159,8 @@ func resourceContainerRegistry() *pluginsdk.Resource {
 				return fmt.Errorf("`data_endpoint_enabled` can only be applied when using the Premium Sku")
 			}
 
-			return nil
+			client, _ := v.(*clients.Client)
+			return sdk.ValidateNameAvailability(ctx, d, client, "name", checkContainerRegistryNameAvailability)
 		}),
 	}
 }
@@ -1350,3 +1352,24 @@ func resourceContainerRegistrySchema() map[string]*pluginsdk.Schema {
 
 	return schema
 }
+
+func checkContainerRegistryNameAvailability(ctx context.Context, client *clients.Client, name string) (*sdk.NameAvailability, error) {
+	operationClient := client.Containers.ContainerRegistryClient_v2023_06_01_preview.Operation
+	subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+	input := operation.RegistryNameCheckRequest{
+		Name: name,
+		Type: operation.ContainerRegistryResourceTypeMicrosoftPointContainerRegistryRegistries,
+	}
+	resp, err := operationClient.RegistriesCheckNameAvailability(ctx, subscriptionId, input)
+	if err != nil {
+		return nil, fmt.Errorf("checking the availability of the Container Registry name %q: %+v", name, err)
+	}
+	if resp.Model == nil || resp.Model.NameAvailable == nil {
+		return nil, fmt.Errorf("checking the availability of the Container Registry name %q: `model` was nil", name)
+	}
+
+	return &sdk.NameAvailability{
+		Available: *resp.Model.NameAvailable,
+		Message:   pointer.From(resp.Model.Message),
+	}, nil
+}
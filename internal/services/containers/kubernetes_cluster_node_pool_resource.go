@@ -81,6 +81,7 @@ func resourceKubernetesClusterNodePool() *pluginsdk.Resource {
 			pluginsdk.ForceNewIfChange("upgrade_settings.0.drain_timeout_in_minutes", func(ctx context.Context, old, new, meta interface{}) bool {
 				return old != 0 && new == 0
 			}),
+			validateNodePoolVMSizeDiff,
 		),
 	}
 }
@@ -419,11 +420,16 @@ func resourceKubernetesClusterNodePoolCreate(d *pluginsdk.ResourceData, meta int
 			return err
 		}
 
-		locks.ByName(subnetID.VirtualNetworkName, network.VirtualNetworkResourceName)
-		defer locks.UnlockByName(subnetID.VirtualNetworkName, network.VirtualNetworkResourceName)
+		virtualNetworkId := commonids.NewVirtualNetworkID(subnetID.SubscriptionId, subnetID.ResourceGroupName, subnetID.VirtualNetworkName)
+		if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+			return err
+		}
+		defer locks.UnlockByID(virtualNetworkId.ID())
 
-		locks.ByName(subnetID.SubnetName, network.SubnetResourceName)
-		defer locks.UnlockByName(subnetID.SubnetName, network.SubnetResourceName)
+		if err := locks.ByIDWithContext(ctx, subnetID.ID()); err != nil {
+			return err
+		}
+		defer locks.UnlockByID(subnetID.ID())
 	}
 
 	id := agentpools.NewAgentPoolID(clusterId.SubscriptionId, clusterId.ResourceGroupName, clusterId.ManagedClusterName, d.Get("name").(string))
@@ -124,6 +124,7 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 			pluginsdk.ForceNewIfChange("custom_ca_trust_certificates_base64", func(ctx context.Context, old, new, meta interface{}) bool {
 				return !features.FourPointOhBeta() && len(old.([]interface{})) > 0 && len(new.([]interface{})) == 0
 			}),
+			validateDefaultNodePoolVMSizeDiff,
 		),
 
 		Timeouts: &pluginsdk.ResourceTimeout{
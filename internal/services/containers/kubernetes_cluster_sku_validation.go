@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/zones"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-05-01/agentpools"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceskus"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// these CustomizeDiff functions check the Virtual Machine sizes used by Node Pools are available (and that there's
+// sufficient vCPU quota) during the plan when Enhanced Validation for SKUs is enabled
+
+func validateDefaultNodePoolVMSizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !features.EnhancedValidationSkusEnabled() {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("location", "default_node_pool.0.vm_size", "default_node_pool.0.node_count", "default_node_pool.0.min_count", "default_node_pool.0.zones") {
+		return nil
+	}
+	if !d.NewValueKnown("location") || !d.NewValueKnown("default_node_pool.0.vm_size") || !d.NewValueKnown("default_node_pool.0.zones") {
+		return nil
+	}
+
+	request := resourceskus.Request{
+		ResourceType: resourceskus.ResourceTypeVirtualMachines,
+		Name:         d.Get("default_node_pool.0.vm_size").(string),
+		Location:     d.Get("location").(string),
+		Zones:        zones.ExpandUntyped(d.Get("default_node_pool.0.zones").(*pluginsdk.Set).List()),
+	}
+	request.AdditionalInstances = nodePoolAdditionalInstances(d, "default_node_pool.0.")
+
+	client := meta.(*clients.Client)
+	return resourceskus.Validate(ctx, nodePoolResourceSkuClients(client), commonids.NewSubscriptionID(client.Account.SubscriptionId), request)
+}
+
+func validateNodePoolVMSizeDiff(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !features.EnhancedValidationSkusEnabled() {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChanges("vm_size", "node_count", "min_count", "zones") {
+		return nil
+	}
+	// the Kubernetes Cluster may be created in the same apply, in which case the Location isn't available yet
+	if !d.NewValueKnown("kubernetes_cluster_id") || !d.NewValueKnown("vm_size") || !d.NewValueKnown("zones") {
+		return nil
+	}
+
+	client := meta.(*clients.Client)
+	clusterId, err := commonids.ParseKubernetesClusterID(d.Get("kubernetes_cluster_id").(string))
+	if err != nil {
+		return err
+	}
+
+	cluster, err := client.Containers.KubernetesClustersClient.Get(ctx, *clusterId)
+	if err != nil || cluster.Model == nil {
+		log.Printf("[WARN] unable to validate the Virtual Machine size for the Node Pool since %s couldn't be retrieved: %+v", *clusterId, err)
+		return nil
+	}
+
+	request := resourceskus.Request{
+		ResourceType: resourceskus.ResourceTypeVirtualMachines,
+		Name:         d.Get("vm_size").(string),
+		Location:     cluster.Model.Location,
+		Zones:        zones.ExpandUntyped(d.Get("zones").(*pluginsdk.Set).List()),
+	}
+
+	// Spot Node Pools use a separate quota
+	if d.Get("priority").(string) != string(agentpools.ScaleSetPrioritySpot) {
+		request.AdditionalInstances = nodePoolAdditionalInstances(d, "")
+	}
+
+	return resourceskus.Validate(ctx, nodePoolResourceSkuClients(client), commonids.NewSubscriptionID(client.Account.SubscriptionId), request)
+}
+
+// nodePoolAdditionalInstances returns the number of additional nodes being provisioned - which is the larger of the
+// `node_count` and `min_count` when the Node Pool is being created, or otherwise any increase in these
+func nodePoolAdditionalInstances(d *pluginsdk.ResourceDiff, prefix string) int64 {
+	nodeCount := func(old bool) int {
+		oldCount, newCount := d.GetChange(prefix + "node_count")
+		oldMinCount, newMinCount := d.GetChange(prefix + "min_count")
+		count, minCount := newCount.(int), newMinCount.(int)
+		if old {
+			count, minCount = oldCount.(int), oldMinCount.(int)
+		} else if !d.NewValueKnown(prefix + "node_count") {
+			count = 0
+		}
+
+		if minCount > count {
+			return minCount
+		}
+		return count
+	}
+
+	if d.Id() == "" {
+		return int64(nodeCount(false))
+	}
+
+	// changing the Virtual Machine size of an existing Node Pool only requires the difference
+	if d.HasChange(prefix + "vm_size") {
+		return 0
+	}
+	if additional := nodeCount(false) - nodeCount(true); additional > 0 {
+		return int64(additional)
+	}
+	return 0
+}
+
+func nodePoolResourceSkuClients(client *clients.Client) resourceskus.Clients {
+	return resourceskus.Clients{
+		SkusClient:   client.Compute.SkusClient,
+		UsagesClient: client.Compute.UsagesClient,
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/cosmos/common"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/cosmos/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/cosmos/parse"
@@ -171,6 +172,8 @@ func resourceCosmosDbAccount() *pluginsdk.Resource {
 				}
 				return nil
 			}),
+
+			sdk.ValidateNameAvailabilityDiff("name", checkCosmosDbAccountNameAvailability),
 		),
 
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
@@ -2276,3 +2279,21 @@ func prepareCapabilities(capabilities interface{}) *[]cosmosdb.Capability {
 	}
 	return &output
 }
+
+func checkCosmosDbAccountNameAvailability(ctx context.Context, client *clients.Client, name string) (*sdk.NameAvailability, error) {
+	resp, err := client.Cosmos.DatabaseClient.CheckNameExists(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("checking the availability of the CosmosDB Account name %q: %+v", name, err)
+	}
+
+	if utils.ResponseWasNotFound(resp) {
+		return &sdk.NameAvailability{
+			Available: true,
+		}, nil
+	}
+
+	return &sdk.NameAvailability{
+		Available: false,
+		Message:   "a CosmosDB Account with this name already exists",
+	}, nil
+}
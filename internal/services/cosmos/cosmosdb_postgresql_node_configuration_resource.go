@@ -83,7 +83,9 @@ func (r CosmosDbPostgreSQLNodeConfigurationResource) Create() sdk.ResourceFunc {
 
 			id := configurations.NewNodeConfigurationID(clusterId.SubscriptionId, clusterId.ResourceGroupName, clusterId.ServerGroupsv2Name, model.Name)
 
-			locks.ByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
+			if err := locks.ByNameWithContext(ctx, id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
 
 			parameters := configurations.ServerConfiguration{
@@ -113,7 +115,9 @@ func (r CosmosDbPostgreSQLNodeConfigurationResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
+			if err := locks.ByNameWithContext(ctx, id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
 
 			var model CosmosDbPostgreSQLNodeConfigurationModel
@@ -185,7 +189,9 @@ func (r CosmosDbPostgreSQLNodeConfigurationResource) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
+			if err := locks.ByNameWithContext(ctx, id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.ServerGroupsv2Name, CosmosDbPostgreSQLClusterResourceName)
 
 			resp, err := client.GetNode(ctx, *id)
@@ -126,7 +126,9 @@ func resourceCosmosDbSQLRoleDefinitionCreate(d *pluginsdk.ResourceData, meta int
 
 	id := parse.NewSqlRoleDefinitionID(subscriptionId, resourceGroup, accountName, roleDefinitionId)
 
-	locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 	existing, err := client.GetSQLRoleDefinition(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
@@ -209,7 +211,9 @@ func resourceCosmosDbSQLRoleDefinitionUpdate(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 	parameters := documentdb.SQLRoleDefinitionCreateUpdateParameters{
@@ -245,7 +249,9 @@ func resourceCosmosDbSQLRoleDefinitionDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, id.DatabaseAccountName, CosmosDbAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DatabaseAccountName, CosmosDbAccountResourceName)
 
 	future, err := client.DeleteSQLRoleDefinition(ctx, id.Name, id.ResourceGroup, id.DatabaseAccountName)
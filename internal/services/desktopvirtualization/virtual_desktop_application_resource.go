@@ -128,12 +128,14 @@ func resourceVirtualDesktopApplicationCreateUpdate(d *pluginsdk.ResourceData, me
 	applicationGroup, _ := applicationgroup.ParseApplicationGroupID(d.Get("application_group_id").(string))
 	id := application.NewApplicationID(subscriptionId, applicationGroup.ResourceGroupName, applicationGroup.ApplicationGroupName, d.Get("name").(string))
 
-	locks.ByName(id.ApplicationName, applicationType)
-	defer locks.UnlockByName(id.ApplicationName, applicationType)
-
 	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
+	if err := locks.ByNameWithContext(ctx, id.ApplicationName, applicationType); err != nil {
+		return err
+	}
+	defer locks.UnlockByName(id.ApplicationName, applicationType)
+
 	if d.IsNewResource() {
 		existing, err := client.Get(ctx, id)
 		if err != nil {
@@ -216,11 +218,13 @@ func resourceVirtualDesktopApplicationDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.ApplicationName, applicationType)
-	defer locks.UnlockByName(id.ApplicationName, applicationType)
-
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
+
+	if err := locks.ByNameWithContext(ctx, id.ApplicationName, applicationType); err != nil {
+		return err
+	}
+	defer locks.UnlockByName(id.ApplicationName, applicationType)
 	if _, err = client.Delete(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
 	}
@@ -281,7 +281,9 @@ func resourceVirtualDesktopHostPoolUpdate(d *pluginsdk.ResourceData, meta interf
 		return err
 	}
 
-	locks.ByName(id.HostPoolName, hostPoolResourceType)
+	if err := locks.ByNameWithContext(ctx, id.HostPoolName, hostPoolResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.HostPoolName, hostPoolResourceType)
 
 	payload := hostpool.HostPoolPatch{}
@@ -415,7 +417,9 @@ func resourceVirtualDesktopHostPoolDelete(d *pluginsdk.ResourceData, meta interf
 		return err
 	}
 
-	locks.ByName(id.HostPoolName, hostPoolResourceType)
+	if err := locks.ByNameWithContext(ctx, id.HostPoolName, hostPoolResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.HostPoolName, hostPoolResourceType)
 
 	options := hostpool.DeleteOperationOptions{
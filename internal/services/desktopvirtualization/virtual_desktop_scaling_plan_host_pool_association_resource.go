@@ -78,10 +78,14 @@ func resourceVirtualDesktopScalingPlanHostPoolAssociationCreate(d *pluginsdk.Res
 	}
 	associationId := parse.NewScalingPlanHostPoolAssociationId(*scalingPlanId, *hostPoolId).ID()
 
-	locks.ByName(scalingPlanId.ScalingPlanName, scalingPlanResourceType)
+	if err := locks.ByNameWithContext(ctx, scalingPlanId.ScalingPlanName, scalingPlanResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(scalingPlanId.ScalingPlanName, scalingPlanResourceType)
 
-	locks.ByName(hostPoolId.HostPoolName, hostPoolResourceType)
+	if err := locks.ByNameWithContext(ctx, hostPoolId.HostPoolName, hostPoolResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(hostPoolId.HostPoolName, hostPoolResourceType)
 
 	existing, err := client.Get(ctx, *scalingPlanId)
@@ -182,10 +186,14 @@ func resourceVirtualDesktopScalingPlanHostPoolAssociationUpdate(d *pluginsdk.Res
 		return err
 	}
 
-	locks.ByName(id.ScalingPlan.ScalingPlanName, scalingPlanResourceType)
+	if err := locks.ByNameWithContext(ctx, id.ScalingPlan.ScalingPlanName, scalingPlanResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ScalingPlan.ScalingPlanName, scalingPlanResourceType)
 
-	locks.ByName(id.HostPool.HostPoolName, hostPoolResourceType)
+	if err := locks.ByNameWithContext(ctx, id.HostPool.HostPoolName, hostPoolResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.HostPool.HostPoolName, hostPoolResourceType)
 
 	existing, err := client.Get(ctx, id.ScalingPlan)
@@ -243,10 +251,14 @@ func resourceVirtualDesktopScalingPlanHostPoolAssociationDelete(d *pluginsdk.Res
 		return err
 	}
 
-	locks.ByName(id.ScalingPlan.ScalingPlanName, scalingPlanResourceType)
+	if err := locks.ByNameWithContext(ctx, id.ScalingPlan.ScalingPlanName, scalingPlanResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ScalingPlan.ScalingPlanName, scalingPlanResourceType)
 
-	locks.ByName(id.HostPool.HostPoolName, hostPoolResourceType)
+	if err := locks.ByNameWithContext(ctx, id.HostPool.HostPoolName, hostPoolResourceType); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.HostPool.HostPoolName, hostPoolResourceType)
 
 	existing, err := client.Get(ctx, id.ScalingPlan)
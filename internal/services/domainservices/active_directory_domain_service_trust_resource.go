@@ -104,7 +104,9 @@ func (r DomainServiceTrustResource) Create() sdk.ResourceFunc {
 			id := parse.NewDomainServiceTrustID(dsid.SubscriptionId, dsid.ResourceGroup, dsid.Name, plan.Name)
 			idsdk := domainservices.NewDomainServiceID(id.SubscriptionId, id.ResourceGroup, id.DomainServiceName)
 
-			locks.ByName(id.DomainServiceName, DomainServiceResourceName)
+			if err := locks.ByNameWithContext(ctx, id.DomainServiceName, DomainServiceResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.DomainServiceName, DomainServiceResourceName)
 
 			existing, err := client.Get(ctx, idsdk)
@@ -252,7 +254,9 @@ func (r DomainServiceTrustResource) Delete() sdk.ResourceFunc {
 
 			idsdk := domainservices.NewDomainServiceID(id.SubscriptionId, id.ResourceGroup, id.DomainServiceName)
 
-			locks.ByName(id.DomainServiceName, DomainServiceResourceName)
+			if err := locks.ByNameWithContext(ctx, id.DomainServiceName, DomainServiceResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.DomainServiceName, DomainServiceResourceName)
 
 			existing, err := client.Get(ctx, idsdk)
@@ -319,7 +323,9 @@ func (r DomainServiceTrustResource) Update() sdk.ResourceFunc {
 
 			idsdk := domainservices.NewDomainServiceID(id.SubscriptionId, id.ResourceGroup, id.DomainServiceName)
 
-			locks.ByName(id.DomainServiceName, DomainServiceResourceName)
+			if err := locks.ByNameWithContext(ctx, id.DomainServiceName, DomainServiceResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.DomainServiceName, DomainServiceResourceName)
 
 			existing, err := client.Get(ctx, idsdk)
@@ -97,10 +97,14 @@ func resourceEventHubAuthorizationRuleCreateUpdate(d *pluginsdk.ResourceData, me
 		}
 	}
 
-	locks.ByName(id.EventhubName, eventHubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.EventhubName, eventHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.EventhubName, eventHubResourceName)
 
-	locks.ByName(id.NamespaceName, eventHubNamespaceResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NamespaceName, eventHubNamespaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, eventHubNamespaceResourceName)
 
 	parameters := authorizationruleseventhubs.AuthorizationRule{
@@ -196,10 +200,14 @@ func resourceEventHubAuthorizationRuleDelete(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(id.EventhubName, eventHubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.EventhubName, eventHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.EventhubName, eventHubResourceName)
 
-	locks.ByName(id.NamespaceName, eventHubNamespaceResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NamespaceName, eventHubNamespaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, eventHubNamespaceResourceName)
 
 	if resp, err := eventhubClient.DeleteAuthorizationRule(ctx, *id); err != nil {
@@ -130,7 +130,9 @@ func resourceFirewallPolicyCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 		}
 	}
 
-	locks.ByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
+	if err := locks.ByNameWithContext(ctx, id.FirewallPolicyName, AzureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
 
 	if err := client.CreateOrUpdateThenPoll(ctx, id, props); err != nil {
@@ -264,7 +266,9 @@ func resourceFirewallPolicyDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
+	if err := locks.ByNameWithContext(ctx, id.FirewallPolicyName, AzureFirewallPolicyResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
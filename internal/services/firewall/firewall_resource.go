@@ -280,17 +280,17 @@ func resourceFirewallCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 
 	m := d.Get("management_ip_configuration").([]interface{})
 	if len(m) == 1 {
-		mgmtIPConfig, mgmtSubnetName, mgmtVirtualNetworkName, err := expandFirewallIPConfigurations(m)
+		mgmtIPConfig, mgmtSubnetID, mgmtVirtualNetworkID, err := expandFirewallIPConfigurations(m)
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall Management IP Configurations: %+v", err)
 		}
 
-		if !utils.SliceContainsValue(*subnetToLock, (*mgmtSubnetName)[0]) {
-			*subnetToLock = append(*subnetToLock, (*mgmtSubnetName)[0])
+		if !utils.SliceContainsValue(*subnetToLock, (*mgmtSubnetID)[0]) {
+			*subnetToLock = append(*subnetToLock, (*mgmtSubnetID)[0])
 		}
 
-		if !utils.SliceContainsValue(*vnetToLock, (*mgmtVirtualNetworkName)[0]) {
-			*vnetToLock = append(*vnetToLock, (*mgmtVirtualNetworkName)[0])
+		if !utils.SliceContainsValue(*vnetToLock, (*mgmtVirtualNetworkID)[0]) {
+			*vnetToLock = append(*vnetToLock, (*mgmtVirtualNetworkID)[0])
 		}
 		if *mgmtIPConfig != nil {
 			if parameters.Properties.IPConfigurations != nil {
@@ -349,18 +349,26 @@ func resourceFirewallCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 
 	if policyId, ok := d.GetOk("firewall_policy_id"); ok {
 		id, _ := firewallpolicies.ParseFirewallPolicyID(policyId.(string))
-		locks.ByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
+		if err := locks.ByNameWithContext(ctx, id.FirewallPolicyName, AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
 	}
 
-	locks.ByName(id.AzureFirewallName, AzureFirewallResourceName)
+	if err := locks.ByNameWithContext(ctx, id.AzureFirewallName, AzureFirewallResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.AzureFirewallName, AzureFirewallResourceName)
 
-	locks.MultipleByName(vnetToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, vnetToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(vnetToLock)
 
-	locks.MultipleByName(subnetToLock, SubnetResourceName)
-	defer locks.UnlockMultipleByName(subnetToLock, SubnetResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, subnetToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(subnetToLock)
 
 	if !d.IsNewResource() {
 		exists, err2 := client.Get(ctx, id)
@@ -493,8 +501,8 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return fmt.Errorf("retrieving Firewall %s : %+v", *id, err)
 	}
 
-	subnetNamesToLock := make([]string, 0)
-	virtualNetworkNamesToLock := make([]string, 0)
+	subnetIDsToLock := make([]string, 0)
+	virtualNetworkIDsToLock := make([]string, 0)
 	if model := read.Model; model != nil {
 		if props := model.Properties; props != nil {
 			if configs := props.IPConfigurations; configs != nil {
@@ -508,12 +516,13 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 						return err2
 					}
 
-					if !utils.SliceContainsValue(subnetNamesToLock, parsedSubnetID.SubnetName) {
-						subnetNamesToLock = append(subnetNamesToLock, parsedSubnetID.SubnetName)
+					if !utils.SliceContainsValue(subnetIDsToLock, parsedSubnetID.ID()) {
+						subnetIDsToLock = append(subnetIDsToLock, parsedSubnetID.ID())
 					}
 
-					if !utils.SliceContainsValue(virtualNetworkNamesToLock, parsedSubnetID.VirtualNetworkName) {
-						virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, parsedSubnetID.VirtualNetworkName)
+					virtualNetworkID := commonids.NewVirtualNetworkID(parsedSubnetID.SubscriptionId, parsedSubnetID.ResourceGroupName, parsedSubnetID.VirtualNetworkName).ID()
+					if !utils.SliceContainsValue(virtualNetworkIDsToLock, virtualNetworkID) {
+						virtualNetworkIDsToLock = append(virtualNetworkIDsToLock, virtualNetworkID)
 					}
 				}
 			}
@@ -525,12 +534,13 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 						return err2
 					}
 
-					if !utils.SliceContainsValue(subnetNamesToLock, parsedSubnetID.SubnetName) {
-						subnetNamesToLock = append(subnetNamesToLock, parsedSubnetID.SubnetName)
+					if !utils.SliceContainsValue(subnetIDsToLock, parsedSubnetID.ID()) {
+						subnetIDsToLock = append(subnetIDsToLock, parsedSubnetID.ID())
 					}
 
-					if !utils.SliceContainsValue(virtualNetworkNamesToLock, parsedSubnetID.VirtualNetworkName) {
-						virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, parsedSubnetID.VirtualNetworkName)
+					virtualNetworkID := commonids.NewVirtualNetworkID(parsedSubnetID.SubscriptionId, parsedSubnetID.ResourceGroupName, parsedSubnetID.VirtualNetworkName).ID()
+					if !utils.SliceContainsValue(virtualNetworkIDsToLock, virtualNetworkID) {
+						virtualNetworkIDsToLock = append(virtualNetworkIDsToLock, virtualNetworkID)
 					}
 				}
 			}
@@ -541,18 +551,26 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 			if err != nil {
 				return err
 			}
-			locks.ByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
+			if err := locks.ByNameWithContext(ctx, id.FirewallPolicyName, AzureFirewallPolicyResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.FirewallPolicyName, AzureFirewallPolicyResourceName)
 		}
 
-		locks.ByName(id.AzureFirewallName, AzureFirewallResourceName)
+		if err := locks.ByNameWithContext(ctx, id.AzureFirewallName, AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.AzureFirewallName, AzureFirewallResourceName)
 
-		locks.MultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
-		defer locks.UnlockMultipleByName(&virtualNetworkNamesToLock, VirtualNetworkResourceName)
+		if err := locks.MultipleByIDWithContext(ctx, &virtualNetworkIDsToLock); err != nil {
+			return err
+		}
+		defer locks.UnlockMultipleByID(&virtualNetworkIDsToLock)
 
-		locks.MultipleByName(&subnetNamesToLock, SubnetResourceName)
-		defer locks.UnlockMultipleByName(&subnetNamesToLock, SubnetResourceName)
+		if err := locks.MultipleByIDWithContext(ctx, &subnetIDsToLock); err != nil {
+			return err
+		}
+		defer locks.UnlockMultipleByID(&subnetIDsToLock)
 
 		// todo see if this is still needed this way
 		/*
@@ -575,8 +593,8 @@ func resourceFirewallDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 
 func expandFirewallIPConfigurations(configs []interface{}) (*[]azurefirewalls.AzureFirewallIPConfiguration, *[]string, *[]string, error) {
 	ipConfigs := make([]azurefirewalls.AzureFirewallIPConfiguration, 0)
-	subnetNamesToLock := make([]string, 0)
-	virtualNetworkNamesToLock := make([]string, 0)
+	subnetIDsToLock := make([]string, 0)
+	virtualNetworkIDsToLock := make([]string, 0)
 
 	for _, configRaw := range configs {
 		data := configRaw.(map[string]interface{})
@@ -601,12 +619,13 @@ func expandFirewallIPConfigurations(configs []interface{}) (*[]azurefirewalls.Az
 				return nil, nil, nil, err
 			}
 
-			if !utils.SliceContainsValue(subnetNamesToLock, subnetID.SubnetName) {
-				subnetNamesToLock = append(subnetNamesToLock, subnetID.SubnetName)
+			if !utils.SliceContainsValue(subnetIDsToLock, subnetID.ID()) {
+				subnetIDsToLock = append(subnetIDsToLock, subnetID.ID())
 			}
 
-			if !utils.SliceContainsValue(virtualNetworkNamesToLock, subnetID.VirtualNetworkName) {
-				virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, subnetID.VirtualNetworkName)
+			virtualNetworkID := commonids.NewVirtualNetworkID(subnetID.SubscriptionId, subnetID.ResourceGroupName, subnetID.VirtualNetworkName).ID()
+			if !utils.SliceContainsValue(virtualNetworkIDsToLock, virtualNetworkID) {
+				virtualNetworkIDsToLock = append(virtualNetworkIDsToLock, virtualNetworkID)
 			}
 
 			ipConfig.Properties.Subnet = &azurefirewalls.SubResource{
@@ -615,7 +634,7 @@ func expandFirewallIPConfigurations(configs []interface{}) (*[]azurefirewalls.Az
 		}
 		ipConfigs = append(ipConfigs, ipConfig)
 	}
-	return &ipConfigs, &subnetNamesToLock, &virtualNetworkNamesToLock, nil
+	return &ipConfigs, &subnetIDsToLock, &virtualNetworkIDsToLock, nil
 }
 
 func flattenFirewallIPConfigurations(input *[]azurefirewalls.AzureFirewallIPConfiguration) []interface{} {
@@ -1,11 +0,0 @@
-// Copyright (c) HashiCorp, Inc.
-// SPDX-License-Identifier: MPL-2.0
-
-package firewall
-
-// TODO: determine what to do with these locking handlers, could we replace it with the ID?
-
-var (
-	SubnetResourceName         = "azurerm_subnet"
-	VirtualNetworkResourceName = "azurerm_virtual_network"
-)
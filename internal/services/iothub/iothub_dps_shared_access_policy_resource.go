@@ -123,7 +123,9 @@ func resourceIotHubDPSSharedAccessPolicyCreateUpdate(d *pluginsdk.ResourceData,
 
 	iothubDpsId := commonids.NewProvisioningServiceID(subscriptionId, d.Get("resource_group_name").(string), d.Get("iothub_dps_name").(string))
 
-	locks.ByName(iothubDpsId.ProvisioningServiceName, IothubResourceName)
+	if err := locks.ByNameWithContext(ctx, iothubDpsId.ProvisioningServiceName, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(iothubDpsId.ProvisioningServiceName, IothubResourceName)
 
 	iothubDps, err := client.Get(ctx, iothubDpsId)
@@ -266,7 +268,9 @@ func resourceIotHubDPSSharedAccessPolicyDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(id.ProvisioningServiceName, IothubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.ProvisioningServiceName, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.ProvisioningServiceName, IothubResourceName)
 
 	iothubDpsId := commonids.NewProvisioningServiceID(id.SubscriptionId, id.ResourceGroupName, id.ProvisioningServiceName)
@@ -168,7 +168,9 @@ func (r IotHubEndpointCosmosDBAccountResource) Create() sdk.ResourceFunc {
 
 			id := parse.NewEndpointCosmosDBAccountID(subscriptionId, iotHubId.ResourceGroup, iotHubId.Name, state.Name)
 
-			locks.ByName(iotHubId.Name, IothubResourceName)
+			if err := locks.ByNameWithContext(ctx, iotHubId.Name, IothubResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(iotHubId.Name, IothubResourceName)
 
 			iothub, err := client.Get(ctx, iotHubId.ResourceGroup, iotHubId.Name)
@@ -332,7 +334,9 @@ func (r IotHubEndpointCosmosDBAccountResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.IotHubName, IothubResourceName)
+			if err := locks.ByNameWithContext(ctx, id.IotHubName, IothubResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.IotHubName, IothubResourceName)
 
 			var state IotHubEndpointCosmosDBAccountModel
@@ -431,7 +435,9 @@ func (r IotHubEndpointCosmosDBAccountResource) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByName(id.IotHubName, IothubResourceName)
+			if err := locks.ByNameWithContext(ctx, id.IotHubName, IothubResourceName); err != nil {
+				return err
+			}
 			defer locks.UnlockByName(id.IotHubName, IothubResourceName)
 
 			iothub, err := client.Get(ctx, id.ResourceGroup, id.IotHubName)
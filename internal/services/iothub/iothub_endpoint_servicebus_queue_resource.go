@@ -142,7 +142,9 @@ func resourceIotHubEndpointServiceBusQueueCreateUpdate(d *pluginsdk.ResourceData
 
 	id := parse.NewEndpointServiceBusQueueID(subscriptionId, iotHubRG, iotHubName, d.Get("name").(string))
 
-	locks.ByName(iotHubName, IothubResourceName)
+	if err := locks.ByNameWithContext(ctx, iotHubName, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(iotHubName, IothubResourceName)
 
 	iothub, err := client.Get(ctx, iotHubRG, iotHubName)
@@ -324,7 +326,9 @@ func resourceIotHubEndpointServiceBusQueueDelete(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.IotHubName, IothubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.IotHubName, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.IotHubName, IothubResourceName)
 
 	iothub, err := client.Get(ctx, id.ResourceGroup, id.IotHubName)
@@ -140,7 +140,9 @@ func resourceIotHubEndpointServiceBusTopicCreateUpdate(d *pluginsdk.ResourceData
 
 	id := parse.NewEndpointServiceBusTopicID(subscriptionId, iotHubRG, iotHubName, d.Get("name").(string))
 
-	locks.ByName(iotHubName, IothubResourceName)
+	if err := locks.ByNameWithContext(ctx, iotHubName, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(iotHubName, IothubResourceName)
 
 	iothub, err := client.Get(ctx, iotHubRG, iotHubName)
@@ -322,7 +324,9 @@ func resourceIotHubEndpointServiceBusTopicDelete(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.IotHubName, IothubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.IotHubName, IothubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.IotHubName, IothubResourceName)
 
 	iothub, err := client.Get(ctx, id.ResourceGroup, id.IotHubName)
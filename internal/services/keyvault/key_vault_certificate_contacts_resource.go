@@ -143,7 +143,9 @@ func (r KeyVaultCertificateContactsResource) Create() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(id.ID())
+			if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			existing, err := client.GetCertificateContacts(ctx, *keyVaultBaseUri)
@@ -247,7 +249,9 @@ func (r KeyVaultCertificateContactsResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(id.ID())
+			if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			existing, err := client.GetCertificateContacts(ctx, id.KeyVaultBaseUrl)
@@ -291,7 +295,9 @@ func (r KeyVaultCertificateContactsResource) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(id.ID())
+			if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			if _, err := client.DeleteCertificateContacts(ctx, id.KeyVaultBaseUrl); err != nil {
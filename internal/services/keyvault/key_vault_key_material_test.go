@@ -0,0 +1,330 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package keyvault
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
+)
+
+func TestParseKeyVaultKeyMaterial(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %+v", err)
+	}
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating P-256 key: %+v", err)
+	}
+	p384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating P-384 key: %+v", err)
+	}
+	p521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating P-521 key: %+v", err)
+	}
+	p224Key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating P-224 key: %+v", err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generating Ed25519 key: %+v", err)
+	}
+
+	encodePEM := func(blockType string, bytes []byte) string {
+		return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}))
+	}
+	marshalPKCS8 := func(key interface{}) string {
+		bytes, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("marshalling PKCS#8 key: %+v", err)
+		}
+		return encodePEM("PRIVATE KEY", bytes)
+	}
+	marshalSEC1 := func(key *ecdsa.PrivateKey) string {
+		bytes, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatalf("marshalling SEC 1 key: %+v", err)
+		}
+		return encodePEM("EC PRIVATE KEY", bytes)
+	}
+	marshalJSON := func(key map[string]interface{}) string {
+		bytes, err := json.Marshal(key)
+		if err != nil {
+			t.Fatalf("marshalling JSON Web Key: %+v", err)
+		}
+		return string(bytes)
+	}
+
+	encode := func(input []byte) string {
+		return base64.RawURLEncoding.EncodeToString(input)
+	}
+	expectedRSA := &keyvault.JSONWebKey{
+		Kty: keyvault.JSONWebKeyTypeRSA,
+		N:   pointer.To(encode(rsaKey.N.Bytes())),
+		E:   pointer.To(encode(big.NewInt(int64(rsaKey.E)).Bytes())),
+		D:   pointer.To(encode(rsaKey.D.Bytes())),
+	}
+	expectedEC := func(key *ecdsa.PrivateKey, curve keyvault.JSONWebKeyCurveName) *keyvault.JSONWebKey {
+		size := (key.Curve.Params().BitSize + 7) / 8
+		return &keyvault.JSONWebKey{
+			Kty: keyvault.JSONWebKeyTypeEC,
+			Crv: curve,
+			X:   pointer.To(encode(key.X.FillBytes(make([]byte, size)))),
+			Y:   pointer.To(encode(key.Y.FillBytes(make([]byte, size)))),
+			D:   pointer.To(encode(key.D.FillBytes(make([]byte, size)))),
+		}
+	}
+
+	testData := []struct {
+		Name          string
+		Input         string
+		Expected      *keyvault.JSONWebKey
+		ExpectedError string
+	}{
+		{
+			Name:     "PKCS#1 RSA key",
+			Input:    encodePEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey)),
+			Expected: expectedRSA,
+		},
+		{
+			Name:     "PKCS#8 RSA key with surrounding whitespace",
+			Input:    "\n  " + marshalPKCS8(rsaKey) + "\n",
+			Expected: expectedRSA,
+		},
+		{
+			Name:     "PKCS#8 EC key",
+			Input:    marshalPKCS8(p384Key),
+			Expected: expectedEC(p384Key, keyvault.JSONWebKeyCurveNameP384),
+		},
+		{
+			Name:     "SEC 1 EC key",
+			Input:    marshalSEC1(p256Key),
+			Expected: expectedEC(p256Key, keyvault.JSONWebKeyCurveNameP256),
+		},
+		{
+			Name:     "SEC 1 EC key which is padded to the size of the curve",
+			Input:    marshalSEC1(p521Key),
+			Expected: expectedEC(p521Key, keyvault.JSONWebKeyCurveNameP521),
+		},
+		{
+			Name: "JSON Web Key",
+			Input: marshalJSON(map[string]interface{}{
+				"kid":     "https://example.vault.azure.net/keys/example",
+				"key_ops": []string{"sign"},
+				"kty":     "RSA",
+				"n":       *expectedRSA.N,
+				"e":       *expectedRSA.E,
+				"d":       *expectedRSA.D,
+			}),
+			Expected: expectedRSA,
+		},
+		{
+			Name:          "JSON Web Key which isn't valid JSON",
+			Input:         `{"kty": "RSA"`,
+			ExpectedError: "unmarshaling JSON Web Key",
+		},
+		{
+			Name: "JSON Web Key with an unsupported key type",
+			Input: marshalJSON(map[string]interface{}{
+				"kty": "oct",
+				"k":   "c2VjcmV0",
+			}),
+			ExpectedError: `expected a JSON Web Key with the key type "RSA" or "EC" but got "oct"`,
+		},
+		{
+			Name: "JSON Web Key without a private key",
+			Input: marshalJSON(map[string]interface{}{
+				"kty": "RSA",
+				"n":   *expectedRSA.N,
+				"e":   *expectedRSA.E,
+			}),
+			ExpectedError: "the JSON Web Key does not contain a private key",
+		},
+		{
+			Name:          "not PEM encoded",
+			Input:         "not a key",
+			ExpectedError: "expected a PEM encoded private key or a JSON Web Key",
+		},
+		{
+			Name:          "encrypted private key",
+			Input:         encodePEM("ENCRYPTED PRIVATE KEY", []byte("encrypted")),
+			ExpectedError: `unsupported PEM block type "ENCRYPTED PRIVATE KEY"`,
+		},
+		{
+			Name:          "public key",
+			Input:         encodePEM("PUBLIC KEY", []byte("public")),
+			ExpectedError: `unsupported PEM block type "PUBLIC KEY"`,
+		},
+		{
+			Name:          "invalid PKCS#1 RSA key",
+			Input:         encodePEM("RSA PRIVATE KEY", []byte("invalid")),
+			ExpectedError: "parsing RSA PRIVATE KEY",
+		},
+		{
+			Name:          "EC key using an unsupported curve",
+			Input:         marshalSEC1(p224Key),
+			ExpectedError: `unsupported elliptic curve "P-224"`,
+		},
+		{
+			Name:          "Ed25519 key",
+			Input:         marshalPKCS8(ed25519Key),
+			ExpectedError: "unsupported private key type ed25519.PrivateKey",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual, err := parseKeyVaultKeyMaterial(v.Input)
+		if v.ExpectedError != "" {
+			if err == nil || !strings.Contains(err.Error(), v.ExpectedError) {
+				t.Fatalf("expected an error containing %q but got %+v", v.ExpectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+
+		if actual.Kty != v.Expected.Kty || actual.Crv != v.Expected.Crv {
+			t.Fatalf("expected a %s key using the curve %q but got a %s key using the curve %q", v.Expected.Kty, v.Expected.Crv, actual.Kty, actual.Crv)
+		}
+		for name, values := range map[string][2]*string{
+			"N": {v.Expected.N, actual.N},
+			"E": {v.Expected.E, actual.E},
+			"D": {v.Expected.D, actual.D},
+			"X": {v.Expected.X, actual.X},
+			"Y": {v.Expected.Y, actual.Y},
+		} {
+			if expected, actual := pointer.From(values[0]), pointer.From(values[1]); expected != actual {
+				t.Fatalf("expected %s to be %q but got %q", name, expected, actual)
+			}
+		}
+		if actual.Kid != nil || actual.KeyOps != nil {
+			t.Fatalf("expected the key identifier and key operations to be removed but got %+v", actual)
+		}
+	}
+}
+
+func TestParseKeyVaultKeyMaterialRSACRTParameters(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generating RSA key: %+v", err)
+	}
+
+	actual, err := parseKeyVaultKeyMaterial(string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})))
+	if err != nil {
+		t.Fatalf("expected no error but got %+v", err)
+	}
+
+	decode := func(input *string) *big.Int {
+		if input == nil {
+			t.Fatalf("expected the CRT parameters to be set but got %+v", actual)
+		}
+		bytes, err := base64.RawURLEncoding.DecodeString(*input)
+		if err != nil {
+			t.Fatalf("decoding %q: %+v", *input, err)
+		}
+		return new(big.Int).SetBytes(bytes)
+	}
+
+	// the key must be usable once it's rebuilt from the JSON Web Key
+	rebuilt := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{
+			N: decode(actual.N),
+			E: int(decode(actual.E).Int64()),
+		},
+		D:      decode(actual.D),
+		Primes: []*big.Int{decode(actual.P), decode(actual.Q)},
+	}
+	if err := rebuilt.Validate(); err != nil {
+		t.Fatalf("expected the JSON Web Key to contain a valid RSA key but got %+v", err)
+	}
+	rebuilt.Precompute()
+	for name, values := range map[string][2]*big.Int{
+		"DP": {rebuilt.Precomputed.Dp, decode(actual.DP)},
+		"DQ": {rebuilt.Precomputed.Dq, decode(actual.DQ)},
+		"QI": {rebuilt.Precomputed.Qinv, decode(actual.QI)},
+	} {
+		if values[0].Cmp(values[1]) != 0 {
+			t.Fatalf("expected %s to be %s but got %s", name, values[0], values[1])
+		}
+	}
+}
+
+func TestExpandKeyVaultKeyImportParametersBYOKTransferBlob(t *testing.T) {
+	blob := []byte(`{"schema_version":"1.0.0","header":{"kid":"https://example.vault.azure.net/keys/kek"}}`)
+
+	testData := []struct {
+		Name          string
+		KeyType       string
+		ExpectedError string
+	}{
+		{
+			Name:    "RSA-HSM",
+			KeyType: string(keyvault.JSONWebKeyTypeRSAHSM),
+		},
+		{
+			Name:    "EC-HSM",
+			KeyType: string(keyvault.JSONWebKeyTypeECHSM),
+		},
+		{
+			Name:          "RSA",
+			KeyType:       string(keyvault.JSONWebKeyTypeRSA),
+			ExpectedError: "`byok_transfer_blob` can only be specified when `key_type` is `RSA-HSM` or `EC-HSM`",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		d := schema.TestResourceDataRaw(t, resourceKeyVaultKey().Schema, map[string]interface{}{
+			"name":               "example",
+			"key_type":           v.KeyType,
+			"key_opts":           []interface{}{"unwrapKey", "wrapKey"},
+			"byok_transfer_blob": base64.StdEncoding.EncodeToString(blob),
+		})
+
+		actual, err := expandKeyVaultKeyImportParameters(d, &keyvault.KeyAttributes{}, map[string]*string{})
+		if v.ExpectedError != "" {
+			if err == nil || !strings.Contains(err.Error(), v.ExpectedError) {
+				t.Fatalf("expected an error containing %q but got %+v", v.ExpectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+
+		if !pointer.From(actual.Hsm) {
+			t.Fatalf("expected the key to be imported into an HSM")
+		}
+		if actual.Key.Kty != keyvault.JSONWebKeyType(v.KeyType) {
+			t.Fatalf("expected the key type to be %q but got %q", v.KeyType, actual.Key.Kty)
+		}
+		if expected := base64.RawURLEncoding.EncodeToString(blob); pointer.From(actual.Key.T) != expected {
+			t.Fatalf("expected the transfer blob to be %q but got %q", expected, pointer.From(actual.Key.T))
+		}
+		if actual.Key.KeyOps == nil || strings.Join(*actual.Key.KeyOps, ",") != "unwrapKey,wrapKey" {
+			t.Fatalf("expected the key operations to be `unwrapKey` and `wrapKey` but got %+v", actual.Key.KeyOps)
+		}
+	}
+}
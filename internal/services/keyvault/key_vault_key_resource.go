@@ -10,6 +10,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"log"
@@ -129,6 +130,30 @@ func resourceKeyVaultKey() *pluginsdk.Resource {
 				ConflictsWith: []string{"key_size"},
 			},
 
+			"key_material_wo": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: pluginsdk.SuppressWriteOnlyDiff,
+				ValidateFunc:     validation.StringIsNotEmpty,
+				ConflictsWith:    []string{"byok_transfer_blob"},
+				RequiredWith:     []string{"key_material_wo_version"},
+			},
+
+			"key_material_wo_version": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"key_material_wo"},
+			},
+
+			"byok_transfer_blob": {
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ValidateFunc:  validation.StringIsBase64,
+				ConflictsWith: []string{"key_material_wo"},
+			},
+
 			"not_before_date": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
@@ -281,6 +306,18 @@ func resourceKeyVaultKey() *pluginsdk.Resource {
 				// If the new expiration date is not further, force recreation
 				return true
 			}),
+			func(ctx context.Context, diff *pluginsdk.ResourceDiff, v interface{}) error {
+				if diff.Get("byok_transfer_blob").(string) == "" {
+					return nil
+				}
+
+				keyType := keyvault.JSONWebKeyType(diff.Get("key_type").(string))
+				if keyType != keyvault.JSONWebKeyTypeRSAHSM && keyType != keyvault.JSONWebKeyTypeECHSM {
+					return fmt.Errorf("`byok_transfer_blob` can only be specified when `key_type` is `%s` or `%s`", keyvault.JSONWebKeyTypeRSAHSM, keyvault.JSONWebKeyTypeECHSM)
+				}
+
+				return nil
+			},
 		),
 	}
 }
@@ -318,8 +355,6 @@ func resourceKeyVaultKeyCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	keyOptions := expandKeyVaultKeyOptions(d)
 	t := d.Get("tags").(map[string]interface{})
 
-	// TODO: support Importing Keys once this is fixed:
-	// https://github.com/Azure/azure-rest-api-specs/issues/1747
 	parameters := keyvault.KeyCreateParameters{
 		Kty:    keyvault.JSONWebKeyType(keyType),
 		KeyOps: keyOptions,
@@ -355,7 +390,19 @@ func resourceKeyVaultKeyCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		parameters.KeyAttributes.Expires = &expirationUnixTime
 	}
 
-	if resp, err := client.CreateKey(ctx, *keyVaultBaseUri, name, parameters); err != nil {
+	// when either the write-only `key_material_wo` or the `byok_transfer_blob` is specified the Key is imported
+	importParameters, err := expandKeyVaultKeyImportParameters(d, parameters.KeyAttributes, parameters.Tags)
+	if err != nil {
+		return err
+	}
+
+	var resp keyvault.KeyBundle
+	if importParameters != nil {
+		resp, err = client.ImportKey(ctx, *keyVaultBaseUri, name, *importParameters)
+	} else {
+		resp, err = client.CreateKey(ctx, *keyVaultBaseUri, name, parameters)
+	}
+	if err != nil {
 		if meta.(*clients.Client).Features.KeyVault.RecoverSoftDeletedKeys && utils.ResponseWasConflict(resp.Response) {
 			recoveredKey, err := client.RecoverDeletedKey(ctx, *keyVaultBaseUri, name)
 			if err != nil {
@@ -378,6 +425,13 @@ func resourceKeyVaultKeyCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 				}
 				log.Printf("[DEBUG] Key %q recovered with ID: %q", name, *kid)
 			}
+
+			// the recovered Key contains the previous key material, so the key material is imported as a new version
+			if importParameters != nil {
+				if _, err := client.ImportKey(ctx, *keyVaultBaseUri, name, *importParameters); err != nil {
+					return fmt.Errorf("importing Key %q into recovered Key: %+v", name, err)
+				}
+			}
 		} else {
 			return fmt.Errorf("Creating Key: %+v", err)
 		}
@@ -461,7 +515,30 @@ func resourceKeyVaultKeyUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		parameters.KeyAttributes.Expires = &expirationUnixTime
 	}
 
-	if _, err = client.UpdateKey(ctx, id.KeyVaultBaseUrl, id.Name, "", parameters); err != nil {
+	var importParameters *keyvault.KeyImportParameters
+	if pluginsdk.HasWriteOnlyChange(d, "key_material_wo") || d.HasChange("byok_transfer_blob") {
+		importParameters, err = expandKeyVaultKeyImportParameters(d, parameters.KeyAttributes, parameters.Tags)
+		if err != nil {
+			return err
+		}
+	}
+
+	if importParameters != nil {
+		// for changing the key material we need to import a new version of the key
+		resp, err := client.ImportKey(ctx, id.KeyVaultBaseUrl, id.Name, *importParameters)
+		if err != nil {
+			return fmt.Errorf("importing new version of Key %q (Key Vault %q): %+v", id.Name, id.KeyVaultBaseUrl, err)
+		}
+		if resp.Key == nil || resp.Key.Kid == nil {
+			return fmt.Errorf("cannot read KeyVault Key '%s' (in key vault '%s')", id.Name, id.KeyVaultBaseUrl)
+		}
+
+		newId, err := parse.ParseNestedItemID(*resp.Key.Kid)
+		if err != nil {
+			return err
+		}
+		d.SetId(newId.ID())
+	} else if _, err = client.UpdateKey(ctx, id.KeyVaultBaseUrl, id.Name, "", parameters); err != nil {
 		return err
 	}
 
@@ -832,6 +909,172 @@ func flattenKeyVaultKeyRotationPolicy(input keyvault.KeyRotationPolicy) []interf
 	return []interface{}{policy}
 }
 
+// expandKeyVaultKeyImportParameters returns the parameters used to import either the write-only `key_material_wo` or
+// the `byok_transfer_blob` into the Key Vault, or nil when neither is specified
+func expandKeyVaultKeyImportParameters(d *pluginsdk.ResourceData, attributes *keyvault.KeyAttributes, keyTags map[string]*string) (*keyvault.KeyImportParameters, error) {
+	keyType := keyvault.JSONWebKeyType(d.Get("key_type").(string))
+	hsm := keyType == keyvault.JSONWebKeyTypeRSAHSM || keyType == keyvault.JSONWebKeyTypeECHSM
+
+	var key *keyvault.JSONWebKey
+	if v := d.Get("byok_transfer_blob").(string); v != "" {
+		if !hsm {
+			return nil, fmt.Errorf("`byok_transfer_blob` can only be specified when `key_type` is `%s` or `%s`", keyvault.JSONWebKeyTypeRSAHSM, keyvault.JSONWebKeyTypeECHSM)
+		}
+
+		blob, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("decoding `byok_transfer_blob`: %+v", err)
+		}
+
+		// the transfer blob is generated (and encrypted using the Key Exchange Key) by the on-premises HSM
+		// so the key type is the HSM-backed key type, rather than the type of the key material
+		key = &keyvault.JSONWebKey{
+			Kty: keyType,
+			T:   pointer.To(base64.RawURLEncoding.EncodeToString(blob)),
+		}
+	} else {
+		material, err := pluginsdk.GetWriteOnlyString(d, "key_material_wo")
+		if err != nil {
+			return nil, err
+		}
+		if material == "" {
+			return nil, nil
+		}
+
+		key, err = parseKeyVaultKeyMaterial(material)
+		if err != nil {
+			return nil, fmt.Errorf("parsing `key_material_wo`: %+v", err)
+		}
+
+		if key.Kty != keyvault.JSONWebKeyType(strings.TrimSuffix(string(keyType), "-HSM")) {
+			return nil, fmt.Errorf("`key_material_wo` contains an %s key but `key_type` is `%s`", key.Kty, keyType)
+		}
+
+		if v, ok := d.GetOk("key_size"); ok && key.N != nil {
+			nBytes, err := base64.RawURLEncoding.DecodeString(*key.N)
+			if err != nil {
+				return nil, fmt.Errorf("decoding N: %+v", err)
+			}
+			if size := new(big.Int).SetBytes(nBytes).BitLen(); size != v.(int) {
+				return nil, fmt.Errorf("`key_material_wo` contains a %d bit key but `key_size` is %d", size, v.(int))
+			}
+		}
+
+		if v, ok := d.GetOk("curve"); ok && key.Crv != "" && !strings.EqualFold(string(key.Crv), v.(string)) {
+			return nil, fmt.Errorf("`key_material_wo` contains a key using the curve %q but `curve` is %q", key.Crv, v.(string))
+		}
+	}
+
+	keyOptions := make([]string, 0)
+	for _, option := range *expandKeyVaultKeyOptions(d) {
+		keyOptions = append(keyOptions, string(option))
+	}
+	key.KeyOps = &keyOptions
+
+	return &keyvault.KeyImportParameters{
+		Hsm:           utils.Bool(hsm),
+		Key:           key,
+		KeyAttributes: attributes,
+		Tags:          keyTags,
+	}, nil
+}
+
+// parseKeyVaultKeyMaterial parses an RSA or EC private key which is either PEM encoded (as PKCS#1, PKCS#8 or SEC 1)
+// or a JSON Web Key into a JSON Web Key which can be imported into the Key Vault
+func parseKeyVaultKeyMaterial(input string) (*keyvault.JSONWebKey, error) {
+	input = strings.TrimSpace(input)
+
+	if strings.HasPrefix(input, "{") {
+		var key keyvault.JSONWebKey
+		if err := json.Unmarshal([]byte(input), &key); err != nil {
+			return nil, fmt.Errorf("unmarshaling JSON Web Key: %+v", err)
+		}
+
+		if key.Kty != keyvault.JSONWebKeyTypeRSA && key.Kty != keyvault.JSONWebKeyTypeEC {
+			return nil, fmt.Errorf("expected a JSON Web Key with the key type %q or %q but got %q", keyvault.JSONWebKeyTypeRSA, keyvault.JSONWebKeyTypeEC, key.Kty)
+		}
+		if key.D == nil || *key.D == "" {
+			return nil, fmt.Errorf("the JSON Web Key does not contain a private key")
+		}
+
+		// the key identifier and key operations are determined by the resource rather than the key material
+		key.Kid = nil
+		key.KeyOps = nil
+
+		return &key, nil
+	}
+
+	block, _ := pem.Decode([]byte(input))
+	if block == nil {
+		return nil, fmt.Errorf("expected a PEM encoded private key or a JSON Web Key")
+	}
+
+	var privateKey interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q, expected an unencrypted `RSA PRIVATE KEY`, `EC PRIVATE KEY` or `PRIVATE KEY`", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %+v", block.Type, err)
+	}
+
+	encode := func(input []byte) *string {
+		return pointer.To(base64.RawURLEncoding.EncodeToString(input))
+	}
+
+	switch key := privateKey.(type) {
+	case *rsa.PrivateKey:
+		if len(key.Primes) != 2 {
+			return nil, fmt.Errorf("multi-prime RSA keys are not supported")
+		}
+		key.Precompute()
+
+		return &keyvault.JSONWebKey{
+			Kty: keyvault.JSONWebKeyTypeRSA,
+			N:   encode(key.N.Bytes()),
+			E:   encode(big.NewInt(int64(key.E)).Bytes()),
+			D:   encode(key.D.Bytes()),
+			P:   encode(key.Primes[0].Bytes()),
+			Q:   encode(key.Primes[1].Bytes()),
+			DP:  encode(key.Precomputed.Dp.Bytes()),
+			DQ:  encode(key.Precomputed.Dq.Bytes()),
+			QI:  encode(key.Precomputed.Qinv.Bytes()),
+		}, nil
+
+	case *ecdsa.PrivateKey:
+		var curve keyvault.JSONWebKeyCurveName
+		switch key.Curve {
+		case elliptic.P256():
+			curve = keyvault.JSONWebKeyCurveNameP256
+		case elliptic.P384():
+			curve = keyvault.JSONWebKeyCurveNameP384
+		case elliptic.P521():
+			curve = keyvault.JSONWebKeyCurveNameP521
+		default:
+			return nil, fmt.Errorf("unsupported elliptic curve %q", key.Curve.Params().Name)
+		}
+
+		// the coordinates and private key must be padded to the size of the curve
+		size := (key.Curve.Params().BitSize + 7) / 8
+		return &keyvault.JSONWebKey{
+			Kty: keyvault.JSONWebKeyTypeEC,
+			Crv: curve,
+			X:   encode(key.X.FillBytes(make([]byte, size))),
+			Y:   encode(key.Y.FillBytes(make([]byte, size))),
+			D:   encode(key.D.FillBytes(make([]byte, size))),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported private key type %T, expected an RSA or EC private key", privateKey)
+}
+
 // Credit to Hashicorp modified from https://github.com/hashicorp/terraform-provider-tls/blob/v3.1.0/internal/provider/util.go#L79-L105
 func readPublicKey(d *pluginsdk.ResourceData, pubKey interface{}) error {
 	pubKeyBytes, err := x509.MarshalPKIXPublicKey(pubKey)
@@ -397,6 +397,64 @@ func TestAccKeyVaultKey_RotationPolicyUnauthorized(t *testing.T) {
 	})
 }
 
+func TestAccKeyVaultKey_importRSAKeyMaterial(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_key", "test")
+	r := KeyVaultKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.importRSAKeyMaterial(data, "first", 1),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("key_material_wo").DoesNotExist(),
+				check.That(data.ResourceName).Key("key_material_wo_version").HasValue("1"),
+				check.That(data.ResourceName).Key("public_key_pem").MatchesOtherKey(check.That("tls_private_key.first").Key("public_key_pem")),
+			),
+		},
+		data.ImportStep("key_size", "key_vault_id", "key_material_wo", "key_material_wo_version"),
+		{
+			Config: r.importRSAKeyMaterial(data, "second", 2),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("key_material_wo").DoesNotExist(),
+				check.That(data.ResourceName).Key("key_material_wo_version").HasValue("2"),
+				check.That(data.ResourceName).Key("public_key_pem").MatchesOtherKey(check.That("tls_private_key.second").Key("public_key_pem")),
+			),
+		},
+		data.ImportStep("key_size", "key_vault_id", "key_material_wo", "key_material_wo_version"),
+	})
+}
+
+func TestAccKeyVaultKey_importECHSMKeyMaterial(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_key", "test")
+	r := KeyVaultKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.importECHSMKeyMaterial(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("key_type").HasValue("EC-HSM"),
+				check.That(data.ResourceName).Key("curve").HasValue("P-384"),
+				check.That(data.ResourceName).Key("public_key_pem").MatchesOtherKey(check.That("tls_private_key.test").Key("public_key_pem")),
+			),
+		},
+		data.ImportStep("key_vault_id", "key_material_wo", "key_material_wo_version"),
+	})
+}
+
+func TestAccKeyVaultKey_byokTransferBlobRequiresHSMKeyType(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_key_vault_key", "test")
+	r := KeyVaultKeyResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.byokTransferBlobRSA(data),
+			ExpectError: regexp.MustCompile("`byok_transfer_blob` can only be specified when `key_type` is `RSA-HSM` or `EC-HSM`"),
+		},
+	})
+}
+
 func (r KeyVaultKeyResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	client := clients.KeyVault
 	subscriptionId := clients.Account.SubscriptionId
@@ -913,6 +971,7 @@ resource "azurerm_key_vault" "test" {
       "Create",
       "Delete",
       "Get",
+      "Import",
       "Purge",
       "Recover",
       "Update",
@@ -1117,3 +1176,98 @@ resource "azurerm_key_vault_key" "test" {
 }
 `, r.template(data, "standard"), data.RandomString)
 }
+
+func (r KeyVaultKeyResource) importRSAKeyMaterial(data acceptance.TestData, keyName string, version int) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "tls_private_key" "first" {
+  algorithm = "RSA"
+  rsa_bits  = 2048
+}
+
+resource "tls_private_key" "second" {
+  algorithm = "RSA"
+  rsa_bits  = 2048
+}
+
+resource "azurerm_key_vault_key" "test" {
+  name                    = "key-%s"
+  key_vault_id            = azurerm_key_vault.test.id
+  key_type                = "RSA"
+  key_size                = 2048
+  key_material_wo         = tls_private_key.%s.private_key_pem
+  key_material_wo_version = %d
+
+  key_opts = [
+    "decrypt",
+    "encrypt",
+    "sign",
+    "unwrapKey",
+    "verify",
+    "wrapKey",
+  ]
+
+  rotation_policy {
+    expire_after         = "P90D"
+    notify_before_expiry = "P29D"
+  }
+}
+`, r.templateStandard(data), data.RandomString, keyName, version)
+}
+
+func (r KeyVaultKeyResource) importECHSMKeyMaterial(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "tls_private_key" "test" {
+  algorithm   = "ECDSA"
+  ecdsa_curve = "P384"
+}
+
+resource "azurerm_key_vault_key" "test" {
+  name                    = "key-%s"
+  key_vault_id            = azurerm_key_vault.test.id
+  key_type                = "EC-HSM"
+  curve                   = "P-384"
+  key_material_wo         = tls_private_key.test.private_key_pem_pkcs8
+  key_material_wo_version = 1
+
+  key_opts = [
+    "sign",
+    "verify",
+  ]
+}
+`, r.templatePremium(data), data.RandomString)
+}
+
+func (r KeyVaultKeyResource) byokTransferBlobRSA(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_key_vault_key" "test" {
+  name               = "key-%s"
+  key_vault_id       = azurerm_key_vault.test.id
+  key_type           = "RSA"
+  key_size           = 2048
+  byok_transfer_blob = base64encode("{}")
+
+  key_opts = [
+    "decrypt",
+    "encrypt",
+  ]
+}
+`, r.templateStandard(data), data.RandomString)
+}
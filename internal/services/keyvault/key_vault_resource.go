@@ -23,9 +23,9 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/set"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
@@ -61,6 +61,8 @@ func resourceKeyVault() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(sdk.ValidateNameAvailabilityDiff("name", checkKeyVaultNameAvailability)),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -258,7 +260,9 @@ func resourceKeyVaultCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	// Locking this resource so we don't make modifications to it at the same time if there is a
 	// key vault access policy trying to update it as well
-	locks.ByName(id.VaultName, keyVaultResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VaultName, keyVaultResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VaultName, keyVaultResourceName)
 
 	isPublic := d.Get("public_network_access_enabled").(bool)
@@ -368,19 +372,22 @@ func resourceKeyVaultCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	}
 
 	// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-	virtualNetworkNames := make([]string, 0)
+	virtualNetworkIds := make([]string, 0)
 	for _, v := range subnetIds {
 		id, err := commonids.ParseSubnetIDInsensitively(v)
 		if err != nil {
 			return err
 		}
-		if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
-			virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
+		virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName).ID()
+		if !utils.SliceContainsValue(virtualNetworkIds, virtualNetworkId) {
+			virtualNetworkIds = append(virtualNetworkIds, virtualNetworkId)
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, &virtualNetworkIds); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(&virtualNetworkIds)
 
 	if err := client.CreateOrUpdateThenPoll(ctx, id, parameters); err != nil {
 		return fmt.Errorf("creating %s: %+v", id, err)
@@ -468,7 +475,9 @@ func resourceKeyVaultUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 
 	// Locking this resource so we don't make modifications to it at the same time if there is a
 	// key vault access policy trying to update it as well
-	locks.ByName(id.VaultName, keyVaultResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VaultName, keyVaultResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VaultName, keyVaultResourceName)
 
 	d.Partial(true)
@@ -537,20 +546,23 @@ func resourceKeyVaultUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		networkAcls, subnetIds := expandKeyVaultNetworkAcls(networkAclsRaw)
 
 		// also lock on the Virtual Network ID's since modifications in the networking stack are exclusive
-		virtualNetworkNames := make([]string, 0)
+		virtualNetworkIds := make([]string, 0)
 		for _, v := range subnetIds {
 			id, err := commonids.ParseSubnetIDInsensitively(v)
 			if err != nil {
 				return err
 			}
 
-			if !utils.SliceContainsValue(virtualNetworkNames, id.VirtualNetworkName) {
-				virtualNetworkNames = append(virtualNetworkNames, id.VirtualNetworkName)
+			virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName).ID()
+			if !utils.SliceContainsValue(virtualNetworkIds, virtualNetworkId) {
+				virtualNetworkIds = append(virtualNetworkIds, virtualNetworkId)
 			}
 		}
 
-		locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-		defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+		if err := locks.MultipleByIDWithContext(ctx, &virtualNetworkIds); err != nil {
+			return err
+		}
+		defer locks.UnlockMultipleByID(&virtualNetworkIds)
 
 		update.Properties.NetworkAcls = networkAcls
 	}
@@ -801,7 +813,9 @@ func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VaultName, keyVaultResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VaultName, keyVaultResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VaultName, keyVaultResourceName)
 
 	read, err := client.Get(ctx, *id)
@@ -812,7 +826,7 @@ func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 	location := ""
 	purgeProtectionEnabled := false
 	softDeleteEnabled := false
-	virtualNetworkNames := make([]string, 0)
+	virtualNetworkIds := make([]string, 0)
 	if model := read.Model; model != nil {
 		if model.Location != nil {
 			location = *model.Location
@@ -835,16 +849,19 @@ func resourceKeyVaultDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 						return err
 					}
 
-					if !utils.SliceContainsValue(virtualNetworkNames, subnetId.VirtualNetworkName) {
-						virtualNetworkNames = append(virtualNetworkNames, subnetId.VirtualNetworkName)
+					virtualNetworkId := commonids.NewVirtualNetworkID(subnetId.SubscriptionId, subnetId.ResourceGroupName, subnetId.VirtualNetworkName).ID()
+					if !utils.SliceContainsValue(virtualNetworkIds, virtualNetworkId) {
+						virtualNetworkIds = append(virtualNetworkIds, virtualNetworkId)
 					}
 				}
 			}
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, &virtualNetworkIds); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(&virtualNetworkIds)
 
 	if _, err := client.Delete(ctx, *id); err != nil {
 		return fmt.Errorf("retrieving %s: %+v", *id, err)
@@ -1093,3 +1110,51 @@ func getSoftDeletedStateForKeyVault(ctx context.Context, client *vaults.VaultsCl
 	// otherwise we've found an existing key vault that is not soft deleted
 	return nil, nil
 }
+
+func checkKeyVaultNameAvailability(ctx context.Context, client *clients.Client, name string) (*sdk.NameAvailability, error) {
+	vaultsClient := client.KeyVault.VaultsClient
+	subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+	input := vaults.VaultCheckNameAvailabilityParameters{
+		Name: name,
+		Type: vaults.TypeMicrosoftPointKeyVaultVaults,
+	}
+	resp, err := vaultsClient.CheckNameAvailability(ctx, subscriptionId, input)
+	if err != nil {
+		return nil, fmt.Errorf("checking the availability of the Key Vault name %q: %+v", name, err)
+	}
+	if resp.Model == nil || resp.Model.NameAvailable == nil {
+		return nil, fmt.Errorf("checking the availability of the Key Vault name %q: `model` was nil", name)
+	}
+
+	if !*resp.Model.NameAvailable && pointer.From(resp.Model.Reason) == vaults.ReasonAlreadyExists {
+		// a Soft-Deleted Key Vault within this Subscription is recovered during the apply, but only when this is
+		// enabled in the Features block - otherwise the apply fails
+		deleted, err := vaultsClient.ListDeletedComplete(ctx, subscriptionId)
+		if err != nil {
+			return nil, fmt.Errorf("listing Soft-Deleted Key Vaults within %s: %+v", subscriptionId, err)
+		}
+		for _, v := range deleted.Items {
+			if !strings.EqualFold(pointer.From(v.Name), name) {
+				continue
+			}
+			if !client.Features.KeyVault.RecoverSoftDeletedKeyVaults {
+				location := ""
+				if v.Properties != nil {
+					location = pointer.From(v.Properties.Location)
+				}
+				return &sdk.NameAvailability{
+					Available: false,
+					Message:   optedOutOfRecoveringSoftDeletedKeyVaultErrorFmt(name, location),
+				}, nil
+			}
+			return &sdk.NameAvailability{
+				Available: true,
+			}, nil
+		}
+	}
+
+	return &sdk.NameAvailability{
+		Available: *resp.Model.NameAvailable,
+		Message:   pointer.From(resp.Model.Message),
+	}, nil
+}
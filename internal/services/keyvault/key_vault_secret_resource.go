@@ -56,9 +56,26 @@ func resourceKeyVaultSecret() *pluginsdk.Resource {
 			"key_vault_id": commonschema.ResourceIDReferenceRequiredForceNew(&commonids.KeyVaultId{}),
 
 			"value": {
-				Type:      pluginsdk.TypeString,
-				Required:  true,
-				Sensitive: true,
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"value", "value_wo"},
+			},
+
+			"value_wo": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: pluginsdk.SuppressWriteOnlyDiff,
+				ExactlyOneOf:     []string{"value", "value_wo"},
+				RequiredWith:     []string{"value_wo_version"},
+			},
+
+			"value_wo_version": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"value_wo"},
 			},
 
 			"content_type": {
@@ -133,7 +150,10 @@ func resourceKeyVaultSecretCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		return tf.ImportAsExistsError("azurerm_key_vault_secret", *existing.ID)
 	}
 
-	value := d.Get("value").(string)
+	value, err := expandKeyVaultSecretValue(d)
+	if err != nil {
+		return err
+	}
 	contentType := d.Get("content_type").(string)
 	t := d.Get("tags").(map[string]interface{})
 
@@ -242,7 +262,6 @@ func resourceKeyVaultSecretUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		return nil
 	}
 
-	value := d.Get("value").(string)
 	contentType := d.Get("content_type").(string)
 	t := d.Get("tags").(map[string]interface{})
 
@@ -260,7 +279,12 @@ func resourceKeyVaultSecretUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		secretAttributes.Expires = &expirationUnixTime
 	}
 
-	if d.HasChange("value") {
+	if d.HasChange("value") || pluginsdk.HasWriteOnlyChange(d, "value_wo") {
+		value, err := expandKeyVaultSecretValue(d)
+		if err != nil {
+			return err
+		}
+
 		// for changing the value of the secret we need to create a new version
 		parameters := keyvault.SecretSetParameters{
 			Value:            utils.String(value),
@@ -356,7 +380,10 @@ func resourceKeyVaultSecretRead(d *pluginsdk.ResourceData, meta interface{}) err
 	}
 
 	d.Set("name", respID.Name)
-	d.Set("value", resp.Value)
+	// when the write-only `value_wo` is used the value is intentionally not persisted into the state
+	if _, ok := d.GetOk("value_wo_version"); !ok {
+		d.Set("value", resp.Value)
+	}
 	d.Set("version", respID.Version)
 	d.Set("content_type", resp.ContentType)
 	d.Set("versionless_id", id.VersionlessID())
@@ -457,3 +484,16 @@ func (d deleteAndPurgeSecret) NestedItemHasBeenPurged(ctx context.Context) (auto
 	resp, err := d.client.GetDeletedSecret(ctx, d.keyVaultUri, d.name)
 	return resp.Response, err
 }
+
+func expandKeyVaultSecretValue(d *pluginsdk.ResourceData) (string, error) {
+	value, err := pluginsdk.GetWriteOnlyString(d, "value_wo")
+	if err != nil {
+		return "", err
+	}
+
+	if value == "" {
+		value = d.Get("value").(string)
+	}
+
+	return value, nil
+}
@@ -291,7 +291,9 @@ func resourceKustoClusterCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		return tf.ImportAsExistsError("azurerm_kusto_cluster", id.ID())
 	}
 
-	locks.ByName(id.KustoClusterName, "azurerm_kusto_cluster")
+	if err := locks.ByNameWithContext(ctx, id.KustoClusterName, "azurerm_kusto_cluster"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.KustoClusterName, "azurerm_kusto_cluster")
 
 	sku, err := expandKustoClusterSku(d.Get("sku").([]interface{}))
@@ -403,7 +405,9 @@ func resourceKustoClusterUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.KustoClusterName, "azurerm_kusto_cluster")
+	if err := locks.ByNameWithContext(ctx, id.KustoClusterName, "azurerm_kusto_cluster"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.KustoClusterName, "azurerm_kusto_cluster")
 
 	existing, err := client.Get(ctx, *id)
@@ -722,7 +722,9 @@ func resourceVirtualMachineCreateUpdate(d *pluginsdk.ResourceData, meta interfac
 		vm.Plan = expandAzureRmVirtualMachinePlan(d)
 	}
 
-	locks.ByName(id.VirtualMachineName, compute2.VirtualMachineResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualMachineName, compute2.VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualMachineName, compute2.VirtualMachineResourceName)
 
 	if err := client.CreateOrUpdateThenPoll(ctx, id, vm, virtualmachines.DefaultCreateOrUpdateOperationOptions()); err != nil {
@@ -906,7 +908,9 @@ func resourceVirtualMachineDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.VirtualMachineName, compute2.VirtualMachineResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VirtualMachineName, compute2.VirtualMachineResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualMachineName, compute2.VirtualMachineResourceName)
 
 	virtualMachine, err := client.Get(ctx, *id, virtualmachines.DefaultGetOperationOptions())
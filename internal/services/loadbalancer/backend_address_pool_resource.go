@@ -179,10 +179,14 @@ func resourceArmLoadBalancerBackendAddressPoolCreateUpdate(d *pluginsdk.Resource
 		}
 	}
 
-	locks.ByName(name, backendAddressPoolResourceName)
+	if err := locks.ByNameWithContext(ctx, name, backendAddressPoolResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(name, backendAddressPoolResourceName)
 
-	locks.ByID(loadBalancerId.ID())
+	if err := locks.ByIDWithContext(ctx, loadBalancerId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(loadBalancerId.ID())
 
 	plbId := loadbalancers.ProviderLoadBalancerId{SubscriptionId: loadBalancerId.SubscriptionId, ResourceGroupName: loadBalancerId.ResourceGroupName, LoadBalancerName: loadBalancerId.LoadBalancerName}
@@ -399,10 +403,14 @@ func resourceArmLoadBalancerBackendAddressPoolDelete(d *pluginsdk.ResourceData,
 
 	loadBalancerId := loadbalancers.NewLoadBalancerID(id.SubscriptionId, id.ResourceGroupName, id.LoadBalancerName)
 	loadBalancerID := loadBalancerId.ID()
-	locks.ByID(loadBalancerID)
+	if err := locks.ByIDWithContext(ctx, loadBalancerID); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(loadBalancerID)
 
-	locks.ByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
+	if err := locks.ByNameWithContext(ctx, id.BackendAddressPoolName, backendAddressPoolResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.BackendAddressPoolName, backendAddressPoolResourceName)
 
 	plbId := loadbalancers.ProviderLoadBalancerId{SubscriptionId: id.SubscriptionId, ResourceGroupName: id.ResourceGroupName, LoadBalancerName: id.LoadBalancerName}
@@ -105,7 +105,9 @@ func (r LogAnalyticsClusterResource) Create() sdk.ResourceFunc {
 
 			id := clusters.NewClusterID(subscriptionId, config.ResourceGroupName, config.Name)
 
-			locks.ByID(id.ID())
+			if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			existing, err := client.Get(ctx, id)
@@ -213,7 +215,9 @@ func (r LogAnalyticsClusterResource) Update() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(id.ID())
+			if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			resp, err := client.Get(ctx, *id)
@@ -258,7 +262,9 @@ func (r LogAnalyticsClusterResource) Delete() sdk.ResourceFunc {
 				return err
 			}
 
-			locks.ByID(id.ID())
+			if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(id.ID())
 
 			err = client.DeleteThenPoll(ctx, *id)
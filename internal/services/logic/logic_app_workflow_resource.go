@@ -382,7 +382,9 @@ func resourceLogicAppWorkflowUpdate(d *pluginsdk.ResourceData, meta interface{})
 	}
 
 	// lock to prevent against Actions, Parameters or Triggers conflicting
-	locks.ByName(id.WorkflowName, logicAppResourceName)
+	if err := locks.ByNameWithContext(ctx, id.WorkflowName, logicAppResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkflowName, logicAppResourceName)
 
 	read, err := client.Get(ctx, *id)
@@ -588,7 +590,9 @@ func resourceLogicAppWorkflowDelete(d *pluginsdk.ResourceData, meta interface{})
 	}
 
 	// lock to prevent against Actions, Parameters or Triggers conflicting
-	locks.ByName(id.WorkflowName, logicAppResourceName)
+	if err := locks.ByNameWithContext(ctx, id.WorkflowName, logicAppResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.WorkflowName, logicAppResourceName)
 
 	resp, err := client.Delete(ctx, *id)
@@ -83,15 +83,31 @@ func resourceMsSqlServer() *pluginsdk.Resource {
 				Computed:     true,
 				ForceNew:     true,
 				AtLeastOneOf: []string{"administrator_login", "azuread_administrator.0.azuread_authentication_only"},
-				RequiredWith: []string{"administrator_login", "administrator_login_password"},
 			},
 
 			"administrator_login_password": {
-				Type:         pluginsdk.TypeString,
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				AtLeastOneOf:  []string{"administrator_login_password", "administrator_login_password_wo", "azuread_administrator.0.azuread_authentication_only"},
+				RequiredWith:  []string{"administrator_login"},
+				ConflictsWith: []string{"administrator_login_password_wo"},
+			},
+
+			"administrator_login_password_wo": {
+				Type:             pluginsdk.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: pluginsdk.SuppressWriteOnlyDiff,
+				RequiredWith:     []string{"administrator_login", "administrator_login_password_wo_version"},
+				ConflictsWith:    []string{"administrator_login_password"},
+			},
+
+			"administrator_login_password_wo_version": {
+				Type:         pluginsdk.TypeInt,
 				Optional:     true,
-				Sensitive:    true,
-				AtLeastOneOf: []string{"administrator_login_password", "azuread_administrator.0.azuread_authentication_only"},
-				RequiredWith: []string{"administrator_login", "administrator_login_password"},
+				ValidateFunc: validation.IntAtLeast(1),
+				RequiredWith: []string{"administrator_login_password_wo"},
 			},
 
 			"azuread_administrator": {
@@ -200,6 +216,8 @@ func resourceMsSqlServer() *pluginsdk.Resource {
 			pluginsdk.CustomizeDiffShim(msSqlMinimumTLSVersionDiff),
 
 			pluginsdk.CustomizeDiffShim(msSqlPasswordChangeWhenAADAuthOnly),
+
+			pluginsdk.CustomizeDiffShim(msSqlAdministratorLoginPasswordRequired),
 		),
 	}
 }
@@ -241,8 +259,17 @@ func resourceMsSqlServerCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		props.Properties.AdministratorLogin = utils.String(v.(string))
 	}
 
-	if v := d.Get("administrator_login_password"); v.(string) != "" {
-		props.Properties.AdministratorLoginPassword = utils.String(v.(string))
+	adminPassword, err := expandMsSqlServerAdministratorLoginPassword(d)
+	if err != nil {
+		return err
+	}
+
+	if adminPassword != "" {
+		props.Properties.AdministratorLoginPassword = utils.String(adminPassword)
+	}
+
+	if props.Properties.AdministratorLogin != nil && props.Properties.AdministratorLoginPassword == nil {
+		return fmt.Errorf("one of `administrator_login_password` or `administrator_login_password_wo` must be specified when `administrator_login` is set")
 	}
 
 	// NOTE: You must set the admin before setting the values of the admin...
@@ -372,8 +399,11 @@ func resourceMsSqlServerUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 			payload.Properties.RestrictOutboundNetworkAccess = pointer.To(servers.ServerNetworkAccessFlagEnabled)
 		}
 
-		if d.HasChange("administrator_login_password") {
-			adminPassword := d.Get("administrator_login_password").(string)
+		if d.HasChange("administrator_login_password") || pluginsdk.HasWriteOnlyChange(d, "administrator_login_password_wo") {
+			adminPassword, err := expandMsSqlServerAdministratorLoginPassword(d)
+			if err != nil {
+				return err
+			}
 			payload.Properties.AdministratorLoginPassword = pointer.To(adminPassword)
 		}
 
@@ -707,5 +737,43 @@ func msSqlPasswordChangeWhenAADAuthOnly(ctx context.Context, d *pluginsdk.Resour
 	if old.(bool) && d.HasChange("administrator_login_password") {
 		err = fmt.Errorf("`administrator_login_password` cannot be changed once `azuread_administrator.0.azuread_authentication_only = true`")
 	}
+	if old.(bool) && d.HasChange("administrator_login_password_wo_version") {
+		err = fmt.Errorf("`administrator_login_password_wo` cannot be changed once `azuread_administrator.0.azuread_authentication_only = true`")
+	}
 	return
 }
+
+// msSqlAdministratorLoginPasswordRequired ensures that a password is specified alongside `administrator_login` at plan
+// time - since the value of `administrator_login_password_wo` is never in the Plan this is checked against the raw config.
+func msSqlAdministratorLoginPasswordRequired(ctx context.Context, d *pluginsdk.ResourceDiff, _ interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+
+	login := config.GetAttr("administrator_login")
+	if login.IsNull() {
+		return nil
+	}
+
+	password := config.GetAttr("administrator_login_password")
+	passwordWriteOnly := config.GetAttr("administrator_login_password_wo")
+	if password.IsNull() && passwordWriteOnly.IsNull() {
+		return fmt.Errorf("one of `administrator_login_password` or `administrator_login_password_wo` must be specified when `administrator_login` is set")
+	}
+
+	return nil
+}
+
+func expandMsSqlServerAdministratorLoginPassword(d *pluginsdk.ResourceData) (string, error) {
+	adminPassword, err := pluginsdk.GetWriteOnlyString(d, "administrator_login_password_wo")
+	if err != nil {
+		return "", err
+	}
+
+	if adminPassword == "" {
+		adminPassword = d.Get("administrator_login_password").(string)
+	}
+
+	return adminPassword, nil
+}
@@ -69,7 +69,9 @@ func resourceIpGroupCidrCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 	}
 	id := parse.NewIpGroupCidrID(subscriptionId, ipGroupId.ResourceGroupName, ipGroupId.IpGroupName, cidrName)
 
-	locks.ByID(ipGroupId.ID())
+	if err := locks.ByIDWithContext(ctx, ipGroupId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(ipGroupId.ID())
 
 	existing, err := client.Get(ctx, *ipGroupId, ipgroups.DefaultGetOperationOptions())
@@ -165,7 +167,9 @@ func resourceIpGroupCidrDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 	cidr := d.Get("cidr").(string)
 	ipGroupId := ipgroups.NewIPGroupID(id.SubscriptionId, id.ResourceGroup, id.IpGroupName)
 
-	locks.ByID(ipGroupId.ID())
+	if err := locks.ByIDWithContext(ctx, ipGroupId.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(ipGroupId.ID())
 
 	existing, err := client.Get(ctx, ipGroupId, ipgroups.DefaultGetOperationOptions())
@@ -98,7 +98,9 @@ func resourceIpGroupCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall ID %q: %+v", fw, err)
 		}
-		locks.ByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
+		if err := locks.ByNameWithContext(ctx, id.AzureFirewallName, firewall.AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
 	}
 
@@ -107,13 +109,17 @@ func resourceIpGroupCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall Policy ID %q: %+v", fwpol, err)
 		}
-		locks.ByName(id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
+		if err := locks.ByNameWithContext(ctx, id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
 	}
 
 	id := ipgroups.NewIPGroupID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByID(id.ID())
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	existing, err := client.Get(ctx, id, ipgroups.DefaultGetOperationOptions())
@@ -215,7 +221,9 @@ func resourceIpGroupUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall ID %q: %+v", fw, err)
 		}
-		locks.ByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
+		if err := locks.ByNameWithContext(ctx, id.AzureFirewallName, firewall.AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.AzureFirewallName, firewall.AzureFirewallResourceName)
 	}
 
@@ -224,7 +232,9 @@ func resourceIpGroupUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall Policy ID %q: %+v", fwpol, err)
 		}
-		locks.ByName(id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
+		if err := locks.ByNameWithContext(ctx, id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(id.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
 	}
 
@@ -233,7 +243,9 @@ func resourceIpGroupUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByID(id.ID())
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	existing, err := client.Get(ctx, *id, ipgroups.DefaultGetOperationOptions())
@@ -298,7 +310,9 @@ func resourceIpGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByID(id.ID())
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	resp, err := client.Get(ctx, *id, ipgroups.DefaultGetOperationOptions())
@@ -322,7 +336,9 @@ func resourceIpGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall ID %q: %+v", pointer.From(fw.Id), err)
 		}
-		locks.ByName(fwID.AzureFirewallName, firewall.AzureFirewallResourceName)
+		if err := locks.ByNameWithContext(ctx, fwID.AzureFirewallName, firewall.AzureFirewallResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(fwID.AzureFirewallName, firewall.AzureFirewallResourceName)
 	}
 
@@ -331,7 +347,9 @@ func resourceIpGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("parsing Azure Firewall Policy ID %q: %+v", *fwpol.Id, err)
 		}
-		locks.ByName(polID.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
+		if err := locks.ByNameWithContext(ctx, polID.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockByName(polID.FirewallPolicyName, firewall.AzureFirewallPolicyResourceName)
 	}
 
@@ -70,7 +70,9 @@ func resourceNATGatewayPublicIpAssociationCreate(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(natGatewayId.NatGatewayName, natGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, natGatewayId.NatGatewayName, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(natGatewayId.NatGatewayName, natGatewayResourceName)
 
 	natGateway, err := client.Get(ctx, *natGatewayId, natgateways.DefaultGetOperationOptions())
@@ -183,7 +185,9 @@ func resourceNATGatewayPublicIpAssociationDelete(d *pluginsdk.ResourceData, meta
 		return err
 	}
 
-	locks.ByName(id.First.NatGatewayName, natGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, id.First.NatGatewayName, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.First.NatGatewayName, natGatewayResourceName)
 
 	natGateway, err := client.Get(ctx, *id.First, natgateways.DefaultGetOperationOptions())
@@ -74,17 +74,21 @@ func resourceNetworkDDoSProtectionPlanCreate(d *pluginsdk.ResourceData, meta int
 	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	vnetsToLock, err := expandNetworkDDoSProtectionPlanVnetNames(d.Get("virtual_network_ids").([]interface{}))
+	vnetsToLock, err := expandNetworkDDoSProtectionPlanVnetIDs(d.Get("virtual_network_ids").([]interface{}))
 	if err != nil {
-		return fmt.Errorf("extracting names of Virtual Network: %+v", err)
+		return fmt.Errorf("extracting IDs of Virtual Network: %+v", err)
 	}
 
 	id := ddosprotectionplans.NewDdosProtectionPlanID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
+	if err := locks.ByNameWithContext(ctx, id.DdosProtectionPlanName, ddosProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, vnetsToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(vnetsToLock)
 
 	existing, err := client.Get(ctx, id)
 	if err != nil {
@@ -116,9 +120,9 @@ func resourceNetworkDDoSProtectionPlanUpdate(d *pluginsdk.ResourceData, meta int
 	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
-	vnetsToLock, err := expandNetworkDDoSProtectionPlanVnetNames(d.Get("virtual_network_ids").([]interface{}))
+	vnetsToLock, err := expandNetworkDDoSProtectionPlanVnetIDs(d.Get("virtual_network_ids").([]interface{}))
 	if err != nil {
-		return fmt.Errorf("extracting names of Virtual Network: %+v", err)
+		return fmt.Errorf("extracting IDs of Virtual Network: %+v", err)
 	}
 
 	id, err := ddosprotectionplans.ParseDdosProtectionPlanID(d.Id())
@@ -126,10 +130,14 @@ func resourceNetworkDDoSProtectionPlanUpdate(d *pluginsdk.ResourceData, meta int
 		return fmt.Errorf("retrieving %s: %+v", id, err)
 	}
 
-	locks.ByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
+	if err := locks.ByNameWithContext(ctx, id.DdosProtectionPlanName, ddosProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, vnetsToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(vnetsToLock)
 
 	existing, err := client.Get(ctx, *id)
 	if err != nil {
@@ -222,16 +230,20 @@ func resourceNetworkDDoSProtectionPlanDelete(d *pluginsdk.ResourceData, meta int
 	}
 	// if there's no VirtualNetworks configured, it's possible for this to be nil
 	subResources := existing.Model.Properties.VirtualNetworks
-	virtualNetworksNamesToLock, err := extractVnetNames(subResources)
+	virtualNetworkIdsToLock, err := extractVnetIDs(subResources)
 	if err != nil {
-		return fmt.Errorf("extracting names of Virtual Network: %+v", err)
+		return fmt.Errorf("extracting IDs of Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
+	if err := locks.ByNameWithContext(ctx, id.DdosProtectionPlanName, ddosProtectionPlanResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.DdosProtectionPlanName, ddosProtectionPlanResourceName)
 
-	locks.MultipleByName(virtualNetworksNamesToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(virtualNetworksNamesToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, virtualNetworkIdsToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(virtualNetworkIdsToLock)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
@@ -240,8 +252,8 @@ func resourceNetworkDDoSProtectionPlanDelete(d *pluginsdk.ResourceData, meta int
 	return nil
 }
 
-func expandNetworkDDoSProtectionPlanVnetNames(input []interface{}) (*[]string, error) {
-	vnetNames := make([]string, 0)
+func expandNetworkDDoSProtectionPlanVnetIDs(input []interface{}) (*[]string, error) {
+	vnetIDs := make([]string, 0)
 
 	for _, vnetID := range input {
 		vnetResourceID, err := commonids.ParseVirtualNetworkID(vnetID.(string))
@@ -249,12 +261,12 @@ func expandNetworkDDoSProtectionPlanVnetNames(input []interface{}) (*[]string, e
 			return nil, err
 		}
 
-		if !utils.SliceContainsValue(vnetNames, vnetResourceID.VirtualNetworkName) {
-			vnetNames = append(vnetNames, vnetResourceID.VirtualNetworkName)
+		if !utils.SliceContainsValue(vnetIDs, vnetResourceID.ID()) {
+			vnetIDs = append(vnetIDs, vnetResourceID.ID())
 		}
 	}
 
-	return &vnetNames, nil
+	return &vnetIDs, nil
 }
 
 func flattenNetworkDDoSProtectionPlanVirtualNetworkIDs(input *[]ddosprotectionplans.SubResource) []string {
@@ -273,8 +285,8 @@ func flattenNetworkDDoSProtectionPlanVirtualNetworkIDs(input *[]ddosprotectionpl
 	return vnetIDs
 }
 
-func extractVnetNames(input *[]ddosprotectionplans.SubResource) (*[]string, error) {
-	vnetNames := make([]string, 0)
+func extractVnetIDs(input *[]ddosprotectionplans.SubResource) (*[]string, error) {
+	vnetIDs := make([]string, 0)
 
 	if input != nil {
 		for _, subresource := range *input {
@@ -287,11 +299,11 @@ func extractVnetNames(input *[]ddosprotectionplans.SubResource) (*[]string, erro
 				return nil, err
 			}
 
-			if !utils.SliceContainsValue(vnetNames, id.VirtualNetworkName) {
-				vnetNames = append(vnetNames, id.VirtualNetworkName)
+			if !utils.SliceContainsValue(vnetIDs, id.ID()) {
+				vnetIDs = append(vnetIDs, id.ID())
 			}
 		}
 	}
 
-	return &vnetNames, nil
+	return &vnetIDs, nil
 }
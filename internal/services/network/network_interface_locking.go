@@ -4,6 +4,8 @@
 package network
 
 import (
+	"context"
+
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/network/2023-11-01/networkinterfaces"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
@@ -11,30 +13,36 @@ import (
 )
 
 type networkInterfaceIPConfigurationLockingDetails struct {
-	subnetNamesToLock         []string
-	virtualNetworkNamesToLock []string
+	subnetIDsToLock         []string
+	virtualNetworkIDsToLock []string
 }
 
-func (details networkInterfaceIPConfigurationLockingDetails) lock() {
-	locks.MultipleByName(&details.virtualNetworkNamesToLock, VirtualNetworkResourceName)
-	locks.MultipleByName(&details.subnetNamesToLock, SubnetResourceName)
+func (details networkInterfaceIPConfigurationLockingDetails) lock(ctx context.Context) error {
+	if err := locks.MultipleByIDWithContext(ctx, &details.virtualNetworkIDsToLock); err != nil {
+		return err
+	}
+	if err := locks.MultipleByIDWithContext(ctx, &details.subnetIDsToLock); err != nil {
+		locks.UnlockMultipleByID(&details.virtualNetworkIDsToLock)
+		return err
+	}
+	return nil
 }
 
 func (details networkInterfaceIPConfigurationLockingDetails) unlock() {
-	locks.UnlockMultipleByName(&details.subnetNamesToLock, SubnetResourceName)
-	locks.UnlockMultipleByName(&details.virtualNetworkNamesToLock, VirtualNetworkResourceName)
+	locks.UnlockMultipleByID(&details.subnetIDsToLock)
+	locks.UnlockMultipleByID(&details.virtualNetworkIDsToLock)
 }
 
 func determineResourcesToLockFromIPConfiguration(input *[]networkinterfaces.NetworkInterfaceIPConfiguration) (*networkInterfaceIPConfigurationLockingDetails, error) {
 	if input == nil {
 		return &networkInterfaceIPConfigurationLockingDetails{
-			subnetNamesToLock:         []string{},
-			virtualNetworkNamesToLock: []string{},
+			subnetIDsToLock:         []string{},
+			virtualNetworkIDsToLock: []string{},
 		}, nil
 	}
 
-	subnetNamesToLock := make([]string, 0)
-	virtualNetworkNamesToLock := make([]string, 0)
+	subnetIDsToLock := make([]string, 0)
+	virtualNetworkIDsToLock := make([]string, 0)
 
 	for _, config := range *input {
 		if config.Properties == nil || config.Properties.Subnet == nil || config.Properties.Subnet.Id == nil {
@@ -46,20 +54,20 @@ func determineResourcesToLockFromIPConfiguration(input *[]networkinterfaces.Netw
 			return nil, err
 		}
 
-		virtualNetworkName := id.VirtualNetworkName
-		subnetName := id.SubnetName
+		virtualNetworkID := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName).ID()
+		subnetID := id.ID()
 
-		if !utils.SliceContainsValue(virtualNetworkNamesToLock, virtualNetworkName) {
-			virtualNetworkNamesToLock = append(virtualNetworkNamesToLock, virtualNetworkName)
+		if !utils.SliceContainsValue(virtualNetworkIDsToLock, virtualNetworkID) {
+			virtualNetworkIDsToLock = append(virtualNetworkIDsToLock, virtualNetworkID)
 		}
 
-		if !utils.SliceContainsValue(subnetNamesToLock, subnetName) {
-			subnetNamesToLock = append(subnetNamesToLock, subnetName)
+		if !utils.SliceContainsValue(subnetIDsToLock, subnetID) {
+			subnetIDsToLock = append(subnetIDsToLock, subnetID)
 		}
 	}
 
 	return &networkInterfaceIPConfigurationLockingDetails{
-		subnetNamesToLock:         subnetNamesToLock,
-		virtualNetworkNamesToLock: virtualNetworkNamesToLock,
+		subnetIDsToLock:         subnetIDsToLock,
+		virtualNetworkIDsToLock: virtualNetworkIDsToLock,
 	}, nil
 }
@@ -276,7 +276,9 @@ func resourceNetworkInterfaceCreate(d *pluginsdk.ResourceData, meta interface{})
 		EnableAcceleratedNetworking: &enableAcceleratedNetworking,
 	}
 
-	locks.ByName(id.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkInterfaceName, networkInterfaceResourceName)
 
 	if auxiliaryMode, hasAuxiliaryMode := d.GetOk("auxiliary_mode"); hasAuxiliaryMode {
@@ -315,7 +317,9 @@ func resourceNetworkInterfaceCreate(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("determining locking details: %+v", err)
 	}
 
-	lockingDetails.lock()
+	if err := lockingDetails.lock(ctx); err != nil {
+		return err
+	}
 	defer lockingDetails.unlock()
 
 	if len(*ipConfigs) > 0 {
@@ -349,7 +353,9 @@ func resourceNetworkInterfaceUpdate(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
-	locks.ByName(id.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkInterfaceName, networkInterfaceResourceName)
 
 	// first get the existing one so that we can pull things as needed
@@ -425,7 +431,9 @@ func resourceNetworkInterfaceUpdate(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("determining locking details: %+v", err)
 		}
 
-		lockingDetails.lock()
+		if err := lockingDetails.lock(ctx); err != nil {
+			return err
+		}
 		defer lockingDetails.unlock()
 
 		// then map the fields managed in other resources back
@@ -573,7 +581,9 @@ func resourceNetworkInterfaceDelete(d *pluginsdk.ResourceData, meta interface{})
 		return err
 	}
 
-	locks.ByName(id.NetworkInterfaceName, networkInterfaceResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NetworkInterfaceName, networkInterfaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkInterfaceName, networkInterfaceResourceName)
 
 	existing, err := client.Get(ctx, *id, networkinterfaces.DefaultGetOperationOptions())
@@ -602,7 +612,9 @@ func resourceNetworkInterfaceDelete(d *pluginsdk.ResourceData, meta interface{})
 		return fmt.Errorf("determining locking details: %+v", err)
 	}
 
-	lockingDetails.lock()
+	if err := lockingDetails.lock(ctx); err != nil {
+		return err
+	}
 	defer lockingDetails.unlock()
 
 	err = client.DeleteThenPoll(ctx, *id)
@@ -122,19 +122,25 @@ func resourceNetworkProfileCreate(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 
 	containerNetworkInterfaceConfigurations := expandNetworkProfileContainerNetworkInterface(d.Get("container_network_interface").([]interface{}))
-	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetNames(containerNetworkInterfaceConfigurations)
+	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetIDs(containerNetworkInterfaceConfigurations)
 	if err != nil {
-		return fmt.Errorf("extracting names of Subnet and Virtual Network: %+v", err)
+		return fmt.Errorf("extracting IDs of Subnet and Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.NetworkProfileName, azureNetworkProfileResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NetworkProfileName, azureNetworkProfileResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkProfileName, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, vnetsToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(vnetsToLock)
 
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
-	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, subnetsToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(subnetsToLock)
 
 	payload := networkprofiles.NetworkProfile{
 		Location: pointer.To(location.Normalize(d.Get("location").(string))),
@@ -178,19 +184,25 @@ func resourceNetworkProfileUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 	payload := existing.Model
 
 	containerNetworkInterfaceConfigurations := expandNetworkProfileContainerNetworkInterface(d.Get("container_network_interface").([]interface{}))
-	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetNames(containerNetworkInterfaceConfigurations)
+	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetIDs(containerNetworkInterfaceConfigurations)
 	if err != nil {
-		return fmt.Errorf("extracting names of Subnet and Virtual Network: %+v", err)
+		return fmt.Errorf("extracting IDs of Subnet and Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.NetworkProfileName, azureNetworkProfileResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NetworkProfileName, azureNetworkProfileResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkProfileName, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, vnetsToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(vnetsToLock)
 
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
-	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, subnetsToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(subnetsToLock)
 
 	if d.HasChange("container_network_interface") {
 		payload.Properties.ContainerNetworkInterfaceConfigurations = containerNetworkInterfaceConfigurations
@@ -274,19 +286,25 @@ func resourceNetworkProfileDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("retrieving existing %s: `model.Properties` was nil", *id)
 	}
 
-	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetNames(existing.Model.Properties.ContainerNetworkInterfaceConfigurations)
+	subnetsToLock, vnetsToLock, err := expandNetworkProfileVirtualNetworkSubnetIDs(existing.Model.Properties.ContainerNetworkInterfaceConfigurations)
 	if err != nil {
-		return fmt.Errorf("extracting names of Subnet and Virtual Network: %+v", err)
+		return fmt.Errorf("extracting IDs of Subnet and Virtual Network: %+v", err)
 	}
 
-	locks.ByName(id.NetworkProfileName, azureNetworkProfileResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NetworkProfileName, azureNetworkProfileResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NetworkProfileName, azureNetworkProfileResourceName)
 
-	locks.MultipleByName(vnetsToLock, VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(vnetsToLock, VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, vnetsToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(vnetsToLock)
 
-	locks.MultipleByName(subnetsToLock, SubnetResourceName)
-	defer locks.UnlockMultipleByName(subnetsToLock, SubnetResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, subnetsToLock); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(subnetsToLock)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
@@ -334,9 +352,9 @@ func expandNetworkProfileContainerNetworkInterface(input []interface{}) *[]netwo
 	return &retCNIConfigs
 }
 
-func expandNetworkProfileVirtualNetworkSubnetNames(input *[]networkprofiles.ContainerNetworkInterfaceConfiguration) (*[]string, *[]string, error) {
-	subnetNames := make([]string, 0)
-	vnetNames := make([]string, 0)
+func expandNetworkProfileVirtualNetworkSubnetIDs(input *[]networkprofiles.ContainerNetworkInterfaceConfiguration) (*[]string, *[]string, error) {
+	subnetIDs := make([]string, 0)
+	vnetIDs := make([]string, 0)
 
 	if input != nil {
 		for _, item := range *input {
@@ -354,18 +372,19 @@ func expandNetworkProfileVirtualNetworkSubnetNames(input *[]networkprofiles.Cont
 					return nil, nil, err
 				}
 
-				if !utils.SliceContainsValue(subnetNames, subnetId.SubnetName) {
-					subnetNames = append(subnetNames, subnetId.SubnetName)
+				if !utils.SliceContainsValue(subnetIDs, subnetId.ID()) {
+					subnetIDs = append(subnetIDs, subnetId.ID())
 				}
 
-				if !utils.SliceContainsValue(vnetNames, subnetId.VirtualNetworkName) {
-					vnetNames = append(vnetNames, subnetId.VirtualNetworkName)
+				vnetID := commonids.NewVirtualNetworkID(subnetId.SubscriptionId, subnetId.ResourceGroupName, subnetId.VirtualNetworkName).ID()
+				if !utils.SliceContainsValue(vnetIDs, vnetID) {
+					vnetIDs = append(vnetIDs, vnetID)
 				}
 			}
 		}
 	}
 
-	return &subnetNames, &vnetNames, nil
+	return &subnetIDs, &vnetIDs, nil
 }
 
 func flattenNetworkProfileContainerNetworkInterface(input *[]networkprofiles.ContainerNetworkInterfaceConfiguration) []interface{} {
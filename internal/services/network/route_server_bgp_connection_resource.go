@@ -79,7 +79,9 @@ func resourceRouteServerBgpConnectionCreate(d *pluginsdk.ResourceData, meta inte
 		return err
 	}
 
-	locks.ByName(routerServerId.VirtualHubName, "azurerm_route_server")
+	if err := locks.ByNameWithContext(ctx, routerServerId.VirtualHubName, "azurerm_route_server"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(routerServerId.VirtualHubName, "azurerm_route_server")
 
 	id := commonids.NewVirtualHubBGPConnectionID(routerServerId.SubscriptionId, routerServerId.ResourceGroupName, routerServerId.VirtualHubName, d.Get("name").(string))
@@ -114,7 +114,9 @@ func resourceRouteServerCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 
 	id := virtualwans.NewVirtualHubID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.VirtualHubName, "azurerm_route_server")
+	if err := locks.ByNameWithContext(ctx, id.VirtualHubName, "azurerm_route_server"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualHubName, "azurerm_route_server")
 
 	existing, err := client.VirtualHubsGet(ctx, id)
@@ -188,7 +190,9 @@ func resourceRouteServerUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, "azurerm_route_server")
+	if err := locks.ByNameWithContext(ctx, id.VirtualHubName, "azurerm_route_server"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VirtualHubName, "azurerm_route_server")
 
 	existing, err := client.VirtualHubsGet(ctx, *id)
@@ -71,12 +71,19 @@ func resourceSubnetNatGatewayAssociationCreate(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(gatewayId.NatGatewayName, natGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, gatewayId.NatGatewayName, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(gatewayId.NatGatewayName, natGatewayResourceName)
-	locks.ByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
-	locks.ByName(subnetId.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(subnetId.SubnetName, SubnetResourceName)
+	virtualNetworkId := commonids.NewVirtualNetworkID(subnetId.SubscriptionId, subnetId.ResourceGroupName, subnetId.VirtualNetworkName)
+	if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualNetworkId.ID())
+	if err := locks.ByIDWithContext(ctx, subnetId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(subnetId.ID())
 
 	subnet, err := client.Get(ctx, *subnetId, subnets.DefaultGetOperationOptions())
 	if err != nil {
@@ -216,10 +223,15 @@ func resourceSubnetNatGatewayAssociationDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(gatewayId.NatGatewayName, natGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, gatewayId.NatGatewayName, natGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(gatewayId.NatGatewayName, natGatewayResourceName)
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName)
+	if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualNetworkId.ID())
 
 	subnet, err = client.Get(ctx, *id, subnets.DefaultGetOperationOptions())
 	if err != nil {
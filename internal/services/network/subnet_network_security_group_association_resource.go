@@ -71,14 +71,21 @@ func resourceSubnetNetworkSecurityGroupAssociationCreate(d *pluginsdk.ResourceDa
 		return err
 	}
 
-	locks.ByName(networkSecurityGroupId.NetworkSecurityGroupName, networkSecurityGroupResourceName)
+	if err := locks.ByNameWithContext(ctx, networkSecurityGroupId.NetworkSecurityGroupName, networkSecurityGroupResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(networkSecurityGroupId.NetworkSecurityGroupName, networkSecurityGroupResourceName)
 
-	locks.ByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(subnetId.VirtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := commonids.NewVirtualNetworkID(subnetId.SubscriptionId, subnetId.ResourceGroupName, subnetId.VirtualNetworkName)
+	if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualNetworkId.ID())
 
-	locks.ByName(subnetId.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(subnetId.SubnetName, SubnetResourceName)
+	if err := locks.ByIDWithContext(ctx, subnetId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(subnetId.ID())
 
 	subnet, err := client.Get(ctx, *subnetId, subnets.DefaultGetOperationOptions())
 	if err != nil {
@@ -223,14 +230,21 @@ func resourceSubnetNetworkSecurityGroupAssociationDelete(d *pluginsdk.ResourceDa
 		return err
 	}
 
-	locks.ByName(networkSecurityGroupId.NetworkSecurityGroupName, networkSecurityGroupResourceName)
+	if err := locks.ByNameWithContext(ctx, networkSecurityGroupId.NetworkSecurityGroupName, networkSecurityGroupResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(networkSecurityGroupId.NetworkSecurityGroupName, networkSecurityGroupResourceName)
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName)
+	if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualNetworkId.ID())
 
-	locks.ByName(id.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(id.ID())
 
 	// then re-retrieve it to ensure we've got the latest state
 	read, err = client.Get(ctx, *id, subnets.DefaultGetOperationOptions())
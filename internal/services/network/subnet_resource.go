@@ -26,8 +26,6 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
-var SubnetResourceName = "azurerm_subnet"
-
 var subnetDelegationServiceNames = []string{
 	"GitHub.Network/networkSettings",
 	"Microsoft.ApiManagement/service",
@@ -319,8 +317,11 @@ func resourceSubnetCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return tf.ImportAsExistsError("azurerm_subnet", id.ID())
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName)
+	if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualNetworkId.ID())
 
 	properties := subnets.SubnetPropertiesFormat{}
 	if value, ok := d.GetOk("address_prefixes"); ok {
@@ -474,11 +475,16 @@ func resourceSubnetUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName)
+	if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualNetworkId.ID())
 
-	locks.ByName(id.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(id.ID())
 
 	existing, err := client.Get(ctx, *id, subnets.DefaultGetOperationOptions())
 	if err != nil {
@@ -697,11 +703,16 @@ func resourceSubnetDelete(d *pluginsdk.ResourceData, meta interface{}) error {
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName)
+	if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualNetworkId.ID())
 
-	locks.ByName(id.SubnetName, SubnetResourceName)
-	defer locks.UnlockByName(id.SubnetName, SubnetResourceName)
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(id.ID())
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
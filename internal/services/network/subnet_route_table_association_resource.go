@@ -73,11 +73,16 @@ func resourceSubnetRouteTableAssociationCreate(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(routeTableId.RouteTableName, routeTableResourceName)
+	if err := locks.ByNameWithContext(ctx, routeTableId.RouteTableName, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(routeTableId.RouteTableName, routeTableResourceName)
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName)
+	if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualNetworkId.ID())
 
 	subnet, err := client.Get(ctx, *id, subnets.DefaultGetOperationOptions())
 	if err != nil {
@@ -222,11 +227,16 @@ func resourceSubnetRouteTableAssociationDelete(d *pluginsdk.ResourceData, meta i
 		return err
 	}
 
-	locks.ByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
+	if err := locks.ByNameWithContext(ctx, parsedRouteTableId.RouteTableName, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(parsedRouteTableId.RouteTableName, routeTableResourceName)
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	virtualNetworkId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName)
+	if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualNetworkId.ID())
 
 	// then re-retrieve it to ensure we've got the latest state
 	read, err = client.Get(ctx, *id, subnets.DefaultGetOperationOptions())
@@ -87,8 +87,10 @@ func resourceVirtualHubBgpConnectionCreate(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(virtHubId.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(virtHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, virtHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtHubId.ID())
 
 	id := commonids.NewVirtualHubBGPConnectionID(virtHubId.SubscriptionId, virtHubId.ResourceGroupName, virtHubId.VirtualHubName, d.Get("name").(string))
 
@@ -138,8 +140,10 @@ func resourceVirtualHubBgpConnectionUpdate(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(virtHubId.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(virtHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, virtHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtHubId.ID())
 
 	id, err := commonids.ParseVirtualHubBGPConnectionID(d.Id())
 	if err != nil {
@@ -228,8 +232,11 @@ func resourceVirtualHubBgpConnectionDelete(d *pluginsdk.ResourceData, meta inter
 		return err
 	}
 
-	locks.ByName(id.HubName, virtualHubResourceName)
-	defer locks.UnlockByName(id.HubName, virtualHubResourceName)
+	virtualHubId := virtualwans.NewVirtualHubID(id.SubscriptionId, id.ResourceGroupName, id.HubName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	if err := client.VirtualHubBgpConnectionDeleteThenPoll(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", id, err)
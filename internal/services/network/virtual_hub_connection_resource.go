@@ -190,16 +190,20 @@ func resourceVirtualHubConnectionCreateOrUpdate(d *pluginsdk.ResourceData, meta
 
 	id := virtualwans.NewHubVirtualNetworkConnectionID(virtualHubId.SubscriptionId, virtualHubId.ResourceGroupName, virtualHubId.VirtualHubName, d.Get("name").(string))
 
-	locks.ByName(virtualHubId.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(virtualHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	remoteVirtualNetworkId, err := commonids.ParseVirtualNetworkID(d.Get("remote_virtual_network_id").(string))
 	if err != nil {
 		return err
 	}
 
-	locks.ByName(remoteVirtualNetworkId.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(remoteVirtualNetworkId.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByIDWithContext(ctx, remoteVirtualNetworkId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(remoteVirtualNetworkId.ID())
 
 	if d.IsNewResource() {
 		existing, err := client.HubVirtualNetworkConnectionsGet(ctx, id)
@@ -300,8 +304,11 @@ func resourceVirtualHubConnectionDelete(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
+	virtualHubId := virtualwans.NewVirtualHubID(id.SubscriptionId, id.ResourceGroupName, id.VirtualHubName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	if err := client.HubVirtualNetworkConnectionsDeleteThenPoll(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
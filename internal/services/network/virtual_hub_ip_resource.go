@@ -97,8 +97,10 @@ func resourceVirtualHubIPCreate(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(virtualHubId.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(virtualHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	id := commonids.NewVirtualHubIPConfigurationID(virtualHubId.SubscriptionId, virtualHubId.ResourceGroupName, virtualHubId.VirtualHubName, d.Get("name").(string))
 
@@ -154,8 +156,10 @@ func resourceVirtualHubIPUpdate(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(virtualHubId.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(virtualHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	id, err := commonids.ParseVirtualHubIPConfigurationID(d.Id())
 	if err != nil {
@@ -244,8 +248,11 @@ func resourceVirtualHubIPDelete(d *pluginsdk.ResourceData, meta interface{}) err
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
+	virtualHubId := virtualwans.NewVirtualHubID(id.SubscriptionId, id.ResourceGroupName, id.VirtualHubName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	if err := client.VirtualHubIPConfigurationDeleteThenPoll(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", id, err)
@@ -26,8 +26,6 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
-const virtualHubResourceName = "azurerm_virtual_hub"
-
 func resourceVirtualHub() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourceVirtualHubCreate,
@@ -157,8 +155,10 @@ func resourceVirtualHubCreate(d *pluginsdk.ResourceData, meta interface{}) error
 
 	id := virtualwans.NewVirtualHubID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(id.ID())
 
 	existing, err := client.VirtualHubsGet(ctx, id)
 	if err != nil {
@@ -241,8 +241,10 @@ func resourceVirtualHubUpdate(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(id.ID())
 
 	existing, err := client.VirtualHubsGet(ctx, *id)
 	if err != nil {
@@ -380,8 +382,10 @@ func resourceVirtualHubDelete(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(id.ID())
 
 	if err := client.VirtualHubsDeleteThenPoll(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
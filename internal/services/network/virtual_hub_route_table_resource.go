@@ -126,8 +126,10 @@ func resourceVirtualHubRouteTableCreate(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(virtHubId.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(virtHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, virtHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtHubId.ID())
 
 	id := virtualwans.NewHubRouteTableID(virtHubId.SubscriptionId, virtHubId.ResourceGroupName, virtHubId.VirtualHubName, d.Get("name").(string))
 
@@ -169,8 +171,10 @@ func resourceVirtualHubRouteTableUpdate(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(virtHubId.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(virtHubId.VirtualHubName, virtualHubResourceName)
+	if err := locks.ByIDWithContext(ctx, virtHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtHubId.ID())
 
 	id, err := virtualwans.ParseHubRouteTableID(d.Id())
 	if err != nil {
@@ -256,8 +260,11 @@ func resourceVirtualHubRouteTableDelete(d *pluginsdk.ResourceData, meta interfac
 		return err
 	}
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
+	virtualHubId := virtualwans.NewVirtualHubID(id.SubscriptionId, id.ResourceGroupName, id.VirtualHubName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	if err := client.HubRouteTablesDeleteThenPoll(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", id, err)
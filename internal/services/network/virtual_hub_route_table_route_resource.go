@@ -103,8 +103,11 @@ func resourceVirtualHubRouteTableRouteCreate(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(routeTableId.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(routeTableId.VirtualHubName, virtualHubResourceName)
+	virtualHubId := virtualwans.NewVirtualHubID(routeTableId.SubscriptionId, routeTableId.ResourceGroupName, routeTableId.VirtualHubName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	routeTable, err := client.HubRouteTablesGet(ctx, *routeTableId)
 	if err != nil {
@@ -167,8 +170,11 @@ func resourceVirtualHubRouteTableRouteUpdate(d *pluginsdk.ResourceData, meta int
 		return err
 	}
 
-	locks.ByName(routeTableId.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(routeTableId.VirtualHubName, virtualHubResourceName)
+	virtualHubId := virtualwans.NewVirtualHubID(routeTableId.SubscriptionId, routeTableId.ResourceGroupName, routeTableId.VirtualHubName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	routeTable, err := client.HubRouteTablesGet(ctx, *routeTableId)
 	if err != nil {
@@ -283,8 +289,11 @@ func resourceVirtualHubRouteTableRouteDelete(d *pluginsdk.ResourceData, meta int
 
 	routeTableId := virtualwans.NewHubRouteTableID(id.SubscriptionId, id.ResourceGroup, id.VirtualHubName, id.HubRouteTableName)
 
-	locks.ByName(id.VirtualHubName, virtualHubResourceName)
-	defer locks.UnlockByName(id.VirtualHubName, virtualHubResourceName)
+	virtualHubId := virtualwans.NewVirtualHubID(id.SubscriptionId, id.ResourceGroup, id.VirtualHubName)
+	if err := locks.ByIDWithContext(ctx, virtualHubId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(virtualHubId.ID())
 
 	// get latest list of routes
 	routeTable, err := client.HubRouteTablesGet(ctx, routeTableId)
@@ -72,8 +72,10 @@ func resourceVirtualNetworkDnsServersCreate(d *pluginsdk.ResourceData, meta inte
 	// This is a virtual resource so the last segment is hardcoded
 	id := parse.NewVirtualNetworkDnsServersID(vnetId.SubscriptionId, vnetId.ResourceGroupName, vnetId.VirtualNetworkName, "default")
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByIDWithContext(ctx, vnetId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(vnetId.ID())
 
 	vnet, err := client.Get(ctx, *vnetId, virtualnetworks.DefaultGetOperationOptions())
 	if err != nil {
@@ -164,8 +166,10 @@ func resourceVirtualNetworkDnsServersUpdate(d *pluginsdk.ResourceData, meta inte
 	// This is a virtual resource so the last segment is hardcoded
 	id := parse.NewVirtualNetworkDnsServersID(vnetId.SubscriptionId, vnetId.ResourceGroupName, vnetId.VirtualNetworkName, "default")
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
+	if err := locks.ByIDWithContext(ctx, vnetId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(vnetId.ID())
 
 	vnet, err := client.Get(ctx, *vnetId, virtualnetworks.DefaultGetOperationOptions())
 	if err != nil {
@@ -221,11 +225,13 @@ func resourceVirtualNetworkDnsServersDelete(d *pluginsdk.ResourceData, meta inte
 		return err
 	}
 
-	locks.ByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-	defer locks.UnlockByName(id.VirtualNetworkName, VirtualNetworkResourceName)
-
 	vnetId := commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroup, id.VirtualNetworkName)
 
+	if err := locks.ByIDWithContext(ctx, vnetId.ID()); err != nil {
+		return err
+	}
+	defer locks.UnlockByID(vnetId.ID())
+
 	vnet, err := client.Get(ctx, vnetId, virtualnetworks.DefaultGetOperationOptions())
 	if err != nil {
 		if response.WasNotFound(vnet.HttpResponse) {
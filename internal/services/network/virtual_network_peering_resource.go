@@ -22,8 +22,6 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
 )
 
-const virtualNetworkPeeringResourceType = "azurerm_virtual_network_peering"
-
 func resourceVirtualNetworkPeering() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourceVirtualNetworkPeeringCreate,
@@ -173,8 +171,14 @@ func resourceVirtualNetworkPeeringCreate(d *pluginsdk.ResourceData, meta interfa
 		peer.Properties.RemoteSubnetNames = utils.ExpandStringSlice(v.([]interface{}))
 	}
 
-	locks.ByID(virtualNetworkPeeringResourceType)
-	defer locks.UnlockByID(virtualNetworkPeeringResourceType)
+	lockIds, err := virtualNetworkPeeringLockIds(id, d.Get("remote_virtual_network_id").(string))
+	if err != nil {
+		return err
+	}
+	if err := locks.MultipleByIDWithContext(ctx, lockIds); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(lockIds)
 
 	deadline, ok := ctx.Deadline()
 	if !ok {
@@ -226,8 +230,14 @@ func resourceVirtualNetworkPeeringUpdate(d *pluginsdk.ResourceData, meta interfa
 		return err
 	}
 
-	locks.ByID(virtualNetworkPeeringResourceType)
-	defer locks.UnlockByID(virtualNetworkPeeringResourceType)
+	lockIds, err := virtualNetworkPeeringLockIds(*id, d.Get("remote_virtual_network_id").(string))
+	if err != nil {
+		return err
+	}
+	if err := locks.MultipleByIDWithContext(ctx, lockIds); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(lockIds)
 
 	existing, err := client.Get(ctx, *id)
 	if err != nil {
@@ -328,8 +338,14 @@ func resourceVirtualNetworkPeeringDelete(d *pluginsdk.ResourceData, meta interfa
 		return err
 	}
 
-	locks.ByID(virtualNetworkPeeringResourceType)
-	defer locks.UnlockByID(virtualNetworkPeeringResourceType)
+	lockIds, err := virtualNetworkPeeringLockIds(*id, d.Get("remote_virtual_network_id").(string))
+	if err != nil {
+		return err
+	}
+	if err := locks.MultipleByIDWithContext(ctx, lockIds); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(lockIds)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
@@ -337,3 +353,18 @@ func resourceVirtualNetworkPeeringDelete(d *pluginsdk.ResourceData, meta interfa
 
 	return err
 }
+
+// virtualNetworkPeeringLockIds returns the IDs of the local and remote Virtual Networks, which are locked whilst the
+// peering is changed since a peering can't be changed at the same time as either of the Virtual Networks (or their
+// Subnets, which also lock the Virtual Network)
+func virtualNetworkPeeringLockIds(id virtualnetworkpeerings.VirtualNetworkPeeringId, remoteVirtualNetworkId string) (*[]string, error) {
+	remoteId, err := commonids.ParseVirtualNetworkIDInsensitively(remoteVirtualNetworkId)
+	if err != nil {
+		return nil, fmt.Errorf("parsing `remote_virtual_network_id`: %+v", err)
+	}
+
+	return &[]string{
+		commonids.NewVirtualNetworkID(id.SubscriptionId, id.ResourceGroupName, id.VirtualNetworkName).ID(),
+		remoteId.ID(),
+	}, nil
+}
@@ -347,7 +347,9 @@ func resourceVirtualNetworkCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.MultipleByName(routeTables, routeTableResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, routeTables, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(routeTables, routeTableResourceName)
 
 	vnet := virtualnetworks.VirtualNetwork{
@@ -377,7 +379,9 @@ func resourceVirtualNetworkCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
-	locks.MultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, &networkSecurityGroupNames, networkSecurityGroupResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
 
 	if err := client.CreateOrUpdateThenPoll(ctx, id, vnet); err != nil {
@@ -551,7 +555,9 @@ func resourceVirtualNetworkUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 		payload.Properties.Subnets = subnets
 
-		locks.MultipleByName(routeTables, routeTableResourceName)
+		if err := locks.MultipleByNameWithContext(ctx, routeTables, routeTableResourceName); err != nil {
+			return err
+		}
 		defer locks.UnlockMultipleByName(routeTables, routeTableResourceName)
 	}
 
@@ -576,7 +582,9 @@ func resourceVirtualNetworkUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		}
 	}
 
-	locks.MultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, &networkSecurityGroupNames, networkSecurityGroupResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&networkSecurityGroupNames, networkSecurityGroupResourceName)
 
 	if err := client.CreateOrUpdateThenPoll(ctx, *id, *payload); err != nil {
@@ -614,10 +622,14 @@ func resourceVirtualNetworkDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return fmt.Errorf("parsing Network Security Group ID's: %+v", err)
 	}
 
-	locks.MultipleByName(&nsgNames, VirtualNetworkResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, &nsgNames, VirtualNetworkResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&nsgNames, VirtualNetworkResourceName)
 
-	locks.MultipleByName(&routeTableNames, routeTableResourceName)
+	if err := locks.MultipleByNameWithContext(ctx, &routeTableNames, routeTableResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockMultipleByName(&routeTableNames, routeTableResourceName)
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
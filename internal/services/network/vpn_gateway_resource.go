@@ -304,7 +304,9 @@ func resourceVPNGatewayUpdate(d *pluginsdk.ResourceData, meta interface{}) error
 		return err
 	}
 
-	locks.ByName(id.VpnGatewayName, VPNGatewayResourceName)
+	if err := locks.ByNameWithContext(ctx, id.VpnGatewayName, VPNGatewayResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.VpnGatewayName, VPNGatewayResourceName)
 
 	existing, err := client.VpnGatewaysGet(ctx, *id)
@@ -130,10 +130,14 @@ func resourceNotificationHubAuthorizationRuleCreateUpdate(d *pluginsdk.ResourceD
 		}
 	}
 
-	locks.ByName(id.NotificationHubName, notificationHubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NotificationHubName, notificationHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NotificationHubName, notificationHubResourceName)
 
-	locks.ByName(id.NamespaceName, notificationHubNamespaceResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NamespaceName, notificationHubNamespaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, notificationHubNamespaceResourceName)
 
 	manage := d.Get("manage").(bool)
@@ -213,10 +217,14 @@ func resourceNotificationHubAuthorizationRuleDelete(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	locks.ByName(id.NotificationHubName, notificationHubResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NotificationHubName, notificationHubResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NotificationHubName, notificationHubResourceName)
 
-	locks.ByName(id.NamespaceName, notificationHubNamespaceResourceName)
+	if err := locks.ByNameWithContext(ctx, id.NamespaceName, notificationHubNamespaceResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.NamespaceName, notificationHubNamespaceResourceName)
 
 	resp, err := client.NotificationHubsDeleteAuthorizationRule(ctx, *id)
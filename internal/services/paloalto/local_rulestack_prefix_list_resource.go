@@ -101,7 +101,9 @@ func (r LocalRuleStackPrefixList) Create() sdk.ResourceFunc {
 			if err != nil {
 				return err
 			}
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByIDWithContext(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			id := prefixlistlocalrulestack.NewLocalRulestackPrefixListID(rulestackId.SubscriptionId, rulestackId.ResourceGroupName, rulestackId.LocalRulestackName, model.Name)
@@ -197,7 +199,9 @@ func (r LocalRuleStackPrefixList) Delete() sdk.ResourceFunc {
 			}
 
 			rulestackId := localrulestacks.NewLocalRulestackID(id.SubscriptionId, id.ResourceGroupName, id.LocalRulestackName)
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByIDWithContext(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			if err = client.DeleteThenPoll(ctx, *id); err != nil {
@@ -232,7 +236,9 @@ func (r LocalRuleStackPrefixList) Update() sdk.ResourceFunc {
 			}
 
 			rulestackId := localrulestacks.NewLocalRulestackID(id.SubscriptionId, id.ResourceGroupName, id.LocalRulestackName)
-			locks.ByID(rulestackId.ID())
+			if err := locks.ByIDWithContext(ctx, rulestackId.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(rulestackId.ID())
 
 			existing, err := client.Get(ctx, *id)
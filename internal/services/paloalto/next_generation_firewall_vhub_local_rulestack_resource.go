@@ -141,7 +141,9 @@ func (r NextGenerationFirewallVHubLocalRuleStackResource) Create() sdk.ResourceF
 				Tags: tags.Expand(model.Tags),
 			}
 
-			locks.ByID(ruleStackID.ID())
+			if err := locks.ByIDWithContext(ctx, ruleStackID.ID()); err != nil {
+				return err
+			}
 			defer locks.UnlockByID(ruleStackID.ID())
 
 			if err = client.CreateOrUpdateThenPoll(ctx, id, firewall); err != nil {
@@ -263,7 +265,9 @@ func (r NextGenerationFirewallVHubLocalRuleStackResource) Update() sdk.ResourceF
 				}
 
 				props.AssociatedRulestack = ruleStack
-				locks.ByID(ruleStackID.ID())
+				if err := locks.ByIDWithContext(ctx, ruleStackID.ID()); err != nil {
+					return err
+				}
 				defer locks.UnlockByID(ruleStackID.ID())
 			}
 
@@ -84,7 +84,9 @@ func resourcePostgresqlFlexibleServerDatabaseCreate(d *pluginsdk.ResourceData, m
 
 	id := databases.NewDatabaseID(subscriptionId, serverId.ResourceGroupName, serverId.FlexibleServerName, d.Get("name").(string))
 
-	locks.ByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
+	if err := locks.ByNameWithContext(ctx, id.FlexibleServerName, postgresqlFlexibleServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
 
 	if d.IsNewResource() {
@@ -157,7 +159,9 @@ func resourcePostgresqlFlexibleServerDatabaseDelete(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	locks.ByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
+	if err := locks.ByNameWithContext(ctx, id.FlexibleServerName, postgresqlFlexibleServerResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.FlexibleServerName, postgresqlFlexibleServerResourceName)
 
 	if err = client.DeleteThenPoll(ctx, *id); err != nil {
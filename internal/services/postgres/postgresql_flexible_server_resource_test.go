@@ -38,6 +38,29 @@ func TestAccPostgresqlFlexibleServer_basic(t *testing.T) {
 	})
 }
 
+func TestAccPostgresqlFlexibleServer_writeOnlyPassword(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_postgresql_flexible_server", "test")
+	r := PostgresqlFlexibleServerResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.writeOnlyPassword(data, "QAZwsx123", 1),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("administrator_password_wo").DoesNotExist(),
+			),
+		},
+		data.ImportStep("administrator_password_wo_version", "create_mode"),
+		{
+			Config: r.writeOnlyPassword(data, "123wsxQAZ", 2),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("administrator_password_wo").DoesNotExist(),
+			),
+		},
+		data.ImportStep("administrator_password_wo_version", "create_mode"),
+	})
+}
+
 func TestAccPostgresqlFlexibleServer_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_postgresql_flexible_server", "test")
 	r := PostgresqlFlexibleServerResource{}
@@ -722,6 +745,24 @@ resource "azurerm_postgresql_flexible_server" "test" {
 `, r.template(data), data.RandomInteger)
 }
 
+func (r PostgresqlFlexibleServerResource) writeOnlyPassword(data acceptance.TestData, password string, version int) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_postgresql_flexible_server" "test" {
+  name                              = "acctest-fs-%d"
+  resource_group_name               = azurerm_resource_group.test.name
+  location                          = azurerm_resource_group.test.location
+  administrator_login               = "adminTerraform"
+  administrator_password_wo         = "%s"
+  administrator_password_wo_version = %d
+  version                           = "12"
+  sku_name                          = "GP_Standard_D2s_v3"
+  zone                              = "2"
+}
+`, r.template(data), data.RandomInteger, password, version)
+}
+
 func (r PostgresqlFlexibleServerResource) geoRestoreSource(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
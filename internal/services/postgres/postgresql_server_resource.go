@@ -582,7 +582,9 @@ func resourcePostgreSQLServerUpdate(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("waiting for %s to become available: %+v", *id, err)
 		}
 	}
-	locks.ByID(primaryID)
+	if err := locks.ByIDWithContext(ctx, primaryID); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(primaryID)
 
 	sku, err := expandServerSkuName(d.Get("sku_name").(string))
@@ -27,7 +27,6 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/redis/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/redis/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -565,11 +564,16 @@ func resourceRedisCacheCreate(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
-		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
+		virtualNetworkId := commonids.NewVirtualNetworkID(parsed.SubscriptionId, parsed.ResourceGroupName, parsed.VirtualNetworkName)
+		if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+			return err
+		}
+		defer locks.UnlockByID(virtualNetworkId.ID())
 
-		locks.ByName(parsed.SubnetName, network.SubnetResourceName)
-		defer locks.UnlockByName(parsed.SubnetName, network.SubnetResourceName)
+		if err := locks.ByIDWithContext(ctx, parsed.ID()); err != nil {
+			return err
+		}
+		defer locks.UnlockByID(parsed.ID())
 
 		parameters.Properties.SubnetId = utils.String(v.(string))
 	}
@@ -891,11 +895,16 @@ func resourceRedisCacheDelete(d *pluginsdk.ResourceData, meta interface{}) error
 			return err
 		}
 
-		locks.ByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
-		defer locks.UnlockByName(parsed.VirtualNetworkName, network.VirtualNetworkResourceName)
+		virtualNetworkId := commonids.NewVirtualNetworkID(parsed.SubscriptionId, parsed.ResourceGroupName, parsed.VirtualNetworkName)
+		if err := locks.ByIDWithContext(ctx, virtualNetworkId.ID()); err != nil {
+			return err
+		}
+		defer locks.UnlockByID(virtualNetworkId.ID())
 
-		locks.ByName(parsed.SubnetName, network.SubnetResourceName)
-		defer locks.UnlockByName(parsed.SubnetName, network.SubnetResourceName)
+		if err := locks.ByIDWithContext(ctx, parsed.ID()); err != nil {
+			return err
+		}
+		defer locks.UnlockByID(parsed.ID())
 	}
 
 	if err := client.DeleteThenPoll(ctx, *id); err != nil {
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-09-01/providers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/resourceproviders"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.DataSource = ResourceProviderRegistrationsDataSource{}
+
+type ResourceProviderRegistrationsDataSource struct{}
+
+type ResourceProviderRegistrationsDataSourceModel struct {
+	ResourceTypes                 []string                             `tfschema:"resource_types"`
+	ResourceProviders             []ResourceProviderRegistrationsModel `tfschema:"resource_providers"`
+	UnregisteredResourceProviders []string                             `tfschema:"unregistered_resource_providers"`
+}
+
+type ResourceProviderRegistrationsModel struct {
+	Name              string   `tfschema:"name"`
+	RegistrationState string   `tfschema:"registration_state"`
+	Registered        bool     `tfschema:"registered"`
+	ResourceTypes     []string `tfschema:"resource_types"`
+}
+
+func (ResourceProviderRegistrationsDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resource_types": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+	}
+}
+
+func (ResourceProviderRegistrationsDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"resource_providers": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"registration_state": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"registered": {
+						Type:     pluginsdk.TypeBool,
+						Computed: true,
+					},
+
+					"resource_types": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+
+		"unregistered_resource_providers": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+	}
+}
+
+func (ResourceProviderRegistrationsDataSource) ModelObject() interface{} {
+	return &ResourceProviderRegistrationsDataSourceModel{}
+}
+
+func (ResourceProviderRegistrationsDataSource) ResourceType() string {
+	return "azurerm_resource_provider_registrations"
+}
+
+func (ResourceProviderRegistrationsDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.ResourceProvidersClient
+			subscriptionId := commonids.NewSubscriptionID(metadata.Client.Account.SubscriptionId)
+
+			var state ResourceProviderRegistrationsDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// when no Resource Types are specified, every Resource Provider available within the Subscription is returned
+			allResourceProviders := len(state.ResourceTypes) == 0
+			resourceTypesByResourceProvider := make(map[string][]string)
+			for _, resourceType := range state.ResourceTypes {
+				resourceProvider, ok := resourceproviders.ForResourceType(resourceType)
+				if !ok {
+					return fmt.Errorf("unable to determine the Resource Provider required by %q", resourceType)
+				}
+				key := strings.ToLower(resourceProvider)
+				resourceTypesByResourceProvider[key] = append(resourceTypesByResourceProvider[key], resourceType)
+			}
+
+			resp, err := client.ListComplete(ctx, subscriptionId, providers.DefaultListOperationOptions())
+			if err != nil {
+				return fmt.Errorf("listing Resource Providers for %s: %+v", subscriptionId, err)
+			}
+
+			state.ResourceProviders = make([]ResourceProviderRegistrationsModel, 0)
+			state.UnregisteredResourceProviders = make([]string, 0)
+			for _, provider := range resp.Items {
+				namespace := pointer.From(provider.Namespace)
+				resourceTypes, ok := resourceTypesByResourceProvider[strings.ToLower(namespace)]
+				if !ok {
+					if !allResourceProviders {
+						continue
+					}
+					resourceTypes = make([]string, 0)
+				}
+				delete(resourceTypesByResourceProvider, strings.ToLower(namespace))
+
+				registrationState := pointer.From(provider.RegistrationState)
+				registered := strings.EqualFold(registrationState, Registered)
+				state.ResourceProviders = append(state.ResourceProviders, ResourceProviderRegistrationsModel{
+					Name:              namespace,
+					RegistrationState: registrationState,
+					Registered:        registered,
+					ResourceTypes:     resourceTypes,
+				})
+				if !registered {
+					state.UnregisteredResourceProviders = append(state.UnregisteredResourceProviders, namespace)
+				}
+			}
+
+			// some Resource Providers may not exist in some non-public clouds
+			for resourceProvider := range resourceTypesByResourceProvider {
+				log.Printf("[WARN] The Resource Provider %q wasn't returned from the Azure API", resourceProvider)
+			}
+
+			sort.Slice(state.ResourceProviders, func(i, j int) bool {
+				return state.ResourceProviders[i].Name < state.ResourceProviders[j].Name
+			})
+			sort.Strings(state.UnregisteredResourceProviders)
+
+			metadata.SetID(subscriptionId)
+			return metadata.Encode(&state)
+		},
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type ResourceProviderRegistrationsDataSource struct{}
+
+func TestAccDataSourceResourceProviderRegistrations_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_provider_registrations", "test")
+	r := ResourceProviderRegistrationsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resource_providers.#").Exists(),
+				check.That(data.ResourceName).Key("unregistered_resource_providers.#").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceResourceProviderRegistrations_withoutProviderRegistrations(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_provider_registrations", "test")
+	r := ResourceProviderRegistrationsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.withoutProviderRegistrations(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resource_providers.0.name").Exists(),
+				check.That(data.ResourceName).Key("unregistered_resource_providers.#").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceResourceProviderRegistrations_resourceTypes(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_provider_registrations", "test")
+	r := ResourceProviderRegistrationsDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.resourceTypes(),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("resource_providers.#").HasValue("2"),
+				check.That(data.ResourceName).Key("resource_providers.0.name").HasValue("Microsoft.Network"),
+				check.That(data.ResourceName).Key("resource_providers.0.registered").HasValue("true"),
+				check.That(data.ResourceName).Key("resource_providers.0.resource_types.#").HasValue("2"),
+				check.That(data.ResourceName).Key("resource_providers.1.name").HasValue("Microsoft.Storage"),
+				check.That(data.ResourceName).Key("resource_providers.1.registration_state").HasValue("Registered"),
+			),
+		},
+	})
+}
+
+func (ResourceProviderRegistrationsDataSource) basic() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_resource_provider_registrations" "test" {}
+`
+}
+
+func (ResourceProviderRegistrationsDataSource) withoutProviderRegistrations() string {
+	return `
+provider "azurerm" {
+  features {}
+  resource_provider_registrations = "none"
+}
+
+data "azurerm_resource_provider_registrations" "test" {}
+`
+}
+
+func (ResourceProviderRegistrationsDataSource) resourceTypes() string {
+	return `
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_resource_provider_registrations" "test" {
+  resource_types = [
+    "azurerm_storage_account",
+    "azurerm_virtual_network",
+    "azurerm_subnet",
+  ]
+}
+`
+}
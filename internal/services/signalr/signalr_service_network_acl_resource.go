@@ -154,7 +154,9 @@ func resourceSignalRServiceNetworkACLCreateUpdate(d *pluginsdk.ResourceData, met
 		return err
 	}
 
-	locks.ByName(id.SignalRName, "azurerm_signalr_service")
+	if err := locks.ByNameWithContext(ctx, id.SignalRName, "azurerm_signalr_service"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.SignalRName, "azurerm_signalr_service")
 
 	resp, err := client.Get(ctx, *id)
@@ -260,7 +262,9 @@ func resourceSignalRServiceNetworkACLDelete(d *pluginsdk.ResourceData, meta inte
 		return err
 	}
 
-	locks.ByName(id.SignalRName, "azurerm_signalr_service")
+	if err := locks.ByNameWithContext(ctx, id.SignalRName, "azurerm_signalr_service"); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.SignalRName, "azurerm_signalr_service")
 
 	resp, err := client.Get(ctx, *id)
@@ -329,7 +329,9 @@ func resourceArmSignalRServiceUpdate(d *pluginsdk.ResourceData, meta interface{}
 		return err
 	}
 
-	locks.ByID(id.ID())
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	resourceType := signalr.SignalRResource{}
@@ -490,7 +492,9 @@ func resourceArmSignalRServiceDelete(d *pluginsdk.ResourceData, meta interface{}
 		return err
 	}
 
-	locks.ByID(id.ID())
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	// @tombuildsstuff: we can't use DeleteThenPoll here since the API returns a 404 on the Future in time
@@ -0,0 +1,528 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/shim"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+// BlobDirectorySync uploads the files within a local directory into a Storage Container, only uploading the
+// files whose content (or properties) differ from the existing blob
+type BlobDirectorySync struct {
+	BlobsClient      *blobs.Client
+	ContainersClient shim.StorageContainerWrapper
+
+	ContainerName string
+	Prefix        string
+
+	CacheControl          string
+	ContentTypes          map[string]string
+	DeleteExtraneousBlobs bool
+	Parallelism           int
+	Source                string
+}
+
+// directoryBlob is the content and properties of a blob, either for a local file or an existing blob
+type directoryBlob struct {
+	// ContentMD5 is the hex encoded MD5 hash of the content
+	ContentMD5   string
+	ContentType  string
+	CacheControl string
+
+	path string
+}
+
+func (b directoryBlob) matches(other directoryBlob) bool {
+	return b.ContentMD5 == other.ContentMD5 && b.ContentType == other.ContentType && b.CacheControl == other.CacheControl
+}
+
+// blobDirectoryBlockSize is the size of each block when uploading a file which is larger than a single block, which
+// limits the memory used by each upload to a single block rather than the entire file
+const blobDirectoryBlockSize = 4 * 1024 * 1024
+
+// blobDirectoryContentTypes are the content types for common file extensions, which are used rather than the
+// system's MIME database so that the content type (and therefore the `content_hash`) is the same on every machine
+var blobDirectoryContentTypes = map[string]string{
+	".avif":  "image/avif",
+	".css":   "text/css; charset=utf-8",
+	".csv":   "text/csv; charset=utf-8",
+	".gif":   "image/gif",
+	".gz":    "application/gzip",
+	".htm":   "text/html; charset=utf-8",
+	".html":  "text/html; charset=utf-8",
+	".ico":   "image/vnd.microsoft.icon",
+	".jpeg":  "image/jpeg",
+	".jpg":   "image/jpeg",
+	".js":    "text/javascript; charset=utf-8",
+	".json":  "application/json",
+	".map":   "application/json",
+	".md":    "text/markdown; charset=utf-8",
+	".mjs":   "text/javascript; charset=utf-8",
+	".mp3":   "audio/mpeg",
+	".mp4":   "video/mp4",
+	".otf":   "font/otf",
+	".pdf":   "application/pdf",
+	".png":   "image/png",
+	".svg":   "image/svg+xml",
+	".tar":   "application/x-tar",
+	".ttf":   "font/ttf",
+	".txt":   "text/plain; charset=utf-8",
+	".wasm":  "application/wasm",
+	".webm":  "video/webm",
+	".webp":  "image/webp",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+	".xml":   "text/xml; charset=utf-8",
+	".yaml":  "application/yaml",
+	".yml":   "application/yaml",
+	".zip":   "application/zip",
+}
+
+// blobDirectoryFiles caches the MD5 hash of each local file (and the content type detected from its content) for the
+// lifetime of the provider process, so that a file is only read again when its size or modification time changes
+var blobDirectoryFiles = &blobDirectoryFileCache{
+	items: make(map[string]blobDirectoryFile),
+}
+
+type blobDirectoryFileCache struct {
+	lock  sync.Mutex
+	items map[string]blobDirectoryFile
+}
+
+type blobDirectoryFile struct {
+	size    int64
+	modTime time.Time
+
+	contentMD5          string
+	detectedContentType string
+}
+
+func (c *blobDirectoryFileCache) get(filePath string, info fs.FileInfo) (*blobDirectoryFile, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	item, ok := c.items[filePath]
+	if !ok || item.size != info.Size() || !item.modTime.Equal(info.ModTime()) {
+		return nil, false
+	}
+	return &item, true
+}
+
+func (c *blobDirectoryFileCache) set(filePath string, item blobDirectoryFile) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.items[filePath] = item
+}
+
+// normalizeBlobDirectoryPrefix returns the prefix with a trailing `/` so that it's used as a virtual directory
+func normalizeBlobDirectoryPrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return prefix + "/"
+}
+
+// directoryHash returns a hash of the name and properties of each blob in the directory, which changes
+// when any file is added, removed or modified
+func directoryHash(items map[string]directoryBlob) string {
+	names := make([]string, 0, len(items))
+	for name := range items {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	hash := sha256.New()
+	for _, name := range names {
+		item := items[name]
+		fmt.Fprintf(hash, "%s\t%s\t%s\t%s\n", name, item.ContentMD5, item.ContentType, item.CacheControl)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// LocalFileNames returns the relative (slash separated) name of each file within the source directory
+func (s BlobDirectorySync) LocalFileNames() ([]string, error) {
+	names := make([]string, 0)
+	err := filepath.WalkDir(s.Source, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.Source, filePath)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading the directory %q: %+v", s.Source, err)
+	}
+
+	return names, nil
+}
+
+// LocalFiles returns the content hash and properties of each file within the source directory, keyed by the
+// relative name of the file
+func (s BlobDirectorySync) LocalFiles() (map[string]directoryBlob, error) {
+	names, err := s.LocalFileNames()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]directoryBlob, len(names))
+	for _, name := range names {
+		filePath := filepath.Join(s.Source, filepath.FromSlash(name))
+		contentMD5, contentType, err := s.inspectFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		result[name] = directoryBlob{
+			ContentMD5:   contentMD5,
+			ContentType:  contentType,
+			CacheControl: s.CacheControl,
+			path:         filePath,
+		}
+	}
+
+	return result, nil
+}
+
+// inspectFile returns the hex encoded MD5 hash and the content type of the file
+func (s BlobDirectorySync) inspectFile(filePath string) (contentMD5 string, contentType string, err error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return "", "", fmt.Errorf("retrieving information for %q: %+v", filePath, err)
+	}
+
+	item, ok := blobDirectoryFiles.get(filePath, info)
+	if !ok {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return "", "", fmt.Errorf("opening %q: %+v", filePath, err)
+		}
+		defer file.Close()
+
+		// the first 512 bytes are used to detect the content type when the extension isn't known
+		head := make([]byte, 512)
+		n, err := io.ReadFull(file, head)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return "", "", fmt.Errorf("reading %q: %+v", filePath, err)
+		}
+		head = head[:n]
+
+		hash := md5.New()
+		hash.Write(head)
+		if _, err := io.Copy(hash, file); err != nil {
+			return "", "", fmt.Errorf("reading %q: %+v", filePath, err)
+		}
+
+		item = &blobDirectoryFile{
+			size:                info.Size(),
+			modTime:             info.ModTime(),
+			contentMD5:          hex.EncodeToString(hash.Sum(nil)),
+			detectedContentType: http.DetectContentType(head),
+		}
+		blobDirectoryFiles.set(filePath, *item)
+	}
+
+	return item.contentMD5, s.contentType(filePath, item.detectedContentType), nil
+}
+
+// contentType returns the content type configured for the file extension, else the content type for common
+// file extensions, else the content type detected from the content of the file
+func (s BlobDirectorySync) contentType(filePath string, detectedContentType string) string {
+	ext := strings.ToLower(path.Ext(filePath))
+	if ext != "" {
+		// the extensions may be configured with or without the leading `.`
+		if v, ok := s.ContentTypes[ext]; ok {
+			return v
+		}
+		if v, ok := s.ContentTypes[strings.TrimPrefix(ext, ".")]; ok {
+			return v
+		}
+		if v, ok := blobDirectoryContentTypes[ext]; ok {
+			return v
+		}
+	}
+	return detectedContentType
+}
+
+// RemoteBlobs returns the content hash and properties of each existing blob within the prefix, keyed by the
+// name of the blob relative to the prefix
+func (s BlobDirectorySync) RemoteBlobs(ctx context.Context) (map[string]directoryBlob, error) {
+	existing, err := s.ContainersClient.ListBlobs(ctx, s.ContainerName, s.Prefix)
+	if err != nil {
+		return nil, fmt.Errorf("listing blobs in Container %q with the prefix %q: %+v", s.ContainerName, s.Prefix, err)
+	}
+
+	result := make(map[string]directoryBlob)
+	if existing == nil {
+		return result, nil
+	}
+	for _, item := range *existing {
+		if item.Deleted || !strings.HasPrefix(item.Name, s.Prefix) {
+			continue
+		}
+
+		blob := directoryBlob{}
+		if props := item.Properties; props != nil {
+			if props.ContentMD5 != nil && *props.ContentMD5 != "" {
+				// Azure uses a Base64 encoded representation of the standard MD5 sum of the file
+				contentMD5, err := convertBase64ToHexEncoding(*props.ContentMD5)
+				if err != nil {
+					return nil, fmt.Errorf("decoding the Content MD5 of the blob %q: %+v", item.Name, err)
+				}
+				blob.ContentMD5 = contentMD5
+			}
+			blob.ContentType = pointer.From(props.ContentType)
+			blob.CacheControl = pointer.From(props.CacheControl)
+		}
+		result[strings.TrimPrefix(item.Name, s.Prefix)] = blob
+	}
+
+	return result, nil
+}
+
+// RemoteHash returns the hash of the existing blobs for each of the names (and any other blobs within the prefix,
+// when these are deleted) - so that this matches the hash of the local files once synced
+func (s BlobDirectorySync) RemoteHash(ctx context.Context, names []string) (string, error) {
+	remote, err := s.RemoteBlobs(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	items := make(map[string]directoryBlob)
+	if s.DeleteExtraneousBlobs {
+		items = remote
+	}
+	for _, name := range names {
+		// a missing blob is included with empty properties, so that the hash differs from the local files
+		items[name] = remote[name]
+	}
+
+	return directoryHash(items), nil
+}
+
+// Sync uploads each new or modified file within the source directory in parallel, deleting the blobs for any
+// previously synced files which no longer exist (and any other blobs within the prefix when DeleteExtraneousBlobs
+// is set) - returning the hash and the (sorted) names of the synced files
+func (s BlobDirectorySync) Sync(ctx context.Context, previous []string) (string, []string, error) {
+	local, err := s.LocalFiles()
+	if err != nil {
+		return "", nil, err
+	}
+	remote, err := s.RemoteBlobs(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	names := make([]string, 0, len(local))
+	uploads := make([]string, 0)
+	for name, item := range local {
+		names = append(names, name)
+		if existing, ok := remote[name]; !ok || !existing.matches(item) {
+			uploads = append(uploads, name)
+		}
+	}
+	sort.Strings(names)
+
+	// the blobs for previously synced files which have since been removed are deleted, as are any other blobs
+	// within the prefix when DeleteExtraneousBlobs is set
+	extraneous := make(map[string]struct{})
+	for _, name := range previous {
+		extraneous[name] = struct{}{}
+	}
+	deletes := make([]string, 0)
+	for name := range remote {
+		if _, ok := local[name]; ok {
+			continue
+		}
+		if _, ok := extraneous[name]; ok || s.DeleteExtraneousBlobs {
+			deletes = append(deletes, name)
+		}
+	}
+
+	log.Printf("[DEBUG] Syncing %q into Container %q with the prefix %q: uploading %d files and deleting %d blobs (%d unchanged)", s.Source, s.ContainerName, s.Prefix, len(uploads), len(deletes), len(local)-len(uploads))
+	if err := s.parallel(uploads, func(name string) error {
+		return s.upload(ctx, name, local[name])
+	}); err != nil {
+		return "", nil, err
+	}
+	if err := s.parallel(deletes, func(name string) error {
+		return s.delete(ctx, name)
+	}); err != nil {
+		return "", nil, err
+	}
+
+	return directoryHash(local), names, nil
+}
+
+// Delete deletes the blobs with the specified names (relative to the prefix), which are the blobs previously
+// synced - so that the source directory isn't required and no other blobs within the prefix are deleted
+func (s BlobDirectorySync) Delete(ctx context.Context, names []string) error {
+	return s.parallel(names, func(name string) error {
+		return s.delete(ctx, name)
+	})
+}
+
+func (s BlobDirectorySync) upload(ctx context.Context, name string, item directoryBlob) error {
+	contentMD5, err := convertHexToBase64Encoding(item.ContentMD5)
+	if err != nil {
+		return fmt.Errorf("encoding the Content MD5 for %q: %+v", item.path, err)
+	}
+
+	file, err := os.Open(item.path)
+	if err != nil {
+		return fmt.Errorf("opening %q: %+v", item.path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("retrieving information for %q: %+v", item.path, err)
+	}
+
+	var cacheControl *string
+	if s.CacheControl != "" {
+		cacheControl = pointer.To(s.CacheControl)
+	}
+
+	blobName := s.Prefix + name
+	if info.Size() <= blobDirectoryBlockSize {
+		content, err := io.ReadAll(file)
+		if err != nil {
+			return fmt.Errorf("reading %q: %+v", item.path, err)
+		}
+
+		input := blobs.PutBlockBlobInput{
+			CacheControl: cacheControl,
+			Content:      &content,
+			ContentMD5:   pointer.To(contentMD5),
+			ContentType:  pointer.To(item.ContentType),
+		}
+		if _, err := s.BlobsClient.PutBlockBlob(ctx, s.ContainerName, blobName, input); err != nil {
+			return fmt.Errorf("uploading %q to the blob %q: %+v", item.path, blobName, err)
+		}
+
+		return nil
+	}
+
+	// larger files are streamed as a series of blocks, which are then committed as the blob
+	buffer := make([]byte, blobDirectoryBlockSize)
+	blockIds := make([]blobs.BlockID, 0)
+	for i := 0; ; i++ {
+		n, err := io.ReadFull(file, buffer)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return fmt.Errorf("reading %q: %+v", item.path, err)
+		}
+
+		// each Block ID within a blob must be the same length
+		blockId := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%06d", i)))
+		input := blobs.PutBlockInput{
+			BlockID: blockId,
+			Content: buffer[:n],
+		}
+		if _, err := s.BlobsClient.PutBlock(ctx, s.ContainerName, blobName, input); err != nil {
+			return fmt.Errorf("uploading block %d of %q to the blob %q: %+v", i, item.path, blobName, err)
+		}
+		blockIds = append(blockIds, blobs.BlockID{Value: blockId})
+	}
+
+	input := blobs.PutBlockListInput{
+		BlockList: blobs.BlockList{
+			LatestBlockIDs: blockIds,
+		},
+		CacheControl: cacheControl,
+		ContentMD5:   pointer.To(contentMD5),
+		ContentType:  pointer.To(item.ContentType),
+	}
+	if _, err := s.BlobsClient.PutBlockList(ctx, s.ContainerName, blobName, input); err != nil {
+		return fmt.Errorf("committing the blocks of %q to the blob %q: %+v", item.path, blobName, err)
+	}
+
+	return nil
+}
+
+func (s BlobDirectorySync) delete(ctx context.Context, name string) error {
+	input := blobs.DeleteInput{
+		DeleteSnapshots: true,
+	}
+	if resp, err := s.BlobsClient.Delete(ctx, s.ContainerName, s.Prefix+name, input); err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return nil
+		}
+		return fmt.Errorf("deleting the blob %q: %+v", s.Prefix+name, err)
+	}
+
+	return nil
+}
+
+// parallel calls fn for each name using up to Parallelism workers, returning the first error (if any)
+func (s BlobDirectorySync) parallel(names []string, fn func(name string) error) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	workerCount := s.Parallelism
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	items := make(chan string, len(names))
+	for _, name := range names {
+		items <- name
+	}
+	close(items)
+
+	errors := make(chan error, len(names))
+	wg := &sync.WaitGroup{}
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for name := range items {
+				if err := fn(name); err != nil {
+					errors <- err
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errors)
+
+	if len(errors) > 0 {
+		return fmt.Errorf("%d of %d operations failed, the first error was: %+v", len(errors), len(names), <-errors)
+	}
+
+	return nil
+}
@@ -32,6 +32,10 @@ type Client struct {
 	SyncServiceClient          *storagesyncservicesresource.StorageSyncServicesResourceClient
 
 	authConfigForAzureAD *auth.Credentials
+
+	// authConfig is used for data plane operations which only support Azure AD authentication, regardless
+	// of whether Azure AD authentication is enabled for the Storage data plane
+	authConfig *auth.Credentials
 }
 
 func NewClient(o *common.ClientOptions) (*Client, error) {
@@ -91,6 +95,8 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		SyncGroupsClient:           syncGroupsClient,
 
 		StorageDomainSuffix: *storageSuffix,
+
+		authConfig: o.AuthConfig,
 	}
 
 	if o.StorageUseAzureAD {
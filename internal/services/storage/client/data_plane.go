@@ -28,6 +28,7 @@ type DataPlaneOperation struct {
 	SupportsSharedKeyAuthentication bool
 
 	sharedKeyAuthenticationType auth.SharedKeyType
+	requiresAadAuthentication   bool
 }
 
 func (Client) DataPlaneOperationSupportingAnyAuthMethod() DataPlaneOperation {
@@ -44,10 +45,25 @@ func (Client) DataPlaneOperationSupportingOnlySharedKeyAuth() DataPlaneOperation
 	}
 }
 
+// DataPlaneOperationSupportingOnlyAzureAD is used for operations which can only be authenticated using Azure AD,
+// such as retrieving a User Delegation Key - and so uses Azure AD even when `storage_use_azuread` isn't enabled
+func (Client) DataPlaneOperationSupportingOnlyAzureAD() DataPlaneOperation {
+	return DataPlaneOperation{
+		SupportsAadAuthentication:       true,
+		SupportsSharedKeyAuthentication: false,
+		requiresAadAuthentication:       true,
+	}
+}
+
 func (c Client) configureDataPlane(ctx context.Context, clientName, resourceIdentifier string, baseClient client.BaseClient, account AccountDetails, operation DataPlaneOperation) error {
-	if operation.SupportsAadAuthentication && c.authConfigForAzureAD != nil {
-		api := c.authConfigForAzureAD.Environment.Storage.WithResourceIdentifier(resourceIdentifier)
-		storageAuth, err := auth.NewAuthorizerFromCredentials(ctx, *c.authConfigForAzureAD, api)
+	authConfig := c.authConfigForAzureAD
+	if operation.requiresAadAuthentication {
+		authConfig = c.authConfig
+	}
+
+	if operation.SupportsAadAuthentication && authConfig != nil {
+		api := authConfig.Environment.Storage.WithResourceIdentifier(resourceIdentifier)
+		storageAuth, err := auth.NewAuthorizerFromCredentials(ctx, *authConfig, api)
 		if err != nil {
 			return fmt.Errorf("unable to build authorizer for Storage API: %+v", err)
 		}
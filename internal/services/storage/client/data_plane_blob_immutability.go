@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+)
+
+type BlobImmutabilityPolicyMode string
+
+const (
+	BlobImmutabilityPolicyModeLocked   BlobImmutabilityPolicyMode = "Locked"
+	BlobImmutabilityPolicyModeUnlocked BlobImmutabilityPolicyMode = "Unlocked"
+)
+
+var _ client.Options = blobImmutabilityOptions{}
+
+type blobImmutabilityOptions struct {
+	comp    string
+	headers map[string]string
+}
+
+func (o blobImmutabilityOptions) ToHeaders() *client.Headers {
+	headers := &client.Headers{}
+	for k, v := range o.headers {
+		headers.Append(k, v)
+	}
+	return headers
+}
+
+func (blobImmutabilityOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (o blobImmutabilityOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("comp", o.comp)
+	return out
+}
+
+// SetBlobImmutabilityPolicy sets the Immutability Policy for the specified Blob, which requires version-level
+// immutability support to be enabled on the Container (or the Storage Account)
+// See: https://learn.microsoft.com/rest/api/storageservices/set-blob-immutability-policy
+func (c Client) SetBlobImmutabilityPolicy(ctx context.Context, account AccountDetails, containerName, blobName string, expiry time.Time, mode BlobImmutabilityPolicyMode, operation DataPlaneOperation) error {
+	return c.executeBlobImmutabilityOperation(ctx, account, containerName, blobName, http.MethodPut, http.StatusOK, blobImmutabilityOptions{
+		comp: "immutabilityPolicies",
+		headers: map[string]string{
+			"x-ms-immutability-policy-until-date": expiry.UTC().Format(http.TimeFormat),
+			"x-ms-immutability-policy-mode":       string(mode),
+		},
+	}, operation)
+}
+
+// DeleteBlobImmutabilityPolicy deletes the Immutability Policy for the specified Blob, which is only possible
+// whilst the policy is Unlocked
+// See: https://learn.microsoft.com/rest/api/storageservices/delete-blob-immutability-policy
+func (c Client) DeleteBlobImmutabilityPolicy(ctx context.Context, account AccountDetails, containerName, blobName string, operation DataPlaneOperation) error {
+	return c.executeBlobImmutabilityOperation(ctx, account, containerName, blobName, http.MethodDelete, http.StatusOK, blobImmutabilityOptions{
+		comp: "immutabilityPolicies",
+	}, operation)
+}
+
+// SetBlobLegalHold sets (or clears) the Legal Hold for the specified Blob
+// See: https://learn.microsoft.com/rest/api/storageservices/set-blob-legal-hold
+func (c Client) SetBlobLegalHold(ctx context.Context, account AccountDetails, containerName, blobName string, enabled bool, operation DataPlaneOperation) error {
+	return c.executeBlobImmutabilityOperation(ctx, account, containerName, blobName, http.MethodPut, http.StatusOK, blobImmutabilityOptions{
+		comp: "legalhold",
+		headers: map[string]string{
+			"x-ms-legal-hold": strconv.FormatBool(enabled),
+		},
+	}, operation)
+}
+
+func (c Client) executeBlobImmutabilityOperation(ctx context.Context, account AccountDetails, containerName, blobName, method string, expectedStatusCode int, options blobImmutabilityOptions, operation DataPlaneOperation) error {
+	const clientName = "Blob Storage Immutability"
+
+	apiClient, err := c.blobsApiClient(ctx, clientName, account, operation)
+	if err != nil {
+		return err
+	}
+
+	opts := client.RequestOptions{
+		ExpectedStatusCodes: []int{
+			expectedStatusCode,
+		},
+		HttpMethod:    method,
+		OptionsObject: options,
+		Path:          fmt.Sprintf("/%s/%s", containerName, blobName),
+	}
+
+	req, err := apiClient.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	if _, err = req.Execute(ctx); err != nil {
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+type listBlobsResult struct {
+	XMLName    xml.Name `xml:"EnumerationResults"`
+	NextMarker *string  `xml:"NextMarker,omitempty"`
+	Blobs      struct {
+		Blobs []listBlobsItem `xml:"Blob"`
+	} `xml:"Blobs"`
+}
+
+type listBlobsItem struct {
+	Name       string                     `xml:"Name"`
+	Deleted    bool                       `xml:"Deleted,omitempty"`
+	Properties *containers.BlobProperties `xml:"Properties,omitempty"`
+	MetaData   blobMetaData               `xml:"Metadata"`
+	Tags       *blobTags                  `xml:"Tags,omitempty"`
+}
+
+// blobMetaData is unmarshalled from the `Metadata` element, where each child element is a key/value pair
+type blobMetaData map[string]string
+
+func (m *blobMetaData) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	out := make(map[string]string)
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value string
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			out[t.Name.Local] = value
+
+		case xml.EndElement:
+			*m = out
+			return nil
+		}
+	}
+}
+
+var _ client.Options = listBlobsOptions{}
+
+type listBlobsOptions struct {
+	include []string
+	marker  *string
+	prefix  *string
+}
+
+func (listBlobsOptions) ToHeaders() *client.Headers {
+	return nil
+}
+
+func (listBlobsOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (o listBlobsOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("restype", "container")
+	out.Append("comp", "list")
+	out.Append("maxresults", "5000")
+	out.Append("include", strings.Join(o.include, ","))
+	if o.marker != nil {
+		out.Append("marker", *o.marker)
+	}
+	if o.prefix != nil {
+		out.Append("prefix", *o.prefix)
+	}
+	return out
+}
+
+type ListedBlob struct {
+	Name       string
+	Deleted    bool
+	Properties *containers.BlobProperties
+	MetaData   map[string]string
+	Tags       map[string]string
+}
+
+// ListBlobsWithMetaDataAndTags lists every blob within the container whose name starts with the prefix, including the
+// MetaData (and optionally the Blob Index Tags) for each blob - which the Containers Client doesn't return - so that
+// these don't need to be retrieved for each blob individually.
+// See: https://learn.microsoft.com/rest/api/storageservices/list-blobs
+func (c Client) ListBlobsWithMetaDataAndTags(ctx context.Context, account AccountDetails, containerName, prefix string, includeTags bool, operation DataPlaneOperation) (*[]ListedBlob, error) {
+	const clientName = "Blob Storage Containers"
+	operation.sharedKeyAuthenticationType = auth.SharedKey
+
+	baseUri, err := account.DataPlaneEndpoint(EndpointTypeBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient, err := containers.NewWithBaseUri(*baseUri)
+	if err != nil {
+		return nil, fmt.Errorf("building %s client: %+v", clientName, err)
+	}
+
+	if err = c.configureDataPlane(ctx, clientName, *baseUri, apiClient.Client, account, operation); err != nil {
+		return nil, err
+	}
+
+	options := listBlobsOptions{
+		include: []string{"metadata"},
+	}
+	// Blob Index Tags aren't supported when the Hierarchical Namespace is enabled
+	if includeTags {
+		options.include = append(options.include, "tags")
+	}
+	if prefix != "" {
+		options.prefix = pointer.To(prefix)
+	}
+
+	result := make([]ListedBlob, 0)
+	for {
+		opts := client.RequestOptions{
+			ContentType: "application/xml; charset=utf-8",
+			ExpectedStatusCodes: []int{
+				http.StatusOK,
+			},
+			HttpMethod:    http.MethodGet,
+			OptionsObject: options,
+			Path:          fmt.Sprintf("/%s", containerName),
+		}
+
+		req, err := apiClient.Client.NewRequest(ctx, opts)
+		if err != nil {
+			return nil, fmt.Errorf("building request: %+v", err)
+		}
+
+		resp, err := req.Execute(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("executing request: %+v", err)
+		}
+
+		var model listBlobsResult
+		if err = resp.Unmarshal(&model); err != nil {
+			return nil, fmt.Errorf("unmarshalling response: %+v", err)
+		}
+
+		for _, item := range model.Blobs.Blobs {
+			blob := ListedBlob{
+				Name:       item.Name,
+				Deleted:    item.Deleted,
+				Properties: item.Properties,
+				MetaData:   item.MetaData,
+				Tags:       make(map[string]string),
+			}
+			if blob.MetaData == nil {
+				blob.MetaData = make(map[string]string)
+			}
+			if item.Tags != nil {
+				for _, tag := range item.Tags.TagSet.Tags {
+					blob.Tags[tag.Key] = tag.Value
+				}
+			}
+			result = append(result, blob)
+		}
+
+		if model.NextMarker == nil || *model.NextMarker == "" {
+			break
+		}
+		options.marker = model.NextMarker
+	}
+
+	return &result, nil
+}
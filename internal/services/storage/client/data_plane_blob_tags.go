@@ -0,0 +1,170 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+type blobTags struct {
+	XMLName xml.Name   `xml:"Tags"`
+	TagSet  blobTagSet `xml:"TagSet"`
+}
+
+type blobTagSet struct {
+	Tags []blobTagXML `xml:"Tag"`
+}
+
+type blobTagXML struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+var _ client.Options = blobTagsOptions{}
+
+type blobTagsOptions struct{}
+
+func (blobTagsOptions) ToHeaders() *client.Headers {
+	return nil
+}
+
+func (blobTagsOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (blobTagsOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("comp", "tags")
+	return out
+}
+
+type BlobTagsResponse struct {
+	HttpResponse *http.Response
+
+	Tags map[string]string
+}
+
+// BlobTags retrieves the Blob Index Tags for the specified Blob, which aren't available from the Blobs Client
+// See: https://learn.microsoft.com/rest/api/storageservices/get-blob-tags
+func (c Client) BlobTags(ctx context.Context, account AccountDetails, containerName, blobName string, operation DataPlaneOperation) (result BlobTagsResponse, err error) {
+	const clientName = "Blob Storage Tags"
+
+	apiClient, err := c.blobsApiClient(ctx, clientName, account, operation)
+	if err != nil {
+		return result, err
+	}
+
+	opts := client.RequestOptions{
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod:    http.MethodGet,
+		OptionsObject: blobTagsOptions{},
+		Path:          fmt.Sprintf("/%s/%s", containerName, blobName),
+	}
+
+	req, err := apiClient.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return result, fmt.Errorf("building request: %+v", err)
+	}
+
+	resp, err := req.Execute(ctx)
+	if resp != nil && resp.Response != nil {
+		result.HttpResponse = resp.Response
+	}
+	if err != nil {
+		return result, fmt.Errorf("executing request: %+v", err)
+	}
+
+	var model blobTags
+	if err = resp.Unmarshal(&model); err != nil {
+		return result, fmt.Errorf("unmarshalling response: %+v", err)
+	}
+
+	result.Tags = make(map[string]string, len(model.TagSet.Tags))
+	for _, tag := range model.TagSet.Tags {
+		result.Tags[tag.Key] = tag.Value
+	}
+
+	return result, nil
+}
+
+// SetBlobTags replaces the Blob Index Tags for the specified Blob, an empty map removes all of the existing tags
+// See: https://learn.microsoft.com/rest/api/storageservices/set-blob-tags
+func (c Client) SetBlobTags(ctx context.Context, account AccountDetails, containerName, blobName string, tags map[string]string, operation DataPlaneOperation) error {
+	const clientName = "Blob Storage Tags"
+
+	apiClient, err := c.blobsApiClient(ctx, clientName, account, operation)
+	if err != nil {
+		return err
+	}
+
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusNoContent,
+		},
+		HttpMethod:    http.MethodPut,
+		OptionsObject: blobTagsOptions{},
+		Path:          fmt.Sprintf("/%s/%s", containerName, blobName),
+	}
+
+	req, err := apiClient.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("building request: %+v", err)
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	input := blobTags{
+		TagSet: blobTagSet{
+			Tags: make([]blobTagXML, 0, len(tags)),
+		},
+	}
+	for _, k := range keys {
+		input.TagSet.Tags = append(input.TagSet.Tags, blobTagXML{
+			Key:   k,
+			Value: tags[k],
+		})
+	}
+	if err = req.Marshal(&input); err != nil {
+		return fmt.Errorf("marshaling request: %+v", err)
+	}
+
+	if _, err = req.Execute(ctx); err != nil {
+		return fmt.Errorf("executing request: %+v", err)
+	}
+
+	return nil
+}
+
+func (c Client) blobsApiClient(ctx context.Context, clientName string, account AccountDetails, operation DataPlaneOperation) (*blobs.Client, error) {
+	baseUri, err := account.DataPlaneEndpoint(EndpointTypeBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient, err := blobs.NewWithBaseUri(*baseUri)
+	if err != nil {
+		return nil, fmt.Errorf("building %s client: %+v", clientName, err)
+	}
+
+	if err = c.configureDataPlane(ctx, clientName, *baseUri, apiClient.Client, account, operation); err != nil {
+		return nil, err
+	}
+
+	return apiClient, nil
+}
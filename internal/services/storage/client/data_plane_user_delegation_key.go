@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-azure-sdk/sdk/odata"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
+)
+
+// UserDelegationKey is a key, obtained using Azure AD credentials, which is used to sign a User Delegation SAS
+type UserDelegationKey struct {
+	SignedOid     string `xml:"SignedOid"`
+	SignedTid     string `xml:"SignedTid"`
+	SignedStart   string `xml:"SignedStart"`
+	SignedExpiry  string `xml:"SignedExpiry"`
+	SignedService string `xml:"SignedService"`
+	SignedVersion string `xml:"SignedVersion"`
+	Value         string `xml:"Value"`
+}
+
+var (
+	// userDelegationKeysCache caches the User Delegation Keys by the Storage Account and validity period, so that the
+	// same key (and therefore the same SAS) is used each time it's requested by this instance of the Provider
+	userDelegationKeysCache = map[string]UserDelegationKey{}
+
+	cacheUserDelegationKeysLock = sync.RWMutex{}
+)
+
+type userDelegationKeyInfo struct {
+	XMLName xml.Name `xml:"KeyInfo"`
+	Start   string   `xml:"Start"`
+	Expiry  string   `xml:"Expiry"`
+}
+
+var _ client.Options = userDelegationKeyOptions{}
+
+type userDelegationKeyOptions struct{}
+
+func (userDelegationKeyOptions) ToHeaders() *client.Headers {
+	return nil
+}
+
+func (userDelegationKeyOptions) ToOData() *odata.Query {
+	return nil
+}
+
+func (userDelegationKeyOptions) ToQuery() *client.QueryParams {
+	out := &client.QueryParams{}
+	out.Append("comp", "userdelegationkey")
+	out.Append("restype", "service")
+	return out
+}
+
+// UserDelegationKey retrieves a User Delegation Key for the Blob service of the Storage Account which is valid
+// between start and expiry (in ISO8601 format), this requires Azure AD authentication
+// See: https://learn.microsoft.com/rest/api/storageservices/get-user-delegation-key
+func (c Client) UserDelegationKey(ctx context.Context, account AccountDetails, start, expiry string) (*UserDelegationKey, error) {
+	const clientName = "Blob Storage User Delegation Key"
+
+	cacheKey := fmt.Sprintf("%s|%s|%s", account.StorageAccountId.ID(), start, expiry)
+	cacheUserDelegationKeysLock.RLock()
+	existing, ok := userDelegationKeysCache[cacheKey]
+	cacheUserDelegationKeysLock.RUnlock()
+	if ok {
+		return &existing, nil
+	}
+
+	log.Printf("[DEBUG] Cache Miss - retrieving a User Delegation Key for %s..", account.StorageAccountId)
+	operation := c.DataPlaneOperationSupportingOnlyAzureAD()
+
+	baseUri, err := account.DataPlaneEndpoint(EndpointTypeBlob)
+	if err != nil {
+		return nil, err
+	}
+
+	apiClient, err := accounts.NewWithBaseUri(*baseUri)
+	if err != nil {
+		return nil, fmt.Errorf("building %s client: %+v", clientName, err)
+	}
+
+	if err = c.configureDataPlane(ctx, clientName, *baseUri, apiClient.Client, account, operation); err != nil {
+		return nil, err
+	}
+
+	opts := client.RequestOptions{
+		ContentType: "application/xml; charset=utf-8",
+		ExpectedStatusCodes: []int{
+			http.StatusOK,
+		},
+		HttpMethod:    http.MethodPost,
+		OptionsObject: userDelegationKeyOptions{},
+		Path:          "/",
+	}
+
+	req, err := apiClient.Client.NewRequest(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+
+	input := userDelegationKeyInfo{
+		Start:  start,
+		Expiry: expiry,
+	}
+	if err = req.Marshal(&input); err != nil {
+		return nil, fmt.Errorf("marshaling request: %+v", err)
+	}
+
+	resp, err := req.Execute(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("executing request: %+v", err)
+	}
+
+	var result UserDelegationKey
+	if err = resp.Unmarshal(&result); err != nil {
+		return nil, fmt.Errorf("unmarshalling response: %+v", err)
+	}
+
+	// the HTTP request isn't made whilst holding the lock, so the first key retrieved for the period is used
+	cacheUserDelegationKeysLock.Lock()
+	defer cacheUserDelegationKeysLock.Unlock()
+	if existing, ok := userDelegationKeysCache[cacheKey]; ok {
+		return &existing, nil
+	}
+	userDelegationKeysCache[cacheKey] = result
+
+	return &result, nil
+}
@@ -31,18 +31,22 @@ func (r Registration) WebsiteCategories() []string {
 // SupportedDataSources returns the supported Data Sources supported by this Service
 func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 	return map[string]*pluginsdk.Resource{
-		"azurerm_storage_account_blob_container_sas": dataSourceStorageAccountBlobContainerSharedAccessSignature(),
-		"azurerm_storage_account_sas":                dataSourceStorageAccountSharedAccessSignature(),
-		"azurerm_storage_account":                    dataSourceStorageAccount(),
-		"azurerm_storage_blob":                       dataSourceStorageBlob(),
-		"azurerm_storage_container":                  dataSourceStorageContainer(),
-		"azurerm_storage_encryption_scope":           dataSourceStorageEncryptionScope(),
-		"azurerm_storage_management_policy":          dataSourceStorageManagementPolicy(),
-		"azurerm_storage_queue":                      dataSourceStorageQueue(),
-		"azurerm_storage_share":                      dataSourceStorageShare(),
-		"azurerm_storage_sync":                       dataSourceStorageSync(),
-		"azurerm_storage_sync_group":                 dataSourceStorageSyncGroup(),
-		"azurerm_storage_table_entity":               dataSourceStorageTableEntity(),
+		"azurerm_storage_account_blob_container_sas":  dataSourceStorageAccountBlobContainerSharedAccessSignature(),
+		"azurerm_storage_account_sas":                 dataSourceStorageAccountSharedAccessSignature(),
+		"azurerm_storage_account_queue_sas":           dataSourceStorageAccountQueueSharedAccessSignature(),
+		"azurerm_storage_account_share_sas":           dataSourceStorageAccountShareSharedAccessSignature(),
+		"azurerm_storage_account_table_sas":           dataSourceStorageAccountTableSharedAccessSignature(),
+		"azurerm_storage_account_user_delegation_sas": dataSourceStorageAccountUserDelegationSharedAccessSignature(),
+		"azurerm_storage_account":                     dataSourceStorageAccount(),
+		"azurerm_storage_blob":                        dataSourceStorageBlob(),
+		"azurerm_storage_container":                   dataSourceStorageContainer(),
+		"azurerm_storage_encryption_scope":            dataSourceStorageEncryptionScope(),
+		"azurerm_storage_management_policy":           dataSourceStorageManagementPolicy(),
+		"azurerm_storage_queue":                       dataSourceStorageQueue(),
+		"azurerm_storage_share":                       dataSourceStorageShare(),
+		"azurerm_storage_sync":                        dataSourceStorageSync(),
+		"azurerm_storage_sync_group":                  dataSourceStorageSyncGroup(),
+		"azurerm_storage_table_entity":                dataSourceStorageTableEntity(),
 	}
 }
 
@@ -53,6 +57,7 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 		"azurerm_storage_account_customer_managed_key": resourceStorageAccountCustomerManagedKey(),
 		"azurerm_storage_account_network_rules":        resourceStorageAccountNetworkRules(),
 		"azurerm_storage_blob":                         resourceStorageBlob(),
+		"azurerm_storage_blob_directory":               resourceStorageBlobDirectory(),
 		"azurerm_storage_blob_inventory_policy":        resourceStorageBlobInventoryPolicy(),
 		"azurerm_storage_container":                    resourceStorageContainer(),
 		"azurerm_storage_encryption_scope":             resourceStorageEncryptionScope(),
@@ -77,6 +82,7 @@ func (r Registration) DataSources() []sdk.DataSource {
 		storageTableDataSource{},
 		storageTableEntitiesDataSource{},
 		storageContainersDataSource{},
+		storageBlobsDataSource{},
 	}
 }
 
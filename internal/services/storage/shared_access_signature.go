@@ -0,0 +1,359 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
+)
+
+// serviceSASSignedVersion is the version of the Service SAS used for Shares, Queues and Tables
+const serviceSASSignedVersion = "2018-11-09"
+
+// userDelegationSASSignedVersion is the version of the User Delegation SAS used for Containers and Blobs
+const userDelegationSASSignedVersion = "2018-11-09"
+
+// serviceSASParameters are the parameters common to each Service SAS (and User Delegation SAS)
+type serviceSASParameters struct {
+	Permissions string
+	Start       string
+	Expiry      string
+	Identifier  string
+	IP          string
+	Protocol    string
+}
+
+// sasResponseHeaders are the values which override the response headers when a File or Blob is retrieved using the SAS
+type sasResponseHeaders struct {
+	CacheControl       string
+	ContentDisposition string
+	ContentEncoding    string
+	ContentLanguage    string
+	ContentType        string
+}
+
+func (h sasResponseHeaders) stringToSign() string {
+	return strings.Join([]string{h.CacheControl, h.ContentDisposition, h.ContentEncoding, h.ContentLanguage, h.ContentType}, "\n")
+}
+
+func (h sasResponseHeaders) appendTo(query *sasQuery) {
+	query.appendIfSet("rscc", h.CacheControl)
+	query.appendIfSet("rscd", h.ContentDisposition)
+	query.appendIfSet("rsce", h.ContentEncoding)
+	query.appendIfSet("rscl", h.ContentLanguage)
+	query.appendIfSet("rsct", h.ContentType)
+}
+
+// tableSASKeyRange limits the entities within a Table which are accessible using the SAS
+type tableSASKeyRange struct {
+	StartPartitionKey string
+	StartRowKey       string
+	EndPartitionKey   string
+	EndRowKey         string
+}
+
+// sasQuery builds the query string for a SAS Token, retaining the order in which the values are appended
+type sasQuery struct {
+	values []string
+}
+
+func (q *sasQuery) append(key, value string) {
+	q.values = append(q.values, fmt.Sprintf("%s=%s", key, url.QueryEscape(value)))
+}
+
+func (q *sasQuery) appendIfSet(key, value string) {
+	if value != "" {
+		q.append(key, value)
+	}
+}
+
+func (q sasQuery) String() string {
+	return "?" + strings.Join(q.values, "&")
+}
+
+// signSharedAccessSignature returns the Base64 encoded HMAC-SHA256 of the string to sign, using the Base64 encoded key
+func signSharedAccessSignature(key string, stringToSign string) (string, error) {
+	binaryKey, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return "", fmt.Errorf("decoding the signing key: %+v", err)
+	}
+	hasher := hmac.New(sha256.New, binaryKey)
+	hasher.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(hasher.Sum(nil)), nil
+}
+
+func (p serviceSASParameters) appendTo(query *sasQuery) {
+	query.append("st", p.Start)
+	query.append("se", p.Expiry)
+	query.append("sp", p.Permissions)
+	query.appendIfSet("sip", p.IP)
+	query.appendIfSet("spr", p.Protocol)
+	query.appendIfSet("si", p.Identifier)
+}
+
+// computeShareSASToken computes a Service SAS Token for a File Share
+// See: https://learn.microsoft.com/rest/api/storageservices/create-service-sas
+func computeShareSASToken(params serviceSASParameters, accountName, accountKey, shareName string, headers sasResponseHeaders) (string, error) {
+	signature, err := signSharedAccessSignature(accountKey, shareSASStringToSign(params, accountName, shareName, headers))
+	if err != nil {
+		return "", err
+	}
+
+	query := &sasQuery{}
+	query.append("sv", serviceSASSignedVersion)
+	query.append("sr", "s") // s for share
+	params.appendTo(query)
+	headers.appendTo(query)
+	query.append("sig", signature)
+
+	return query.String(), nil
+}
+
+func shareSASStringToSign(params serviceSASParameters, accountName, shareName string, headers sasResponseHeaders) string {
+	canonicalizedResource := fmt.Sprintf("/file/%s/%s", accountName, shareName)
+
+	return strings.Join([]string{
+		params.Permissions,
+		params.Start,
+		params.Expiry,
+		canonicalizedResource,
+		params.Identifier,
+		params.IP,
+		params.Protocol,
+		serviceSASSignedVersion,
+		headers.stringToSign(),
+	}, "\n")
+}
+
+// computeQueueSASToken computes a Service SAS Token for a Queue
+// See: https://learn.microsoft.com/rest/api/storageservices/create-service-sas
+func computeQueueSASToken(params serviceSASParameters, accountName, accountKey, queueName string) (string, error) {
+	signature, err := signSharedAccessSignature(accountKey, queueSASStringToSign(params, accountName, queueName))
+	if err != nil {
+		return "", err
+	}
+
+	query := &sasQuery{}
+	query.append("sv", serviceSASSignedVersion)
+	params.appendTo(query)
+	query.append("sig", signature)
+
+	return query.String(), nil
+}
+
+func queueSASStringToSign(params serviceSASParameters, accountName, queueName string) string {
+	canonicalizedResource := fmt.Sprintf("/queue/%s/%s", accountName, queueName)
+
+	return strings.Join([]string{
+		params.Permissions,
+		params.Start,
+		params.Expiry,
+		canonicalizedResource,
+		params.Identifier,
+		params.IP,
+		params.Protocol,
+		serviceSASSignedVersion,
+	}, "\n")
+}
+
+// computeTableSASToken computes a Service SAS Token for a Table, optionally limited to a range of Partition and Row Keys
+// See: https://learn.microsoft.com/rest/api/storageservices/create-service-sas
+func computeTableSASToken(params serviceSASParameters, accountName, accountKey, tableName string, keys tableSASKeyRange) (string, error) {
+	signature, err := signSharedAccessSignature(accountKey, tableSASStringToSign(params, accountName, tableName, keys))
+	if err != nil {
+		return "", err
+	}
+
+	query := &sasQuery{}
+	query.append("sv", serviceSASSignedVersion)
+	query.append("tn", tableName)
+	params.appendTo(query)
+	query.appendIfSet("spk", keys.StartPartitionKey)
+	query.appendIfSet("srk", keys.StartRowKey)
+	query.appendIfSet("epk", keys.EndPartitionKey)
+	query.appendIfSet("erk", keys.EndRowKey)
+	query.append("sig", signature)
+
+	return query.String(), nil
+}
+
+func tableSASStringToSign(params serviceSASParameters, accountName, tableName string, keys tableSASKeyRange) string {
+	// the name of the table must be lower-case within the canonicalized resource
+	canonicalizedResource := fmt.Sprintf("/table/%s/%s", accountName, strings.ToLower(tableName))
+
+	return strings.Join([]string{
+		params.Permissions,
+		params.Start,
+		params.Expiry,
+		canonicalizedResource,
+		params.Identifier,
+		params.IP,
+		params.Protocol,
+		serviceSASSignedVersion,
+		keys.StartPartitionKey,
+		keys.StartRowKey,
+		keys.EndPartitionKey,
+		keys.EndRowKey,
+	}, "\n")
+}
+
+// computeUserDelegationSASToken computes a User Delegation SAS Token for a Container - or a Blob within it when
+// blobName is specified - which is signed using a User Delegation Key obtained using Azure AD credentials
+// See: https://learn.microsoft.com/rest/api/storageservices/create-user-delegation-sas
+func computeUserDelegationSASToken(params serviceSASParameters, key client.UserDelegationKey, accountName, containerName, blobName string, headers sasResponseHeaders) (string, error) {
+	signedResource := userDelegationSASSignedResource(blobName)
+
+	signature, err := signSharedAccessSignature(key.Value, userDelegationSASStringToSign(params, key, accountName, containerName, blobName, headers))
+	if err != nil {
+		return "", err
+	}
+
+	query := &sasQuery{}
+	query.append("sv", userDelegationSASSignedVersion)
+	query.append("sr", signedResource)
+	query.append("st", params.Start)
+	query.append("se", params.Expiry)
+	query.append("sp", params.Permissions)
+	query.append("skoid", key.SignedOid)
+	query.append("sktid", key.SignedTid)
+	query.append("skt", key.SignedStart)
+	query.append("ske", key.SignedExpiry)
+	query.append("sks", key.SignedService)
+	query.append("skv", key.SignedVersion)
+	query.appendIfSet("sip", params.IP)
+	query.appendIfSet("spr", params.Protocol)
+	headers.appendTo(query)
+	query.append("sig", signature)
+
+	return query.String(), nil
+}
+
+func userDelegationSASSignedResource(blobName string) string {
+	if blobName != "" {
+		return "b" // b for blob
+	}
+	return "c" // c for container
+}
+
+func userDelegationSASStringToSign(params serviceSASParameters, key client.UserDelegationKey, accountName, containerName, blobName string, headers sasResponseHeaders) string {
+	canonicalizedResource := fmt.Sprintf("/blob/%s/%s", accountName, containerName)
+	if blobName != "" {
+		canonicalizedResource = fmt.Sprintf("%s/%s", canonicalizedResource, blobName)
+	}
+	signedSnapshotTime := ""
+
+	// a User Delegation SAS doesn't support a Stored Access Policy, so the Identifier isn't included
+	return strings.Join([]string{
+		params.Permissions,
+		params.Start,
+		params.Expiry,
+		canonicalizedResource,
+		key.SignedOid,
+		key.SignedTid,
+		key.SignedStart,
+		key.SignedExpiry,
+		key.SignedService,
+		key.SignedVersion,
+		params.IP,
+		params.Protocol,
+		userDelegationSASSignedVersion,
+		userDelegationSASSignedResource(blobName),
+		signedSnapshotTime,
+		headers.stringToSign(),
+	}, "\n")
+}
+
+// userDelegationKeyMaximumValidity is the maximum period (from the current time) for which a User Delegation Key,
+// and therefore a User Delegation SAS, can be valid
+const userDelegationKeyMaximumValidity = 7 * 24 * time.Hour
+
+func validateUserDelegationKeyPeriod(start, expiry, now time.Time) error {
+	if !expiry.After(start) {
+		return fmt.Errorf("`expiry` must be after `start`")
+	}
+	if expiry.After(now.Add(userDelegationKeyMaximumValidity)) {
+		return fmt.Errorf("`expiry` must be within 7 days of the current time, since a User Delegation Key can only be valid for up to 7 days")
+	}
+	return nil
+}
+
+// BuildSharePermissionsString builds the permissions string for a Service SAS for a File Share
+func BuildSharePermissionsString(perms map[string]interface{}) string {
+	retVal := ""
+
+	if val, pres := perms["read"].(bool); pres && val {
+		retVal += "r"
+	}
+
+	if val, pres := perms["create"].(bool); pres && val {
+		retVal += "c"
+	}
+
+	if val, pres := perms["write"].(bool); pres && val {
+		retVal += "w"
+	}
+
+	if val, pres := perms["delete"].(bool); pres && val {
+		retVal += "d"
+	}
+
+	if val, pres := perms["list"].(bool); pres && val {
+		retVal += "l"
+	}
+
+	return retVal
+}
+
+// BuildQueuePermissionsString builds the permissions string for a Service SAS for a Queue
+func BuildQueuePermissionsString(perms map[string]interface{}) string {
+	retVal := ""
+
+	if val, pres := perms["read"].(bool); pres && val {
+		retVal += "r"
+	}
+
+	if val, pres := perms["add"].(bool); pres && val {
+		retVal += "a"
+	}
+
+	if val, pres := perms["update"].(bool); pres && val {
+		retVal += "u"
+	}
+
+	if val, pres := perms["process"].(bool); pres && val {
+		retVal += "p"
+	}
+
+	return retVal
+}
+
+// BuildTablePermissionsString builds the permissions string for a Service SAS for a Table
+func BuildTablePermissionsString(perms map[string]interface{}) string {
+	retVal := ""
+
+	if val, pres := perms["read"].(bool); pres && val {
+		retVal += "r"
+	}
+
+	if val, pres := perms["add"].(bool); pres && val {
+		retVal += "a"
+	}
+
+	if val, pres := perms["update"].(bool); pres && val {
+		retVal += "u"
+	}
+
+	if val, pres := perms["delete"].(bool); pres && val {
+		retVal += "d"
+	}
+
+	return retVal
+}
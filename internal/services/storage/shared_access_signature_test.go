@@ -0,0 +1,326 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
+)
+
+const (
+	// testSASAccountKey is the Base64 encoding of `0123456789abcdef0123456789abcdef`
+	testSASAccountKey = "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+
+	// testSASUserDelegationKey is the Base64 encoding of `fedcba9876543210fedcba9876543210`
+	testSASUserDelegationKey = "ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="
+
+	testSASStart  = "2024-01-01T00:00:00Z"
+	testSASExpiry = "2024-01-02T00:00:00Z"
+)
+
+func TestShareSASToken(t *testing.T) {
+	testData := []struct {
+		Name                 string
+		Params               serviceSASParameters
+		Headers              sasResponseHeaders
+		ExpectedStringToSign []string
+		ExpectedToken        string
+	}{
+		{
+			Name: "ip address and response headers",
+			Params: serviceSASParameters{
+				Permissions: "rcwdl",
+				Start:       testSASStart,
+				Expiry:      testSASExpiry,
+				IP:          "168.1.5.60-168.1.5.70",
+				Protocol:    "https",
+			},
+			Headers: sasResponseHeaders{
+				CacheControl: "max-age=5",
+				ContentType:  "application/json",
+			},
+			ExpectedStringToSign: []string{
+				"rcwdl",
+				testSASStart,
+				testSASExpiry,
+				"/file/example/share1",
+				"",
+				"168.1.5.60-168.1.5.70",
+				"https",
+				"2018-11-09",
+				"max-age=5",
+				"",
+				"",
+				"",
+				"application/json",
+			},
+			ExpectedToken: "?sv=2018-11-09&sr=s&st=2024-01-01T00%3A00%3A00Z&se=2024-01-02T00%3A00%3A00Z&sp=rcwdl&sip=168.1.5.60-168.1.5.70&spr=https&rscc=max-age%3D5&rsct=application%2Fjson&sig=lcVi79B0qL8drmHT6ruyo3dzspKwLhiPJ%2FHjtuXyby0%3D",
+		},
+		{
+			Name: "stored access policy",
+			Params: serviceSASParameters{
+				Permissions: "r",
+				Start:       testSASStart,
+				Expiry:      testSASExpiry,
+				Identifier:  "policy1",
+				Protocol:    "https,http",
+			},
+			ExpectedStringToSign: []string{
+				"r",
+				testSASStart,
+				testSASExpiry,
+				"/file/example/share1",
+				"policy1",
+				"",
+				"https,http",
+				"2018-11-09",
+				"",
+				"",
+				"",
+				"",
+				"",
+			},
+			ExpectedToken: "?sv=2018-11-09&sr=s&st=2024-01-01T00%3A00%3A00Z&se=2024-01-02T00%3A00%3A00Z&sp=r&spr=https%2Chttp&si=policy1&sig=A32WTdT0vOjxfEERkJguBuPyxmBq8qdBoGzXY4tUFSg%3D",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		if actual, expected := shareSASStringToSign(v.Params, "example", "share1", v.Headers), strings.Join(v.ExpectedStringToSign, "\n"); actual != expected {
+			t.Fatalf("expected the string to sign to be %q but got %q", expected, actual)
+		}
+
+		token, err := computeShareSASToken(v.Params, "example", testSASAccountKey, "share1", v.Headers)
+		if err != nil {
+			t.Fatalf("computing the token: %+v", err)
+		}
+		if token != v.ExpectedToken {
+			t.Fatalf("expected the token to be %q but got %q", v.ExpectedToken, token)
+		}
+	}
+}
+
+func TestQueueSASToken(t *testing.T) {
+	params := serviceSASParameters{
+		Permissions: "raup",
+		Start:       testSASStart,
+		Expiry:      testSASExpiry,
+		Protocol:    "https",
+	}
+
+	expectedStringToSign := strings.Join([]string{
+		"raup",
+		testSASStart,
+		testSASExpiry,
+		"/queue/example/queue1",
+		"",
+		"",
+		"https",
+		"2018-11-09",
+	}, "\n")
+	if actual := queueSASStringToSign(params, "example", "queue1"); actual != expectedStringToSign {
+		t.Fatalf("expected the string to sign to be %q but got %q", expectedStringToSign, actual)
+	}
+
+	token, err := computeQueueSASToken(params, "example", testSASAccountKey, "queue1")
+	if err != nil {
+		t.Fatalf("computing the token: %+v", err)
+	}
+	expectedToken := "?sv=2018-11-09&st=2024-01-01T00%3A00%3A00Z&se=2024-01-02T00%3A00%3A00Z&sp=raup&spr=https&sig=m%2Bzl4yEMcnhWr9GS7hRklFH%2BOJkWLPxmB7zvAMznsoU%3D"
+	if token != expectedToken {
+		t.Fatalf("expected the token to be %q but got %q", expectedToken, token)
+	}
+}
+
+func TestTableSASToken(t *testing.T) {
+	params := serviceSASParameters{
+		Permissions: "raud",
+		Start:       testSASStart,
+		Expiry:      testSASExpiry,
+		Protocol:    "https",
+	}
+	keys := tableSASKeyRange{
+		StartPartitionKey: "pk1",
+		StartRowKey:       "rk1",
+		EndPartitionKey:   "pk2",
+		EndRowKey:         "rk2",
+	}
+
+	// the name of the table is lower-cased within the canonicalized resource, but not within the token
+	expectedStringToSign := strings.Join([]string{
+		"raud",
+		testSASStart,
+		testSASExpiry,
+		"/table/example/mytable",
+		"",
+		"",
+		"https",
+		"2018-11-09",
+		"pk1",
+		"rk1",
+		"pk2",
+		"rk2",
+	}, "\n")
+	if actual := tableSASStringToSign(params, "example", "MyTable", keys); actual != expectedStringToSign {
+		t.Fatalf("expected the string to sign to be %q but got %q", expectedStringToSign, actual)
+	}
+
+	token, err := computeTableSASToken(params, "example", testSASAccountKey, "MyTable", keys)
+	if err != nil {
+		t.Fatalf("computing the token: %+v", err)
+	}
+	expectedToken := "?sv=2018-11-09&tn=MyTable&st=2024-01-01T00%3A00%3A00Z&se=2024-01-02T00%3A00%3A00Z&sp=raud&spr=https&spk=pk1&srk=rk1&epk=pk2&erk=rk2&sig=vGRpRpO6RBM4AuExdxjAv722hYWTWK%2BxhyA47KNZlqo%3D"
+	if token != expectedToken {
+		t.Fatalf("expected the token to be %q but got %q", expectedToken, token)
+	}
+}
+
+func TestUserDelegationSASToken(t *testing.T) {
+	key := client.UserDelegationKey{
+		SignedOid:     "00000000-0000-0000-0000-000000000001",
+		SignedTid:     "00000000-0000-0000-0000-000000000002",
+		SignedStart:   testSASStart,
+		SignedExpiry:  testSASExpiry,
+		SignedService: "b",
+		SignedVersion: "2018-11-09",
+		Value:         testSASUserDelegationKey,
+	}
+	params := serviceSASParameters{
+		Permissions: "rl",
+		Start:       testSASStart,
+		Expiry:      testSASExpiry,
+		Protocol:    "https",
+	}
+
+	testData := []struct {
+		Name                 string
+		BlobName             string
+		Headers              sasResponseHeaders
+		ExpectedStringToSign []string
+		ExpectedToken        string
+	}{
+		{
+			Name: "container",
+			ExpectedStringToSign: []string{
+				"rl",
+				testSASStart,
+				testSASExpiry,
+				"/blob/example/container1",
+				"00000000-0000-0000-0000-000000000001",
+				"00000000-0000-0000-0000-000000000002",
+				testSASStart,
+				testSASExpiry,
+				"b",
+				"2018-11-09",
+				"",
+				"https",
+				"2018-11-09",
+				"c",
+				"",
+				"",
+				"",
+				"",
+				"",
+				"",
+			},
+			ExpectedToken: "?sv=2018-11-09&sr=c&st=2024-01-01T00%3A00%3A00Z&se=2024-01-02T00%3A00%3A00Z&sp=rl&skoid=00000000-0000-0000-0000-000000000001&sktid=00000000-0000-0000-0000-000000000002&skt=2024-01-01T00%3A00%3A00Z&ske=2024-01-02T00%3A00%3A00Z&sks=b&skv=2018-11-09&spr=https&sig=4mSU1NWt7Da55W98TnuUsgS1ND8WPLUHVJVRBBtSEL0%3D",
+		},
+		{
+			Name:     "blob with response headers",
+			BlobName: "dir/blob.txt",
+			Headers: sasResponseHeaders{
+				ContentType: "text/plain",
+			},
+			ExpectedStringToSign: []string{
+				"rl",
+				testSASStart,
+				testSASExpiry,
+				"/blob/example/container1/dir/blob.txt",
+				"00000000-0000-0000-0000-000000000001",
+				"00000000-0000-0000-0000-000000000002",
+				testSASStart,
+				testSASExpiry,
+				"b",
+				"2018-11-09",
+				"",
+				"https",
+				"2018-11-09",
+				"b",
+				"",
+				"",
+				"",
+				"",
+				"",
+				"text/plain",
+			},
+			ExpectedToken: "?sv=2018-11-09&sr=b&st=2024-01-01T00%3A00%3A00Z&se=2024-01-02T00%3A00%3A00Z&sp=rl&skoid=00000000-0000-0000-0000-000000000001&sktid=00000000-0000-0000-0000-000000000002&skt=2024-01-01T00%3A00%3A00Z&ske=2024-01-02T00%3A00%3A00Z&sks=b&skv=2018-11-09&spr=https&rsct=text%2Fplain&sig=xHov%2B1QheQ11zwAcMCsA7i0x%2FiKyQiym19%2BO%2FpKzaHU%3D",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		if actual, expected := userDelegationSASStringToSign(params, key, "example", "container1", v.BlobName, v.Headers), strings.Join(v.ExpectedStringToSign, "\n"); actual != expected {
+			t.Fatalf("expected the string to sign to be %q but got %q", expected, actual)
+		}
+
+		token, err := computeUserDelegationSASToken(params, key, "example", "container1", v.BlobName, v.Headers)
+		if err != nil {
+			t.Fatalf("computing the token: %+v", err)
+		}
+		if token != v.ExpectedToken {
+			t.Fatalf("expected the token to be %q but got %q", v.ExpectedToken, token)
+		}
+	}
+}
+
+func TestValidateUserDelegationKeyPeriod(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	testData := []struct {
+		Name        string
+		Start       time.Time
+		Expiry      time.Time
+		ExpectError bool
+	}{
+		{
+			Name:   "one day",
+			Start:  now,
+			Expiry: now.Add(24 * time.Hour),
+		},
+		{
+			Name:   "seven days",
+			Start:  now.Add(-time.Hour),
+			Expiry: now.Add(7 * 24 * time.Hour),
+		},
+		{
+			Name:        "more than seven days",
+			Start:       now,
+			Expiry:      now.Add(7*24*time.Hour + time.Second),
+			ExpectError: true,
+		},
+		{
+			Name:        "expiry before start",
+			Start:       now,
+			Expiry:      now.Add(-time.Hour),
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateUserDelegationKeyPeriod(v.Start, v.Expiry, now)
+		if v.ExpectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ExpectError && err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+	}
+}
@@ -14,6 +14,7 @@ type StorageContainerWrapper interface {
 	Delete(ctx context.Context, containerName string) error
 	Exists(ctx context.Context, containerName string) (*bool, error)
 	Get(ctx context.Context, containerName string) (*StorageContainerProperties, error)
+	ListBlobs(ctx context.Context, containerName string, prefix string) (*[]containers.BlobDetails, error)
 	UpdateAccessLevel(ctx context.Context, containerName string, level containers.AccessLevel) error
 	UpdateMetaData(ctx context.Context, containerName string, metaData map[string]string) error
 }
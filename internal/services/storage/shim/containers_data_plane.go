@@ -69,6 +69,34 @@ func (w DataPlaneStorageContainerWrapper) Get(ctx context.Context, containerName
 	}, nil
 }
 
+// ListBlobs returns every blob within the container whose name starts with the prefix, following the
+// continuation marker until all pages have been retrieved
+func (w DataPlaneStorageContainerWrapper) ListBlobs(ctx context.Context, containerName string, prefix string) (*[]containers.BlobDetails, error) {
+	result := make([]containers.BlobDetails, 0)
+
+	input := containers.ListBlobsInput{
+		MaxResults: pointer.To(5000),
+	}
+	if prefix != "" {
+		input.Prefix = pointer.To(prefix)
+	}
+	for {
+		resp, err := w.client.ListBlobs(ctx, containerName, input)
+		if err != nil {
+			return nil, fmt.Errorf("listing blobs: %+v", err)
+		}
+
+		result = append(result, resp.Blobs.Blobs...)
+
+		if resp.NextMarker == nil || *resp.NextMarker == "" {
+			break
+		}
+		input.Marker = resp.NextMarker
+	}
+
+	return &result, nil
+}
+
 func (w DataPlaneStorageContainerWrapper) UpdateAccessLevel(ctx context.Context, containerName string, level containers.AccessLevel) error {
 	input := containers.SetAccessControlInput{
 		AccessLevel: level,
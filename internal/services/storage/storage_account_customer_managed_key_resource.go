@@ -123,7 +123,9 @@ func resourceStorageAccountCustomerManagedKeyCreateUpdate(d *pluginsdk.ResourceD
 		return err
 	}
 
-	locks.ByName(id.StorageAccountName, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, id.StorageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.StorageAccountName, storageAccountResourceName)
 
 	existing, err := storageClient.GetProperties(ctx, *id, storageaccounts.DefaultGetPropertiesOperationOptions())
@@ -318,7 +320,9 @@ func resourceStorageAccountCustomerManagedKeyDelete(d *pluginsdk.ResourceData, m
 		return err
 	}
 
-	locks.ByName(id.StorageAccountName, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, id.StorageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.StorageAccountName, storageAccountResourceName)
 
 	// confirm it still exists prior to trying to update it, else we'll get an error
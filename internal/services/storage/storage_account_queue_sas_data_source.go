@@ -0,0 +1,134 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/storage"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	storageValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func dataSourceStorageAccountQueueSharedAccessSignature() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageQueueSasRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"connection_string": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"queue_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"https_only": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ip_address": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: storageValidate.SharedAccessSignatureIP,
+			},
+
+			"start": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ISO8601DateTime,
+			},
+
+			"expiry": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ISO8601DateTime,
+			},
+
+			"permissions": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"read": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"add": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"update": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"process": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"sas": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceStorageQueueSasRead(d *pluginsdk.ResourceData, _ interface{}) error {
+	connString := d.Get("connection_string").(string)
+	queueName := d.Get("queue_name").(string)
+	permissionsIface := d.Get("permissions").([]interface{})
+
+	// Parse the connection string
+	kvp, err := storage.ParseAccountSASConnectionString(connString)
+	if err != nil {
+		return err
+	}
+
+	params := serviceSASParameters{
+		Permissions: BuildQueuePermissionsString(permissionsIface[0].(map[string]interface{})),
+		Start:       d.Get("start").(string),
+		Expiry:      d.Get("expiry").(string),
+		IP:          d.Get("ip_address").(string),
+		Protocol:    "https,http",
+	}
+	if d.Get("https_only").(bool) {
+		params.Protocol = "https"
+	}
+
+	sasToken, err := computeQueueSASToken(params, kvp[connStringAccountNameKey], kvp[connStringAccountKeyKey], queueName)
+	if err != nil {
+		return err
+	}
+
+	d.Set("sas", sasToken)
+	tokenHash := sha256.Sum256([]byte(sasToken))
+	d.SetId(hex.EncodeToString(tokenHash[:]))
+
+	return nil
+}
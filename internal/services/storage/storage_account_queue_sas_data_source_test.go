@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage"
+)
+
+type StorageAccountQueueSASDataSource struct{}
+
+func TestAccDataSourceStorageAccountQueueSas_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_queue_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageAccountQueueSASDataSource{}.basic(data, startDate, endDate),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("https_only").HasValue("true"),
+				check.That(data.ResourceName).Key("start").HasValue(startDate),
+				check.That(data.ResourceName).Key("expiry").HasValue(endDate),
+				check.That(data.ResourceName).Key("ip_address").HasValue("168.1.5.65"),
+				check.That(data.ResourceName).Key("permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.read").HasValue("true"),
+				check.That(data.ResourceName).Key("permissions.0.add").HasValue("true"),
+				check.That(data.ResourceName).Key("permissions.0.update").HasValue("false"),
+				check.That(data.ResourceName).Key("permissions.0.process").HasValue("true"),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func (d StorageAccountQueueSASDataSource) basic(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "storage" {
+  name                = "acctestsads%s"
+  resource_group_name = azurerm_resource_group.rg.name
+
+  location                 = azurerm_resource_group.rg.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_queue" "queue" {
+  name                 = "sas-test"
+  storage_account_name = azurerm_storage_account.storage.name
+}
+
+data "azurerm_storage_account_queue_sas" "test" {
+  connection_string = azurerm_storage_account.storage.primary_connection_string
+  queue_name        = azurerm_storage_queue.queue.name
+  https_only        = true
+
+  ip_address = "168.1.5.65"
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read    = true
+    add     = true
+    update  = false
+    process = true
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, startDate, endDate)
+}
+
+func TestAccDataSourceStorageAccountQueueSas_permissionsString(t *testing.T) {
+	testCases := []struct {
+		input    map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"read": true}, "r"},
+		{map[string]interface{}{"add": true}, "a"},
+		{map[string]interface{}{"update": true}, "u"},
+		{map[string]interface{}{"process": true}, "p"},
+		{map[string]interface{}{"process": true, "add": true, "read": true}, "rap"},
+	}
+
+	for _, test := range testCases {
+		result := storage.BuildQueuePermissionsString(test.input)
+		if test.expected != result {
+			t.Fatalf("Failed to build resource type string: expected: %s, result: %s", test.expected, result)
+		}
+	}
+}
@@ -28,12 +28,12 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	keyVaultClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/client"
 	keyVaultParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/parse"
 	keyVaultValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/keyvault/validate"
 	managedHsmParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/parse"
 	managedHsmValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/managedhsm/validate"
-	"github.com/hashicorp/terraform-provider-azurerm/internal/services/network"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
@@ -1263,6 +1263,7 @@ func resourceStorageAccount() *pluginsdk.Resource {
 				}
 				return false
 			}),
+			sdk.ValidateNameAvailabilityDiff("name", checkStorageAccountNameAvailability),
 		),
 	}
 
@@ -1292,7 +1293,9 @@ func resourceStorageAccountCreate(d *pluginsdk.ResourceData, meta interface{}) e
 	defer cancel()
 
 	id := commonids.NewStorageAccountID(subscriptionId, d.Get("resource_group_name").(string), d.Get("name").(string))
-	locks.ByName(id.StorageAccountName, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, id.StorageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.StorageAccountName, storageAccountResourceName)
 
 	existing, err := client.GetProperties(ctx, id, storageaccounts.DefaultGetPropertiesOperationOptions())
@@ -1630,7 +1633,9 @@ func resourceStorageAccountUpdate(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.StorageAccountName, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, id.StorageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.StorageAccountName, storageAccountResourceName)
 
 	accountTier := storageaccounts.SkuTier(d.Get("account_tier").(string))
@@ -2288,7 +2293,9 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 		return err
 	}
 
-	locks.ByName(id.StorageAccountName, storageAccountResourceName)
+	if err := locks.ByNameWithContext(ctx, id.StorageAccountName, storageAccountResourceName); err != nil {
+		return err
+	}
 	defer locks.UnlockByName(id.StorageAccountName, storageAccountResourceName)
 
 	existing, err := client.GetProperties(ctx, *id, storageaccounts.DefaultGetPropertiesOperationOptions())
@@ -2300,7 +2307,7 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 	}
 
 	// the networking api's only allow a single change to be made to a network layout at once, so let's lock to handle that
-	virtualNetworkNames := make([]string, 0)
+	virtualNetworkIds := make([]string, 0)
 	if model := existing.Model; model != nil && model.Properties != nil {
 		if acls := model.Properties.NetworkAcls; acls != nil {
 			if vnr := acls.VirtualNetworkRules; vnr != nil {
@@ -2310,20 +2317,22 @@ func resourceStorageAccountDelete(d *pluginsdk.ResourceData, meta interface{}) e
 						return err
 					}
 
-					networkName := subnetId.VirtualNetworkName
-					for _, virtualNetworkName := range virtualNetworkNames {
-						if networkName == virtualNetworkName {
+					networkId := commonids.NewVirtualNetworkID(subnetId.SubscriptionId, subnetId.ResourceGroupName, subnetId.VirtualNetworkName).ID()
+					for _, virtualNetworkId := range virtualNetworkIds {
+						if networkId == virtualNetworkId {
 							continue
 						}
 					}
-					virtualNetworkNames = append(virtualNetworkNames, networkName)
+					virtualNetworkIds = append(virtualNetworkIds, networkId)
 				}
 			}
 		}
 	}
 
-	locks.MultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
-	defer locks.UnlockMultipleByName(&virtualNetworkNames, network.VirtualNetworkResourceName)
+	if err := locks.MultipleByIDWithContext(ctx, &virtualNetworkIds); err != nil {
+		return err
+	}
+	defer locks.UnlockMultipleByID(&virtualNetworkIds)
 
 	if _, err := client.Delete(ctx, *id); err != nil {
 		return fmt.Errorf("deleting %s: %+v", *id, err)
@@ -3507,3 +3516,23 @@ func flattenEdgeZone(input *edgezones.Model) string {
 	}
 	return output
 }
+
+func checkStorageAccountNameAvailability(ctx context.Context, client *clients.Client, name string) (*sdk.NameAvailability, error) {
+	subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+	input := storageaccounts.StorageAccountCheckNameAvailabilityParameters{
+		Name: name,
+		Type: storageaccounts.TypeMicrosoftPointStorageStorageAccounts,
+	}
+	resp, err := client.Storage.ResourceManager.StorageAccounts.CheckNameAvailability(ctx, subscriptionId, input)
+	if err != nil {
+		return nil, fmt.Errorf("checking the availability of the Storage Account name %q: %+v", name, err)
+	}
+	if resp.Model == nil || resp.Model.NameAvailable == nil {
+		return nil, fmt.Errorf("checking the availability of the Storage Account name %q: `model` was nil", name)
+	}
+
+	return &sdk.NameAvailability{
+		Available: *resp.Model.NameAvailable,
+		Message:   pointer.From(resp.Model.Message),
+	}, nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"testing"
 
@@ -98,6 +99,39 @@ func TestAccStorageAccount_tagCount(t *testing.T) {
 	})
 }
 
+func TestAccStorageAccount_defaultTagsUpdated(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
+	r := StorageAccountResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.defaultTags(data, "Production"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags_all.%").HasValue("2"),
+				data.CheckWithClient(r.hasTagsInAzure(map[string]string{
+					"environment": "Production",
+					"project":     "example",
+				})),
+			),
+		},
+		{
+			// only the Default Tags change, so `tags` is unchanged
+			Config: r.defaultTags(data, "Staging"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags_all.environment").HasValue("Staging"),
+				data.CheckWithClient(r.hasTagsInAzure(map[string]string{
+					"environment": "Staging",
+					"project":     "example",
+				})),
+			),
+		},
+	})
+}
+
 func TestAccStorageAccount_writeLock(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_storage_account", "test")
 	r := StorageAccountResource{}
@@ -1799,6 +1833,29 @@ func (r StorageAccountResource) Exists(ctx context.Context, client *clients.Clie
 	return utils.Bool(true), nil
 }
 
+func (r StorageAccountResource) hasTagsInAzure(expected map[string]string) acceptance.ClientCheckFunc {
+	return func(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) error {
+		id, err := commonids.ParseStorageAccountID(state.ID)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Storage.ResourceManager.StorageAccounts.GetProperties(ctx, *id, storageaccounts.DefaultGetPropertiesOperationOptions())
+		if err != nil {
+			return fmt.Errorf("retrieving %s: %+v", id, err)
+		}
+
+		actual := make(map[string]string)
+		if resp.Model != nil && resp.Model.Tags != nil {
+			actual = *resp.Model.Tags
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			return fmt.Errorf("expected the Tags for %s to be %+v but got %+v", id, expected, actual)
+		}
+
+		return nil
+	}
+}
+
 func (r StorageAccountResource) Destroy(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := commonids.ParseStorageAccountID(state.ID)
 	if err != nil {
@@ -1917,6 +1974,37 @@ resource "azurerm_storage_account" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomString, tags)
 }
 
+func (r StorageAccountResource) defaultTags(data acceptance.TestData, environment string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+
+  default_tags {
+    tags = {
+      environment = "%s"
+    }
+  }
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "unlikely23exst2acct%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  tags = {
+    project = "example"
+  }
+}
+`, environment, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
 func (r StorageAccountResource) requiresImport(data acceptance.TestData) string {
 	template := r.basic(data)
 	return fmt.Sprintf(`
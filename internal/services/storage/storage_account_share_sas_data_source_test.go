@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage"
+)
+
+type StorageAccountShareSASDataSource struct{}
+
+func TestAccDataSourceStorageAccountShareSas_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_share_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageAccountShareSASDataSource{}.basic(data, startDate, endDate),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("https_only").HasValue("true"),
+				check.That(data.ResourceName).Key("start").HasValue(startDate),
+				check.That(data.ResourceName).Key("expiry").HasValue(endDate),
+				check.That(data.ResourceName).Key("permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.read").HasValue("true"),
+				check.That(data.ResourceName).Key("permissions.0.create").HasValue("false"),
+				check.That(data.ResourceName).Key("permissions.0.write").HasValue("false"),
+				check.That(data.ResourceName).Key("permissions.0.delete").HasValue("false"),
+				check.That(data.ResourceName).Key("permissions.0.list").HasValue("true"),
+				check.That(data.ResourceName).Key("content_disposition").HasValue("attachment"),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func (d StorageAccountShareSASDataSource) basic(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "storage" {
+  name                = "acctestsads%s"
+  resource_group_name = azurerm_resource_group.rg.name
+
+  location                 = azurerm_resource_group.rg.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_share" "share" {
+  name                 = "sas-test"
+  storage_account_name = azurerm_storage_account.storage.name
+  quota                = 1
+}
+
+data "azurerm_storage_account_share_sas" "test" {
+  connection_string = azurerm_storage_account.storage.primary_connection_string
+  share_name        = azurerm_storage_share.share.name
+  https_only        = true
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read   = true
+    create = false
+    write  = false
+    delete = false
+    list   = true
+  }
+
+  content_disposition = "attachment"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, startDate, endDate)
+}
+
+func TestAccDataSourceStorageAccountShareSas_permissionsString(t *testing.T) {
+	testCases := []struct {
+		input    map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"read": true}, "r"},
+		{map[string]interface{}{"create": true}, "c"},
+		{map[string]interface{}{"write": true}, "w"},
+		{map[string]interface{}{"delete": true}, "d"},
+		{map[string]interface{}{"list": true}, "l"},
+		{map[string]interface{}{"list": true, "write": true, "read": true, "delete": true}, "rwdl"},
+	}
+
+	for _, test := range testCases {
+		result := storage.BuildSharePermissionsString(test.input)
+		if test.expected != result {
+			t.Fatalf("Failed to build resource type string: expected: %s, result: %s", test.expected, result)
+		}
+	}
+}
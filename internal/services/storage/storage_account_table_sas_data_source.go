@@ -0,0 +1,165 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/storage"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	storageValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func dataSourceStorageAccountTableSharedAccessSignature() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageTableSasRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"connection_string": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"table_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"https_only": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ip_address": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: storageValidate.SharedAccessSignatureIP,
+			},
+
+			"start": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ISO8601DateTime,
+			},
+
+			"expiry": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ISO8601DateTime,
+			},
+
+			"permissions": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"read": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"add": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"update": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"delete": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"start_partition_key": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"start_row_key": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"end_partition_key": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"end_row_key": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"sas": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceStorageTableSasRead(d *pluginsdk.ResourceData, _ interface{}) error {
+	connString := d.Get("connection_string").(string)
+	tableName := d.Get("table_name").(string)
+	permissionsIface := d.Get("permissions").([]interface{})
+
+	// Parse the connection string
+	kvp, err := storage.ParseAccountSASConnectionString(connString)
+	if err != nil {
+		return err
+	}
+
+	params := serviceSASParameters{
+		Permissions: BuildTablePermissionsString(permissionsIface[0].(map[string]interface{})),
+		Start:       d.Get("start").(string),
+		Expiry:      d.Get("expiry").(string),
+		IP:          d.Get("ip_address").(string),
+		Protocol:    "https,http",
+	}
+	if d.Get("https_only").(bool) {
+		params.Protocol = "https"
+	}
+
+	keys := tableSASKeyRange{
+		StartPartitionKey: d.Get("start_partition_key").(string),
+		StartRowKey:       d.Get("start_row_key").(string),
+		EndPartitionKey:   d.Get("end_partition_key").(string),
+		EndRowKey:         d.Get("end_row_key").(string),
+	}
+
+	sasToken, err := computeTableSASToken(params, kvp[connStringAccountNameKey], kvp[connStringAccountKeyKey], tableName, keys)
+	if err != nil {
+		return err
+	}
+
+	d.Set("sas", sasToken)
+	tokenHash := sha256.Sum256([]byte(sasToken))
+	d.SetId(hex.EncodeToString(tokenHash[:]))
+
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage"
+)
+
+type StorageAccountTableSASDataSource struct{}
+
+func TestAccDataSourceStorageAccountTableSas_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_table_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageAccountTableSASDataSource{}.basic(data, startDate, endDate),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("https_only").HasValue("false"),
+				check.That(data.ResourceName).Key("start").HasValue(startDate),
+				check.That(data.ResourceName).Key("expiry").HasValue(endDate),
+				check.That(data.ResourceName).Key("permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.read").HasValue("true"),
+				check.That(data.ResourceName).Key("permissions.0.add").HasValue("false"),
+				check.That(data.ResourceName).Key("permissions.0.update").HasValue("true"),
+				check.That(data.ResourceName).Key("permissions.0.delete").HasValue("false"),
+				check.That(data.ResourceName).Key("start_partition_key").HasValue("orders"),
+				check.That(data.ResourceName).Key("end_partition_key").HasValue("orders"),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func (d StorageAccountTableSASDataSource) basic(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "storage" {
+  name                = "acctestsads%s"
+  resource_group_name = azurerm_resource_group.rg.name
+
+  location                 = azurerm_resource_group.rg.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_table" "table" {
+  name                 = "sastest"
+  storage_account_name = azurerm_storage_account.storage.name
+}
+
+data "azurerm_storage_account_table_sas" "test" {
+  connection_string = azurerm_storage_account.storage.primary_connection_string
+  table_name        = azurerm_storage_table.table.name
+  https_only        = false
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read   = true
+    add    = false
+    update = true
+    delete = false
+  }
+
+  start_partition_key = "orders"
+  end_partition_key   = "orders"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, startDate, endDate)
+}
+
+func TestAccDataSourceStorageAccountTableSas_permissionsString(t *testing.T) {
+	testCases := []struct {
+		input    map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{"read": true}, "r"},
+		{map[string]interface{}{"add": true}, "a"},
+		{map[string]interface{}{"update": true}, "u"},
+		{map[string]interface{}{"delete": true}, "d"},
+		{map[string]interface{}{"delete": true, "update": true, "read": true, "add": true}, "raud"},
+	}
+
+	for _, test := range testCases {
+		result := storage.BuildTablePermissionsString(test.input)
+		if test.expected != result {
+			t.Fatalf("Failed to build resource type string: expected: %s, result: %s", test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	iso8601 "github.com/btubbs/datetime"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	storageValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func dataSourceStorageAccountUserDelegationSharedAccessSignature() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Read: dataSourceStorageUserDelegationSasRead,
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Read: pluginsdk.DefaultTimeout(5 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_id": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: commonids.ValidateStorageAccountID,
+			},
+
+			"container_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: storageValidate.StorageContainerName,
+			},
+
+			"blob_name": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"https_only": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ip_address": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ValidateFunc: storageValidate.SharedAccessSignatureIP,
+			},
+
+			"start": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ISO8601DateTime,
+			},
+
+			"expiry": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validate.ISO8601DateTime,
+			},
+
+			"permissions": {
+				Type:     pluginsdk.TypeList,
+				Required: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"read": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"add": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"create": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"write": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"delete": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+
+						"list": {
+							Type:     pluginsdk.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"cache_control": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_disposition": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_encoding": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_language": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_type": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"sas": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceStorageUserDelegationSasRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := commonids.ParseStorageAccountID(d.Get("storage_account_id").(string))
+	if err != nil {
+		return err
+	}
+
+	containerName := d.Get("container_name").(string)
+	blobName := d.Get("blob_name").(string)
+	permissionsIface := d.Get("permissions").([]interface{})
+
+	params := serviceSASParameters{
+		Permissions: BuildContainerPermissionsString(permissionsIface[0].(map[string]interface{})),
+		Start:       d.Get("start").(string),
+		Expiry:      d.Get("expiry").(string),
+		IP:          d.Get("ip_address").(string),
+		Protocol:    "https,http",
+	}
+	if d.Get("https_only").(bool) {
+		params.Protocol = "https"
+	}
+
+	headers := sasResponseHeaders{
+		CacheControl:       d.Get("cache_control").(string),
+		ContentDisposition: d.Get("content_disposition").(string),
+		ContentEncoding:    d.Get("content_encoding").(string),
+		ContentLanguage:    d.Get("content_language").(string),
+		ContentType:        d.Get("content_type").(string),
+	}
+
+	// the User Delegation Key is requested for the same period as the SAS, which must be in UTC
+	keyStart, err := iso8601.Parse(params.Start, time.UTC)
+	if err != nil {
+		return fmt.Errorf("parsing `start`: %+v", err)
+	}
+	keyExpiry, err := iso8601.Parse(params.Expiry, time.UTC)
+	if err != nil {
+		return fmt.Errorf("parsing `expiry`: %+v", err)
+	}
+	if err := validateUserDelegationKeyPeriod(keyStart, keyExpiry, time.Now()); err != nil {
+		return err
+	}
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, id.StorageAccountName)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %v", id, err)
+	}
+	if account == nil {
+		return fmt.Errorf("locating %s", id)
+	}
+
+	key, err := storageClient.UserDelegationKey(ctx, *account, formatUserDelegationKeyTime(keyStart), formatUserDelegationKeyTime(keyExpiry))
+	if err != nil {
+		return fmt.Errorf("retrieving a User Delegation Key for %s: %v", id, err)
+	}
+
+	sasToken, err := computeUserDelegationSASToken(params, *key, id.StorageAccountName, containerName, blobName, headers)
+	if err != nil {
+		return err
+	}
+
+	d.Set("sas", sasToken)
+
+	// the ID is based on the arguments rather than the SAS, since the User Delegation Key may differ between runs
+	idHash := sha256.Sum256([]byte(strings.Join([]string{id.ID(), containerName, blobName, params.Permissions, params.Start, params.Expiry, params.IP, params.Protocol, headers.stringToSign()}, "\n")))
+	d.SetId(hex.EncodeToString(idHash[:]))
+
+	return nil
+}
+
+func formatUserDelegationKeyTime(input time.Time) string {
+	return input.UTC().Format("2006-01-02T15:04:05Z")
+}
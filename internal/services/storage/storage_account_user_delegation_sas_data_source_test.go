@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type StorageAccountUserDelegationSASDataSource struct{}
+
+func TestAccDataSourceStorageAccountUserDelegationSas_container(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_user_delegation_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageAccountUserDelegationSASDataSource{}.container(data, startDate, endDate),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("https_only").HasValue("true"),
+				check.That(data.ResourceName).Key("start").HasValue(startDate),
+				check.That(data.ResourceName).Key("expiry").HasValue(endDate),
+				check.That(data.ResourceName).Key("permissions.#").HasValue("1"),
+				check.That(data.ResourceName).Key("permissions.0.read").HasValue("true"),
+				check.That(data.ResourceName).Key("permissions.0.list").HasValue("true"),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceStorageAccountUserDelegationSas_blob(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_account_user_delegation_sas", "test")
+	utcNow := time.Now().UTC()
+	startDate := utcNow.Format(time.RFC3339)
+	endDate := utcNow.Add(time.Hour * 24).Format(time.RFC3339)
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageAccountUserDelegationSASDataSource{}.blob(data, startDate, endDate),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("blob_name").HasValue("example.txt"),
+				check.That(data.ResourceName).Key("content_type").HasValue("text/plain"),
+				check.That(data.ResourceName).Key("sas").Exists(),
+			),
+		},
+	})
+}
+
+func (d StorageAccountUserDelegationSASDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "rg" {
+  name     = "acctestRG-storage-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "storage" {
+  name                = "acctestsads%s"
+  resource_group_name = azurerm_resource_group.rg.name
+
+  location                 = azurerm_resource_group.rg.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_role_assignment" "delegator" {
+  scope                = azurerm_storage_account.storage.id
+  role_definition_name = "Storage Blob Delegator"
+  principal_id         = data.azurerm_client_config.current.object_id
+}
+
+resource "azurerm_storage_container" "container" {
+  name                  = "sas-test"
+  storage_account_name  = azurerm_storage_account.storage.name
+  container_access_type = "private"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (d StorageAccountUserDelegationSASDataSource) container(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_account_user_delegation_sas" "test" {
+  storage_account_id = azurerm_storage_account.storage.id
+  container_name     = azurerm_storage_container.container.name
+  https_only         = true
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read   = true
+    add    = false
+    create = false
+    write  = false
+    delete = false
+    list   = true
+  }
+
+  depends_on = [azurerm_role_assignment.delegator]
+}
+`, d.template(data), startDate, endDate)
+}
+
+func (d StorageAccountUserDelegationSASDataSource) blob(data acceptance.TestData, startDate string, endDate string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_storage_account_user_delegation_sas" "test" {
+  storage_account_id = azurerm_storage_account.storage.id
+  container_name     = azurerm_storage_container.container.name
+  blob_name          = "example.txt"
+
+  start  = "%s"
+  expiry = "%s"
+
+  permissions {
+    read   = true
+    add    = false
+    create = false
+    write  = false
+    delete = false
+    list   = false
+  }
+
+  content_type = "text/plain"
+
+  depends_on = [azurerm_role_assignment.delegator]
+}
+`, d.template(data), startDate, endDate)
+}
@@ -4,15 +4,18 @@
 package storage
 
 import (
+	"encoding/base64"
 	"fmt"
 	"log"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
@@ -73,6 +76,29 @@ func dataSourceStorageBlob() *pluginsdk.Resource {
 				Computed: true,
 			},
 
+			"include_content": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"max_content_size_in_bytes": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      1048576,
+				ValidateFunc: validation.IntBetween(1, 4194304),
+			},
+
+			"content": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
+			"content_base64": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"metadata": MetaDataComputedSchema(),
 		},
 	}
@@ -153,5 +179,30 @@ func dataSourceStorageBlobRead(d *pluginsdk.ResourceData, meta interface{}) erro
 		return fmt.Errorf("setting `metadata`: %+v", err)
 	}
 
+	content := ""
+	contentBase64 := ""
+	if d.Get("include_content").(bool) {
+		// the content is stored in the state, so is limited to small blobs
+		maxSize := int64(d.Get("max_content_size_in_bytes").(int))
+		if props.ContentLength > maxSize {
+			return fmt.Errorf("the content of %s is %d bytes which exceeds `max_content_size_in_bytes` (%d bytes)", id, props.ContentLength, maxSize)
+		}
+
+		log.Printf("[INFO] Retrieving the content of %s", id)
+		blob, err := blobsClient.Get(ctx, containerName, name, blobs.GetInput{})
+		if err != nil {
+			return fmt.Errorf("retrieving the content of %s: %v", id, err)
+		}
+		if blob.Contents != nil {
+			// the content is only exposed as text when it's valid UTF-8
+			if utf8.Valid(*blob.Contents) {
+				content = string(*blob.Contents)
+			}
+			contentBase64 = base64.StdEncoding.EncodeToString(*blob.Contents)
+		}
+	}
+	d.Set("content", content)
+	d.Set("content_base64", contentBase64)
+
 	return nil
 }
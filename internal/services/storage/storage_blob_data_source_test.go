@@ -40,6 +40,20 @@ func TestAccDataSourceStorageBlob_basic(t *testing.T) {
 	})
 }
 
+func TestAccDataSourceStorageBlob_content(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_blob", "test")
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: StorageBlobDataSource{}.content(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("content").HasValue("Wubba Lubba Dub Dub"),
+				check.That(data.ResourceName).Key("content_base64").HasValue("V3ViYmEgTHViYmEgRHViIER1Yg=="),
+			),
+		},
+	})
+}
+
 func (d StorageBlobDataSource) basic(data acceptance.TestData, fileName string) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -100,3 +114,29 @@ data "azurerm_storage_blob" "test" {
 }
 `, config)
 }
+
+func (d StorageBlobDataSource) content(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "example.txt"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "Wubba Lubba Dub Dub"
+}
+
+data "azurerm_storage_blob" "test" {
+  name                      = azurerm_storage_blob.test.name
+  storage_account_name      = azurerm_storage_blob.test.storage_account_name
+  storage_container_name    = azurerm_storage_blob.test.storage_container_name
+  include_content           = true
+  max_content_size_in_bytes = 1024
+}
+`, StorageBlobResource{}.template(data, "private"))
+}
@@ -0,0 +1,390 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+func resourceStorageBlobDirectory() *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: resourceStorageBlobDirectoryCreate,
+		Read:   resourceStorageBlobDirectoryRead,
+		Update: resourceStorageBlobDirectoryUpdate,
+		Delete: resourceStorageBlobDirectoryDelete,
+
+		// NOTE: since the source directory only exists locally the blobs aren't tracked once imported, instead the
+		// files within the source directory are tracked once synced during the next apply
+		Importer: helpers.ImporterValidatingStorageResourceIdThen(func(id, storageDomainSuffix string) error {
+			_, _, err := parseStorageBlobDirectoryID(id, storageDomainSuffix)
+			return err
+		}, func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) ([]*pluginsdk.ResourceData, error) {
+			containerId, prefix, err := parseStorageBlobDirectoryID(d.Id(), meta.(*clients.Client).Storage.StorageDomainSuffix)
+			if err != nil {
+				return []*pluginsdk.ResourceData{d}, err
+			}
+
+			d.Set("storage_account_name", containerId.AccountId.AccountName)
+			d.Set("storage_container_name", containerId.ContainerName)
+			d.Set("prefix", strings.TrimSuffix(prefix, "/"))
+			d.Set("delete_extraneous_blobs", false)
+			d.Set("parallelism", 8)
+
+			return []*pluginsdk.ResourceData{d}, nil
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(60 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*pluginsdk.Schema{
+			"storage_account_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageAccountName,
+			},
+
+			"storage_container_name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.StorageContainerName,
+			},
+
+			"source": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+
+			"prefix": {
+				Type:         pluginsdk.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				DiffSuppressFunc: func(_, old, new string, _ *pluginsdk.ResourceData) bool {
+					return normalizeBlobDirectoryPrefix(old) == normalizeBlobDirectoryPrefix(new)
+				},
+			},
+
+			"cache_control": {
+				Type:     pluginsdk.TypeString,
+				Optional: true,
+			},
+
+			"content_types": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type:         pluginsdk.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+
+			"delete_extraneous_blobs": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"parallelism": {
+				Type:         pluginsdk.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntBetween(1, 64),
+			},
+
+			"blobs": {
+				Type:     pluginsdk.TypeSet,
+				Computed: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"content_hash": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+		},
+
+		CustomizeDiff: func(ctx context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+			// deleting the extraneous blobs without a prefix would delete every other blob within the container
+			if diff.Get("delete_extraneous_blobs").(bool) && diff.NewValueKnown("prefix") && normalizeBlobDirectoryPrefix(diff.Get("prefix").(string)) == "" {
+				return fmt.Errorf("`prefix` must be specified when `delete_extraneous_blobs` is enabled")
+			}
+
+			// the hash of the local files is compared to the hash of the existing blobs, so that a change to
+			// any file within the source directory results in an update
+			sync := BlobDirectorySync{
+				CacheControl: diff.Get("cache_control").(string),
+				ContentTypes: expandBlobDirectoryContentTypes(diff.Get("content_types").(map[string]interface{})),
+				Source:       diff.Get("source").(string),
+			}
+			if !diff.NewValueKnown("source") || sync.Source == "" {
+				// the source isn't known until apply
+				if diff.Id() != "" {
+					if err := diff.SetNewComputed("content_hash"); err != nil {
+						return err
+					}
+					return diff.SetNewComputed("blobs")
+				}
+				return nil
+			}
+
+			local, err := sync.LocalFiles()
+			if err != nil {
+				return err
+			}
+			if hash := directoryHash(local); hash != diff.Get("content_hash").(string) {
+				names := make([]interface{}, 0, len(local))
+				for name := range local {
+					names = append(names, name)
+				}
+				if err := diff.SetNew("blobs", names); err != nil {
+					return err
+				}
+				return diff.SetNew("content_hash", hash)
+			}
+			return nil
+		},
+	}
+}
+
+func resourceStorageBlobDirectoryCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+	prefix := normalizeBlobDirectoryPrefix(d.Get("prefix").(string))
+
+	accountId := accounts.AccountId{
+		AccountName:   accountName,
+		DomainSuffix:  storageClient.StorageDomainSuffix,
+		SubDomainType: accounts.BlobSubDomainType,
+	}
+	containerId := containers.NewContainerID(accountId, containerName)
+
+	// the ID is the URL of the virtual directory within the container
+	id := containerId.ID()
+	if prefix != "" {
+		id = fmt.Sprintf("%s/%s", id, strings.TrimSuffix(prefix, "/"))
+	}
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Container %q: %v", accountName, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("locating Storage Account %q", accountName)
+	}
+
+	sync, err := buildBlobDirectorySync(ctx, d, storageClient, *account)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Syncing %q into %s..", sync.Source, id)
+	hash, names, err := sync.Sync(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("syncing %q into %s: %v", sync.Source, id, err)
+	}
+	log.Printf("[DEBUG] Synced %q into %s.", sync.Source, id)
+
+	d.SetId(id)
+	d.Set("blobs", names)
+	d.Set("content_hash", hash)
+
+	return resourceStorageBlobDirectoryRead(d, meta)
+}
+
+func resourceStorageBlobDirectoryUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Container %q: %v", accountName, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("locating Storage Account %q", accountName)
+	}
+
+	sync, err := buildBlobDirectorySync(ctx, d, storageClient, *account)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChanges("source", "cache_control", "content_types", "delete_extraneous_blobs", "blobs", "content_hash") {
+		// the blobs which were previously synced are used to delete the blobs for any files which have since been removed
+		previous, _ := d.GetChange("blobs")
+
+		log.Printf("[DEBUG] Syncing %q into %s..", sync.Source, d.Id())
+		hash, names, err := sync.Sync(ctx, *utils.ExpandStringSlice(previous.(*pluginsdk.Set).List()))
+		if err != nil {
+			return fmt.Errorf("syncing %q into %s: %v", sync.Source, d.Id(), err)
+		}
+		log.Printf("[DEBUG] Synced %q into %s.", sync.Source, d.Id())
+
+		d.Set("blobs", names)
+		d.Set("content_hash", hash)
+	}
+
+	return resourceStorageBlobDirectoryRead(d, meta)
+}
+
+func resourceStorageBlobDirectoryRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Container %q: %v", accountName, containerName, err)
+	}
+	if account == nil {
+		log.Printf("[DEBUG] Unable to locate Account %q for Container %q - assuming removed & removing from state!", accountName, containerName)
+		d.SetId("")
+		return nil
+	}
+
+	sync, err := buildBlobDirectorySync(ctx, d, storageClient, *account)
+	if err != nil {
+		return err
+	}
+
+	exists, err := sync.ContainersClient.Exists(ctx, containerName)
+	if err != nil {
+		return fmt.Errorf("checking for the existence of Container %q (Account %q): %v", containerName, accountName, err)
+	}
+	if exists == nil || !*exists {
+		log.Printf("[INFO] Container %q was not found in Account %q - assuming removed & removing from state...", containerName, accountName)
+		d.SetId("")
+		return nil
+	}
+
+	// the hash of the existing blobs for the previously synced files is compared to the hash of the local files
+	// during the plan, so that new, modified and removed files are all detected
+	names := *utils.ExpandStringSlice(d.Get("blobs").(*pluginsdk.Set).List())
+	hash, err := sync.RemoteHash(ctx, names)
+	if err != nil {
+		return fmt.Errorf("retrieving blobs for %s: %v", d.Id(), err)
+	}
+	d.Set("content_hash", hash)
+
+	return nil
+}
+
+func resourceStorageBlobDirectoryDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	storageClient := meta.(*clients.Client).Storage
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	accountName := d.Get("storage_account_name").(string)
+	containerName := d.Get("storage_container_name").(string)
+
+	account, err := storageClient.FindAccount(ctx, subscriptionId, accountName)
+	if err != nil {
+		return fmt.Errorf("retrieving Account %q for Container %q: %v", accountName, containerName, err)
+	}
+	if account == nil {
+		return fmt.Errorf("locating Storage Account %q", accountName)
+	}
+
+	sync, err := buildBlobDirectorySync(ctx, d, storageClient, *account)
+	if err != nil {
+		return err
+	}
+
+	// only the blobs which were synced are deleted, since the source directory may no longer exist (or contain
+	// the same files) when the resource is destroyed
+	names := *utils.ExpandStringSlice(d.Get("blobs").(*pluginsdk.Set).List())
+	if err := sync.Delete(ctx, names); err != nil {
+		return fmt.Errorf("deleting blobs for %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+func buildBlobDirectorySync(ctx context.Context, d *pluginsdk.ResourceData, storageClient *client.Client, account client.AccountDetails) (*BlobDirectorySync, error) {
+	blobsClient, err := storageClient.BlobsDataPlaneClient(ctx, account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Blobs Client: %v", err)
+	}
+	containersClient, err := storageClient.ContainersDataPlaneClient(ctx, account, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Containers Client: %v", err)
+	}
+
+	return &BlobDirectorySync{
+		BlobsClient:      blobsClient,
+		ContainersClient: containersClient,
+
+		ContainerName: d.Get("storage_container_name").(string),
+		Prefix:        normalizeBlobDirectoryPrefix(d.Get("prefix").(string)),
+
+		CacheControl:          d.Get("cache_control").(string),
+		ContentTypes:          expandBlobDirectoryContentTypes(d.Get("content_types").(map[string]interface{})),
+		DeleteExtraneousBlobs: d.Get("delete_extraneous_blobs").(bool),
+		Parallelism:           d.Get("parallelism").(int),
+		Source:                d.Get("source").(string),
+	}, nil
+}
+
+// parseStorageBlobDirectoryID parses the ID of a Storage Blob Directory, which is the URL of either the Container or
+// the virtual directory (prefix) within the Container, returning the Container ID and the normalized prefix
+func parseStorageBlobDirectoryID(input, domainSuffix string) (*containers.ContainerId, string, error) {
+	if containerId, err := containers.ParseContainerID(input, domainSuffix); err == nil {
+		return containerId, "", nil
+	}
+
+	blobId, err := blobs.ParseBlobID(input, domainSuffix)
+	if err != nil {
+		return nil, "", fmt.Errorf("parsing %q as a Storage Blob Directory ID: %+v", input, err)
+	}
+
+	containerId := containers.NewContainerID(blobId.AccountId, blobId.ContainerName)
+	return &containerId, normalizeBlobDirectoryPrefix(blobId.BlobName), nil
+}
+
+func expandBlobDirectoryContentTypes(input map[string]interface{}) map[string]string {
+	output := make(map[string]string, len(input))
+	for k, v := range input {
+		output[strings.ToLower(k)] = v.(string)
+	}
+	return output
+}
@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+)
+
+type StorageBlobDirectoryResource struct{}
+
+func TestAccStorageBlobDirectory_basic(t *testing.T) {
+	source := t.TempDir()
+	if err := populateTempDirectory(source, map[string]string{
+		"index.html":    "<html><body>Wubba Lubba Dub Dub</body></html>",
+		"css/site.css":  "body { color: green; }",
+		"data/notes.md": "# Notes",
+	}); err != nil {
+		t.Fatalf("populating temp directory: %+v", err)
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := StorageBlobDirectoryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, source),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("content_hash").IsSet(),
+				data.CheckWithClient(r.blobHasContentType("site/index.html", "text/html; charset=utf-8")),
+				data.CheckWithClient(r.blobHasContentType("site/css/site.css", "text/css; charset=utf-8")),
+				check.That(data.ResourceName).Key("blobs.#").HasValue("3"),
+			),
+		},
+		data.ImportStep("source", "blobs", "content_hash"),
+	})
+}
+
+func TestAccStorageBlobDirectory_removedFile(t *testing.T) {
+	source := t.TempDir()
+	if err := populateTempDirectory(source, map[string]string{
+		"index.html":   "<html><body>Wubba Lubba Dub Dub</body></html>",
+		"css/site.css": "body { color: green; }",
+	}); err != nil {
+		t.Fatalf("populating temp directory: %+v", err)
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := StorageBlobDirectoryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, source),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("blobs.#").HasValue("2"),
+			),
+		},
+		{
+			// the blob for a removed file is deleted even though `delete_extraneous_blobs` isn't enabled
+			PreConfig: func() {
+				if err := os.Remove(filepath.Join(source, "css", "site.css")); err != nil {
+					t.Fatalf("removing file: %+v", err)
+				}
+			},
+			Config: r.basic(data, source),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("blobs.#").HasValue("1"),
+				data.CheckWithClient(r.blobIsDeleted("site/css/site.css")),
+			),
+		},
+	})
+}
+
+func TestAccStorageBlobDirectory_deleteExtraneousBlobsRequiresPrefix(t *testing.T) {
+	source := t.TempDir()
+	if err := populateTempDirectory(source, map[string]string{
+		"index.html": "<html><body>Wubba Lubba Dub Dub</body></html>",
+	}); err != nil {
+		t.Fatalf("populating temp directory: %+v", err)
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := StorageBlobDirectoryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.deleteExtraneousBlobsWithoutPrefix(data, source),
+			ExpectError: regexp.MustCompile("`prefix` must be specified when `delete_extraneous_blobs` is enabled"),
+		},
+	})
+}
+
+func TestAccStorageBlobDirectory_update(t *testing.T) {
+	source := t.TempDir()
+	if err := populateTempDirectory(source, map[string]string{
+		"index.html":   "<html><body>Wubba Lubba Dub Dub</body></html>",
+		"css/site.css": "body { color: green; }",
+	}); err != nil {
+		t.Fatalf("populating temp directory: %+v", err)
+	}
+
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob_directory", "test")
+	r := StorageBlobDirectoryResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data, source),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			PreConfig: func() {
+				if err := os.Remove(filepath.Join(source, "css", "site.css")); err != nil {
+					t.Fatalf("removing file: %+v", err)
+				}
+				if err := populateTempDirectory(source, map[string]string{
+					"index.html": "<html><body>Get Schwifty</body></html>",
+					"app.wasm":   "wasm",
+				}); err != nil {
+					t.Fatalf("populating temp directory: %+v", err)
+				}
+			},
+			Config: r.complete(data, source),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.blobHasContentType("site/app.wasm", "application/wasm")),
+				data.CheckWithClient(r.blobIsDeleted("site/css/site.css")),
+			),
+		},
+	})
+}
+
+func populateTempDirectory(directory string, files map[string]string) error {
+	for name, content := range files {
+		path := filepath.Join(directory, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r StorageBlobDirectoryResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	blobsClient, err := r.blobsClient(ctx, client, state)
+	if err != nil {
+		return nil, err
+	}
+
+	// the directory exists when the blob for the `index.html` file within the source directory exists
+	containerName := state.Attributes["storage_container_name"]
+	name := state.Attributes["prefix"] + "/index.html"
+	if _, err := blobsClient.GetProperties(ctx, containerName, name, blobs.GetPropertiesInput{}); err != nil {
+		return utils.Bool(false), nil
+	}
+	return utils.Bool(true), nil
+}
+
+func (r StorageBlobDirectoryResource) blobsClient(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*blobs.Client, error) {
+	accountName := state.Attributes["storage_account_name"]
+	account, err := client.Storage.FindAccount(ctx, client.Account.SubscriptionId, accountName)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Account %q: %+v", accountName, err)
+	}
+	if account == nil {
+		return nil, fmt.Errorf("unable to locate Storage Account %q", accountName)
+	}
+	blobsClient, err := client.Storage.BlobsDataPlaneClient(ctx, *account, client.Storage.DataPlaneOperationSupportingAnyAuthMethod())
+	if err != nil {
+		return nil, fmt.Errorf("building Blobs Client: %+v", err)
+	}
+	return blobsClient, nil
+}
+
+func (r StorageBlobDirectoryResource) blobHasContentType(name, contentType string) func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		blobsClient, err := r.blobsClient(ctx, clients, state)
+		if err != nil {
+			return err
+		}
+
+		containerName := state.Attributes["storage_container_name"]
+		props, err := blobsClient.GetProperties(ctx, containerName, name, blobs.GetPropertiesInput{})
+		if err != nil {
+			return fmt.Errorf("retrieving Properties for Blob %q (Container %q): %+v", name, containerName, err)
+		}
+		if props.ContentType != contentType {
+			return fmt.Errorf("expected Blob %q to have the content type %q but got %q", name, contentType, props.ContentType)
+		}
+		return nil
+	}
+}
+
+func (r StorageBlobDirectoryResource) blobIsDeleted(name string) func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		blobsClient, err := r.blobsClient(ctx, clients, state)
+		if err != nil {
+			return err
+		}
+
+		containerName := state.Attributes["storage_container_name"]
+		if _, err := blobsClient.GetProperties(ctx, containerName, name, blobs.GetPropertiesInput{}); err == nil {
+			return fmt.Errorf("expected Blob %q (Container %q) to have been deleted", name, containerName)
+		}
+		return nil
+	}
+}
+
+func (r StorageBlobDirectoryResource) basic(data acceptance.TestData, source string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_storage_blob_directory" "test" {
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  source                 = "%s"
+  prefix                 = "site"
+}
+`, StorageBlobResource{}.template(data, "private"), filepath.ToSlash(source))
+}
+
+func (r StorageBlobDirectoryResource) complete(data acceptance.TestData, source string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_storage_blob_directory" "test" {
+  storage_account_name    = azurerm_storage_account.test.name
+  storage_container_name  = azurerm_storage_container.test.name
+  source                  = "%s"
+  prefix                  = "site"
+  cache_control           = "public, max-age=3600"
+  delete_extraneous_blobs = true
+  parallelism             = 4
+
+  content_types = {
+    ".wasm" = "application/wasm"
+  }
+}
+`, StorageBlobResource{}.template(data, "private"), filepath.ToSlash(source))
+}
+
+func (r StorageBlobDirectoryResource) deleteExtraneousBlobsWithoutPrefix(data acceptance.TestData, source string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_storage_blob_directory" "test" {
+  storage_account_name    = azurerm_storage_account.test.name
+  storage_container_name  = azurerm_storage_container.test.name
+  source                  = "%s"
+  delete_extraneous_blobs = true
+}
+`, StorageBlobResource{}.template(data, "private"), filepath.ToSlash(source))
+}
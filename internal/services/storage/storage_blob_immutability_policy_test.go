@@ -0,0 +1,92 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"testing"
+)
+
+func TestValidateStorageBlobImmutabilityPolicyChange(t *testing.T) {
+	policy := func(expiry, mode string) []interface{} {
+		return []interface{}{
+			map[string]interface{}{
+				"expiry_time": expiry,
+				"mode":        mode,
+			},
+		}
+	}
+
+	testData := []struct {
+		Name        string
+		Old         []interface{}
+		New         []interface{}
+		ExpectError bool
+	}{
+		{
+			Name: "adding a Locked policy",
+			Old:  []interface{}{},
+			New:  policy("2024-01-01T00:00:00Z", "Locked"),
+		},
+		{
+			Name: "removing an Unlocked policy",
+			Old:  policy("2024-01-01T00:00:00Z", "Unlocked"),
+			New:  []interface{}{},
+		},
+		{
+			Name: "shortening an Unlocked policy",
+			Old:  policy("2024-01-02T00:00:00Z", "Unlocked"),
+			New:  policy("2024-01-01T00:00:00Z", "Unlocked"),
+		},
+		{
+			Name: "locking an Unlocked policy",
+			Old:  policy("2024-01-01T00:00:00Z", "Unlocked"),
+			New:  policy("2024-01-01T00:00:00Z", "Locked"),
+		},
+		{
+			Name: "extending a Locked policy",
+			Old:  policy("2024-01-01T00:00:00Z", "Locked"),
+			New:  policy("2024-01-02T00:00:00Z", "Locked"),
+		},
+		{
+			Name: "the same Locked policy in another time zone",
+			Old:  policy("2024-01-01T00:00:00Z", "Locked"),
+			New:  policy("2024-01-01T01:00:00+01:00", "Locked"),
+		},
+		{
+			Name: "a Locked policy with an unknown expiry",
+			Old:  policy("2024-01-01T00:00:00Z", "Locked"),
+			New:  policy("", "Locked"),
+		},
+		{
+			Name:        "removing a Locked policy",
+			Old:         policy("2024-01-01T00:00:00Z", "Locked"),
+			New:         []interface{}{},
+			ExpectError: true,
+		},
+		{
+			Name:        "unlocking a Locked policy",
+			Old:         policy("2024-01-01T00:00:00Z", "Locked"),
+			New:         policy("2024-01-02T00:00:00Z", "Unlocked"),
+			ExpectError: true,
+		},
+		{
+			Name:        "shortening a Locked policy",
+			Old:         policy("2024-01-02T00:00:00Z", "Locked"),
+			New:         policy("2024-01-01T00:00:00Z", "Locked"),
+			ExpectError: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		err := validateStorageBlobImmutabilityPolicyChange(v.Old, v.New)
+		if v.ExpectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.ExpectError && err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"time"
 
@@ -14,10 +15,12 @@ import (
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/helpers"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/migration"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
 	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
@@ -160,6 +163,47 @@ func resourceStorageBlob() *pluginsdk.Resource {
 			},
 
 			"metadata": MetaDataComputedSchema(),
+
+			"index_tags": {
+				Type:         pluginsdk.TypeMap,
+				Optional:     true,
+				ValidateFunc: validate.StorageBlobIndexTags,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+
+			"immutability_policy": {
+				Type:     pluginsdk.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &pluginsdk.Resource{
+					Schema: map[string]*pluginsdk.Schema{
+						"expiry_time": {
+							Type:             pluginsdk.TypeString,
+							Required:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppress.RFC3339Time,
+						},
+
+						"mode": {
+							Type:     pluginsdk.TypeString,
+							Optional: true,
+							Default:  string(client.BlobImmutabilityPolicyModeUnlocked),
+							ValidateFunc: validation.StringInSlice([]string{
+								string(client.BlobImmutabilityPolicyModeLocked),
+								string(client.BlobImmutabilityPolicyModeUnlocked),
+							}, false),
+						},
+					},
+				},
+			},
+
+			"legal_hold_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 
 		CustomizeDiff: func(ctx context.Context, diff *pluginsdk.ResourceDiff, i interface{}) error {
@@ -168,11 +212,54 @@ func resourceStorageBlob() *pluginsdk.Resource {
 					return fmt.Errorf(`"source" must be aligned to 512-byte boundary for "type" set to "Page"`)
 				}
 			}
+
+			if diff.HasChange("immutability_policy") {
+				old, new := diff.GetChange("immutability_policy")
+				if err := validateStorageBlobImmutabilityPolicyChange(old.([]interface{}), new.([]interface{})); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		},
 	}
 }
 
+// validateStorageBlobImmutabilityPolicyChange returns an error when the change would weaken a Locked Immutability Policy,
+// which the API rejects - a Locked policy can't be unlocked or removed, and its expiry can only be extended
+func validateStorageBlobImmutabilityPolicyChange(old, new []interface{}) error {
+	if len(old) == 0 || old[0] == nil {
+		return nil
+	}
+	oldPolicy := old[0].(map[string]interface{})
+	if oldPolicy["mode"].(string) != string(client.BlobImmutabilityPolicyModeLocked) {
+		return nil
+	}
+
+	if len(new) == 0 || new[0] == nil {
+		return fmt.Errorf("a `Locked` `immutability_policy` can't be removed")
+	}
+	newPolicy := new[0].(map[string]interface{})
+	if newPolicy["mode"].(string) != string(client.BlobImmutabilityPolicyModeLocked) {
+		return fmt.Errorf("a `Locked` `immutability_policy` can't be changed to `%s`", newPolicy["mode"].(string))
+	}
+
+	// the `expiry_time` is validated by the schema, however it's unknown during the plan when it's interpolated
+	oldExpiry, err := time.Parse(time.RFC3339, oldPolicy["expiry_time"].(string))
+	if err != nil {
+		return nil
+	}
+	newExpiry, err := time.Parse(time.RFC3339, newPolicy["expiry_time"].(string))
+	if err != nil {
+		return nil
+	}
+	if newExpiry.Before(oldExpiry) {
+		return fmt.Errorf("the `expiry_time` of a `Locked` `immutability_policy` can only be extended, but %q is before %q", newPolicy["expiry_time"].(string), oldPolicy["expiry_time"].(string))
+	}
+
+	return nil
+}
+
 func resourceStorageBlobCreate(d *pluginsdk.ResourceData, meta interface{}) error {
 	storageClient := meta.(*clients.Client).Storage
 	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
@@ -346,6 +433,44 @@ func resourceStorageBlobUpdate(d *pluginsdk.ResourceData, meta interface{}) erro
 		log.Printf("[DEBUG] Updated Access Tier for %s", id)
 	}
 
+	if d.HasChange("index_tags") {
+		log.Printf("[DEBUG] Updating Index Tags for %s...", id)
+		indexTags := expandStorageBlobIndexTags(d.Get("index_tags").(map[string]interface{}))
+		if err := storageClient.SetBlobTags(ctx, *account, id.ContainerName, id.BlobName, indexTags, storageClient.DataPlaneOperationSupportingAnyAuthMethod()); err != nil {
+			return fmt.Errorf("updating Index Tags for %s: %v", id, err)
+		}
+		log.Printf("[DEBUG] Updated Index Tags for %s", id)
+	}
+
+	if d.HasChange("immutability_policy") {
+		log.Printf("[DEBUG] Updating Immutability Policy for %s...", id)
+		if v := d.Get("immutability_policy").([]interface{}); len(v) > 0 && v[0] != nil {
+			policy := v[0].(map[string]interface{})
+			expiry, err := time.Parse(time.RFC3339, policy["expiry_time"].(string))
+			if err != nil {
+				return fmt.Errorf("parsing `expiry_time`: %v", err)
+			}
+			mode := client.BlobImmutabilityPolicyMode(policy["mode"].(string))
+			if err := storageClient.SetBlobImmutabilityPolicy(ctx, *account, id.ContainerName, id.BlobName, expiry, mode, storageClient.DataPlaneOperationSupportingAnyAuthMethod()); err != nil {
+				return fmt.Errorf("updating Immutability Policy for %s: %v", id, err)
+			}
+		} else {
+			// only an Unlocked policy can be deleted, the API returns an error for a Locked policy
+			if err := storageClient.DeleteBlobImmutabilityPolicy(ctx, *account, id.ContainerName, id.BlobName, storageClient.DataPlaneOperationSupportingAnyAuthMethod()); err != nil {
+				return fmt.Errorf("deleting Immutability Policy for %s: %v", id, err)
+			}
+		}
+		log.Printf("[DEBUG] Updated Immutability Policy for %s", id)
+	}
+
+	if d.HasChange("legal_hold_enabled") {
+		log.Printf("[DEBUG] Updating Legal Hold for %s...", id)
+		if err := storageClient.SetBlobLegalHold(ctx, *account, id.ContainerName, id.BlobName, d.Get("legal_hold_enabled").(bool), storageClient.DataPlaneOperationSupportingAnyAuthMethod()); err != nil {
+			return fmt.Errorf("updating Legal Hold for %s: %v", id, err)
+		}
+		log.Printf("[DEBUG] Updated Legal Hold for %s", id)
+	}
+
 	return resourceStorageBlobRead(d, meta)
 }
 
@@ -420,6 +545,39 @@ func resourceStorageBlobRead(d *pluginsdk.ResourceData, meta interface{}) error
 		d.Set("source_uri", props.CopySource)
 	}
 
+	// Blob Index Tags aren't supported when the Hierarchical Namespace is enabled
+	indexTags := make(map[string]string)
+	if !account.IsHnsEnabled {
+		resp, err := storageClient.BlobTags(ctx, *account, id.ContainerName, id.BlobName, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+		if err != nil {
+			// reading the tags requires an additional permission when using Azure AD, which is only required when
+			// the tags are managed by Terraform
+			if !response.WasForbidden(resp.HttpResponse) || len(d.Get("index_tags").(map[string]interface{})) > 0 {
+				return fmt.Errorf("retrieving Index Tags for %s: %v", id, err)
+			}
+			log.Printf("[DEBUG] Insufficient permissions to retrieve the Index Tags for %s - assuming there are none", id)
+		} else {
+			indexTags = resp.Tags
+		}
+	}
+	if err = d.Set("index_tags", indexTags); err != nil {
+		return fmt.Errorf("setting `index_tags`: %v", err)
+	}
+
+	immutabilityPolicy, err := flattenStorageBlobImmutabilityPolicy(props.HttpResponse)
+	if err != nil {
+		return fmt.Errorf("flattening `immutability_policy`: %v", err)
+	}
+	if err = d.Set("immutability_policy", immutabilityPolicy); err != nil {
+		return fmt.Errorf("setting `immutability_policy`: %v", err)
+	}
+
+	legalHold := false
+	if props.HttpResponse != nil {
+		legalHold = strings.EqualFold(props.HttpResponse.Header.Get("x-ms-legal-hold"), "true")
+	}
+	d.Set("legal_hold_enabled", legalHold)
+
 	return nil
 }
 
@@ -447,6 +605,23 @@ func resourceStorageBlobDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 		return fmt.Errorf("building Blobs Client: %v", err)
 	}
 
+	// a Blob can't be deleted whilst a Legal Hold or an Immutability Policy is set, an Unlocked policy can be deleted
+	// however a Locked policy can't - and so the Blob can only be deleted once this has expired
+	if d.Get("legal_hold_enabled").(bool) {
+		log.Printf("[DEBUG] Removing Legal Hold for %s...", id)
+		if err := storageClient.SetBlobLegalHold(ctx, *account, id.ContainerName, id.BlobName, false, storageClient.DataPlaneOperationSupportingAnyAuthMethod()); err != nil {
+			return fmt.Errorf("removing Legal Hold for %s: %v", id, err)
+		}
+	}
+	if v := d.Get("immutability_policy").([]interface{}); len(v) > 0 && v[0] != nil {
+		if policy := v[0].(map[string]interface{}); policy["mode"].(string) == string(client.BlobImmutabilityPolicyModeUnlocked) {
+			log.Printf("[DEBUG] Deleting Immutability Policy for %s...", id)
+			if err := storageClient.DeleteBlobImmutabilityPolicy(ctx, *account, id.ContainerName, id.BlobName, storageClient.DataPlaneOperationSupportingAnyAuthMethod()); err != nil {
+				return fmt.Errorf("deleting Immutability Policy for %s: %v", id, err)
+			}
+		}
+	}
+
 	input := blobs.DeleteInput{
 		DeleteSnapshots: true,
 	}
@@ -459,3 +634,48 @@ func resourceStorageBlobDelete(d *pluginsdk.ResourceData, meta interface{}) erro
 
 	return nil
 }
+
+func expandStorageBlobIndexTags(input map[string]interface{}) map[string]string {
+	output := make(map[string]string, len(input))
+	for k, v := range input {
+		output[k] = v.(string)
+	}
+	return output
+}
+
+// flattenStorageBlobImmutabilityPolicy flattens the Immutability Policy returned in the headers of the Blob's
+// properties, since these aren't exposed by the Blobs Client
+func flattenStorageBlobImmutabilityPolicy(resp *http.Response) ([]interface{}, error) {
+	if resp == nil {
+		return []interface{}{}, nil
+	}
+
+	expiry := resp.Header.Get("x-ms-immutability-policy-until-date")
+	if expiry == "" {
+		return []interface{}{}, nil
+	}
+
+	// the mode is returned in lower-case
+	var mode client.BlobImmutabilityPolicyMode
+	switch rawMode := resp.Header.Get("x-ms-immutability-policy-mode"); {
+	case strings.EqualFold(rawMode, string(client.BlobImmutabilityPolicyModeLocked)):
+		mode = client.BlobImmutabilityPolicyModeLocked
+	case strings.EqualFold(rawMode, string(client.BlobImmutabilityPolicyModeUnlocked)):
+		mode = client.BlobImmutabilityPolicyModeUnlocked
+	default:
+		// a policy which has been deleted is returned as `Mutable`
+		return []interface{}{}, nil
+	}
+
+	expiryTime, err := http.ParseTime(expiry)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the expiry time %q: %v", expiry, err)
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"expiry_time": expiryTime.UTC().Format(time.RFC3339),
+			"mode":        string(mode),
+		},
+	}, nil
+}
@@ -543,6 +543,86 @@ func TestAccStorageBlob_archive(t *testing.T) {
 	})
 }
 
+func TestAccStorageBlob_indexTags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.indexTags(data, `project = "archive"`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("index_tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("index_tags.project").HasValue("archive"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+		{
+			Config: r.indexTags(data, `project = "archive"
+    retention = "7y"`),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("index_tags.%").HasValue("2"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+		{
+			Config: r.indexTags(data, ""),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("index_tags.%").HasValue("0"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+	})
+}
+
+func TestAccStorageBlob_immutabilityPolicyAndLegalHold(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_storage_blob", "test")
+	r := StorageBlobResource{}
+	expiry := time.Now().UTC().Add(time.Hour * 24).Truncate(time.Second).Format(time.RFC3339)
+	extendedExpiry := time.Now().UTC().Add(time.Hour * 48).Truncate(time.Second).Format(time.RFC3339)
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.immutabilityPolicyAndLegalHold(data, expiry, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("immutability_policy.0.expiry_time").HasValue(expiry),
+				check.That(data.ResourceName).Key("immutability_policy.0.mode").HasValue("Unlocked"),
+				check.That(data.ResourceName).Key("legal_hold_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+		{
+			Config: r.immutabilityPolicyAndLegalHold(data, extendedExpiry, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("immutability_policy.0.expiry_time").HasValue(extendedExpiry),
+				check.That(data.ResourceName).Key("legal_hold_enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+		{
+			Config: r.immutabilityRemoved(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("immutability_policy.#").HasValue("0"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+		{
+			// the Legal Hold and the Unlocked policy are removed when the blob is deleted
+			Config: r.immutabilityPolicyAndLegalHold(data, extendedExpiry, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("legal_hold_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep("parallelism", "size", "type", "source_content"),
+	})
+}
+
 func (r StorageBlobResource) Exists(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := blobs.ParseBlobID(state.ID, client.Storage.StorageDomainSuffix)
 	if err != nil {
@@ -1460,3 +1540,103 @@ func populateTempFile(input *os.File) error {
 
 	return nil
 }
+
+func (r StorageBlobResource) indexTags(data acceptance.TestData, indexTags string) string {
+	template := r.template(data, "private")
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "rick.morty"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "Wubba Lubba Dub Dub"
+
+  index_tags = {
+    %s
+  }
+}
+`, template, indexTags)
+}
+
+func (r StorageBlobResource) templateVersionLevelImmutability(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+
+  blob_properties {
+    versioning_enabled = true
+  }
+
+  immutability_policy {
+    allow_protected_append_writes = false
+    period_since_creation_in_days = 1
+    state                         = "Disabled"
+  }
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "test"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString)
+}
+
+func (r StorageBlobResource) immutabilityPolicyAndLegalHold(data acceptance.TestData, expiry string, legalHold bool) string {
+	template := r.templateVersionLevelImmutability(data)
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "rick.morty"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "Wubba Lubba Dub Dub"
+  legal_hold_enabled     = %t
+
+  immutability_policy {
+    expiry_time = "%s"
+    mode        = "Unlocked"
+  }
+}
+`, template, legalHold, expiry)
+}
+
+func (r StorageBlobResource) immutabilityRemoved(data acceptance.TestData) string {
+	template := r.templateVersionLevelImmutability(data)
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_storage_blob" "test" {
+  name                   = "rick.morty"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  source_content         = "Wubba Lubba Dub Dub"
+}
+`, template)
+}
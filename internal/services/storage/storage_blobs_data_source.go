@@ -0,0 +1,234 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/storage/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/accounts"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/blobs"
+	"github.com/tombuildsstuff/giovanni/storage/2023-11-03/blob/containers"
+)
+
+type storageBlobsDataSource struct{}
+
+var _ sdk.DataSource = storageBlobsDataSource{}
+
+type storageBlobsDataSourceModel struct {
+	StorageAccountId     string      `tfschema:"storage_account_id"`
+	StorageContainerName string      `tfschema:"storage_container_name"`
+	Prefix               string      `tfschema:"prefix"`
+	Blobs                []blobModel `tfschema:"blobs"`
+}
+
+type blobModel struct {
+	Name         string            `tfschema:"name"`
+	Url          string            `tfschema:"url"`
+	Type         string            `tfschema:"type"`
+	AccessTier   string            `tfschema:"access_tier"`
+	ContentType  string            `tfschema:"content_type"`
+	ContentMD5   string            `tfschema:"content_md5"`
+	SizeInBytes  int64             `tfschema:"size_in_bytes"`
+	LastModified string            `tfschema:"last_modified"`
+	MetaData     map[string]string `tfschema:"metadata"`
+	Tags         map[string]string `tfschema:"tags"`
+}
+
+func (r storageBlobsDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"storage_account_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: commonids.ValidateStorageAccountID,
+		},
+		"storage_container_name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validate.StorageContainerName,
+		},
+		"prefix": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+}
+
+func (r storageBlobsDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"blobs": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"url": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"type": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"access_tier": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"content_type": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"content_md5": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"size_in_bytes": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+					"last_modified": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+					"metadata": {
+						Type:     pluginsdk.TypeMap,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+					"tags": {
+						Type:     pluginsdk.TypeMap,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r storageBlobsDataSource) ResourceType() string {
+	return "azurerm_storage_blobs"
+}
+
+func (r storageBlobsDataSource) ModelObject() interface{} {
+	return &storageBlobsDataSourceModel{}
+}
+
+func (r storageBlobsDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			storageClient := metadata.Client.Storage
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var plan storageBlobsDataSourceModel
+			if err := metadata.Decode(&plan); err != nil {
+				return fmt.Errorf("decoding %+v", err)
+			}
+
+			id, err := commonids.ParseStorageAccountID(plan.StorageAccountId)
+			if err != nil {
+				return err
+			}
+
+			account, err := storageClient.FindAccount(ctx, subscriptionId, id.StorageAccountName)
+			if err != nil {
+				return fmt.Errorf("retrieving Storage Account %q: %v", id.StorageAccountName, err)
+			}
+			if account == nil {
+				return fmt.Errorf("locating Storage Account %q", id.StorageAccountName)
+			}
+
+			// Determine the blob endpoint, so we can build a data plane ID
+			endpoint, err := account.DataPlaneEndpoint(client.EndpointTypeBlob)
+			if err != nil {
+				return fmt.Errorf("determining Blob endpoint: %v", err)
+			}
+
+			// Parse the blob endpoint as a data plane account ID
+			accountId, err := accounts.ParseAccountID(*endpoint, storageClient.StorageDomainSuffix)
+			if err != nil {
+				return fmt.Errorf("parsing Account ID: %v", err)
+			}
+
+			containerId := containers.NewContainerID(*accountId, plan.StorageContainerName)
+
+			// the metadata and tags are included when listing the blobs, rather than being retrieved for each blob
+			items, err := storageClient.ListBlobsWithMetaDataAndTags(ctx, *account, plan.StorageContainerName, plan.Prefix, !account.IsHnsEnabled, storageClient.DataPlaneOperationSupportingAnyAuthMethod())
+			if err != nil {
+				return fmt.Errorf("listing blobs within %s: %v", containerId, err)
+			}
+
+			plan.Blobs = make([]blobModel, 0)
+			if items != nil {
+				for _, item := range *items {
+					if item.Deleted {
+						continue
+					}
+
+					blob, err := flattenStorageBlobsBlob(item, *accountId, plan.StorageContainerName)
+					if err != nil {
+						return err
+					}
+
+					plan.Blobs = append(plan.Blobs, *blob)
+				}
+			}
+
+			if err := metadata.Encode(&plan); err != nil {
+				return fmt.Errorf("encoding %s: %+v", containerId, err)
+			}
+
+			metadata.SetID(containerId)
+
+			return nil
+		},
+	}
+}
+
+func flattenStorageBlobsBlob(input client.ListedBlob, accountId accounts.AccountId, containerName string) (*blobModel, error) {
+	output := blobModel{
+		Name:     input.Name,
+		Url:      blobs.NewBlobID(accountId, containerName, input.Name).ID(),
+		MetaData: input.MetaData,
+		Tags:     input.Tags,
+	}
+
+	if props := input.Properties; props != nil {
+		output.Type = strings.TrimSuffix(pointer.From(props.BlobType), "Blob")
+		output.AccessTier = pointer.From(props.AccessTier)
+		output.ContentType = pointer.From(props.ContentType)
+		output.SizeInBytes = pointer.From(props.ContentLength)
+		output.LastModified = pointer.From(props.LastModified)
+
+		// Set the ContentMD5 value to md5 hash in hex
+		if v := pointer.From(props.ContentMD5); v != "" {
+			contentMD5, err := convertBase64ToHexEncoding(v)
+			if err != nil {
+				return nil, fmt.Errorf("converting the content_md5 of the blob %q to hex encoding: %v", input.Name, err)
+			}
+			output.ContentMD5 = contentMD5
+		}
+	}
+
+	return &output, nil
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type storageBlobsDataSource struct{}
+
+func TestAccDataSourceStorageBlobs_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_blobs", "test")
+	d := storageBlobsDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: d.basic(data, "null"),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("blobs.#").HasValue("3"),
+				check.That(data.ResourceName).Key("blobs.0.name").HasValue("logs/one.txt"),
+				check.That(data.ResourceName).Key("blobs.0.type").HasValue("Block"),
+				check.That(data.ResourceName).Key("blobs.0.size_in_bytes").HasValue("3"),
+				check.That(data.ResourceName).Key("blobs.0.content_type").HasValue("text/plain"),
+				check.That(data.ResourceName).Key("blobs.0.metadata.%").HasValue("1"),
+				check.That(data.ResourceName).Key("blobs.0.metadata.source").HasValue("acctest"),
+				check.That(data.ResourceName).Key("blobs.0.url").HasValue(
+					fmt.Sprintf("https://acctestacc%s.blob.core.windows.net/test/logs/one.txt", data.RandomString),
+				),
+				check.That(data.ResourceName).Key("blobs.2.name").HasValue("site/index.html"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceStorageBlobs_prefix(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_storage_blobs", "test")
+	d := storageBlobsDataSource{}
+
+	data.DataSourceTest(t, []resource.TestStep{
+		{
+			Config: d.basic(data, `"logs/"`),
+			Check: resource.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("blobs.#").HasValue("2"),
+				check.That(data.ResourceName).Key("blobs.0.name").HasValue("logs/one.txt"),
+				check.That(data.ResourceName).Key("blobs.1.name").HasValue("logs/two.txt"),
+			),
+		},
+	})
+}
+
+func (d storageBlobsDataSource) basic(data acceptance.TestData, prefix string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestacc%s"
+  resource_group_name      = azurerm_resource_group.test.name
+  location                 = azurerm_resource_group.test.location
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "test"
+  storage_account_name  = azurerm_storage_account.test.name
+  container_access_type = "private"
+}
+
+resource "azurerm_storage_blob" "one" {
+  name                   = "logs/one.txt"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  content_type           = "text/plain"
+  source_content         = "one"
+
+  metadata = {
+    source = "acctest"
+  }
+}
+
+resource "azurerm_storage_blob" "two" {
+  name                   = "logs/two.txt"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  content_type           = "text/plain"
+  source_content         = "two"
+}
+
+resource "azurerm_storage_blob" "index" {
+  name                   = "site/index.html"
+  storage_account_name   = azurerm_storage_account.test.name
+  storage_container_name = azurerm_storage_container.test.name
+  type                   = "Block"
+  content_type           = "text/html"
+  source_content         = "<html></html>"
+}
+
+data "azurerm_storage_blobs" "test" {
+  storage_account_id     = azurerm_storage_account.test.id
+  storage_container_name = azurerm_storage_container.test.name
+  prefix                 = %s
+  depends_on             = [azurerm_storage_blob.one, azurerm_storage_blob.two, azurerm_storage_blob.index]
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomString, prefix)
+}
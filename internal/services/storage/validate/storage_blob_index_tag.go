@@ -24,3 +24,27 @@ func StorageBlobIndexTagValue(v interface{}, k string) (warnings []string, error
 	}
 	return warnings, errors
 }
+
+// StorageBlobIndexTags validates a map of Blob Index Tags, of which a maximum of 10 can be assigned to a Blob
+func StorageBlobIndexTags(v interface{}, k string) (warnings []string, errors []error) {
+	tagsMap := v.(map[string]interface{})
+
+	if len(tagsMap) > 10 {
+		errors = append(errors, fmt.Errorf("a maximum of 10 Blob Index Tags can be assigned to a Blob: %q contains %d", k, len(tagsMap)))
+	}
+
+	for key, value := range tagsMap {
+		_, keyErrors := StorageBlobIndexTagName(key, fmt.Sprintf("%s key", k))
+		errors = append(errors, keyErrors...)
+
+		str, ok := value.(string)
+		if !ok {
+			errors = append(errors, fmt.Errorf("the value of %q in %q must be a string", key, k))
+			continue
+		}
+		_, valueErrors := StorageBlobIndexTagValue(str, fmt.Sprintf("%s value for %q", k, key))
+		errors = append(errors, valueErrors...)
+	}
+
+	return warnings, errors
+}
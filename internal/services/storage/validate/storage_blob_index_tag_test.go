@@ -54,3 +54,28 @@ func TestStorageBlobIndexTagValue(t *testing.T) {
 		}
 	}
 }
+
+func TestStorageBlobIndexTags(t *testing.T) {
+	tooMany := make(map[string]interface{})
+	for i := 0; i < 11; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "value"
+	}
+
+	testCases := []struct {
+		input map[string]interface{}
+		valid bool
+	}{
+		{map[string]interface{}{}, true},
+		{map[string]interface{}{"project": "archive", "retention": ""}, true},
+		{map[string]interface{}{strings.Repeat("w", 128): strings.Repeat("w", 256)}, true},
+		{map[string]interface{}{strings.Repeat("w", 129): "value"}, false},
+		{map[string]interface{}{"project": strings.Repeat("w", 257)}, false},
+		{tooMany, false},
+	}
+	for _, tc := range testCases {
+		_, errors := StorageBlobIndexTags(tc.input, "index_tags")
+		if valid := len(errors) == 0; valid != tc.valid {
+			t.Fatalf("expected %v to be valid %t but got %t: %q", tc.input, tc.valid, valid, errors)
+		}
+	}
+}
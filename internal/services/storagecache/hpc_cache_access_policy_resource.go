@@ -132,7 +132,9 @@ func resourceHPCCacheAccessPolicyCreateUpdate(d *pluginsdk.ResourceData, meta in
 	}
 	id := parse.NewCacheAccessPolicyID(cacheId.SubscriptionId, cacheId.ResourceGroupName, cacheId.CacheName, name)
 
-	locks.ByID(id.ID())
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	existCache, err := client.Get(ctx, *cacheId)
@@ -259,7 +261,9 @@ func resourceHPCCacheAccessPolicyDelete(d *pluginsdk.ResourceData, meta interfac
 	}
 	cacheId := caches.NewCacheID(id.SubscriptionId, id.ResourceGroup, id.CacheName)
 
-	locks.ByID(id.ID())
+	if err := locks.ByIDWithContext(ctx, id.ID()); err != nil {
+		return err
+	}
 	defer locks.UnlockByID(id.ID())
 
 	existCache, err := client.Get(ctx, cacheId)
@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"strings"
+
+	resourceTags "github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/tags"
+)
+
+// ProviderConfiguration is the Tags configuration specified in the Provider block, which applies to every
+// taggable resource
+type ProviderConfiguration struct {
+	// DefaultTags are the Tags which should be assigned to every taggable resource, where the Tags
+	// specified on the resource take precedence
+	DefaultTags map[string]string
+
+	// IgnoreKeys are the Tag keys which should be ignored when reading the Tags for a resource, for
+	// example those assigned by Azure Policy
+	IgnoreKeys []string
+
+	// IgnoreKeyPrefixes are the prefixes for Tag keys which should be ignored when reading the Tags
+	// for a resource, for example those assigned by Azure Policy
+	IgnoreKeyPrefixes []string
+
+	// Client is used to retrieve and update the Tags assigned to a Resource Manager resource, for example
+	// when only the Default Tags have changed, or to retain any ignored Tags during an update
+	Client *resourceTags.TagsClient
+}
+
+// ProviderConfigurationSource is implemented by the Provider's meta (the Client), which exposes the Tags
+// configuration specified in the Provider block for this instance of the Provider
+type ProviderConfigurationSource interface {
+	ProviderTagsConfiguration() ProviderConfiguration
+}
+
+// providerConfigurationFromMeta returns the Tags configuration for the instance of the Provider which is
+// managing this resource - noting that each (aliased) Provider block can specify a different configuration
+func providerConfigurationFromMeta(meta interface{}) ProviderConfiguration {
+	if v, ok := meta.(ProviderConfigurationSource); ok {
+		return v.ProviderTagsConfiguration()
+	}
+
+	return ProviderConfiguration{}
+}
+
+// hasIgnoredTags returns whether any Tags should be ignored
+func (c ProviderConfiguration) hasIgnoredTags() bool {
+	return len(c.IgnoreKeys) > 0 || len(c.IgnoreKeyPrefixes) > 0
+}
+
+// isIgnored returns whether the specified Tag key should be ignored - noting that Tag keys are
+// case-insensitive in Azure
+func (c ProviderConfiguration) isIgnored(key string) bool {
+	for _, v := range c.IgnoreKeys {
+		if strings.EqualFold(v, key) {
+			return true
+		}
+	}
+
+	for _, v := range c.IgnoreKeyPrefixes {
+		if v != "" && strings.HasPrefix(strings.ToLower(key), strings.ToLower(v)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// mergeDefaultTags returns the Default Tags merged with the specified Tags, where the specified
+// Tags take precedence
+func (c ProviderConfiguration) mergeDefaultTags(input map[string]interface{}) map[string]interface{} {
+	output := make(map[string]interface{}, len(c.DefaultTags)+len(input))
+	for k, v := range c.DefaultTags {
+		if _, ok := lookupKey(input, k); ok {
+			continue
+		}
+		output[k] = v
+	}
+	for k, v := range input {
+		output[k] = v
+	}
+
+	return output
+}
+
+// filterTags splits the Tags returned from the API into the Tags which should be set into `tags` - omitting
+// those which came from the Default Tags - and those which should be set into `tags_all`. In both cases
+// any ignored Tags are omitted, unless these are specified in the configuration.
+func (c ProviderConfiguration) filterTags(input map[string]interface{}, configured map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	resourceTags := make(map[string]interface{})
+	allTags := make(map[string]interface{})
+
+	for k, v := range input {
+		if _, ok := lookupKey(configured, k); ok {
+			resourceTags[k] = v
+			allTags[k] = v
+			continue
+		}
+
+		if c.isIgnored(k) {
+			continue
+		}
+
+		allTags[k] = v
+		if defaultValue, ok := lookupDefaultTag(c.DefaultTags, k); ok && defaultValue == v {
+			continue
+		}
+		resourceTags[k] = v
+	}
+
+	return resourceTags, allTags
+}
+
+// retainIgnoredTags returns the specified Tags along with any ignored Tags currently assigned to the resource,
+// so that updating the Tags doesn't remove those assigned outside of Terraform (for example by Azure Policy)
+func (c ProviderConfiguration) retainIgnoredTags(input map[string]interface{}, existing map[string]string) map[string]interface{} {
+	output := make(map[string]interface{}, len(input)+len(existing))
+	for k, v := range existing {
+		if !c.isIgnored(k) {
+			continue
+		}
+		if _, ok := lookupKey(input, k); ok {
+			continue
+		}
+		output[k] = v
+	}
+	for k, v := range input {
+		output[k] = v
+	}
+
+	return output
+}
+
+func lookupKey(input map[string]interface{}, key string) (interface{}, bool) {
+	for k, v := range input {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+func lookupDefaultTag(input map[string]string, key string) (interface{}, bool) {
+	for k, v := range input {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,233 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProviderConfigurationIsIgnored(t *testing.T) {
+	config := ProviderConfiguration{
+		IgnoreKeys:        []string{"CreatedBy"},
+		IgnoreKeyPrefixes: []string{"policy:", ""},
+	}
+
+	testData := map[string]bool{
+		"CreatedBy":        true,
+		"createdby":        true,
+		"Policy:Owner":     true,
+		"policy:":          true,
+		"environment":      false,
+		"CreatedByPolicy":  false,
+		"my-policy:source": false,
+	}
+
+	for key, expected := range testData {
+		t.Logf("[DEBUG] Testing %q", key)
+
+		if actual := config.isIgnored(key); actual != expected {
+			t.Fatalf("expected %q to be ignored to be %t but got %t", key, expected, actual)
+		}
+	}
+}
+
+func TestProviderConfigurationMergeDefaultTags(t *testing.T) {
+	config := ProviderConfiguration{
+		DefaultTags: map[string]string{
+			"environment": "production",
+			"owner":       "platform",
+		},
+	}
+
+	actual := config.mergeDefaultTags(map[string]interface{}{
+		"Environment": "development",
+		"project":     "example",
+	})
+	expected := map[string]interface{}{
+		"Environment": "development",
+		"owner":       "platform",
+		"project":     "example",
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v but got %+v", expected, actual)
+	}
+}
+
+func TestProviderConfigurationFilterTags(t *testing.T) {
+	config := ProviderConfiguration{
+		DefaultTags: map[string]string{
+			"environment": "production",
+			"owner":       "platform",
+		},
+		IgnoreKeys:        []string{"CreatedBy"},
+		IgnoreKeyPrefixes: []string{"policy:"},
+	}
+
+	testData := []struct {
+		Name                 string
+		Input                map[string]interface{}
+		Configured           map[string]interface{}
+		ExpectedResourceTags map[string]interface{}
+		ExpectedAllTags      map[string]interface{}
+	}{
+		{
+			Name: "default tags are omitted from tags",
+			Input: map[string]interface{}{
+				"environment": "production",
+				"owner":       "platform",
+				"project":     "example",
+			},
+			Configured: map[string]interface{}{
+				"project": "example",
+			},
+			ExpectedResourceTags: map[string]interface{}{
+				"project": "example",
+			},
+			ExpectedAllTags: map[string]interface{}{
+				"environment": "production",
+				"owner":       "platform",
+				"project":     "example",
+			},
+		},
+		{
+			Name: "default tags which are configured are retained",
+			Input: map[string]interface{}{
+				"environment": "production",
+				"owner":       "platform",
+			},
+			Configured: map[string]interface{}{
+				"Environment": "production",
+			},
+			ExpectedResourceTags: map[string]interface{}{
+				"environment": "production",
+			},
+			ExpectedAllTags: map[string]interface{}{
+				"environment": "production",
+				"owner":       "platform",
+			},
+		},
+		{
+			Name: "default tags which have been changed are retained",
+			Input: map[string]interface{}{
+				"environment": "development",
+			},
+			Configured: map[string]interface{}{},
+			ExpectedResourceTags: map[string]interface{}{
+				"environment": "development",
+			},
+			ExpectedAllTags: map[string]interface{}{
+				"environment": "development",
+			},
+		},
+		{
+			Name: "ignored tags are omitted",
+			Input: map[string]interface{}{
+				"createdby":     "policy",
+				"policy:source": "initiative",
+				"project":       "example",
+			},
+			Configured: map[string]interface{}{
+				"project": "example",
+			},
+			ExpectedResourceTags: map[string]interface{}{
+				"project": "example",
+			},
+			ExpectedAllTags: map[string]interface{}{
+				"project": "example",
+			},
+		},
+		{
+			Name: "ignored tags which are configured are retained",
+			Input: map[string]interface{}{
+				"CreatedBy": "terraform",
+			},
+			Configured: map[string]interface{}{
+				"CreatedBy": "terraform",
+			},
+			ExpectedResourceTags: map[string]interface{}{
+				"CreatedBy": "terraform",
+			},
+			ExpectedAllTags: map[string]interface{}{
+				"CreatedBy": "terraform",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		resourceTags, allTags := config.filterTags(v.Input, v.Configured)
+		if !reflect.DeepEqual(resourceTags, v.ExpectedResourceTags) {
+			t.Fatalf("expected the resource tags to be %+v but got %+v", v.ExpectedResourceTags, resourceTags)
+		}
+		if !reflect.DeepEqual(allTags, v.ExpectedAllTags) {
+			t.Fatalf("expected all tags to be %+v but got %+v", v.ExpectedAllTags, allTags)
+		}
+	}
+}
+
+func TestProviderConfigurationRetainIgnoredTags(t *testing.T) {
+	config := ProviderConfiguration{
+		IgnoreKeys:        []string{"CreatedBy"},
+		IgnoreKeyPrefixes: []string{"policy:"},
+	}
+
+	testData := []struct {
+		Name     string
+		Input    map[string]interface{}
+		Existing map[string]string
+		Expected map[string]interface{}
+	}{
+		{
+			Name: "ignored tags are retained",
+			Input: map[string]interface{}{
+				"project": "example",
+			},
+			Existing: map[string]string{
+				"createdby":     "policy",
+				"policy:source": "initiative",
+				"project":       "previous",
+			},
+			Expected: map[string]interface{}{
+				"createdby":     "policy",
+				"policy:source": "initiative",
+				"project":       "example",
+			},
+		},
+		{
+			Name: "tags which aren't ignored are removed",
+			Input: map[string]interface{}{
+				"project": "example",
+			},
+			Existing: map[string]string{
+				"environment": "production",
+			},
+			Expected: map[string]interface{}{
+				"project": "example",
+			},
+		},
+		{
+			Name: "configured tags take precedence",
+			Input: map[string]interface{}{
+				"CreatedBy": "terraform",
+			},
+			Existing: map[string]string{
+				"createdby": "policy",
+			},
+			Expected: map[string]interface{}{
+				"CreatedBy": "terraform",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		actual := config.retainIgnoredTags(v.Input, v.Existing)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	resourceTags "github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/tags"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// WithProviderTags applies the Tags configuration specified in the Provider block (the `default_tags`
+// and `ignore_tags` blocks) to the specified resource when it's taggable - that is, it exposes an
+// updatable top-level `tags` field.
+//
+// This exposes a `tags_all` attribute containing every Tag assigned to the resource (including the
+// Default Tags), merges the Default Tags into the `tags` used during Create and Update, and omits any
+// ignored Tags when reading the resource. The configuration is read from the Provider's meta, so this
+// must be applied once per resource, when the Provider is built.
+func WithProviderTags(resource *pluginsdk.Resource) *pluginsdk.Resource {
+	if !isTaggable(resource) {
+		return resource
+	}
+
+	resource.Schema["tags_all"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeMap,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+
+	resource.Create = wrapLegacyFunc(resource.Create, providerTagsOperationCreate)
+	resource.Read = wrapLegacyFunc(resource.Read, providerTagsOperationRead)
+	resource.Update = wrapLegacyFunc(resource.Update, providerTagsOperationUpdate)
+	resource.CreateContext = wrapContextFunc(resource.CreateContext, providerTagsOperationCreate)
+	resource.ReadContext = wrapContextFunc(resource.ReadContext, providerTagsOperationRead)
+	resource.UpdateContext = wrapContextFunc(resource.UpdateContext, providerTagsOperationUpdate)
+	resource.CreateWithoutTimeout = wrapContextFunc(resource.CreateWithoutTimeout, providerTagsOperationCreate)
+	resource.ReadWithoutTimeout = wrapContextFunc(resource.ReadWithoutTimeout, providerTagsOperationRead)
+	resource.UpdateWithoutTimeout = wrapContextFunc(resource.UpdateWithoutTimeout, providerTagsOperationUpdate)
+
+	if existing := resource.CustomizeDiff; existing != nil {
+		resource.CustomizeDiff = pluginsdk.CustomDiffInSequence(existing, customizeDiffForProviderTags)
+	} else {
+		resource.CustomizeDiff = customizeDiffForProviderTags
+	}
+
+	return resource
+}
+
+// isTaggable returns whether the Tags configuration specified in the Provider block can be applied to this
+// resource - resources where Tags are Computed-only or can't be updated in-place aren't supported.
+func isTaggable(resource *pluginsdk.Resource) bool {
+	if resource == nil || resource.Schema == nil {
+		return false
+	}
+	if _, exists := resource.Schema["tags_all"]; exists {
+		return false
+	}
+
+	tagsSchema, ok := resource.Schema["tags"]
+	if !ok || tagsSchema.Type != pluginsdk.TypeMap || !tagsSchema.Optional || tagsSchema.ForceNew {
+		return false
+	}
+
+	return resource.Update != nil || resource.UpdateContext != nil || resource.UpdateWithoutTimeout != nil
+}
+
+// providerTagsOperation is the operation being performed against the resource, which determines how the
+// Tags configuration from the Provider block is applied
+type providerTagsOperation int
+
+const (
+	providerTagsOperationCreate providerTagsOperation = iota
+	providerTagsOperationRead
+	providerTagsOperationUpdate
+)
+
+func wrapLegacyFunc(in func(*pluginsdk.ResourceData, interface{}) error, operation providerTagsOperation) func(*pluginsdk.ResourceData, interface{}) error {
+	if in == nil {
+		return nil
+	}
+
+	return func(d *pluginsdk.ResourceData, meta interface{}) error {
+		ctx, cancel := context.WithTimeout(context.Background(), operationTimeout(d, operation))
+		defer cancel()
+
+		config := providerConfigurationFromMeta(meta)
+		configured, err := expandProviderTags(ctx, d, config, operation)
+		if err != nil {
+			return err
+		}
+
+		err = in(d, meta)
+		if flattenErr := flattenProviderTags(d, config, configured); flattenErr != nil && err == nil {
+			err = flattenErr
+		}
+
+		return err
+	}
+}
+
+func wrapContextFunc(in func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics, operation providerTagsOperation) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	if in == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		config := providerConfigurationFromMeta(meta)
+		configured, err := expandProviderTags(ctx, d, config, operation)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		diags := in(ctx, d, meta)
+		if err := flattenProviderTags(d, config, configured); err != nil && !diags.HasError() {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+
+		return diags
+	}
+}
+
+func operationTimeout(d *pluginsdk.ResourceData, operation providerTagsOperation) time.Duration {
+	switch operation {
+	case providerTagsOperationCreate:
+		return d.Timeout(pluginsdk.TimeoutCreate)
+	case providerTagsOperationUpdate:
+		return d.Timeout(pluginsdk.TimeoutUpdate)
+	}
+
+	return d.Timeout(pluginsdk.TimeoutRead)
+}
+
+// expandProviderTags returns the Tags specified on the resource. During Create and Update the Default Tags
+// are merged into `tags` so that these are sent to the API - and during Update any ignored Tags currently
+// assigned to a Resource Manager resource are retained.
+//
+// Since Terraform only considers `tags` to have changed when the configuration for the resource changes,
+// when only the Default Tags change (and as such only `tags_all` has changed) the Tags are updated directly
+// for Resource Manager resources, rather than relying on the resource to send these.
+func expandProviderTags(ctx context.Context, d *pluginsdk.ResourceData, config ProviderConfiguration, operation providerTagsOperation) (map[string]interface{}, error) {
+	configured := d.Get("tags").(map[string]interface{})
+	if operation == providerTagsOperationRead {
+		return configured, nil
+	}
+
+	desired := config.mergeDefaultTags(configured)
+
+	scopeId, isResourceManagerId := resourceManagerScope(d.Id())
+	canManageTags := operation == providerTagsOperationUpdate && isResourceManagerId && config.Client != nil
+	if canManageTags && config.hasIgnoredTags() {
+		resp, err := config.Client.GetAtScope(ctx, *scopeId)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving the Tags assigned to %s: %+v", scopeId, err)
+		}
+		existing := make(map[string]string)
+		if model := resp.Model; model != nil && model.Properties.Tags != nil {
+			existing = *model.Properties.Tags
+		}
+		desired = config.retainIgnoredTags(desired, existing)
+	}
+
+	if !reflect.DeepEqual(configured, desired) {
+		if err := d.Set("tags", desired); err != nil {
+			return nil, fmt.Errorf("setting `tags`: %+v", err)
+		}
+	}
+
+	if canManageTags && d.HasChange("tags_all") && !d.HasChange("tags") {
+		payload := resourceTags.TagsPatchResource{
+			Operation: pointer.To(resourceTags.TagsPatchOperationReplace),
+			Properties: &resourceTags.Tags{
+				Tags: pointer.To(expandStringMap(desired)),
+			},
+		}
+		if err := config.Client.UpdateAtScopeThenPoll(ctx, *scopeId, payload); err != nil {
+			return nil, fmt.Errorf("updating the Tags assigned to %s: %+v", scopeId, err)
+		}
+	}
+
+	return configured, nil
+}
+
+// resourceManagerScope returns the Scope for the Tags assigned to the resource when it's a Resource Manager
+// resource - data plane resources (for example Key Vault Keys) are identified by a URI instead
+func resourceManagerScope(id string) (*commonids.ScopeId, bool) {
+	if !strings.HasPrefix(id, "/") || strings.Contains(id, "|") {
+		return nil, false
+	}
+
+	scopeId := commonids.NewScopeID(id)
+	return &scopeId, true
+}
+
+func expandStringMap(input map[string]interface{}) map[string]string {
+	output := make(map[string]string, len(input))
+	for k, v := range input {
+		output[k] = v.(string)
+	}
+
+	return output
+}
+
+// flattenProviderTags updates `tags` to omit any Default or ignored Tags which aren't specified on the
+// resource, and sets `tags_all` to contain every (non-ignored) Tag assigned to the resource
+func flattenProviderTags(d *pluginsdk.ResourceData, config ProviderConfiguration, configured map[string]interface{}) error {
+	// the resource is gone
+	if d.Id() == "" {
+		return nil
+	}
+
+	current := d.Get("tags").(map[string]interface{})
+	filteredTags, allTags := config.filterTags(current, configured)
+
+	if !reflect.DeepEqual(current, filteredTags) {
+		if err := d.Set("tags", filteredTags); err != nil {
+			return fmt.Errorf("setting `tags`: %+v", err)
+		}
+	}
+	if err := d.Set("tags_all", allTags); err != nil {
+		return fmt.Errorf("setting `tags_all`: %+v", err)
+	}
+
+	return nil
+}
+
+// customizeDiffForProviderTags computes the planned value for `tags_all` from the Tags specified on the
+// resource and the Default Tags
+func customizeDiffForProviderTags(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	configured := d.Get("tags").(map[string]interface{})
+	for k := range configured {
+		if !d.NewValueKnown(fmt.Sprintf("tags.%s", k)) {
+			return d.SetNewComputed("tags_all")
+		}
+	}
+
+	config := providerConfigurationFromMeta(meta)
+	existing := d.Get("tags_all").(map[string]interface{})
+
+	// existing resources which haven't been refreshed since `tags_all` was introduced are populated
+	// during the next apply, rather than surfacing a diff for every resource
+	if d.Id() != "" && len(config.DefaultTags) == 0 && len(existing) == 0 && !d.HasChange("tags") {
+		return nil
+	}
+
+	allTags := config.mergeDefaultTags(configured)
+	if reflect.DeepEqual(existing, allTags) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", allTags)
+}
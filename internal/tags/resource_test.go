@@ -0,0 +1,239 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func testTaggableResource(tagsSchema *pluginsdk.Schema, read func(d *pluginsdk.ResourceData, meta interface{}) error) *pluginsdk.Resource {
+	return &pluginsdk.Resource{
+		Create: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			d.SetId("example")
+			return read(d, meta)
+		},
+		Read: read,
+		Update: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return read(d, meta)
+		},
+		Delete: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": tagsSchema,
+		},
+	}
+}
+
+type testProviderMeta struct {
+	config ProviderConfiguration
+}
+
+func (m testProviderMeta) ProviderTagsConfiguration() ProviderConfiguration {
+	return m.config
+}
+
+func TestWithProviderTagsTaggable(t *testing.T) {
+	noop := func(d *pluginsdk.ResourceData, meta interface{}) error {
+		return nil
+	}
+
+	testData := []struct {
+		Name     string
+		Resource *pluginsdk.Resource
+		Expected bool
+	}{
+		{
+			Name:     "updatable tags",
+			Resource: testTaggableResource(Schema(), noop),
+			Expected: true,
+		},
+		{
+			Name:     "tags which require recreation",
+			Resource: testTaggableResource(ForceNewSchema(), noop),
+			Expected: false,
+		},
+		{
+			Name:     "computed-only tags",
+			Resource: testTaggableResource(SchemaDataSource(), noop),
+			Expected: false,
+		},
+		{
+			Name: "no tags",
+			Resource: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{},
+			},
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		resource := WithProviderTags(v.Resource)
+		_, actual := resource.Schema["tags_all"]
+		if actual != v.Expected {
+			t.Fatalf("expected `tags_all` to exist to be %t but got %t", v.Expected, actual)
+		}
+		if v.Expected {
+			if err := resource.InternalValidate(nil, true); err != nil {
+				t.Fatalf("validating the resource: %+v", err)
+			}
+		}
+	}
+}
+
+func TestWithProviderTagsCreate(t *testing.T) {
+	meta := testProviderMeta{
+		config: ProviderConfiguration{
+			DefaultTags: map[string]string{
+				"environment": "production",
+			},
+			IgnoreKeys: []string{"CreatedBy"},
+		},
+	}
+
+	var sentTags map[string]interface{}
+	read := func(d *pluginsdk.ResourceData, meta interface{}) error {
+		if sentTags == nil {
+			sentTags = d.Get("tags").(map[string]interface{})
+		}
+
+		// the API returns the tags which were sent, in addition to any added by Azure Policy
+		apiTags := map[string]interface{}{
+			"CreatedBy": "policy",
+		}
+		for k, v := range sentTags {
+			apiTags[k] = v
+		}
+		return d.Set("tags", apiTags)
+	}
+	resource := WithProviderTags(testTaggableResource(Schema(), read))
+
+	d := resource.TestResourceData()
+	if err := d.Set("tags", map[string]interface{}{"project": "example"}); err != nil {
+		t.Fatalf("setting `tags`: %+v", err)
+	}
+	if err := resource.Create(d, meta); err != nil {
+		t.Fatalf("creating: %+v", err)
+	}
+
+	expectedSentTags := map[string]interface{}{
+		"environment": "production",
+		"project":     "example",
+	}
+	if !reflect.DeepEqual(sentTags, expectedSentTags) {
+		t.Fatalf("expected the tags sent to the API to be %+v but got %+v", expectedSentTags, sentTags)
+	}
+
+	expectedTags := map[string]interface{}{
+		"project": "example",
+	}
+	if actual := d.Get("tags").(map[string]interface{}); !reflect.DeepEqual(actual, expectedTags) {
+		t.Fatalf("expected `tags` to be %+v but got %+v", expectedTags, actual)
+	}
+	if actual := d.Get("tags_all").(map[string]interface{}); !reflect.DeepEqual(actual, expectedSentTags) {
+		t.Fatalf("expected `tags_all` to be %+v but got %+v", expectedSentTags, actual)
+	}
+}
+
+func TestWithProviderTagsIsPerProvider(t *testing.T) {
+	var sentTags map[string]interface{}
+	read := func(d *pluginsdk.ResourceData, meta interface{}) error {
+		sentTags = d.Get("tags").(map[string]interface{})
+		return nil
+	}
+	resource := WithProviderTags(testTaggableResource(Schema(), read))
+
+	testData := []struct {
+		Name     string
+		Meta     interface{}
+		Expected map[string]interface{}
+	}{
+		{
+			Name: "default tags",
+			Meta: testProviderMeta{
+				config: ProviderConfiguration{
+					DefaultTags: map[string]string{
+						"environment": "production",
+					},
+				},
+			},
+			Expected: map[string]interface{}{
+				"environment": "production",
+				"project":     "example",
+			},
+		},
+		{
+			Name: "aliased provider without default tags",
+			Meta: testProviderMeta{},
+			Expected: map[string]interface{}{
+				"project": "example",
+			},
+		},
+		{
+			Name: "no provider configuration",
+			Meta: nil,
+			Expected: map[string]interface{}{
+				"project": "example",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		d := resource.TestResourceData()
+		if err := d.Set("tags", map[string]interface{}{"project": "example"}); err != nil {
+			t.Fatalf("setting `tags`: %+v", err)
+		}
+		if err := resource.Create(d, v.Meta); err != nil {
+			t.Fatalf("creating: %+v", err)
+		}
+
+		if !reflect.DeepEqual(sentTags, v.Expected) {
+			t.Fatalf("expected the tags sent to the API to be %+v but got %+v", v.Expected, sentTags)
+		}
+	}
+}
+
+func TestResourceManagerScope(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example",
+			Expected: true,
+		},
+		{
+			Input:    "/providers/Microsoft.Management/managementGroups/example",
+			Expected: true,
+		},
+		{
+			Input:    "https://example.vault.azure.net/keys/example/fdf067c93bbb4b22bff4d8b7a9a56217",
+			Expected: false,
+		},
+		{
+			Input:    "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/first|/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/second",
+			Expected: false,
+		},
+		{
+			Input:    "",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		_, actual := resourceManagerScope(v.Input)
+		if actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
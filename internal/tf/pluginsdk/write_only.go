@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// Write-only arguments are sensitive values (such as passwords) which are sent to the API during Create/Update
+// but are never persisted into the State.
+//
+// NOTE: this is implemented using a DiffSuppressFunc rather than Terraform's native write-only attributes, which
+// means that Terraform Core still sends the value to the Provider and stores it within the Plan (including any
+// saved Plan file) - as such these arguments only keep the value out of the State, not out of the Plan.
+//
+// Since the value isn't available in the State, changes to a write-only argument can't be detected - as such
+// each write-only argument `foo_wo` has a companion argument `foo_wo_version` which must be changed in order
+// to send an updated value to the API.
+//
+// Example Usage:
+//
+//	"administrator_login_password_wo": {
+//		Type:             pluginsdk.TypeString,
+//		Optional:         true,
+//		Sensitive:        true,
+//		DiffSuppressFunc: pluginsdk.SuppressWriteOnlyDiff,
+//		ConflictsWith:    []string{"administrator_login_password"},
+//		RequiredWith:     []string{"administrator_login_password_wo_version"},
+//	},
+//
+//	"administrator_login_password_wo_version": {
+//		Type:         pluginsdk.TypeInt,
+//		Optional:     true,
+//		RequiredWith: []string{"administrator_login_password_wo"},
+//	},
+
+// SuppressWriteOnlyDiff is a DiffSuppressFunc which ensures that a diff is never shown for a write-only argument,
+// so that the value is never written to the State - the value should instead be retrieved from the raw configuration
+// using GetWriteOnly.
+//
+// NOTE: this only suppresses the diff - the value is still sent by Terraform Core and stored within the Plan.
+func SuppressWriteOnlyDiff(_, _, _ string, _ *ResourceData) bool {
+	return true
+}
+
+// WriteOnlyVersionKey returns the key of the companion `_wo_version` argument for the write-only argument `key`
+func WriteOnlyVersionKey(key string) string {
+	return fmt.Sprintf("%s_version", key)
+}
+
+// HasWriteOnlyChange returns whether the value of the write-only argument `key` should be sent to the API,
+// which is determined by a change to the companion `_wo_version` argument.
+func HasWriteOnlyChange(d *ResourceData, key string) bool {
+	return d.HasChange(WriteOnlyVersionKey(key))
+}
+
+// GetWriteOnly returns the value of the top-level write-only argument `key` from the raw configuration,
+// returning a Null value when the argument isn't specified.
+//
+// NOTE: the raw configuration is only available during Create and Update.
+func GetWriteOnly(d *ResourceData, key string, ty cty.Type) (cty.Value, error) {
+	return getWriteOnlyFromRawConfig(d.GetRawConfig(), key, ty)
+}
+
+// GetWriteOnlyString returns the value of the top-level write-only string argument `key` from the raw configuration,
+// returning an empty string when the argument isn't specified.
+func GetWriteOnlyString(d *ResourceData, key string) (string, error) {
+	v, err := GetWriteOnly(d, key, cty.String)
+	if err != nil {
+		return "", err
+	}
+
+	if v.IsNull() {
+		return "", nil
+	}
+
+	return v.AsString(), nil
+}
+
+func getWriteOnlyFromRawConfig(config cty.Value, key string, ty cty.Type) (cty.Value, error) {
+	if config.IsNull() || !config.IsKnown() {
+		return cty.NullVal(ty), nil
+	}
+
+	if !config.Type().IsObjectType() || !config.Type().HasAttribute(key) {
+		return cty.NullVal(ty), fmt.Errorf("internal-error: the write-only argument %q was not found in the configuration", key)
+	}
+
+	v := config.GetAttr(key)
+	if v.IsNull() {
+		return cty.NullVal(ty), nil
+	}
+
+	if !v.IsKnown() {
+		return cty.NullVal(ty), fmt.Errorf("the value of the write-only argument %q is not known", key)
+	}
+
+	if !v.Type().Equals(ty) {
+		return cty.NullVal(ty), fmt.Errorf("internal-error: expected the write-only argument %q to be of type %s but got %s", key, ty.FriendlyName(), v.Type().FriendlyName())
+	}
+
+	return v, nil
+}
@@ -0,0 +1,105 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+)
+
+type ImportType int
+
+func (i ImportType) String() string {
+	return []string{"has no Import section", "has an invalid Resource ID in the Import example"}[i]
+}
+
+const (
+	ImportMissed    ImportType = iota // no import part in document
+	ImportInvalidID                   // the resource id in the import example can't be parsed
+)
+
+type importDiff struct {
+	checkBase
+	Type         ImportType
+	ResourceType string
+	Got          string // the resource id in the import example
+	Want         string // an example resource id from the ID parser, may be empty if it can't be determined
+}
+
+func newImportDiff(checkBase checkBase, typ ImportType, rt, got, want string) *importDiff {
+	return &importDiff{checkBase: checkBase, Type: typ, ResourceType: rt, Got: got, Want: want}
+}
+
+func (i importDiff) String() string {
+	msg := fmt.Sprintf("%d Document %s", i.checkBase.Line(), i.Type)
+	if i.Want != "" {
+		msg += fmt.Sprintf(", the Resource ID should be like %s", util.ItalicCode(i.Want))
+	}
+	return msg
+}
+
+func (i importDiff) Fix(line string) (result string, err error) {
+	if i.Type == ImportInvalidID && i.Want != "" {
+		return strings.Replace(line, i.Got, i.Want, 1), nil
+	}
+	return line, nil
+}
+
+// ShouldSkip an import diff is reported and fixed, but doesn't fail the check since the Resource ID can't
+// be validated for every resource
+func (i importDiff) ShouldSkip() bool {
+	return true
+}
+
+var _ Checker = (*importDiff)(nil)
+
+func genImportSection(rt, id string) []string {
+	return []string{
+		"## Import",
+		"",
+		fmt.Sprintf("%ss can be imported using the `resource id`, e.g.", util.NormalizeResourceName(rt)),
+		"",
+		"```shell",
+		fmt.Sprintf("terraform import %s.example %s", rt, id),
+		"```",
+	}
+}
+
+// tryFixImport generates the Import section when it's missing from the document
+func tryFixImport(lines []string, diff *importDiff) []string {
+	if diff.Type != ImportMissed || diff.Want == "" {
+		return lines
+	}
+	return appendSection(lines, genImportSection(diff.ResourceType, diff.Want))
+}
+
+func diffImport(r *schema.Resource, lines []string) (res []Checker) {
+	if r.Schema == nil || r.Schema.Importer == nil {
+		return nil
+	}
+
+	start, end := sectionRange(lines, model.PosImport)
+	if start < 0 {
+		return append(res, newImportDiff(newCheckBase(0, "", nil), ImportMissed, r.ResourceType, "", r.ExampleResourceID()))
+	}
+
+	for idx := start + 1; idx < end; idx++ {
+		// terraform import azurerm_resource_group.example /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1
+		fields := strings.Fields(lines[idx])
+		if len(fields) != 4 || fields[0] != "terraform" || fields[1] != "import" {
+			continue
+		}
+		id := strings.Trim(fields[3], `"'`)
+		if err := r.ValidateResourceID(id); err == nil || errors.Is(err, schema.ErrIDValidationUnavailable) {
+			continue
+		}
+		res = append(res, newImportDiff(newCheckBase(idx, "", nil), ImportInvalidID, r.ResourceType, id, r.ExampleResourceID()))
+	}
+	return res
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 
+	schema2 "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
 )
@@ -30,7 +31,8 @@ const (
 type propertyMissDiff struct {
 	checkBase
 	MissType    MissType
-	correctName string // for misspelling diff only
+	correctName string          // for misspelling diff only
+	schema      *schema2.Schema // for miss in doc diff only, used to generate the document
 }
 
 func newPropertyMiss(checkBase checkBase, missType MissType) *propertyMissDiff {
@@ -75,8 +77,17 @@ func newMissInCode(path string, f *model.Field) Checker {
 }
 
 // miss in doc will fill a mock `f`
-func newMissInDoc(path string, f *model.Field) Checker {
-	return newMissItem(path, f, MissInDoc)
+func newMissInDoc(path string, f *model.Field, s *schema2.Schema) Checker {
+	item := newMissItem(path, f, MissInDoc).(*propertyMissDiff)
+	item.schema = s
+	return item
+}
+
+// miss in doc attribute will fill a mock `f`, for Computed only properties
+func newMissInDocAttr(path string, f *model.Field, s *schema2.Schema) Checker {
+	item := newMissItem(path, f, MissInDocAttr).(*propertyMissDiff)
+	item.schema = s
+	return item
 }
 
 func newMissBlockDeclare(path string, f *model.Field) Checker {
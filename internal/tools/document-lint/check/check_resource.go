@@ -95,4 +95,10 @@ func (r *ResourceDiff) DiffAll() {
 
 	timeouts := diffTimeout(r.tf, r.md)
 	r.Diff = append(r.Diff, timeouts...)
+
+	if content, err := os.ReadFile(r.MDFile); err == nil {
+		lines := strings.Split(string(content), "\n")
+		r.Diff = append(r.Diff, diffImport(r.tf, lines)...)
+		r.Diff = append(r.Diff, diffSectionOrder(lines)...)
+	}
 }
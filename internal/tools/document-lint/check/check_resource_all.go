@@ -44,6 +44,20 @@ func (d *DiffResult) FixDocuments() (err error) {
 	return nil
 }
 
+// PreviewFixes returns the changes which FixDocuments would make to each document in the unified diff format,
+// without updating the documents
+func (d *DiffResult) PreviewFixes() (string, error) {
+	var bs strings.Builder
+	for _, r := range d.result {
+		fix := NewFixer(r)
+		if err := fix.TryFix(); err != nil {
+			return "", fmt.Errorf("when try fix document: %v", err)
+		}
+		bs.WriteString(fix.UnifiedDiff())
+	}
+	return bs.String(), nil
+}
+
 func (d *DiffResult) GetResult() []*ResourceDiff {
 	return d.result
 }
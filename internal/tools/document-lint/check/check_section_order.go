@@ -0,0 +1,184 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/md"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+)
+
+// the canonical order of the sections within a document, any other sections stay after the preceding section
+var sectionOrder = map[model.PosType]int{
+	model.PosExample: 1,
+	model.PosArgs:    2,
+	model.PosAttr:    3,
+	model.PosTimeout: 4,
+	model.PosImport:  5,
+}
+
+type docSection struct {
+	header string
+	pos    model.PosType
+	order  int
+	lines  []string // including the header
+}
+
+func isSectionHeader(line string) bool {
+	return strings.HasPrefix(line, "## ")
+}
+
+// splitSections splits the document into the lines before the first section, and each (level 2) section
+func splitSections(lines []string) (preamble []string, sections []docSection) {
+	var inHCL bool
+	var order int
+	for idx, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inHCL = !inHCL
+		}
+		if !inHCL && isSectionHeader(line) {
+			pos := md.HeadPos(line)
+			if o, ok := sectionOrder[pos]; ok {
+				order = o
+			}
+			sections = append(sections, docSection{
+				header: line,
+				pos:    pos,
+				order:  order,
+			})
+		}
+
+		if len(sections) == 0 {
+			preamble = append(preamble, lines[idx])
+		} else {
+			last := &sections[len(sections)-1]
+			last.lines = append(last.lines, line)
+		}
+	}
+	return preamble, sections
+}
+
+// sectionRange returns the range of lines for the section, or -1 if the section doesn't exist
+func sectionRange(lines []string, pos model.PosType) (start, end int) {
+	start = -1
+	var inHCL bool
+	for idx, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inHCL = !inHCL
+		}
+		if inHCL || !isSectionHeader(line) {
+			continue
+		}
+		if start >= 0 {
+			return start, idx
+		}
+		if md.HeadPos(line) == pos {
+			start = idx
+		}
+	}
+	if start < 0 {
+		return -1, -1
+	}
+	return start, len(lines)
+}
+
+func trimTrailingEmptyLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// appendSection appends the section to the end of the document, it's moved into place by reorderSections
+func appendSection(lines []string, section []string) []string {
+	var trailingNewLine bool
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		trailingNewLine = true
+	}
+	lines = append(trimTrailingEmptyLines(lines), "")
+	lines = append(lines, trimTrailingEmptyLines(section)...)
+	if trailingNewLine {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+func sectionsSorted(sections []docSection) bool {
+	return sort.SliceIsSorted(sections, func(i, j int) bool {
+		return sections[i].order < sections[j].order
+	})
+}
+
+// reorderSections reorders the sections of the document into the canonical layout
+func reorderSections(lines []string) []string {
+	preamble, sections := splitSections(lines)
+	if sectionsSorted(sections) {
+		return lines
+	}
+
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].order < sections[j].order
+	})
+
+	res := append(trimTrailingEmptyLines(preamble), "")
+	for idx, section := range sections {
+		if idx > 0 {
+			res = append(res, "")
+		}
+		res = append(res, trimTrailingEmptyLines(section.lines)...)
+	}
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		res = append(res, "")
+	}
+	return res
+}
+
+type sectionOrderDiff struct {
+	checkBase
+	Got  []string
+	Want []string
+}
+
+func newSectionOrderDiff(checkBase checkBase, got, want []string) *sectionOrderDiff {
+	return &sectionOrderDiff{checkBase: checkBase, Got: got, Want: want}
+}
+
+func (s sectionOrderDiff) String() string {
+	return fmt.Sprintf("%d Document sections are in the order [%s] but should be [%s]", s.checkBase.Line(), strings.Join(s.Got, ", "), strings.Join(s.Want, ", "))
+}
+
+func (s sectionOrderDiff) Fix(line string) (result string, err error) {
+	// cannot fix the order of sections by line
+	return line, nil
+}
+
+// ShouldSkip a section order diff is reported and fixed, but doesn't fail the check
+func (s sectionOrderDiff) ShouldSkip() bool {
+	return true
+}
+
+var _ Checker = (*sectionOrderDiff)(nil)
+
+func diffSectionOrder(lines []string) (res []Checker) {
+	_, sections := splitSections(lines)
+	if sectionsSorted(sections) {
+		return nil
+	}
+
+	var got []string
+	for _, section := range sections {
+		got = append(got, strings.TrimPrefix(section.header, "## "))
+	}
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].order < sections[j].order
+	})
+	var want []string
+	for _, section := range sections {
+		want = append(want, strings.TrimPrefix(section.header, "## "))
+	}
+	return append(res, newSectionOrderDiff(newCheckBase(0, "", nil), got, want))
+}
@@ -26,7 +26,7 @@ func crossCheckProperty(r *schema.Resource, md *model.ResourceDoc) (res []Checke
 		if field == nil && md.Attr != nil {
 			field = md.Attr[key]
 		}
-		res = append(res, diffDocMiss(r.ResourceType, key, val, field)...)
+		res = append(res, diffDocMiss(r.ResourceType, key, val, field, true)...)
 	}
 
 	for key, f := range docProps {
@@ -60,7 +60,8 @@ func shouldSkipDocProp(rt, path string) bool {
 	return _shouldSkip(diffDocSkip, rt, path)
 }
 
-func diffDocMiss(rt, path string, s *schema2.Schema, f *model.Field) (res []Checker) {
+// inAttr is whether any Computed only property missing in the document should be documented as an attribute
+func diffDocMiss(rt, path string, s *schema2.Schema, f *model.Field, inAttr bool) (res []Checker) {
 	// skip deprecated property
 	if shouldSkipDocProp(rt, path) {
 		return
@@ -71,15 +72,21 @@ func diffDocMiss(rt, path string, s *schema2.Schema, f *model.Field) (res []Chec
 	}
 
 	if f == nil {
-		if s.Deprecated == "" && !s.Computed && path != "id" {
-			parts := strings.Split(path, ".")
-			name := parts[len(parts)-1]
-			f2 := &model.Field{
-				Name:    name,
-				Path:    path,
-				Content: s.GoString(),
-			}
-			res = append(res, newMissInDoc(path, f2))
+		// `tags_all` is documented once for the provider, within the `default_tags` block
+		if s.Deprecated != "" || path == "id" || path == "tags_all" {
+			return res
+		}
+		parts := strings.Split(path, ".")
+		name := parts[len(parts)-1]
+		f2 := &model.Field{
+			Name:    name,
+			Path:    path,
+			Content: s.GoString(),
+		}
+		if !s.Computed {
+			res = append(res, newMissInDoc(path, f2, s))
+		} else if !s.Optional && !s.Required && inAttr {
+			res = append(res, newMissInDocAttr(path, f2, s))
 		}
 		return res
 	}
@@ -97,7 +104,7 @@ func diffDocMiss(rt, path string, s *schema2.Schema, f *model.Field) (res []Chec
 		}
 		for key, val := range ele.Schema {
 			subField := f.Subs[key]
-			res = append(res, diffDocMiss(rt, path+"."+key, val, subField)...)
+			res = append(res, diffDocMiss(rt, path+"."+key, val, subField, f.Pos == model.PosAttr)...)
 		}
 	default:
 		return res
@@ -6,8 +6,10 @@ package check
 import (
 	"log"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
 )
 
@@ -19,7 +21,10 @@ type Fixer struct {
 
 	Diff []Checker // diff of exist in both md and code
 
-	FixedContent string
+	OriginalContent string
+	FixedContent    string
+
+	resource *schema.Resource
 }
 
 func NewFixer(d *ResourceDiff) *Fixer {
@@ -28,6 +33,7 @@ func NewFixer(d *ResourceDiff) *Fixer {
 		SchemaFile:   d.SchemaFile,
 		ResourceType: d.tf.ResourceType,
 		Diff:         d.Diff,
+		resource:     d.tf,
 	}
 	return f
 }
@@ -106,7 +112,11 @@ func (f *Fixer) TryFix() (err error) {
 		return err
 	}
 
-	lines := strings.Split(string(content), "\n")
+	f.OriginalContent = string(content)
+	lines := strings.Split(f.OriginalContent, "\n")
+	// properties missing in the document and the import section are inserted once every line has been fixed
+	var missed []*propertyMissDiff
+	var imports []*importDiff
 	for idx, item := range f.Diff {
 		_ = idx
 		// fix timeout first!
@@ -115,6 +125,24 @@ func (f *Fixer) TryFix() (err error) {
 			continue
 		}
 
+		switch v := item.(type) {
+		case *propertyMissDiff:
+			if v.MissType == MissInDoc || v.MissType == MissInDocAttr {
+				missed = append(missed, v)
+				continue
+			}
+		case *importDiff:
+			if v.Type == ImportInvalidID {
+				lines[v.line], _ = v.Fix(lines[v.line])
+			} else {
+				imports = append(imports, v)
+			}
+			continue
+		case *sectionOrderDiff:
+			// sections are always reordered once fixed
+			continue
+		}
+
 		// mdField is nil for no document exists or page title mismatch
 		if item.ShouldSkip() {
 			continue
@@ -135,10 +163,40 @@ func (f *Fixer) TryFix() (err error) {
 
 		lines[lineIdx] = line
 	}
+
+	sort.Slice(missed, func(i, j int) bool {
+		return missed[i].Key() < missed[j].Key()
+	})
+	possibleValues := map[string][]string{}
+	if f.resource != nil {
+		possibleValues = f.resource.PossibleValues
+	}
+	for _, item := range missed {
+		lines = tryFixMissingProperty(f.ResourceType, lines, item, possibleValues)
+	}
+	for _, item := range imports {
+		lines = tryFixImport(lines, item)
+	}
+
+	// the sections generated above are appended to the document, so need to be moved into place
+	lines = reorderSections(lines)
+
 	f.FixedContent = strings.Join(lines, "\n")
 	return nil
 }
 
+// UnifiedDiff returns the changes made to the document by TryFix in the unified diff format
+func (f *Fixer) UnifiedDiff() string {
+	if f.FixedContent == "" {
+		return ""
+	}
+	file := f.MDFile
+	if idx := strings.Index(file, "website"); idx > 0 {
+		file = file[idx:]
+	}
+	return util.UnifiedDiff("a/"+file, "b/"+file, strings.Split(f.OriginalContent, "\n"), strings.Split(f.FixedContent, "\n"), 3)
+}
+
 func (f *Fixer) WriteBack() (err error) {
 	if len(f.Diff) == 0 {
 		return
@@ -0,0 +1,260 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	schema2 "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/md"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/util"
+)
+
+// logic to generate the documentation of properties missing in the document from the schema
+
+func article(name string) string {
+	if name != "" && strings.ContainsRune("aeiou", rune(name[0])) {
+		return "An"
+	}
+	return "A"
+}
+
+func propertyDescription(name string, s *schema2.Schema) string {
+	desc := strings.TrimSpace(s.Description)
+	if desc == "" {
+		desc = fmt.Sprintf("The %s", strings.ReplaceAll(name, "_", " "))
+	}
+	if !strings.HasSuffix(desc, ".") {
+		desc += "."
+	}
+	return desc
+}
+
+// genPropertyLine generates the documentation for a property from the schema, including the requiredness,
+// possible values, default value and whether changing it forces a new resource to be created for arguments
+func genPropertyLine(name string, s *schema2.Schema, possibleValues []string, pos model.PosType) string {
+	var bs strings.Builder
+	bs.WriteString(fmt.Sprintf("* `%s` - ", name))
+	if pos == model.PosArgs {
+		if s.Required {
+			bs.WriteString("(Required) ")
+		} else {
+			bs.WriteString("(Optional) ")
+		}
+	}
+
+	if _, ok := s.Elem.(*schema2.Resource); ok {
+		if s.MaxItems == 1 {
+			bs.WriteString(fmt.Sprintf("%s `%s` block as defined below.", article(name), name))
+		} else {
+			bs.WriteString(fmt.Sprintf("One or more `%s` blocks as defined below.", name))
+		}
+	} else {
+		bs.WriteString(propertyDescription(name, s))
+	}
+
+	if pos != model.PosArgs {
+		return bs.String()
+	}
+
+	if len(possibleValues) == 1 {
+		bs.WriteString(fmt.Sprintf(" The only possible value is %s.", patchWantEnums(possibleValues)))
+	} else if len(possibleValues) > 1 {
+		bs.WriteString(fmt.Sprintf(" Possible values are %s.", patchWantEnums(possibleValues)))
+	}
+
+	if s.Default != nil {
+		if str, ok := s.Default.(string); !ok || str != "" {
+			bs.WriteString(fmt.Sprintf(" Defaults to `%v`.", s.Default))
+		}
+	}
+
+	if s.ForceNew {
+		bs.WriteString(" Changing this forces a new resource to be created.")
+	}
+	return bs.String()
+}
+
+// documentedInPos returns whether the property should be documented within the Arguments or Attributes
+func documentedInPos(s *schema2.Schema, pos model.PosType) bool {
+	if s.Deprecated != "" {
+		return false
+	}
+	if pos == model.PosArgs {
+		return s.Required || s.Optional
+	}
+	return true
+}
+
+// sortedProperties returns the names of the properties to document, where Required properties come first
+func sortedProperties(props map[string]*schema2.Schema, pos model.PosType) []string {
+	var names []string
+	for name, s := range props {
+		if documentedInPos(s, pos) {
+			names = append(names, name)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if props[names[i]].Required != props[names[j]].Required {
+			return props[names[i]].Required
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// genBlockLines generates the documentation for a block, followed by the documentation of any nested blocks
+func genBlockLines(name string, block *schema2.Resource, path string, possibleValues map[string][]string, pos model.PosType) []string {
+	verb := "supports"
+	if pos == model.PosAttr {
+		verb = "exports"
+	}
+	lines := []string{"---", "", fmt.Sprintf("%s `%s` block %s the following:", article(name), name, verb), ""}
+
+	var nested []string
+	for _, propName := range sortedProperties(block.Schema, pos) {
+		s := block.Schema[propName]
+		lines = append(lines, genPropertyLine(propName, s, possibleValues[path+"."+propName], pos), "")
+		if _, ok := s.Elem.(*schema2.Resource); ok {
+			nested = append(nested, propName)
+		}
+	}
+
+	for _, propName := range nested {
+		lines = append(lines, genBlockLines(propName, block.Schema[propName].Elem.(*schema2.Resource), path+"."+propName, possibleValues, pos)...)
+	}
+	return lines
+}
+
+// genSectionLines generates the header and introduction for the Arguments or Attributes section
+func genSectionLines(rt string, pos model.PosType) []string {
+	if pos == model.PosArgs {
+		return []string{"## Arguments Reference", "", "The following arguments are supported:", ""}
+	}
+	return []string{
+		"## Attributes Reference",
+		"",
+		"In addition to the Arguments listed above - the following Attributes are exported:",
+		"",
+		fmt.Sprintf("* `id` - The ID of the %s.", util.NormalizeResourceName(rt)),
+		"",
+	}
+}
+
+func insertLines(lines []string, idx int, insert ...string) []string {
+	res := make([]string, 0, len(lines)+len(insert))
+	res = append(res, lines[:idx]...)
+	res = append(res, insert...)
+	return append(res, lines[idx:]...)
+}
+
+func isFieldLine(line string) bool {
+	return strings.HasPrefix(line, "* `")
+}
+
+// blockRange returns the range of lines for the block within the section, or -1 if the block isn't documented
+func blockRange(lines []string, sectionStart, sectionEnd int, name string) (start, end int) {
+	start = -1
+	for idx := sectionStart + 1; idx < sectionEnd; idx++ {
+		if !md.IsBlockHead(lines[idx]) {
+			continue
+		}
+		if start >= 0 {
+			return start, idx
+		}
+		for _, blockName := range md.ExtractBlockNames(lines[idx]) {
+			if blockName == name {
+				start = idx
+			}
+		}
+	}
+	if start < 0 {
+		return -1, -1
+	}
+	return start, sectionEnd
+}
+
+// topLevelRange returns the range of lines for the top-level properties within the section
+func topLevelRange(lines []string, sectionStart, sectionEnd int) (start, end int) {
+	for idx := sectionStart + 1; idx < sectionEnd; idx++ {
+		if md.IsBlockHead(lines[idx]) {
+			return sectionStart, idx
+		}
+	}
+	return sectionStart, sectionEnd
+}
+
+// insertProperty inserts the documentation for a property into the range of lines, placing Required
+// properties after the last Required property and other properties at the end of the range
+func insertProperty(lines []string, start, end int, line string, required bool) []string {
+	lastRequired := -1
+	for idx := start + 1; idx < end; idx++ {
+		if isFieldLine(lines[idx]) && strings.Contains(lines[idx], "(Required)") {
+			lastRequired = idx
+		}
+	}
+	if required && lastRequired >= 0 {
+		for idx := lastRequired + 1; idx < end; idx++ {
+			if isFieldLine(lines[idx]) || lines[idx] == "---" {
+				return insertLines(lines, idx, line, "")
+			}
+		}
+	}
+
+	idx := end - 1
+	for idx > start && (strings.TrimSpace(lines[idx]) == "" || lines[idx] == "---") {
+		idx--
+	}
+	return insertLines(lines, idx+1, "", line)
+}
+
+// tryFixMissingProperty inserts the documentation generated from the schema for a property missing in the
+// document, including the documentation for the block if it's a block
+func tryFixMissingProperty(rt string, lines []string, diff *propertyMissDiff, possibleValues map[string][]string) []string {
+	if diff.schema == nil {
+		return lines
+	}
+
+	pos := model.PosArgs
+	if diff.MissType == MissInDocAttr {
+		pos = model.PosAttr
+	}
+
+	sectionStart, sectionEnd := sectionRange(lines, pos)
+	if sectionStart < 0 {
+		lines = appendSection(lines, genSectionLines(rt, pos))
+		sectionStart, sectionEnd = sectionRange(lines, pos)
+	}
+
+	path := diff.Key()
+	parts := strings.Split(path, ".")
+	name := parts[len(parts)-1]
+	line := genPropertyLine(name, diff.schema, possibleValues[path], pos)
+
+	start, end := topLevelRange(lines, sectionStart, sectionEnd)
+	if len(parts) > 1 {
+		if start, end = blockRange(lines, sectionStart, sectionEnd, parts[len(parts)-2]); start < 0 {
+			// the block of a nested property should be documented already, since otherwise it's reported
+			// as a poorly formatted block instead
+			return lines
+		}
+	}
+	lines = insertProperty(lines, start, end, line, diff.schema.Required)
+
+	if block, ok := diff.schema.Elem.(*schema2.Resource); ok {
+		sectionStart, sectionEnd = sectionRange(lines, pos)
+		if blockStart, _ := blockRange(lines, sectionStart, sectionEnd, name); blockStart < 0 {
+			idx := sectionEnd
+			for idx > sectionStart && strings.TrimSpace(lines[idx-1]) == "" {
+				idx--
+			}
+			blockLines := genBlockLines(name, block, path, possibleValues, pos)
+			lines = insertLines(lines, idx, append([]string{""}, blockLines[:len(blockLines)-1]...)...)
+		}
+	}
+	return lines
+}
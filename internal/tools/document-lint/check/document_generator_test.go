@@ -0,0 +1,247 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"strings"
+	"testing"
+
+	schema2 "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/model"
+)
+
+func TestGenPropertyLine(t *testing.T) {
+	tests := []struct {
+		name           string
+		schema         *schema2.Schema
+		possibleValues []string
+		pos            model.PosType
+		want           string
+	}{
+		{
+			name: "sku_name",
+			schema: &schema2.Schema{
+				Type:     schema2.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			possibleValues: []string{"Basic", "Standard", "Premium"},
+			pos:            model.PosArgs,
+			want:           "* `sku_name` - (Required) The sku name. Possible values are `Basic`, `Standard` and `Premium`. Changing this forces a new resource to be created.",
+		},
+		{
+			name: "retention_in_days",
+			schema: &schema2.Schema{
+				Type:        schema2.TypeInt,
+				Optional:    true,
+				Default:     7,
+				Description: "The number of days to retain logs for",
+			},
+			pos:  model.PosArgs,
+			want: "* `retention_in_days` - (Optional) The number of days to retain logs for. Defaults to `7`.",
+		},
+		{
+			name: "identity",
+			schema: &schema2.Schema{
+				Type:     schema2.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem:     &schema2.Resource{},
+			},
+			pos:  model.PosArgs,
+			want: "* `identity` - (Optional) An `identity` block as defined below.",
+		},
+		{
+			name: "endpoint",
+			schema: &schema2.Schema{
+				Type:     schema2.TypeList,
+				Computed: true,
+				Elem:     &schema2.Resource{},
+			},
+			pos:  model.PosAttr,
+			want: "* `endpoint` - One or more `endpoint` blocks as defined below.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := genPropertyLine(tt.name, tt.schema, tt.possibleValues, tt.pos); got != tt.want {
+				t.Errorf("genPropertyLine() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+const testDocument = `---
+subcategory: "Example"
+---
+
+# azurerm_example
+
+Manages an Example.
+
+## Example Usage
+
+` + "```hcl" + `
+## not a section
+` + "```" + `
+
+## Import
+
+Examples can be imported using the ` + "`resource id`" + `, e.g.
+
+## Arguments Reference
+
+The following arguments are supported:
+
+* ` + "`name`" + ` - (Required) The name of the Example.
+
+* ` + "`tags`" + ` - (Optional) A mapping of tags to assign to the resource.
+
+* ` + "`network`" + ` - (Optional) A ` + "`network`" + ` block as defined below.
+
+---
+
+A ` + "`network`" + ` block supports the following:
+
+* ` + "`subnet_id`" + ` - (Required) The ID of the Subnet.
+
+## Timeouts
+
+The ` + "`timeouts`" + ` block allows you to specify timeouts for certain actions:
+`
+
+func TestReorderSections(t *testing.T) {
+	lines := strings.Split(testDocument, "\n")
+	if diffs := diffSectionOrder(lines); len(diffs) != 1 {
+		t.Fatalf("expected 1 section order diff, got %d", len(diffs))
+	}
+
+	fixed := reorderSections(lines)
+	_, sections := splitSections(fixed)
+	var got []string
+	for _, section := range sections {
+		got = append(got, section.header)
+	}
+	want := []string{"## Example Usage", "## Arguments Reference", "## Timeouts", "## Import"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected sections %v, got %v", want, got)
+	}
+	if fixed[len(fixed)-1] != "" {
+		t.Fatalf("expected the trailing new line to be kept")
+	}
+	if diffs := diffSectionOrder(fixed); len(diffs) != 0 {
+		t.Fatalf("expected no section order diff, got %d", len(diffs))
+	}
+}
+
+func TestTryFixMissingProperty(t *testing.T) {
+	lines := strings.Split(testDocument, "\n")
+
+	missed := []*propertyMissDiff{
+		{
+			checkBase: newCheckBase(0, "location", nil),
+			MissType:  MissInDoc,
+			schema:    &schema2.Schema{Type: schema2.TypeString, Required: true, ForceNew: true},
+		},
+		{
+			checkBase: newCheckBase(0, "network.private_enabled", nil),
+			MissType:  MissInDoc,
+			schema:    &schema2.Schema{Type: schema2.TypeBool, Optional: true, Default: false},
+		},
+		{
+			checkBase: newCheckBase(0, "identity", nil),
+			MissType:  MissInDoc,
+			schema: &schema2.Schema{
+				Type:     schema2.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema2.Resource{
+					Schema: map[string]*schema2.Schema{
+						"type":         {Type: schema2.TypeString, Required: true},
+						"principal_id": {Type: schema2.TypeString, Computed: true},
+					},
+				},
+			},
+		},
+		{
+			checkBase: newCheckBase(0, "fqdn", nil),
+			MissType:  MissInDocAttr,
+			schema:    &schema2.Schema{Type: schema2.TypeString, Computed: true},
+		},
+	}
+	possibleValues := map[string][]string{
+		"identity.type": {"SystemAssigned", "UserAssigned"},
+	}
+	for _, item := range missed {
+		lines = tryFixMissingProperty("azurerm_example", lines, item, possibleValues)
+	}
+	lines = reorderSections(lines)
+
+	start, end := sectionRange(lines, model.PosArgs)
+	want := []string{
+		"## Arguments Reference",
+		"",
+		"The following arguments are supported:",
+		"",
+		"* `name` - (Required) The name of the Example.",
+		"",
+		"* `location` - (Required) The location. Changing this forces a new resource to be created.",
+		"",
+		"* `tags` - (Optional) A mapping of tags to assign to the resource.",
+		"",
+		"* `network` - (Optional) A `network` block as defined below.",
+		"",
+		"* `identity` - (Optional) An `identity` block as defined below.",
+		"",
+		"---",
+		"",
+		"A `network` block supports the following:",
+		"",
+		"* `subnet_id` - (Required) The ID of the Subnet.",
+		"",
+		"* `private_enabled` - (Optional) The private enabled. Defaults to `false`.",
+		"",
+		"---",
+		"",
+		"An `identity` block supports the following:",
+		"",
+		"* `type` - (Required) The type. Possible values are `SystemAssigned` and `UserAssigned`.",
+		"",
+	}
+	if got := lines[start:end]; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("expected Arguments Reference:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	start, end = sectionRange(lines, model.PosAttr)
+	want = []string{
+		"## Attributes Reference",
+		"",
+		"In addition to the Arguments listed above - the following Attributes are exported:",
+		"",
+		"* `id` - The ID of the Example.",
+		"",
+		"* `fqdn` - The fqdn.",
+		"",
+	}
+	if got := lines[start:end]; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("expected Attributes Reference:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func TestTryFixImport(t *testing.T) {
+	lines := strings.Split("# azurerm_example\n\n## Arguments Reference\n\n## Timeouts\n", "\n")
+	diff := newImportDiff(newCheckBase(0, "", nil), ImportMissed, "azurerm_example", "", "/subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Example/examples/exampleValue")
+
+	lines = reorderSections(tryFixImport(lines, diff))
+	want := "# azurerm_example\n\n## Arguments Reference\n\n## Timeouts\n\n## Import\n\nExamples can be imported using the `resource id`, e.g.\n\n```shell\nterraform import azurerm_example.example /subscriptions/12345678-1234-9876-4563-123456789012/resourceGroups/example-resource-group/providers/Microsoft.Example/examples/exampleValue\n```\n"
+	if got := strings.Join(lines, "\n"); got != want {
+		t.Fatalf("expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	invalid := newImportDiff(newCheckBase(0, "", nil), ImportInvalidID, "azurerm_example", "/subscriptions/00000000-0000-0000-0000-000000000000/examples/example1", "/subscriptions/12345678-1234-9876-4563-123456789012/examples/exampleValue")
+	if got, _ := invalid.Fix("terraform import azurerm_example.example /subscriptions/00000000-0000-0000-0000-000000000000/examples/example1"); got != "terraform import azurerm_example.example /subscriptions/12345678-1234-9876-4563-123456789012/examples/exampleValue" {
+		t.Fatalf("unexpected fixed line %q", got)
+	}
+}
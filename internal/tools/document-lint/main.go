@@ -17,6 +17,7 @@ func printHelp() {
 CMD:
   check:	check documents and print the error information
   fix:	 	check and try to fix existing errors
+  diff:	 	check and print the fixes as a unified diff for each document, without updating the documents
 
 OPTIONS:
 `
@@ -53,6 +54,8 @@ func parseArgs() {
 		case "fix":
 			dryRun = false
 			_ = fs.Parse(os.Args[2:])
+		case "diff":
+			_ = fs.Parse(os.Args[2:])
 		default:
 			fs.Usage()
 		}
@@ -63,17 +66,28 @@ func main() {
 	parseArgs()
 
 	result := check.DiffAll(check.AzurermAllResources(service, skipService, resource, skipResource), dryRun)
-	if !result.HasDiff() {
-		log.Printf("document linter runs success, time costs: %v", result.CostTime())
-		return
+	hasDiff := result.HasDiff()
+	if hasDiff {
+		log.Printf("%s\n", result.ToString())
 	}
 
-	log.Printf("%s\n", result.ToString())
-
-	if cmd == "fix" {
+	// missing properties, the import section and the order of sections are fixed even though these don't fail the check
+	switch cmd {
+	case "fix":
 		if err := result.FixDocuments(); err != nil {
 			log.Fatalf("error occurs when trying to fix documents: %v", err)
 		}
+	case "diff":
+		diff, err := result.PreviewFixes()
+		if err != nil {
+			log.Fatalf("error occurs when trying to fix documents: %v", err)
+		}
+		fmt.Print(diff)
+	}
+
+	if !hasDiff {
+		log.Printf("document linter runs success, time costs: %v", result.CostTime())
+		return
 	}
 	os.Exit(1)
 }
@@ -120,7 +120,7 @@ func FixFileNormalize(file string) {
 			continue
 		}
 		line = replaceNBSP(line)
-		if pos := headPos(line); pos > 0 {
+		if pos := HeadPos(line); pos > 0 {
 			curScope = pos
 		}
 		if line == "--" {
@@ -167,7 +167,7 @@ func newMarkFromString(content string, filepath string) *Mark {
 			result.addLineOrItem(idx, line, ItemExample)
 		case strings.HasPrefix(line, "->"), strings.HasPrefix(line, "~>"):
 			result.addItemWith(idx, line, ItemNote)
-		case isBlockHead(line):
+		case IsBlockHead(line):
 			result.addItemWith(idx, line, ItemBlockHead)
 		default:
 			// plain text
@@ -190,7 +190,8 @@ func newMarkFromString(content string, filepath string) *Mark {
 	return result
 }
 
-func isBlockHead(line string) bool {
+// IsBlockHead returns whether this line starts a block, e.g. "A `foo` block supports the following:"
+func IsBlockHead(line string) bool {
 	return blockHeadReg.MatchString(line)
 }
 
@@ -246,7 +247,7 @@ func (m *Mark) buildField() {
 			if inBlock {
 				m.addBlock(block)
 			}
-			names := extractBlockNames(item.lines[0])
+			names := ExtractBlockNames(item.lines[0])
 			// of/within block
 			var of string
 			for _, sep := range []string{" of ", " within "} {
@@ -155,7 +155,8 @@ func extractFieldFromLine(line string) (field *model.Field) {
 	return field
 }
 
-func extractBlockNames(line string) (res []string) {
+// ExtractBlockNames returns the names of the blocks defined by a block head line
+func ExtractBlockNames(line string) (res []string) {
 	if blockHeadReg.MatchString(line) {
 		idx := strings.Index(line, "block")
 		names := codeReg.FindAllString(line[:idx], -1)
@@ -198,7 +199,8 @@ func newFieldFromLine(line string) *model.Field {
 	return f
 }
 
-func headPos(line string) (pos model.PosType) {
+// HeadPos returns the position of the section started by this header line, or 0 when this isn't a header
+func HeadPos(line string) (pos model.PosType) {
 	if !strings.HasPrefix(line, "#") {
 		return 0
 	}
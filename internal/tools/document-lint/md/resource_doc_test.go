@@ -76,7 +76,7 @@ func TestExtractBlockNames(t *testing.T) {
 
 	for idx, test := range tests {
 		t.Run(fmt.Sprint(idx), func(t *testing.T) {
-			names := extractBlockNames(test.line)
+			names := ExtractBlockNames(test.line)
 			if !reflect.DeepEqual(names, test.names) {
 				t.Fatalf("test %d want: %v, got: A%v", idx, test.names, names)
 			}
@@ -0,0 +1,263 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/recaser"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+// ErrIDValidationUnavailable is returned when the Resource ID can't be validated without calling the API,
+// for example when the resource isn't importable or uses a custom importer
+var ErrIDValidationUnavailable = errors.New("the resource id can not be validated")
+
+// idParserFuncReg matches the names of the functions which parse or validate a Resource ID from the resourceids
+// package, capturing the name of the Resource ID
+var idParserFuncReg = regexp.MustCompile(`^(?:Parse|Validate)(\w+)ID(?:Insensitively)?$`)
+
+// knownResourceIdTypes is each of the Resource ID types registered by the APIs used by the Provider, keyed by the
+// package path and the name of the type (e.g. `github.com/[...]/commonids.SubnetId`)
+var knownResourceIdTypes = sync.OnceValue(func() map[string]resourceids.ResourceId {
+	out := make(map[string]resourceids.ResourceId)
+	for _, id := range recaser.KnownResourceIds() {
+		t := reflect.TypeOf(id)
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		out[t.PkgPath()+"."+t.Name()] = id
+	}
+	return out
+})
+
+// ValidateResourceID validates the Resource ID using the Resource ID type (or the ID parser) of this resource
+func (r *Resource) ValidateResourceID(id string) error {
+	if idType := r.resourceIDTypeFromSource(); idType != nil {
+		_, err := resourceids.NewParserFromResourceIdType(idType).Parse(id, false)
+		return err
+	}
+
+	validateFunc := r.idValidationFunc()
+	if validateFunc == nil {
+		return ErrIDValidationUnavailable
+	}
+	return validateFunc(id)
+}
+
+// ExampleResourceID returns an example Resource ID built from the segments of the Resource ID type of this resource,
+// which is only available when the resource uses a Resource ID from the resourceids package - else an empty string
+// is returned
+func (r *Resource) ExampleResourceID() string {
+	idType := r.ResourceIDType()
+	if idType == nil {
+		return ""
+	}
+	return exampleForResourceIdType(idType)
+}
+
+// ResourceIDType returns the Resource ID type used by this resource, or nil when it can't be determined
+func (r *Resource) ResourceIDType() resourceids.ResourceId {
+	if idType := r.resourceIDTypeFromSource(); idType != nil {
+		return idType
+	}
+
+	validateFunc := r.idValidationFunc()
+	if validateFunc == nil {
+		return nil
+	}
+
+	// the ID parser is either an anonymous function or isn't from the resourceids package, so look for the only
+	// Resource ID type whose example is valid
+	var match resourceids.ResourceId
+	for _, id := range knownResourceIdTypes() {
+		if validateFunc(exampleForResourceIdType(id)) != nil {
+			continue
+		}
+		if match != nil {
+			return nil
+		}
+		match = id
+	}
+	return match
+}
+
+// resourceIDTypeFromSource returns the Resource ID type validated by the IDValidationFunc of a Typed resource, or
+// parsed by the importer of an Untyped resource - or nil when it can't be determined
+func (r *Resource) resourceIDTypeFromSource() resourceids.ResourceId {
+	if r.SDKResource != nil {
+		return r.resourceIDTypeByName()
+	}
+	return r.importerResourceIDType()
+}
+
+// resourceIDTypeByName returns the Resource ID type validated by the Typed resource's IDValidationFunc, which
+// is named `Validate{Name}ID` within the same package as the `{Name}Id` type when it's from the resourceids package
+func (r *Resource) resourceIDTypeByName() resourceids.ResourceId {
+	validateFunc := r.SDKResource.IDValidationFunc()
+	if validateFunc == nil {
+		return nil
+	}
+
+	fn := runtime.FuncForPC(reflect.ValueOf(validateFunc).Pointer())
+	if fn == nil {
+		return nil
+	}
+	name := fn.Name()
+	idx := strings.LastIndex(name, ".")
+	if idx < 0 {
+		return nil
+	}
+	return resourceIdTypeForFunc(name[:idx], name[idx+1:])
+}
+
+// importerResourceIDType returns the Resource ID type parsed by the importer of the Untyped resource, which is found
+// from the source of the resource since the function used by the importer can't be retrieved from the importer - that
+// is the ID parser called within the importer of the resource whose Read function is the same as this resource's
+func (r *Resource) importerResourceIDType() resourceids.ResourceId {
+	if r.Schema == nil || r.Schema.Importer == nil || r.FilePath == "" {
+		return nil
+	}
+
+	readFunc := reflect.ValueOf(r.Schema.Read) //nolint:staticcheck
+	if readFunc.IsNil() {
+		readFunc = reflect.ValueOf(r.Schema.ReadContext)
+	}
+	if readFunc.IsNil() {
+		return nil
+	}
+	fn := runtime.FuncForPC(readFunc.Pointer())
+	if fn == nil {
+		return nil
+	}
+	_, readLine := fn.FileLine(readFunc.Pointer())
+	readName := fn.Name()[strings.LastIndex(fn.Name(), ".")+1:]
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, r.FilePath, nil, 0)
+	if err != nil {
+		return nil
+	}
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+
+	// find the importer of the resource (e.g. `&pluginsdk.Resource{Read: ..., Importer: ...}`) with this Read function
+	var importer ast.Expr
+	ast.Inspect(file, func(n ast.Node) bool {
+		lit, ok := n.(*ast.CompositeLit)
+		if !ok || importer != nil {
+			return importer == nil
+		}
+		fields := make(map[string]ast.Expr)
+		for _, elt := range lit.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok {
+					fields[key.Name] = kv.Value
+				}
+			}
+		}
+		read, ok := fields["Read"]
+		if !ok {
+			read = fields["ReadContext"]
+		}
+		if read == nil || fields["Importer"] == nil {
+			return true
+		}
+		switch v := read.(type) {
+		case *ast.Ident:
+			ok = v.Name == readName
+		case *ast.SelectorExpr:
+			ok = v.Sel.Name == readName
+		case *ast.FuncLit:
+			ok = fset.Position(v.Pos()).Line <= readLine && readLine <= fset.Position(v.End()).Line
+		default:
+			ok = false
+		}
+		if ok {
+			importer = fields["Importer"]
+		}
+		return true
+	})
+	if importer == nil {
+		return nil
+	}
+
+	var idType resourceids.ResourceId
+	ast.Inspect(importer, func(n ast.Node) bool {
+		if idType != nil {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if pkg, ok := sel.X.(*ast.Ident); ok {
+			if path, ok := imports[pkg.Name]; ok {
+				idType = resourceIdTypeForFunc(path, sel.Sel.Name)
+			}
+		}
+		return true
+	})
+	return idType
+}
+
+// resourceIdTypeForFunc returns the Resource ID type parsed by the function within the package, which is named
+// `Parse{Name}ID`, `Parse{Name}IDInsensitively` or `Validate{Name}ID` when the `{Name}Id` type is from the
+// resourceids package - else nil
+func resourceIdTypeForFunc(pkgPath, funcName string) resourceids.ResourceId {
+	match := idParserFuncReg.FindStringSubmatch(funcName)
+	if match == nil {
+		return nil
+	}
+	return knownResourceIdTypes()[pkgPath+"."+match[1]+"Id"]
+}
+
+// idValidationFunc returns the function used to validate the Resource ID of a Typed resource when it's imported, or
+// nil when the resource isn't a Typed resource
+func (r *Resource) idValidationFunc() func(id string) error {
+	if r.SDKResource == nil {
+		return nil
+	}
+	validateFunc := r.SDKResource.IDValidationFunc()
+	if validateFunc == nil {
+		return nil
+	}
+	return func(id string) error {
+		_, errs := validateFunc(id, "id")
+		return errors.Join(errs...)
+	}
+}
+
+// exampleForResourceIdType builds a Resource ID from the example value of each segment of the Resource ID type
+func exampleForResourceIdType(id resourceids.ResourceId) string {
+	components := make([]string, 0)
+	for _, segment := range id.Segments() {
+		components = append(components, strings.TrimPrefix(segment.ExampleValue, "/"))
+	}
+	return fmt.Sprintf("/%s", strings.Join(components, "/"))
+}
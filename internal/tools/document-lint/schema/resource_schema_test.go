@@ -4,9 +4,12 @@
 package schema_test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/automation"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/document-lint/schema"
 )
 
@@ -21,3 +24,41 @@ func TestResourceFile(t *testing.T) {
 		t.Fatalf("resource type not equal: want: %s, got: %s", p.ResourceType(), r.ResourceType)
 	}
 }
+
+func TestExampleResourceID(t *testing.T) {
+	r := schema.NewResourceByTyped(automation.SoftwareUpdateConfigurationResource{})
+
+	id := r.ExampleResourceID()
+	if !strings.HasPrefix(id, "/subscriptions/") {
+		t.Fatalf("expected an example Resource ID, got %q", id)
+	}
+	if err := r.ValidateResourceID(id); err != nil {
+		t.Fatalf("expected the example Resource ID %q to be valid: %+v", id, err)
+	}
+	if err := r.ValidateResourceID("/subscriptions/12345678-1234-9876-4563-123456789012"); err == nil {
+		t.Fatalf("expected the Subscription ID to be invalid")
+	}
+}
+
+func TestExampleResourceIDUntyped(t *testing.T) {
+	r := schema.NewResourceByUntyped(&pluginsdk.Resource{
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := commonids.ParseSubnetID(id)
+			return err
+		}),
+	}, "azurerm_example")
+
+	expected := commonids.NewSubnetID("12345678-1234-9876-4563-123456789012", "example-resource-group", "virtualNetworksValue", "subnetValue").ID()
+	if id := r.ExampleResourceID(); id != expected {
+		t.Fatalf("expected the example Resource ID to be %q, got %q", expected, id)
+	}
+	if err := r.ValidateResourceID(expected); err != nil {
+		t.Fatalf("expected the example Resource ID %q to be valid: %+v", expected, err)
+	}
+	if err := r.ValidateResourceID("/subscriptions/12345678-1234-9876-4563-123456789012"); err == nil {
+		t.Fatalf("expected the Subscription ID to be invalid")
+	}
+}
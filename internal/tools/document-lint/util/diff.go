@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+type diffOp struct {
+	kind byte // ' ' for an unchanged line, '-' for a removed line and '+' for an added line
+	line string
+}
+
+// diffLines returns the operations required to turn `from` into `to`, based on the longest common
+// subsequence of the lines after the common prefix and suffix have been trimmed
+func diffLines(from, to []string) []diffOp {
+	var prefix int
+	for prefix < len(from) && prefix < len(to) && from[prefix] == to[prefix] {
+		prefix++
+	}
+	var suffix int
+	for suffix < len(from)-prefix && suffix < len(to)-prefix && from[len(from)-1-suffix] == to[len(to)-1-suffix] {
+		suffix++
+	}
+
+	a, b := from[prefix:len(from)-suffix], to[prefix:len(to)-suffix]
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(from)+len(to))
+	for _, line := range from[:prefix] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', line: a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', line: a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', line: b[j]})
+			j++
+		}
+	}
+	for _, line := range from[len(from)-suffix:] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+	return ops
+}
+
+// UnifiedDiff returns the changes between `from` and `to` in the unified diff format, including `context`
+// unchanged lines around each change - an empty string is returned when there are no changes
+func UnifiedDiff(fromFile, toFile string, from, to []string, context int) string {
+	ops := diffLines(from, to)
+
+	// the (zero-based) position in `from` and `to` prior to each operation
+	fromPos := make([]int, len(ops)+1)
+	toPos := make([]int, len(ops)+1)
+	for idx, op := range ops {
+		fromPos[idx+1], toPos[idx+1] = fromPos[idx], toPos[idx]
+		if op.kind != '+' {
+			fromPos[idx+1]++
+		}
+		if op.kind != '-' {
+			toPos[idx+1]++
+		}
+	}
+
+	var bs strings.Builder
+	for idx := 0; idx < len(ops); idx++ {
+		if ops[idx].kind == ' ' {
+			continue
+		}
+
+		// changes separated by no more than twice the context are included in the same hunk
+		start, lastChange := max(0, idx-context), idx
+		for idx < len(ops) && idx <= lastChange+2*context {
+			if ops[idx].kind != ' ' {
+				lastChange = idx
+			}
+			idx++
+		}
+		end := min(len(ops), lastChange+context+1)
+
+		if bs.Len() == 0 {
+			bs.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", fromFile, toFile))
+		}
+		fromStart, fromCount := fromPos[start], fromPos[end]-fromPos[start]
+		if fromCount > 0 {
+			fromStart++
+		}
+		toStart, toCount := toPos[start], toPos[end]-toPos[start]
+		if toCount > 0 {
+			toStart++
+		}
+		bs.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", fromStart, fromCount, toStart, toCount))
+		for _, op := range ops[start:end] {
+			bs.WriteByte(op.kind)
+			bs.WriteString(op.line)
+			bs.WriteByte('\n')
+		}
+		idx = end - 1
+	}
+	return bs.String()
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want string
+	}{
+		{
+			name: "no changes",
+			from: "a\nb\nc",
+			to:   "a\nb\nc",
+			want: "",
+		},
+		{
+			name: "line added",
+			from: "a\nb\nc\nd\ne\nf",
+			to:   "a\nb\nc\nnew\nd\ne\nf",
+			want: `--- a/doc.md
++++ b/doc.md
+@@ -3,2 +3,3 @@
+ c
++new
+ d
+`,
+		},
+		{
+			name: "line changed",
+			from: "a\nb\nc",
+			to:   "a\nB\nc",
+			want: `--- a/doc.md
++++ b/doc.md
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+`,
+		},
+		{
+			name: "separate hunks",
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10",
+			to:   "0\n1\n2\n3\n4\n5\n6\n7\n8\n9",
+			want: `--- a/doc.md
++++ b/doc.md
+@@ -1,1 +1,2 @@
++0
+ 1
+@@ -9,2 +10,1 @@
+ 9
+-10
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := UnifiedDiff("a/doc.md", "b/doc.md", strings.Split(tt.from, "\n"), strings.Split(tt.to, "\n"), 1)
+			if got != tt.want {
+				t.Errorf("UnifiedDiff() got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
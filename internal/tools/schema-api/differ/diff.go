@@ -5,6 +5,8 @@ package differ
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
@@ -15,104 +17,216 @@ type Differ struct {
 	current *providerjson.ProviderWrapper
 }
 
-func (d *Differ) Diff(fileName string, providerName string) []string {
+func (d *Differ) Diff(fileName string, providerName string) ([]Violation, error) {
 	if err := d.loadFromProvider(providerjson.LoadData(), providerName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if err := d.loadFromFile(fileName); err != nil {
-		return []string{err.Error()}
+		return nil, err
 	}
 
 	if d.base.ProviderName != d.current.ProviderName {
-		return []string{fmt.Sprintf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)}
+		return nil, fmt.Errorf("provider name mismatch, expected %q, got %q", d.base.ProviderName, d.current.ProviderName)
 	}
 
-	violations := make([]string, 0)
+	return d.violations(), nil
+}
 
-	for resource, rs := range d.current.ProviderSchema.ResourcesMap {
-		_, ok := d.base.ProviderSchema.ResourcesMap[resource]
-		if !ok {
-			// New resource, no breaking changes to worry about
+func (d *Differ) violations() []Violation {
+	baseSchema := d.base.ProviderSchema
+	if baseSchema == nil {
+		baseSchema = &providerjson.ProviderSchemaJSON{}
+	}
+	currentSchema := d.current.ProviderSchema
+	if currentSchema == nil {
+		currentSchema = &providerjson.ProviderSchemaJSON{}
+	}
+
+	// exports prior to the version being included in the schema are version 1
+	schemaVersion, err := strconv.Atoi(d.base.SchemaVersion)
+	if err != nil {
+		schemaVersion = 1
+	}
+
+	violations := make([]Violation, 0)
+	violations = append(violations, compareResources(KindResource, baseSchema.ResourcesMap, currentSchema.ResourcesMap, rulesForSchemaVersion(schema_rules.BreakingChangeRules, schemaVersion))...)
+	violations = append(violations, compareResources(KindDataSource, baseSchema.DataSourcesMap, currentSchema.DataSourcesMap, rulesForSchemaVersion(schema_rules.BreakingChangeRulesDataSource, schemaVersion))...)
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].Kind != violations[j].Kind {
+			return violations[i].Kind > violations[j].Kind
+		}
+		if violations[i].Name != violations[j].Name {
+			return violations[i].Name < violations[j].Name
+		}
+		return violations[i].Property < violations[j].Property
+	})
+
+	return violations
+}
+
+// rulesForSchemaVersion returns the rules which can be checked against a base schema export of the specified version
+func rulesForSchemaVersion(rules []schema_rules.BreakingChangeRule, schemaVersion int) []schema_rules.BreakingChangeRule {
+	output := make([]schema_rules.BreakingChangeRule, 0, len(rules))
+	for _, rule := range rules {
+		if v, ok := rule.(schema_rules.BreakingChangeRuleWithMinimumSchemaVersion); ok && schemaVersion < v.MinimumSchemaVersion() {
 			continue
 		}
-		for propertyName, propertySchema := range rs.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.ResourcesMap[resource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeResource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+		output = append(output, rule)
+	}
+
+	return output
+}
+
+func compareResources(kind Kind, base map[string]providerjson.ResourceJSON, current map[string]providerjson.ResourceJSON, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
+
+	for name := range base {
+		if _, ok := current[name]; !ok {
+			violations = append(violations, Violation{
+				Rule:    kind.removedRule(),
+				Level:   LevelError,
+				Kind:    kind,
+				Name:    name,
+				Message: fmt.Sprintf("%s %q has been removed", kind, name),
+			})
 		}
 	}
 
-	for dataSource, ds := range d.current.ProviderSchema.DataSourcesMap {
-		_, ok := d.base.ProviderSchema.DataSourcesMap[dataSource]
+	for name, currentResource := range current {
+		baseResource, ok := base[name]
 		if !ok {
-			// New data source, no breaking changes to worry about
+			// New resource/data source, no breaking changes to worry about
 			continue
 		}
-		for propertyName, propertySchema := range ds.Schema {
-			// Get the same from the base (released) json
-			baseItem, ok := d.base.ProviderSchema.DataSourcesMap[dataSource].Schema[propertyName]
-			if !ok {
-				// New property, could be breaking - Required etc
-				baseItem = providerjson.SchemaJSON{}
-			}
-			if errs := compareNodeDataSource(baseItem, propertySchema, propertyName); errs != nil {
-				violations = append(violations, errs...)
-			}
+
+		for _, v := range compareSchemas(baseResource.Schema, currentResource.Schema, "", rules) {
+			v.Kind = kind
+			v.Name = name
+			violations = append(violations, v)
+		}
+
+		for _, v := range compareTimeouts(baseResource.Timeouts, currentResource.Timeouts) {
+			v.Kind = kind
+			v.Name = name
+			violations = append(violations, v)
 		}
 	}
 
 	return violations
 }
 
-func compareNodeResource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeResource(newBase, newCurrent[k], k)...)
+// compareSchemas compares the properties within the base and current schemas, recursing into any nested blocks
+func compareSchemas(base map[string]providerjson.SchemaJSON, current map[string]providerjson.SchemaJSON, path string, rules []schema_rules.BreakingChangeRule) []Violation {
+	violations := make([]Violation, 0)
+
+	for propertyName := range base {
+		if _, ok := current[propertyName]; !ok {
+			propertyPath := joinPath(path, propertyName)
+			violations = append(violations, Violation{
+				Rule:     RulePropertyRemoved,
+				Level:    LevelError,
+				Property: propertyPath,
+				Message:  fmt.Sprintf("property %q has been removed", propertyPath),
+			})
 		}
 	}
 
-	for _, v := range schema_rules.BreakingChangeRules {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+	for propertyName, currentItem := range current {
+		propertyPath := joinPath(path, propertyName)
+
+		// Get the same from the base (released) json
+		baseItem, ok := base[propertyName]
+		if !ok {
+			// New property, could be breaking - Required etc
+			baseItem = providerjson.SchemaJSON{}
+		}
+
+		for _, rule := range rules {
+			if err := rule.Check(baseItem, currentItem, propertyPath); err != nil {
+				violations = append(violations, Violation{
+					Rule:     rule.Name(),
+					Level:    LevelError,
+					Property: propertyPath,
+					Message:  *err,
+				})
+			}
+		}
+
+		// properties within a new block are covered by the rules for the block itself
+		if !ok {
+			continue
+		}
+
+		baseBlock, baseIsBlock := nodeBlock(baseItem)
+		currentBlock, currentIsBlock := nodeBlock(currentItem)
+		if baseIsBlock && currentIsBlock {
+			violations = append(violations, compareSchemas(baseBlock.Schema, currentBlock.Schema, propertyPath, rules)...)
 		}
 	}
 
-	return
+	return violations
 }
 
-func compareNodeDataSource(base providerjson.SchemaJSON, current providerjson.SchemaJSON, nodeName string) (errs []string) {
-	if nodeIsBlock(base) {
-		newBaseRaw := base.Elem.(providerjson.ResourceJSON).Schema
-		newCurrent := current.Elem.(*providerjson.ResourceJSON).Schema
-		for k, newBase := range newBaseRaw {
-			errs = append(errs, compareNodeDataSource(newBase, newCurrent[k], k)...)
-		}
+// compareTimeouts compares the default timeouts - where a reduction is flagged as an error since existing
+// operations may no longer complete in time, and an increase is flagged as a warning
+func compareTimeouts(base *providerjson.ResourceTimeoutJSON, current *providerjson.ResourceTimeoutJSON) []Violation {
+	violations := make([]Violation, 0)
+	if base == nil || current == nil {
+		return violations
 	}
 
-	for _, v := range schema_rules.BreakingChangeRulesDataSource {
-		if err := v.Check(base, current, nodeName); err != nil {
-			errs = append(errs, *err)
+	timeouts := []struct {
+		operation string
+		base      int
+		current   int
+	}{
+		{operation: "create", base: base.Create, current: current.Create},
+		{operation: "read", base: base.Read, current: current.Read},
+		{operation: "update", base: base.Update, current: current.Update},
+		{operation: "delete", base: base.Delete, current: current.Delete},
+	}
+	for _, t := range timeouts {
+		if t.base == t.current || t.base == 0 || t.current == 0 {
+			continue
+		}
+
+		level := LevelWarning
+		if t.current < t.base {
+			level = LevelError
 		}
+		violations = append(violations, Violation{
+			Rule:    RuleTimeoutDefaultChanged,
+			Level:   level,
+			Message: fmt.Sprintf("the default %s timeout has changed (%d to %d minutes)", t.operation, t.base, t.current),
+		})
 	}
 
-	return
+	return violations
 }
 
-func nodeIsBlock(input providerjson.SchemaJSON) bool {
-	if input.Type == providerjson.SchemaTypeList || input.Type == providerjson.SchemaTypeSet {
-		if _, ok := input.Elem.(providerjson.ResourceJSON); ok {
-			return true
-		}
+// nodeBlock returns the nested Resource when the property is a block - noting that this is a value when
+// loaded from a file but a pointer when loaded from the Provider
+func nodeBlock(input providerjson.SchemaJSON) (*providerjson.ResourceJSON, bool) {
+	if input.Type != providerjson.SchemaTypeList && input.Type != providerjson.SchemaTypeSet {
+		return nil, false
+	}
+
+	switch elem := input.Elem.(type) {
+	case providerjson.ResourceJSON:
+		return &elem, true
+	case *providerjson.ResourceJSON:
+		return elem, elem != nil
+	}
+
+	return nil, false
+}
+
+func joinPath(path string, propertyName string) string {
+	if path == "" {
+		return propertyName
 	}
 
-	return false
+	return fmt.Sprintf("%s.%s", path, propertyName)
 }
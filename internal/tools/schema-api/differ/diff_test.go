@@ -0,0 +1,246 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+	schema_rules "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/schema-rules"
+)
+
+func testBaseProvider() *providerjson.ProviderWrapper {
+	return &providerjson.ProviderWrapper{
+		ProviderName:  "azurerm",
+		SchemaVersion: providerjson.SchemaVersion,
+		ProviderSchema: &providerjson.ProviderSchemaJSON{
+			ResourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     "TypeString",
+							Required: true,
+							ForceNew: true,
+						},
+						"sku": {
+							Type:     "TypeString",
+							Optional: true,
+						},
+						"network": {
+							Type:     providerjson.SchemaTypeList,
+							Optional: true,
+							MaxItems: 2,
+							// blocks are values when loaded from a file
+							Elem: providerjson.ResourceJSON{
+								Schema: map[string]providerjson.SchemaJSON{
+									"subnet_id": {
+										Type:     "TypeString",
+										Optional: true,
+									},
+									"legacy": {
+										Type:     "TypeBool",
+										Optional: true,
+									},
+								},
+							},
+						},
+					},
+					Timeouts: &providerjson.ResourceTimeoutJSON{
+						Create: 30,
+						Read:   5,
+						Update: 30,
+						Delete: 30,
+					},
+				},
+				"azurerm_removed": {
+					Schema: map[string]providerjson.SchemaJSON{},
+				},
+			},
+			DataSourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     "TypeString",
+							Required: true,
+						},
+						"sku": {
+							Type:     "TypeString",
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func testCurrentProvider() *providerjson.ProviderWrapper {
+	return &providerjson.ProviderWrapper{
+		ProviderName: "azurerm",
+		ProviderSchema: &providerjson.ProviderSchemaJSON{
+			ResourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     "TypeString",
+							Required: true,
+							ForceNew: true,
+						},
+						"sku": {
+							Type:     "TypeString",
+							Optional: true,
+							ForceNew: true,
+						},
+						"network": {
+							Type:     providerjson.SchemaTypeList,
+							Optional: true,
+							MaxItems: 1,
+							// blocks are pointers when loaded from the Provider
+							Elem: &providerjson.ResourceJSON{
+								Schema: map[string]providerjson.SchemaJSON{
+									"subnet_id": {
+										Type:     "TypeString",
+										Required: true,
+									},
+								},
+							},
+						},
+						"new_block": {
+							Type:     providerjson.SchemaTypeList,
+							Optional: true,
+							Elem: &providerjson.ResourceJSON{
+								Schema: map[string]providerjson.SchemaJSON{
+									"required_within_new_block": {
+										Type:     "TypeString",
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+					Timeouts: &providerjson.ResourceTimeoutJSON{
+						Create: 60,
+						Read:   5,
+						Update: 10,
+						Delete: 30,
+					},
+				},
+				"azurerm_new": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     "TypeString",
+							Required: true,
+						},
+					},
+				},
+			},
+			DataSourcesMap: map[string]providerjson.ResourceJSON{
+				"azurerm_example": {
+					Schema: map[string]providerjson.SchemaJSON{
+						"name": {
+							Type:     "TypeString",
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestDiffer_violations(t *testing.T) {
+	d := Differ{
+		base:    testBaseProvider(),
+		current: testCurrentProvider(),
+	}
+
+	expected := map[string]Level{
+		"resource|azurerm_example|network|max_items_reduced":                  LevelError,
+		"resource|azurerm_example|network.legacy|property_removed":            LevelError,
+		"resource|azurerm_example|network.subnet_id|optional_to_required":     LevelError,
+		"resource|azurerm_example|sku|force_new_added":                        LevelError,
+		"resource|azurerm_example||timeout_default_changed|create":            LevelWarning,
+		"resource|azurerm_example||timeout_default_changed|update":            LevelError,
+		"resource|azurerm_removed||resource_removed":                          LevelError,
+		"data source|azurerm_example|sku|property_removed":                    LevelError,
+		"resource|azurerm_example|new_block.required_within_new_block|ignore": "",
+	}
+
+	violations := d.violations()
+	actual := make(map[string]Level)
+	for _, v := range violations {
+		key := string(v.Kind) + "|" + v.Name + "|" + v.Property + "|" + v.Rule
+		if v.Rule == RuleTimeoutDefaultChanged {
+			if v.Level == LevelWarning {
+				key += "|create"
+			} else {
+				key += "|update"
+			}
+		}
+		actual[key] = v.Level
+	}
+
+	for key, level := range expected {
+		if level == "" {
+			if _, ok := actual[key]; ok {
+				t.Fatalf("expected no violation for %q", key)
+			}
+			continue
+		}
+		if actual[key] != level {
+			t.Fatalf("expected a violation %q with the level %q but got %q - violations: %+v", key, level, actual[key], violations)
+		}
+	}
+	if len(violations) != len(expected)-1 {
+		t.Fatalf("expected %d violations but got %d: %+v", len(expected)-1, len(violations), violations)
+	}
+	if count := ErrorCount(violations); count != len(expected)-2 {
+		t.Fatalf("expected %d errors but got %d: %+v", len(expected)-2, count, violations)
+	}
+}
+
+func TestRulesForSchemaVersion(t *testing.T) {
+	for _, rule := range rulesForSchemaVersion(schema_rules.BreakingChangeRules, 1) {
+		if _, ok := rule.(schema_rules.BreakingChangeRuleWithMinimumSchemaVersion); ok {
+			t.Fatalf("expected the rule %q to be skipped for version 1 of the schema", rule.Name())
+		}
+	}
+
+	if actual := rulesForSchemaVersion(schema_rules.BreakingChangeRules, 2); len(actual) != len(schema_rules.BreakingChangeRules) {
+		t.Fatalf("expected all %d rules for version 2 of the schema but got %d", len(schema_rules.BreakingChangeRules), len(actual))
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	violations := []Violation{
+		{
+			Rule:     "force_new_added",
+			Level:    LevelError,
+			Kind:     KindResource,
+			Name:     "azurerm_example",
+			Property: "sku",
+			Message:  `cannot change the existing property "sku" to be ForceNew`,
+		},
+	}
+
+	for _, format := range []string{ReportFormatJSON, ReportFormatSARIF} {
+		t.Logf("[DEBUG] Testing %q", format)
+
+		buf := &bytes.Buffer{}
+		if err := WriteReport(buf, format, violations); err != nil {
+			t.Fatalf("writing the %s report: %+v", format, err)
+		}
+
+		var out map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+			t.Fatalf("expected the %s report to be valid JSON: %+v", format, err)
+		}
+	}
+
+	if err := WriteReport(&bytes.Buffer{}, "xml", violations); err == nil {
+		t.Fatalf("expected an error for an unsupported format")
+	}
+}
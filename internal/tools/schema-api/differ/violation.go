@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package differ
+
+import "fmt"
+
+type Kind string
+
+const (
+	KindDataSource Kind = "data source"
+	KindResource   Kind = "resource"
+)
+
+func (k Kind) removedRule() string {
+	if k == KindDataSource {
+		return RuleDataSourceRemoved
+	}
+
+	return RuleResourceRemoved
+}
+
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+)
+
+// these rules are checked by the Differ directly, rather than being a `schema_rules.BreakingChangeRule`
+const (
+	RuleDataSourceRemoved     = "data_source_removed"
+	RulePropertyRemoved       = "property_removed"
+	RuleResourceRemoved       = "resource_removed"
+	RuleTimeoutDefaultChanged = "timeout_default_changed"
+)
+
+// Violation is a (potentially) breaking change between the base and current schemas
+type Violation struct {
+	Rule     string `json:"rule"`
+	Level    Level  `json:"level"`
+	Kind     Kind   `json:"kind"`
+	Name     string `json:"name"`
+	Property string `json:"property,omitempty"`
+	Message  string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("[%s] %s %q: %s", v.Level, v.Kind, v.Name, v.Message)
+}
+
+// ErrorCount returns the number of Violations which are errors, rather than warnings
+func ErrorCount(violations []Violation) int {
+	count := 0
+	for _, v := range violations {
+		if v.Level == LevelError {
+			count++
+		}
+	}
+
+	return count
+}
@@ -26,6 +26,8 @@ func main() {
 	exportSchema := f.String("export", "", "export the schema to the given path/filename. Intended for use in the release process")
 	detectBreakingChanges := f.String("detect", "", "compare current schema to named dump.")
 	errorOnBreakingChange := f.Bool("error-on-violation", false, "should the detect mode exit with a non-zero error code. Defaults to `false`")
+	reportFormat := f.String("format", differ.ReportFormatText, "the format of the report output by the detect mode, one of `text`, `json` or `sarif`. Defaults to `text`")
+	reportOutput := f.String("output", "", "the path/filename the report output by the detect mode should be written to. Defaults to stdout")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		fmt.Printf("error parsing args: %+v", err)
@@ -41,7 +43,7 @@ func main() {
 			log.Printf("dumping schema for '%s'", *providerName)
 			wrappedProvider := &providerjson.ProviderWrapper{
 				ProviderName:  *providerName,
-				SchemaVersion: "1",
+				SchemaVersion: providerjson.SchemaVersion,
 			}
 			if err := providerjson.DumpWithWrapper(wrappedProvider, data); err != nil {
 				log.Fatalf("error dumping provider: %+v", err)
@@ -53,13 +55,29 @@ func main() {
 	case pointer.From(detectBreakingChanges) != "":
 		{
 			d := differ.Differ{}
-			if violations := d.Diff(*detectBreakingChanges, *providerName); violations != nil {
-				for _, v := range violations {
-					log.Println(v)
+			violations, err := d.Diff(*detectBreakingChanges, *providerName)
+			if err != nil {
+				log.Fatalf("error detecting breaking changes: %+v", err)
+			}
+
+			if path := pointer.From(reportOutput); path != "" {
+				file, err := os.Create(path)
+				if err != nil {
+					log.Fatalf("error creating %q: %+v", path, err)
+				}
+				if err := differ.WriteReport(file, pointer.From(reportFormat), violations); err != nil {
+					log.Fatalf("error writing report to %q: %+v", path, err)
 				}
-				if pointer.From(errorOnBreakingChange) {
-					os.Exit(1)
+				if err := file.Close(); err != nil {
+					log.Fatalf("error writing report to %q: %+v", path, err)
 				}
+			} else if err := differ.WriteReport(os.Stdout, pointer.From(reportFormat), violations); err != nil {
+				log.Fatalf("error writing report: %+v", err)
+			}
+
+			// warnings are included in the report but shouldn't fail the check
+			if differ.ErrorCount(violations) > 0 && pointer.From(errorOnBreakingChange) {
+				os.Exit(1)
 			}
 
 			os.Exit(0)
@@ -70,7 +88,7 @@ func main() {
 			log.Printf("dumping schema for '%s'", *providerName)
 			wrappedProvider := &providerjson.ProviderWrapper{
 				ProviderName:  *providerName,
-				SchemaVersion: "1",
+				SchemaVersion: providerjson.SchemaVersion,
 			}
 			if err := providerjson.WriteWithWrapper(wrappedProvider, data, *exportSchema); err != nil {
 				log.Fatalf("error writing provider schema for %q to %q: %+v", *providerName, *exportSchema, err)
@@ -19,6 +19,10 @@ const (
 	SchemaTypeFloat  = "Float"
 )
 
+// SchemaVersion is the version of the format used when exporting the Provider Schema. Version 2 adds
+// the validation fields (`validated`, `conflictsWith`, `exactlyOneOf`, `atLeastOneOf` and `requiredWith`).
+const SchemaVersion = "2"
+
 type ProviderJSON schema.Provider
 
 type SchemaJSON struct {
@@ -33,6 +37,13 @@ type SchemaJSON struct {
 	Elem        interface{} `json:"elem,omitempty"`
 	MaxItems    int         `json:"maxItems,omitempty"`
 	MinItems    int         `json:"minItems,omitempty"`
+
+	// Validated specifies whether a ValidateFunc (or ValidateDiagFunc) is defined for this property
+	Validated     bool     `json:"validated,omitempty"`
+	ConflictsWith []string `json:"conflictsWith,omitempty"`
+	ExactlyOneOf  []string `json:"exactlyOneOf,omitempty"`
+	AtLeastOneOf  []string `json:"atLeastOneOf,omitempty"`
+	RequiredWith  []string `json:"requiredWith,omitempty"`
 }
 
 func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
@@ -52,8 +63,13 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 		b.MaxItems = int(max)
 	}
 	if min, ok := m["minItems"].(float64); ok {
-		b.MaxItems = int(min)
+		b.MinItems = int(min)
 	}
+	b.Validated, _ = m["validated"].(bool)
+	b.ConflictsWith = stringSliceFromRaw(m["conflictsWith"])
+	b.ExactlyOneOf = stringSliceFromRaw(m["exactlyOneOf"])
+	b.AtLeastOneOf = stringSliceFromRaw(m["atLeastOneOf"])
+	b.RequiredWith = stringSliceFromRaw(m["requiredWith"])
 
 	if def, ok := m["default"]; ok && def != nil {
 		switch def.(type) {
@@ -71,13 +87,7 @@ func (b *SchemaJSON) UnmarshalJSON(body []byte) error {
 	}
 
 	if e, ok := m["elem"]; ok && e != nil {
-		elem := e.(map[string]interface{})
-		if schema, ok := elem["schema"]; ok {
-			b.Elem = ResourceFromMap(schema.(map[string]interface{}))
-		}
-		if t, ok := elem["type"]; ok {
-			b.Elem = t.(string)
-		}
+		b.Elem = elemFromMap(e)
 	}
 
 	return nil
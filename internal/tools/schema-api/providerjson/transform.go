@@ -57,6 +57,12 @@ func schemaFromRaw(input *schema.Schema) SchemaJSON {
 		Elem:        decodeElem(input.Elem),
 		MaxItems:    input.MaxItems,
 		MinItems:    input.MinItems,
+
+		Validated:     input.ValidateFunc != nil || input.ValidateDiagFunc != nil,
+		ConflictsWith: input.ConflictsWith,
+		ExactlyOneOf:  input.ExactlyOneOf,
+		AtLeastOneOf:  input.AtLeastOneOf,
+		RequiredWith:  input.RequiredWith,
 	}
 }
 
@@ -94,12 +100,8 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.ForceNew = t.(bool)
 	}
 
-	if t, ok := input["forceNew"]; ok {
-		result.ForceNew = t.(bool)
-	}
-
-	if t, ok := input["elem"]; ok {
-		result.Elem = decodeElem(t)
+	if t, ok := input["elem"]; ok && t != nil {
+		result.Elem = elemFromMap(t)
 	}
 
 	if t, ok := input["minItems"]; ok {
@@ -110,9 +112,52 @@ func SchemaFromMap(input map[string]interface{}) SchemaJSON {
 		result.MaxItems = int(t.(float64))
 	}
 
+	if t, ok := input["validated"]; ok {
+		result.Validated = t.(bool)
+	}
+
+	result.ConflictsWith = stringSliceFromRaw(input["conflictsWith"])
+	result.ExactlyOneOf = stringSliceFromRaw(input["exactlyOneOf"])
+	result.AtLeastOneOf = stringSliceFromRaw(input["atLeastOneOf"])
+	result.RequiredWith = stringSliceFromRaw(input["requiredWith"])
+
 	return result
 }
 
+// elemFromMap decodes the `elem` of a property which has been unmarshalled from JSON - which is either
+// a nested Resource (a block) or a Schema (where only the type is retained)
+func elemFromMap(input interface{}) interface{} {
+	elem, ok := input.(map[string]interface{})
+	if !ok {
+		return decodeElem(input)
+	}
+
+	if schema, ok := elem["schema"]; ok {
+		return ResourceFromMap(schema.(map[string]interface{}))
+	}
+	if t, ok := elem["type"]; ok {
+		return t.(string)
+	}
+
+	return nil
+}
+
+func stringSliceFromRaw(input interface{}) []string {
+	raw, ok := input.([]interface{})
+	if !ok || len(raw) == 0 {
+		return nil
+	}
+
+	output := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			output = append(output, s)
+		}
+	}
+
+	return output
+}
+
 func ResourceFromMap(input map[string]interface{}) ResourceJSON {
 	result := ResourceJSON{
 		Schema: make(map[string]SchemaJSON, 0),
@@ -15,6 +15,10 @@ type defaultValueChange struct {
 
 var _ BreakingChangeRule = defaultValueChange{}
 
+func (defaultValueChange) Name() string {
+	return "default_value_change"
+}
+
 // Check - Checks that an Optional or Required property is not updated to become Computed only
 func (o defaultValueChange) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
 	if base.Default != current.Default {
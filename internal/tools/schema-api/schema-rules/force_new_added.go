@@ -0,0 +1,28 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = forceNewAdded{}
+
+type forceNewAdded struct{}
+
+func (forceNewAdded) Name() string {
+	return "force_new_added"
+}
+
+// Check - Checks that an existing property isn't updated to be ForceNew, since changing this would now recreate the resource
+func (forceNewAdded) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	if base.Type != "" && !base.ForceNew && current.ForceNew {
+		return pointer.To(fmt.Sprintf("cannot change the existing property %q to be ForceNew", propertyName))
+	}
+
+	return nil
+}
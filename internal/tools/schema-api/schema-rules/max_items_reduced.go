@@ -0,0 +1,33 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRule = maxItemsReduced{}
+
+type maxItemsReduced struct{}
+
+func (maxItemsReduced) Name() string {
+	return "max_items_reduced"
+}
+
+// Check - Checks that the MaxItems of an existing property isn't introduced or reduced, since existing configurations may specify more items
+func (maxItemsReduced) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	// this only applies to properties which can be specified in the user config
+	if base.Type == "" || current.MaxItems == 0 || !(current.Optional || current.Required) {
+		return nil
+	}
+
+	if base.MaxItems == 0 || current.MaxItems < base.MaxItems {
+		return pointer.To(fmt.Sprintf("cannot reduce the MaxItems of property %q (%d to %d)", propertyName, base.MaxItems, current.MaxItems))
+	}
+
+	return nil
+}
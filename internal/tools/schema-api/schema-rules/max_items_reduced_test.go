@@ -0,0 +1,56 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var maxItemsReducedBaseNode = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MaxItems: 5,
+}
+
+var maxItemsReducedUnboundedBaseNode = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MaxItems: 0,
+}
+
+var maxItemsReducedPasses = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MaxItems: 10,
+}
+
+var maxItemsReducedComputedPasses = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Computed: true,
+	MaxItems: 1,
+}
+
+var maxItemsReducedViolates = providerjson.SchemaJSON{
+	Type:     providerjson.SchemaTypeList,
+	Optional: true,
+	MaxItems: 1, // violation
+}
+
+func TestMaxItemsReduced_Check(t *testing.T) {
+	data := maxItemsReduced{}
+	if res := data.Check(maxItemsReducedBaseNode, maxItemsReducedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(maxItemsReducedBaseNode, maxItemsReducedComputedPasses, ""); res != nil {
+		t.Errorf("expected no violation for a Computed-only property, got %+v", res)
+	}
+	if res := data.Check(maxItemsReducedBaseNode, maxItemsReducedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(maxItemsReducedUnboundedBaseNode, maxItemsReducedViolates, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
@@ -6,17 +6,36 @@ package schema_rules
 import "github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
 
 type BreakingChangeRule interface {
+	// Name returns the identifier for this rule, which is used in machine-readable reports
+	Name() string
+
 	Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string
 }
 
+// BreakingChangeRuleWithMinimumSchemaVersion is implemented by rules which depend on fields which are only
+// present in schema exports of (at least) the returned version - these rules are skipped for older exports
+type BreakingChangeRuleWithMinimumSchemaVersion interface {
+	BreakingChangeRule
+
+	MinimumSchemaVersion() int
+}
+
 var BreakingChangeRules = []BreakingChangeRule{
 	becomeComputedOnly{},
+	forceNewAdded{},
+	maxItemsReduced{},
 	newRequiredPropertyExistingResource{},
 	optionalRemoveComputed{},
 	optionalToRequired{},
 	propertyType{},
+	validationTightened{},
 }
 
 var BreakingChangeRulesDataSource = []BreakingChangeRule{
+	becomeComputedOnly{},
+	maxItemsReduced{},
+	newRequiredPropertyExistingResource{},
+	optionalToRequired{},
 	propertyType{},
+	validationTightened{},
 }
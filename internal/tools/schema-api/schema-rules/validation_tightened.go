@@ -0,0 +1,81 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var _ BreakingChangeRuleWithMinimumSchemaVersion = validationTightened{}
+
+type validationTightened struct{}
+
+func (validationTightened) Name() string {
+	return "validation_tightened"
+}
+
+// MinimumSchemaVersion - the validation fields are only present in version 2 of the schema export
+func (validationTightened) MinimumSchemaVersion() int {
+	return 2
+}
+
+// Check - Checks that the validation of an existing property isn't tightened, since existing configurations may no longer be valid.
+// Since validation functions can't be compared, this covers validation being introduced, an increase to MinItems and new
+// ConflictsWith, ExactlyOneOf, AtLeastOneOf or RequiredWith constraints.
+func (validationTightened) Check(base providerjson.SchemaJSON, current providerjson.SchemaJSON, propertyName string) *string {
+	// this only applies to existing properties which can be specified in the user config
+	if base.Type == "" || current.Type == "" || !(current.Optional || current.Required) {
+		return nil
+	}
+
+	reasons := make([]string, 0)
+	if !base.Validated && current.Validated {
+		reasons = append(reasons, "validation has been added")
+	}
+	if current.MinItems > base.MinItems {
+		reasons = append(reasons, fmt.Sprintf("MinItems has increased (%d to %d)", base.MinItems, current.MinItems))
+	}
+
+	constraints := []struct {
+		name    string
+		base    []string
+		current []string
+	}{
+		{name: "ConflictsWith", base: base.ConflictsWith, current: current.ConflictsWith},
+		{name: "ExactlyOneOf", base: base.ExactlyOneOf, current: current.ExactlyOneOf},
+		{name: "AtLeastOneOf", base: base.AtLeastOneOf, current: current.AtLeastOneOf},
+		{name: "RequiredWith", base: base.RequiredWith, current: current.RequiredWith},
+	}
+	for _, c := range constraints {
+		if added := addedValues(c.base, c.current); len(added) > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s has been added for %s", c.name, strings.Join(added, ", ")))
+		}
+	}
+
+	if len(reasons) > 0 {
+		return pointer.To(fmt.Sprintf("validation has been tightened for property %q: %s", propertyName, strings.Join(reasons, "; ")))
+	}
+
+	return nil
+}
+
+func addedValues(base []string, current []string) []string {
+	existing := make(map[string]struct{}, len(base))
+	for _, v := range base {
+		existing[v] = struct{}{}
+	}
+
+	added := make([]string, 0)
+	for _, v := range current {
+		if _, ok := existing[v]; !ok {
+			added = append(added, v)
+		}
+	}
+
+	return added
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package schema_rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tools/schema-api/providerjson"
+)
+
+var validationTightenedBaseNode = providerjson.SchemaJSON{
+	Type:          providerjson.SchemaTypeList,
+	Optional:      true,
+	MinItems:      1,
+	ConflictsWith: []string{"example"},
+}
+
+var validationTightenedPasses = providerjson.SchemaJSON{
+	Type:          providerjson.SchemaTypeList,
+	Optional:      true,
+	MinItems:      0,
+	ConflictsWith: []string{"example"},
+}
+
+var validationTightenedValidationAdded = providerjson.SchemaJSON{
+	Type:          providerjson.SchemaTypeList,
+	Optional:      true,
+	MinItems:      1,
+	ConflictsWith: []string{"example"},
+	Validated:     true, // violation
+}
+
+var validationTightenedMinItemsIncreased = providerjson.SchemaJSON{
+	Type:          providerjson.SchemaTypeList,
+	Optional:      true,
+	MinItems:      2, // violation
+	ConflictsWith: []string{"example"},
+}
+
+var validationTightenedConstraintAdded = providerjson.SchemaJSON{
+	Type:          providerjson.SchemaTypeList,
+	Optional:      true,
+	MinItems:      1,
+	ConflictsWith: []string{"example", "other"}, // violation
+}
+
+func TestValidationTightened_Check(t *testing.T) {
+	data := validationTightened{}
+	if res := data.Check(validationTightenedBaseNode, validationTightenedPasses, ""); res != nil {
+		t.Errorf("expected no violation, got %+v", res)
+	}
+	if res := data.Check(validationTightenedBaseNode, validationTightenedValidationAdded, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(validationTightenedBaseNode, validationTightenedMinItemsIncreased, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+	if res := data.Check(validationTightenedBaseNode, validationTightenedConstraintAdded, ""); res == nil {
+		t.Errorf("expected violation, but didn't get one")
+	}
+}
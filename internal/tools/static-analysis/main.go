@@ -5,6 +5,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"os"
 	"slices"
@@ -14,7 +15,12 @@ import (
 )
 
 var allRules = map[string]rules.Rule{
-	rules.TypedSDKBitCheck{}.Name(): rules.TypedSDKBitCheck{},
+	rules.TypedSDKBitCheck{}.Name():                 rules.TypedSDKBitCheck{},
+	rules.TypedSDKSchemaTagCheck{}.Name():           rules.TypedSDKSchemaTagCheck{},
+	rules.TypedSDKTimeoutCheck{}.Name():             rules.TypedSDKTimeoutCheck{},
+	rules.TypedSDKMarkAsGoneCheck{}.Name():          rules.TypedSDKMarkAsGoneCheck{},
+	rules.TypedSDKUpdatablePropertiesCheck{}.Name(): rules.TypedSDKUpdatablePropertiesCheck{},
+	rules.TypedSDKIDValidationCheck{}.Name():        rules.TypedSDKIDValidationCheck{},
 }
 
 func main() {
@@ -22,6 +28,7 @@ func main() {
 
 	rulesToCheck := f.String("rules", "all", "Comma separated list of rules to run. Defaults to all. ")
 	failOnError := f.Bool("fail-on-error", true, "If set to true will fail on error, otherwise will only log. Defaults to true.")
+	files := f.String("files", "", "Comma separated list of files (relative to the root of the repository) to report issues for. Defaults to all files.")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		log.Fatalf("failed to parse flags: %v", err)
@@ -50,12 +57,39 @@ func main() {
 		}
 	}
 
+	if *files != "" {
+		errors = onlyInFiles(errors, strings.Split(*files, ","))
+	}
+
 	if len(errors) > 0 {
+		// output one issue per line, issues found in the source are formatted as `file:line: rule: message`
+		messages := make([]string, 0, len(errors))
+		for _, err := range errors {
+			messages = append(messages, strings.TrimSpace(err.Error()))
+		}
+		slices.Sort(messages)
+		for _, message := range messages {
+			fmt.Println(message)
+		}
+
 		if *failOnError {
-			log.Fatalf("failed to run rules: %v", errors)
+			log.Fatalf("failed to run rules: found %d issues", len(errors))
 		} else {
-			log.Printf("failed to run rules: %v", errors)
+			log.Printf("failed to run rules: found %d issues", len(errors))
 			os.Exit(0)
 		}
 	}
 }
+
+// onlyInFiles returns the errors which are issues within the files, along with any errors which aren't positioned
+// within the source
+func onlyInFiles(errors []error, files []string) []error {
+	output := make([]error, 0)
+	for _, err := range errors {
+		if issue, ok := err.(rules.Issue); ok && !issue.InFiles(files) {
+			continue
+		}
+		output = append(output, err)
+	}
+	return output
+}
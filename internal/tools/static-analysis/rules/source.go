@@ -0,0 +1,372 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const modulePath = "github.com/hashicorp/terraform-provider-azurerm"
+
+// repositoryRoot returns the root of the repository, which is the closest directory to the working directory
+// containing a `go.mod` file - falling back to the working directory when one isn't found
+var repositoryRoot = sync.OnceValue(func() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+	for dir := wd; ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		if filepath.Dir(dir) == dir {
+			return wd
+		}
+	}
+})
+
+// Issue is a problem found by a Rule at a position within the source, output as `file:line: rule: message`
+type Issue struct {
+	Rule     string
+	Position token.Position
+	Message  string
+}
+
+func (i Issue) Error() string {
+	file := i.Position.Filename
+	if rel, err := filepath.Rel(repositoryRoot(), file); err == nil {
+		file = rel
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", file, i.Position.Line, i.Rule, i.Message)
+}
+
+// InFiles returns whether the Issue is within one of the files (relative to the root of the repository) - an Issue
+// which is positioned at a package rather than a file is within each of the files in the package
+func (i Issue) InFiles(files []string) bool {
+	path := i.Position.Filename
+	if rel, err := filepath.Rel(repositoryRoot(), path); err == nil {
+		path = rel
+	}
+	for _, file := range files {
+		file = filepath.Clean(strings.TrimSpace(file))
+		if file == path || filepath.Dir(file) == path {
+			return true
+		}
+	}
+	return false
+}
+
+// sourcePackage is the parsed (non-test) source of a package within the provider
+type sourcePackage struct {
+	fset    *token.FileSet
+	funcs   map[string][]*ast.FuncDecl   // functions and methods by name
+	methods map[string]*ast.FuncDecl     // methods by `Receiver.Method`
+	structs map[string]*ast.StructType   // struct types by name
+	types   map[string]*ast.TypeSpec     // type declarations (including aliases) by name
+	imports map[string]map[string]string // the path of each imported package by name, keyed by file
+}
+
+var sourcePackages = map[string]*sourcePackage{}
+
+// loadSourcePackage parses the source of the package containing the specified type, returning nil when
+// the source isn't available - for example when the type is defined outside the provider
+func loadSourcePackage(t reflect.Type) *sourcePackage {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if !strings.HasPrefix(t.PkgPath(), modulePath+"/") {
+		return nil
+	}
+	return loadSourcePackageByPath(t.PkgPath())
+}
+
+// loadSourcePackageByPath parses the source of the package with the specified import path, which is either within
+// the provider or vendored - returning nil when the source isn't available
+func loadSourcePackageByPath(pkgPath string) *sourcePackage {
+	if pkg, ok := sourcePackages[pkgPath]; ok {
+		return pkg
+	}
+	sourcePackages[pkgPath] = nil
+
+	dir := filepath.Join(repositoryRoot(), "vendor", filepath.FromSlash(pkgPath))
+	if strings.HasPrefix(pkgPath, modulePath+"/") {
+		dir = filepath.Join(repositoryRoot(), filepath.FromSlash(strings.TrimPrefix(pkgPath, modulePath+"/")))
+	}
+	pkg, err := parseSourcePackage(dir)
+	if err != nil {
+		return nil
+	}
+
+	sourcePackages[pkgPath] = pkg
+	return pkg
+}
+
+// parseSourcePackage parses the (non-test) source files within the directory
+func parseSourcePackage(dir string) (*sourcePackage, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &sourcePackage{
+		fset:    token.NewFileSet(),
+		funcs:   map[string][]*ast.FuncDecl{},
+		methods: map[string]*ast.FuncDecl{},
+		structs: map[string]*ast.StructType{},
+		types:   map[string]*ast.TypeSpec{},
+		imports: map[string]map[string]string{},
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(pkg.fset, filepath.Join(dir, entry.Name()), nil, 0)
+		if err != nil {
+			continue
+		}
+		imports := make(map[string]string)
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			name := path[strings.LastIndex(path, "/")+1:]
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+			imports[name] = path
+		}
+		pkg.imports[pkg.fset.File(file.Pos()).Name()] = imports
+		for _, decl := range file.Decls {
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				pkg.funcs[d.Name.Name] = append(pkg.funcs[d.Name.Name], d)
+				if receiver := receiverName(d); receiver != "" {
+					pkg.methods[receiver+"."+d.Name.Name] = d
+				}
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if ts, ok := spec.(*ast.TypeSpec); ok {
+						pkg.types[ts.Name.Name] = ts
+						if st, ok := ts.Type.(*ast.StructType); ok {
+							pkg.structs[ts.Name.Name] = st
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return pkg, nil
+}
+
+func receiverName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	expr := fn.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// findMethod returns the declaration of the method for the type, and the package it's declared in - including
+// methods promoted from embedded types. The declaration is nil if it's not found
+func findMethod(t reflect.Type, name string) (*sourcePackage, *ast.FuncDecl) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	pkg := loadSourcePackage(t)
+	if pkg != nil {
+		if decl, ok := pkg.methods[t.Name()+"."+name]; ok {
+			return pkg, decl
+		}
+	}
+	if t.Kind() == reflect.Struct {
+		for i := 0; i < t.NumField(); i++ {
+			if field := t.Field(i); field.Anonymous {
+				if fieldPkg, decl := findMethod(field.Type, name); decl != nil {
+					return fieldPkg, decl
+				}
+			}
+		}
+	}
+	return pkg, nil
+}
+
+// position returns the position of the node, falling back to the start of the package when it's unknown
+func (p *sourcePackage) position(node ast.Node, t reflect.Type) token.Position {
+	if p != nil && node != nil {
+		return p.fset.Position(node.Pos())
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return token.Position{Filename: filepath.Join(repositoryRoot(), filepath.FromSlash(strings.TrimPrefix(t.PkgPath(), modulePath+"/")))}
+}
+
+// structField returns the declaration of the field within the struct, or nil if it's not found - this is returned as
+// an ast.Node so that it can be passed to position
+func (p *sourcePackage) structField(structName, fieldName string) ast.Node {
+	if p == nil {
+		return nil
+	}
+	st, ok := p.structs[structName]
+	if !ok {
+		return nil
+	}
+	for _, field := range st.Fields.List {
+		for _, name := range field.Names {
+			if name.Name == fieldName {
+				return field
+			}
+		}
+	}
+	return nil
+}
+
+// callName returns the name of the function or method called, e.g. `MarkAsGone` for `metadata.MarkAsGone(id)`
+func callName(call *ast.CallExpr) string {
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		return fn.Sel.Name
+	}
+	return ""
+}
+
+// findCalls returns every call within the node to a function or method with the specified name
+func findCalls(node ast.Node, name string) (calls []*ast.CallExpr) {
+	if node == nil {
+		return nil
+	}
+	ast.Inspect(node, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && callName(call) == name {
+			calls = append(calls, call)
+		}
+		return true
+	})
+	return calls
+}
+
+// callsTransitively returns whether the node calls the specified function or method, either directly or via
+// other functions and methods within the same package - matching these by name
+func (p *sourcePackage) callsTransitively(node ast.Node, name string, visited map[*ast.FuncDecl]bool) bool {
+	if p == nil || node == nil {
+		return false
+	}
+	if fn, ok := node.(*ast.FuncDecl); ok {
+		if fn.Body == nil || visited[fn] {
+			return false
+		}
+		visited[fn] = true
+		node = fn.Body
+	}
+
+	var found bool
+	ast.Inspect(node, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		called := callName(call)
+		if called == name {
+			found = true
+			return false
+		}
+		for _, decl := range p.funcs[called] {
+			if p.callsTransitively(decl, name, visited) {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// callsWhenNotFound returns whether the function calls the specified function or method within an `if` statement
+// which checks for a 404 (e.g. `response.WasNotFound(resp.HttpResponse)`), either directly or via other functions
+// and methods within the same package
+func (p *sourcePackage) callsWhenNotFound(fn *ast.FuncDecl, name string, visited map[*ast.FuncDecl]bool) bool {
+	if p == nil || fn == nil || fn.Body == nil || visited[fn] {
+		return false
+	}
+	visited[fn] = true
+
+	var found bool
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch v := n.(type) {
+		case *ast.IfStmt:
+			if isNotFoundCheck(v.Cond) && p.callsTransitively(v.Body, name, map[*ast.FuncDecl]bool{}) {
+				found = true
+				return false
+			}
+		case *ast.CallExpr:
+			for _, decl := range p.funcs[callName(v)] {
+				if p.callsWhenNotFound(decl, name, visited) {
+					found = true
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// isNotFoundCheck returns whether the expression checks for a 404, either using a helper such as
+// `response.WasNotFound` or by comparing the status code
+func isNotFoundCheck(expr ast.Expr) (found bool) {
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.CallExpr:
+			if name := callName(v); name == "WasNotFound" || name == "ResponseWasNotFound" {
+				found = true
+			}
+		case *ast.SelectorExpr:
+			if v.Sel.Name == "StatusNotFound" {
+				found = true
+			}
+		case *ast.BasicLit:
+			if v.Kind == token.INT && v.Value == "404" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// stringArgs returns the values of the string literal arguments of the call
+func stringArgs(call *ast.CallExpr) (values []string) {
+	for _, arg := range call.Args {
+		if lit, ok := arg.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			if v, err := strconv.Unquote(lit.Value); err == nil {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
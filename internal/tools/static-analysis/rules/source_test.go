@@ -0,0 +1,168 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// The rules are tested against the packages within `testdata/src`, where the issues expected on a line are specified
+// using a `// want "<regexp>"` comment (in the same style as `golang.org/x/tools/go/analysis/analysistest`) - since
+// these packages are only parsed, they don't need to compile.
+
+var wantPattern = regexp.MustCompile("// want (\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`)")
+
+// testdataPackage parses the package `testdata/src/<name>`, returning it along with the issues expected within it -
+// keyed by the file and line
+func testdataPackage(t *testing.T, name string) (*sourcePackage, map[string]*regexp.Regexp) {
+	dir := filepath.Join("testdata", "src", name)
+	pkg, err := parseSourcePackage(dir)
+	if err != nil {
+		t.Fatalf("parsing %q: %+v", dir, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("reading %q: %+v", dir, err)
+	}
+	expected := make(map[string]*regexp.Regexp)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", entry.Name(), err)
+		}
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				match := wantPattern.FindStringSubmatch(comment.Text)
+				if match == nil {
+					continue
+				}
+				pattern, err := strconv.Unquote(match[1])
+				if err != nil {
+					t.Fatalf("unquoting %s: %+v", match[1], err)
+				}
+				position := fset.Position(comment.Pos())
+				expected[issueKey(position)] = regexp.MustCompile(pattern)
+			}
+		}
+	}
+
+	return pkg, expected
+}
+
+func issueKey(position token.Position) string {
+	return fmt.Sprintf("%s:%d", filepath.Base(position.Filename), position.Line)
+}
+
+// checkIssues asserts that the issues match those which are expected, with each issue on a line with a `// want`
+// comment matching its pattern
+func checkIssues(t *testing.T, expected map[string]*regexp.Regexp, errors []error) {
+	t.Helper()
+
+	for _, err := range errors {
+		issue, ok := err.(Issue)
+		if !ok {
+			t.Errorf("expected an Issue but got %+v", err)
+			continue
+		}
+		key := issueKey(issue.Position)
+		pattern, ok := expected[key]
+		if !ok {
+			t.Errorf("%s: unexpected issue %q", key, issue.Message)
+			continue
+		}
+		if !pattern.MatchString(issue.Message) {
+			t.Errorf("%s: expected an issue matching %q but got %q", key, pattern, issue.Message)
+		}
+		delete(expected, key)
+	}
+
+	for key, pattern := range expected {
+		t.Errorf("%s: expected an issue matching %q but didn't get one", key, pattern)
+	}
+}
+
+// testdataMethods returns the methods with the specified name within the package keyed by the resource type, which is
+// derived from the name of the receiver, e.g. `example_other_error` for `OtherErrorResource`
+func testdataMethods(pkg *sourcePackage, name string) map[string]*ast.FuncDecl {
+	output := make(map[string]*ast.FuncDecl)
+	for key, decl := range pkg.methods {
+		receiver, method, _ := strings.Cut(key, ".")
+		if method != name {
+			continue
+		}
+
+		resourceType := "example"
+		for _, r := range strings.TrimSuffix(receiver, "Resource") {
+			if unicode.IsUpper(r) {
+				resourceType += "_"
+			}
+			resourceType += string(unicode.ToLower(r))
+		}
+		output[resourceType] = decl
+	}
+	return output
+}
+
+func sortedKeys[T any](input map[string]T) []string {
+	keys := make([]string, 0, len(input))
+	for k := range input {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestIssueInFiles(t *testing.T) {
+	root := repositoryRoot()
+	testData := []struct {
+		Name     string
+		Filename string
+		Expected bool
+	}{
+		{
+			Name:     "changed file",
+			Filename: filepath.Join(root, "internal", "services", "example", "example_resource.go"),
+			Expected: true,
+		},
+		{
+			Name:     "other file",
+			Filename: filepath.Join(root, "internal", "services", "example", "other_resource.go"),
+			Expected: false,
+		},
+		{
+			Name:     "package containing the changed file",
+			Filename: filepath.Join(root, "internal", "services", "example"),
+			Expected: true,
+		},
+		{
+			Name:     "other package",
+			Filename: filepath.Join(root, "internal", "services", "other"),
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Name)
+
+		issue := Issue{
+			Position: token.Position{Filename: v.Filename},
+		}
+		if actual := issue.InFiles([]string{"internal/services/example/example_resource.go"}); actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
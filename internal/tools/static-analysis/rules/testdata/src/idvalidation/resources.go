@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package idvalidation
+
+import (
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+)
+
+type MatchingResource struct{}
+
+func (r MatchingResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return commonids.ValidateResourceGroupID
+}
+
+func (r MatchingResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id := commonids.NewResourceGroupID(subscriptionId, model.Name)
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+type FunctionAppResource struct{}
+
+func (r FunctionAppResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return commonids.ValidateFunctionAppID
+}
+
+func (r FunctionAppResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id := commonids.NewAppServiceID(subscriptionId, model.ResourceGroupName, model.Name)
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+type LegacyResource struct{}
+
+func (r LegacyResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.DnsAliasID
+}
+
+func (r LegacyResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id := parse.NewServerDNSAliasID(subscriptionId, model.ResourceGroupName, model.ServerName, model.Name)
+			metadata.SetID(&id)
+			return nil
+		},
+	}
+}
+
+type UnknownResource struct{}
+
+func (r UnknownResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return func(input interface{}, key string) ([]string, []error) {
+		return nil, nil
+	}
+}
+
+func (r UnknownResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			metadata.SetID(commonids.NewSubnetID(subscriptionId, model.ResourceGroupName, model.VirtualNetworkName, model.Name))
+			return nil
+		},
+	}
+}
+
+type MismatchedResource struct{}
+
+func (r MismatchedResource) IDValidationFunc() pluginsdk.SchemaValidateFunc { // want "example_mismatched: IDValidationFunc returns ValidateVirtualNetworkID but Create sets the ID from NewSubnetID"
+	return commonids.ValidateVirtualNetworkID
+}
+
+func (r MismatchedResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id := commonids.NewSubnetID(subscriptionId, model.ResourceGroupName, model.VirtualNetworkName, model.Name)
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package markasgone
+
+type NotFoundResource struct{}
+
+func (r NotFoundResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			return nil
+		},
+	}
+}
+
+type StatusCodeResource struct{}
+
+func (r StatusCodeResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if resp.HttpResponse != nil && resp.HttpResponse.StatusCode == http.StatusNotFound {
+					return metadata.MarkAsGone(id)
+				}
+				return err
+			}
+			return nil
+		},
+	}
+}
+
+type HelperResource struct{}
+
+func (r HelperResource) Read() sdk.ResourceFunc { // the 404 is handled by the helper function
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			return readHelper(ctx, metadata)
+		},
+	}
+}
+
+func readHelper(ctx context.Context, metadata sdk.ResourceMetaData) error {
+	existing, err := client.Get(ctx, *id)
+	if err != nil {
+		if utils.ResponseWasNotFound(existing.Response) {
+			return markAsGone(metadata)
+		}
+		return err
+	}
+	return nil
+}
+
+func markAsGone(metadata sdk.ResourceMetaData) error {
+	return metadata.MarkAsGone(id)
+}
+
+type MissingResource struct{}
+
+func (r MissingResource) Read() sdk.ResourceFunc { // want "example_missing: the Read function does not call MarkAsGone when the API returns a 404"
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			if _, err := client.Get(ctx, *id); err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			return nil
+		},
+	}
+}
+
+type OtherErrorResource struct{}
+
+func (r OtherErrorResource) Read() sdk.ResourceFunc { // want "example_other_error: the Read function does not call MarkAsGone when the API returns a 404"
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			if _, err := client.Get(ctx, *id); err != nil {
+				return metadata.MarkAsGone(id)
+			}
+			return nil
+		},
+	}
+}
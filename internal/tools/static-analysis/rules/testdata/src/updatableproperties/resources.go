@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package updatableproperties
+
+type UpdateResource struct{}
+
+func (r UpdateResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			if metadata.ResourceData.HasChange("tags") {
+				payload.Tags = tags.Expand(model.Tags)
+			}
+
+			if metadata.ResourceData.HasChange("identity.0.type") {
+				payload.Identity = expandIdentity(model.Identity)
+			}
+
+			if metadata.ResourceData.HasChange("location") { // want `example_update: the Update function checks for changes to "location" which is ForceNew`
+				payload.Location = model.Location
+			}
+
+			if metadata.ResourceData.HasChanges("sku_name", "fqdn") { // want `example_update: the Update function checks for changes to "fqdn" which is not an argument`
+				payload.Sku = model.SkuName
+			}
+
+			return nil
+		},
+	}
+}
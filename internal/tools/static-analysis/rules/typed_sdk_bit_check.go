@@ -22,17 +22,17 @@ func (r TypedSDKBitCheck) Run() (errors []error) {
 			case modelType != nil && modelType.Kind() == reflect.Ptr:
 				model := modelType.Elem()
 				if model.Kind() != reflect.Struct {
-					errors = append(errors, fmt.Errorf("%s is not a pointer to a struct\n", modelType.Name()))
+					errors = append(errors, r.modelObjectIssue(resource, fmt.Sprintf("%s is not a pointer to a struct", modelType.Name())))
 					continue
 				}
 
-				errors = append(errors, checkForBits(model)...)
+				errors = append(errors, r.checkForBits(model)...)
 
 			case modelType == nil:
 				continue
 
 			default:
-				errors = append(errors, fmt.Errorf("%q cannot be bit checked, ModelObject did not return a pointer\n", resource.ResourceType()))
+				errors = append(errors, r.modelObjectIssue(resource, fmt.Sprintf("%q cannot be bit checked, ModelObject did not return a pointer", resource.ResourceType())))
 			}
 
 		}
@@ -41,13 +41,13 @@ func (r TypedSDKBitCheck) Run() (errors []error) {
 			if modelType != nil && modelType.Kind() == reflect.Ptr { // Have to nil-check here due to base types not having a model. e.g. roleAssignmentBaseResource
 				model := modelType.Elem()
 				if model.Kind() != reflect.Struct {
-					errors = append(errors, fmt.Errorf("%s is not a pointer to a struct", modelType.Name()))
+					errors = append(errors, r.modelObjectIssue(datasource, fmt.Sprintf("%s is not a pointer to a struct", modelType.Name())))
 					continue
 				}
 
-				errors = append(errors, checkForBits(model)...)
+				errors = append(errors, r.checkForBits(model)...)
 			} else {
-				errors = append(errors, fmt.Errorf("%q cannot be bit checked, ModelObject did not return a pointer", datasource.ResourceType()))
+				errors = append(errors, r.modelObjectIssue(datasource, fmt.Sprintf("%q cannot be bit checked, ModelObject did not return a pointer", datasource.ResourceType())))
 			}
 		}
 	}
@@ -55,6 +55,17 @@ func (r TypedSDKBitCheck) Run() (errors []error) {
 	return
 }
 
+// modelObjectIssue returns an Issue positioned at the ModelObject function of the resource
+func (r TypedSDKBitCheck) modelObjectIssue(resource interface{}, message string) Issue {
+	t := reflect.TypeOf(resource)
+	pkg, decl := findMethod(t, "ModelObject")
+	return Issue{
+		Rule:     r.Name(),
+		Position: pkg.position(decl, t),
+		Message:  message,
+	}
+}
+
 func (r TypedSDKBitCheck) Name() string {
 	return "checkBittiness"
 }
@@ -65,23 +76,33 @@ The '%s' check function is used to check the correct Go types are used in TypedS
 `, r.Name())
 }
 
-func checkForBits(model reflect.Type) (errors []error) {
+func (r TypedSDKBitCheck) checkForBits(model reflect.Type) (errors []error) {
+	pkg := loadSourcePackage(model)
 	for i := 0; i < model.NumField(); i++ {
-		switch t := model.Field(i).Type; t.Kind() {
+		field := model.Field(i)
+		issue := func(message string) Issue {
+			return Issue{
+				Rule:     r.Name(),
+				Position: pkg.position(pkg.structField(model.Name(), field.Name), model),
+				Message:  message,
+			}
+		}
+
+		switch t := field.Type; t.Kind() {
 		case reflect.Int, reflect.Int16, reflect.Int32:
-			errors = append(errors, fmt.Errorf("property %s in model %s should be type int64, got `%s`\n", model.Field(i).Name, model.Name(), t.String()))
+			errors = append(errors, issue(fmt.Sprintf("property %s in model %s should be type int64, got `%s`", field.Name, model.Name(), t.String())))
 		case reflect.Float32:
-			errors = append(errors, fmt.Errorf("property %s in model %s should be type float64, got `%s`\n", model.Field(i).Name, model.Name(), t.String()))
+			errors = append(errors, issue(fmt.Sprintf("property %s in model %s should be type float64, got `%s`", field.Name, model.Name(), t.String())))
 		case reflect.Slice, reflect.Array:
 			switch t.Elem().Kind() {
 			case reflect.Struct:
-				errors = append(errors, checkForBits(t.Elem())...)
+				errors = append(errors, r.checkForBits(t.Elem())...)
 
 			case reflect.Int, reflect.Int16, reflect.Int32:
-				errors = append(errors, fmt.Errorf("property %s in model %s should be type []int64, got `%s`\n", model.Field(i).Name, model.Name(), t.String()))
+				errors = append(errors, issue(fmt.Sprintf("property %s in model %s should be type []int64, got `%s`", field.Name, model.Name(), t.String())))
 
 			case reflect.Float32:
-				errors = append(errors, fmt.Errorf("property %s in model %s should be type []float64, got `%s`\n", model.Field(i).Name, model.Name(), t.String()))
+				errors = append(errors, issue(fmt.Sprintf("property %s in model %s should be type []float64, got `%s`", field.Name, model.Name(), t.String())))
 			default:
 			}
 
@@ -0,0 +1,48 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type bitCheckNestedModel struct {
+	Count   int64   `tfschema:"count"`
+	Weights []int32 `tfschema:"weights"`
+}
+
+type bitCheckModel struct {
+	Name     string                `tfschema:"name"`
+	Capacity int64                 `tfschema:"capacity"`
+	Port     int                   `tfschema:"port"`
+	Ratio    float32               `tfschema:"ratio"`
+	Nested   []bitCheckNestedModel `tfschema:"nested"`
+}
+
+func TestTypedSDKBitCheck(t *testing.T) {
+	errors := TypedSDKBitCheck{}.checkForBits(reflect.TypeOf(bitCheckModel{}))
+
+	expected := []string{
+		"property Port in model bitCheckModel should be type int64, got `int`",
+		"property Ratio in model bitCheckModel should be type float64, got `float32`",
+		"property Weights in model bitCheckNestedModel should be type []int64, got `[]int32`",
+	}
+	if len(errors) != len(expected) {
+		t.Fatalf("expected %d issues but got %d: %+v", len(expected), len(errors), errors)
+	}
+	for i, err := range errors {
+		issue, ok := err.(Issue)
+		if !ok {
+			t.Fatalf("expected an Issue but got %+v", err)
+		}
+		if issue.Message != expected[i] {
+			t.Fatalf("expected %q but got %q", expected[i], issue.Message)
+		}
+		if !strings.HasSuffix(issue.Error(), ": checkBittiness: "+expected[i]) {
+			t.Fatalf("expected the issue to be formatted as `file:line: rule: message` but got %q", issue.Error())
+		}
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+)
+
+var _ Rule = TypedSDKIDValidationCheck{}
+
+type TypedSDKIDValidationCheck struct{}
+
+func (r TypedSDKIDValidationCheck) Run() (errors []error) {
+	for _, s := range provider.SupportedTypedServices() {
+		for _, resource := range s.Resources() {
+			t := reflect.TypeOf(resource)
+			createPkg, create := findMethod(t, "Create")
+			validationPkg, validation := findMethod(t, "IDValidationFunc")
+			if create == nil || validation == nil {
+				continue
+			}
+			if issue := r.checkIDs(createPkg, create, validationPkg, validation, resource.ResourceType()); issue != nil {
+				errors = append(errors, *issue)
+			}
+		}
+	}
+	return
+}
+
+// checkIDs returns an Issue if the segments of the Resource ID validated by the IDValidationFunc differ from those of
+// the Resource ID set by the Create function, when both of these can be determined
+func (r TypedSDKIDValidationCheck) checkIDs(createPkg *sourcePackage, create *ast.FuncDecl, validationPkg *sourcePackage, validation *ast.FuncDecl, resourceType string) *Issue {
+	validator := validationFunc(validation)
+	if validator == nil {
+		return nil
+	}
+	validatorPkg, validatorName := validationPkg.resolveFunc(validation, validator)
+	validated := validatorPkg.resourceIdSegments(validatorName)
+	if validated == "" {
+		return nil
+	}
+
+	constructors := createdIDConstructors(create)
+	if len(constructors) == 0 {
+		return nil
+	}
+	names := make([]string, 0)
+	for _, constructor := range constructors {
+		constructorPkg, constructorName := createPkg.resolveFunc(create, constructor)
+		created := constructorPkg.resourceIdSegments(constructorName)
+		if created == "" || created == validated || strings.HasPrefix(created, "scope") {
+			// either this is the same Resource ID, this can't be determined (e.g. it's a legacy Resource ID) or
+			// the Resource ID is scoped to another resource, which can be any Resource ID
+			return nil
+		}
+		names = append(names, constructorName)
+	}
+
+	return &Issue{
+		Rule:     r.Name(),
+		Position: validationPkg.fset.Position(validation.Pos()),
+		Message:  fmt.Sprintf("%s: IDValidationFunc returns %s but Create sets the ID from %s", resourceType, validatorName, strings.Join(names, ", ")),
+	}
+}
+
+func (r TypedSDKIDValidationCheck) Name() string {
+	return "checkIDValidation"
+}
+
+func (r TypedSDKIDValidationCheck) Description() string {
+	return fmt.Sprintf(`
+The '%s' check function is used to check the IDValidationFunc of Resources in TypedSDK validates the same type of Resource ID which is set by the Create function.
+`, r.Name())
+}
+
+// validationFunc returns the function returned by IDValidationFunc, or nil if this can't be determined - for
+// example when a function literal is returned
+func validationFunc(fn *ast.FuncDecl) ast.Expr {
+	if fn.Body == nil {
+		return nil
+	}
+	for _, stmt := range fn.Body.List {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		switch v := ret.Results[0].(type) {
+		case *ast.Ident, *ast.SelectorExpr:
+			return v
+		}
+	}
+	return nil
+}
+
+// createdIDConstructors returns the Resource ID constructors (e.g. `commonids.NewResourceGroupID`) used for the ID
+// set by the Create function, when these can be determined
+func createdIDConstructors(fn *ast.FuncDecl) (constructors []ast.Expr) {
+	// the values assigned to each variable within the function
+	values := make(map[string][]ast.Expr)
+	ast.Inspect(fn, func(n ast.Node) bool {
+		switch v := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range v.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if len(v.Rhs) == len(v.Lhs) {
+					values[ident.Name] = append(values[ident.Name], v.Rhs[i])
+				} else if len(v.Rhs) == 1 {
+					values[ident.Name] = append(values[ident.Name], v.Rhs[0])
+				}
+			}
+		case *ast.ValueSpec:
+			for i, ident := range v.Names {
+				if i < len(v.Values) {
+					values[ident.Name] = append(values[ident.Name], v.Values[i])
+				}
+			}
+		}
+		return true
+	})
+
+	seen := make(map[string]bool)
+	var resolve func(expr ast.Expr, depth int)
+	resolve = func(expr ast.Expr, depth int) {
+		if depth > 5 {
+			return
+		}
+		switch v := expr.(type) {
+		case *ast.UnaryExpr:
+			resolve(v.X, depth+1)
+		case *ast.StarExpr:
+			resolve(v.X, depth+1)
+		case *ast.ParenExpr:
+			resolve(v.X, depth+1)
+		case *ast.Ident:
+			for _, value := range values[v.Name] {
+				resolve(value, depth+1)
+			}
+		case *ast.CallExpr:
+			name := callName(v)
+			if strings.HasPrefix(name, "New") && (strings.HasSuffix(name, "ID") || strings.HasSuffix(name, "Id")) && !seen[name] {
+				seen[name] = true
+				constructors = append(constructors, v.Fun)
+			}
+		}
+	}
+
+	for _, call := range findCalls(fn, "SetID") {
+		if len(call.Args) == 1 {
+			resolve(call.Args[0], 0)
+		}
+	}
+	return constructors
+}
+
+// resolveFunc returns the package declaring the function referenced within the declaration, using the imports of
+// the file containing the declaration for a function from another package, along with the name of the function
+func (p *sourcePackage) resolveFunc(decl *ast.FuncDecl, expr ast.Expr) (*sourcePackage, string) {
+	if p == nil {
+		return nil, ""
+	}
+	switch v := expr.(type) {
+	case *ast.Ident:
+		return p, v.Name
+	case *ast.SelectorExpr:
+		x, ok := v.X.(*ast.Ident)
+		if !ok {
+			return nil, v.Sel.Name
+		}
+		path, ok := p.imports[p.fset.Position(decl.Pos()).Filename][x.Name]
+		if !ok {
+			return nil, v.Sel.Name
+		}
+		return loadSourcePackageByPath(path), v.Sel.Name
+	}
+	return nil, ""
+}
+
+// resourceIdSegments returns the segments of the Resource ID returned by a constructor (e.g. `NewResourceGroupID`)
+// or parsed by a validation function (e.g. `ValidateResourceGroupID`) within the package, in the form
+// `static:subscriptions/subscriptionid/[...]` - or an empty string when these can't be determined, for example
+// when the Resource ID doesn't implement resourceids.ResourceId
+func (p *sourcePackage) resourceIdSegments(funcName string) string {
+	if p == nil {
+		return ""
+	}
+	if strings.HasPrefix(funcName, "Validate") {
+		// the validation functions within the resourceids packages call the parser for the Resource ID
+		funcName = "Parse" + strings.TrimPrefix(funcName, "Validate")
+	}
+
+	var typeName string
+	for _, decl := range p.funcs[funcName] {
+		if decl.Recv != nil || decl.Type.Results == nil || len(decl.Type.Results.List) == 0 {
+			continue
+		}
+		result := decl.Type.Results.List[0].Type
+		if star, ok := result.(*ast.StarExpr); ok {
+			result = star.X
+		}
+		if ident, ok := result.(*ast.Ident); ok {
+			typeName = ident.Name
+		}
+	}
+
+	// Resource IDs can be aliases of another Resource ID, e.g. `type FunctionAppId = AppServiceId`
+	for i := 0; i < 5 && typeName != ""; i++ {
+		ts, ok := p.types[typeName]
+		if !ok {
+			return ""
+		}
+		ident, ok := ts.Type.(*ast.Ident)
+		if !ok {
+			break
+		}
+		typeName = ident.Name
+	}
+
+	method, ok := p.methods[typeName+".Segments"]
+	if !ok || method.Body == nil {
+		return ""
+	}
+	for _, stmt := range method.Body.List {
+		ret, ok := stmt.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) != 1 {
+			continue
+		}
+		lit, ok := ret.Results[0].(*ast.CompositeLit)
+		if !ok {
+			return ""
+		}
+		segments := make([]string, 0)
+		for _, elt := range lit.Elts {
+			call, ok := elt.(*ast.CallExpr)
+			if !ok {
+				return ""
+			}
+			segments = append(segments, segmentSignature(call))
+		}
+		return strings.Join(segments, "/")
+	}
+	return ""
+}
+
+// segmentSignature returns the kind of the segment, along with the (case-insensitive) fixed value for a static or
+// resource provider segment, since the names of the segments don't matter when comparing Resource IDs
+func segmentSignature(call *ast.CallExpr) string {
+	kind := strings.TrimSuffix(callName(call), "Segment")
+	switch kind {
+	case "Static", "ResourceProvider":
+		if values := stringArgs(call); len(values) >= 2 {
+			return fmt.Sprintf("%s:%s", strings.ToLower(kind), strings.ToLower(values[1]))
+		}
+	}
+	return strings.ToLower(kind)
+}
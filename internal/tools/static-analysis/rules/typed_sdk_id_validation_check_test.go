@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"testing"
+)
+
+func TestTypedSDKIDValidationCheck(t *testing.T) {
+	pkg, expected := testdataPackage(t, "idvalidation")
+
+	creates := testdataMethods(pkg, "Create")
+	validations := testdataMethods(pkg, "IDValidationFunc")
+	errors := make([]error, 0)
+	for _, resourceType := range sortedKeys(creates) {
+		if issue := (TypedSDKIDValidationCheck{}).checkIDs(pkg, creates[resourceType], pkg, validations[resourceType], resourceType); issue != nil {
+			errors = append(errors, *issue)
+		}
+	}
+
+	checkIssues(t, expected, errors)
+}
+
+func TestResourceIdSegments(t *testing.T) {
+	pkg := loadSourcePackageByPath("github.com/hashicorp/go-azure-helpers/resourcemanager/commonids")
+	if pkg == nil {
+		t.Fatalf("expected the commonids package to be loaded")
+	}
+
+	testData := []struct {
+		Constructor string
+		Validator   string
+		Expected    bool
+	}{
+		{
+			Constructor: "NewResourceGroupID",
+			Validator:   "ValidateResourceGroupID",
+			Expected:    true,
+		},
+		{
+			Constructor: "NewAppServiceID",
+			Validator:   "ValidateFunctionAppID",
+			Expected:    true,
+		},
+		{
+			Constructor: "NewSubnetID",
+			Validator:   "ValidateVirtualNetworkID",
+			Expected:    false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q and %q", v.Constructor, v.Validator)
+
+		created := pkg.resourceIdSegments(v.Constructor)
+		validated := pkg.resourceIdSegments(v.Validator)
+		if created == "" || validated == "" {
+			t.Fatalf("expected the segments to be found but got %q and %q", created, validated)
+		}
+		if actual := created == validated; actual != v.Expected {
+			t.Fatalf("expected %t but got %t for %q and %q", v.Expected, actual, created, validated)
+		}
+	}
+}
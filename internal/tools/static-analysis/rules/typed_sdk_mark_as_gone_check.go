@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+)
+
+var _ Rule = TypedSDKMarkAsGoneCheck{}
+
+type TypedSDKMarkAsGoneCheck struct{}
+
+func (r TypedSDKMarkAsGoneCheck) Run() (errors []error) {
+	for _, s := range provider.SupportedTypedServices() {
+		for _, resource := range s.Resources() {
+			pkg, decl := findMethod(reflect.TypeOf(resource), "Read")
+			if decl == nil {
+				continue
+			}
+			if issue := r.checkRead(pkg, decl, resource.ResourceType()); issue != nil {
+				errors = append(errors, *issue)
+			}
+		}
+	}
+	return
+}
+
+// checkRead returns an Issue if the Read function doesn't call MarkAsGone when the API returns a 404
+func (r TypedSDKMarkAsGoneCheck) checkRead(pkg *sourcePackage, decl *ast.FuncDecl, resourceType string) *Issue {
+	if pkg.callsWhenNotFound(decl, "MarkAsGone", map[*ast.FuncDecl]bool{}) {
+		return nil
+	}
+	return &Issue{
+		Rule:     r.Name(),
+		Position: pkg.fset.Position(decl.Pos()),
+		Message:  fmt.Sprintf("%s: the Read function does not call MarkAsGone when the API returns a 404", resourceType),
+	}
+}
+
+func (r TypedSDKMarkAsGoneCheck) Name() string {
+	return "checkMarkAsGone"
+}
+
+func (r TypedSDKMarkAsGoneCheck) Description() string {
+	return fmt.Sprintf(`
+The '%s' check function is used to check the Read function of Resources in TypedSDK calls 'metadata.MarkAsGone' when the API returns a 404 (e.g. within 'if response.WasNotFound(resp.HttpResponse)'), so that the resource is removed from the state.
+`, r.Name())
+}
@@ -0,0 +1,22 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"testing"
+)
+
+func TestTypedSDKMarkAsGoneCheck(t *testing.T) {
+	pkg, expected := testdataPackage(t, "markasgone")
+
+	methods := testdataMethods(pkg, "Read")
+	errors := make([]error, 0)
+	for _, resourceType := range sortedKeys(methods) {
+		if issue := (TypedSDKMarkAsGoneCheck{}).checkRead(pkg, methods[resourceType], resourceType); issue != nil {
+			errors = append(errors, *issue)
+		}
+	}
+
+	checkIssues(t, expected, errors)
+}
@@ -0,0 +1,110 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ Rule = TypedSDKSchemaTagCheck{}
+
+type TypedSDKSchemaTagCheck struct{}
+
+func (r TypedSDKSchemaTagCheck) Run() (errors []error) {
+	for _, s := range provider.SupportedTypedServices() {
+		for _, resource := range s.Resources() {
+			errors = append(errors, r.checkResource(resource)...)
+		}
+		for _, datasource := range s.DataSources() {
+			errors = append(errors, r.checkResource(datasource)...)
+		}
+	}
+	return
+}
+
+func (r TypedSDKSchemaTagCheck) Name() string {
+	return "checkSchemaTags"
+}
+
+func (r TypedSDKSchemaTagCheck) Description() string {
+	return fmt.Sprintf(`
+The '%s' check function is used to check the 'tfschema' tags of the model in TypedSDK match a key in the Arguments or Attributes of the schema.
+`, r.Name())
+}
+
+type typedSchemaResource interface {
+	Arguments() map[string]*pluginsdk.Schema
+	Attributes() map[string]*pluginsdk.Schema
+	ModelObject() interface{}
+	ResourceType() string
+}
+
+func (r TypedSDKSchemaTagCheck) checkResource(resource typedSchemaResource) []error {
+	modelType := reflect.TypeOf(resource.ModelObject())
+	if modelType == nil || modelType.Kind() != reflect.Ptr || modelType.Elem().Kind() != reflect.Struct {
+		// the model is checked by checkBittiness
+		return nil
+	}
+
+	schema := make(map[string]*pluginsdk.Schema)
+	for k, v := range resource.Arguments() {
+		schema[k] = v
+	}
+	for k, v := range resource.Attributes() {
+		schema[k] = v
+	}
+
+	return r.checkModel(resource.ResourceType(), modelType.Elem(), "", schema)
+}
+
+func (r TypedSDKSchemaTagCheck) checkModel(resourceType string, model reflect.Type, path string, schema map[string]*pluginsdk.Schema) (errors []error) {
+	pkg := loadSourcePackage(model)
+	for i := 0; i < model.NumField(); i++ {
+		field := model.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			errors = append(errors, r.checkModel(resourceType, field.Type, path, schema)...)
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup("tfschema")
+		if !ok || tag == "" || tag == "-" {
+			continue
+		}
+		options := strings.Split(tag, ",")
+		name := options[0]
+		if len(options) > 1 && strings.EqualFold(options[1], "removedInNextMajorVersion") {
+			// these may be conditionally removed from the schema
+			continue
+		}
+
+		position := pkg.position(pkg.structField(model.Name(), field.Name), model)
+		item, ok := schema[name]
+		if !ok {
+			errors = append(errors, Issue{
+				Rule:     r.Name(),
+				Position: position,
+				Message:  fmt.Sprintf("%s: field %s.%s has tfschema tag %q which is not in the schema", resourceType, model.Name(), field.Name, path+name),
+			})
+			continue
+		}
+
+		nested, ok := item.Elem.(*pluginsdk.Resource)
+		if !ok || nested == nil {
+			continue
+		}
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr || fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct {
+			errors = append(errors, r.checkModel(resourceType, fieldType, path+name+".", nested.Schema)...)
+		}
+	}
+	return
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type schemaTagNestedModel struct {
+	Enabled bool   `tfschema:"enabled"`
+	Mode    string `tfschema:"mode"`
+}
+
+type schemaTagModel struct {
+	Name      string                 `tfschema:"name"`
+	Endpoint  string                 `tfschema:"endpoint"`
+	Legacy    string                 `tfschema:"legacy,removedInNextMajorVersion"`
+	Internal  string                 `tfschema:"-"`
+	Settings  []schemaTagNestedModel `tfschema:"settings"`
+	Untracked string
+}
+
+func TestTypedSDKSchemaTagCheck(t *testing.T) {
+	schema := map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+		"settings": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"enabled": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+
+	errors := TypedSDKSchemaTagCheck{}.checkModel("example_resource", reflect.TypeOf(schemaTagModel{}), "", schema)
+
+	expected := []string{
+		`example_resource: field schemaTagModel.Endpoint has tfschema tag "endpoint" which is not in the schema`,
+		`example_resource: field schemaTagNestedModel.Mode has tfschema tag "settings.mode" which is not in the schema`,
+	}
+	if len(errors) != len(expected) {
+		t.Fatalf("expected %d issues but got %d: %+v", len(expected), len(errors), errors)
+	}
+	for i, err := range errors {
+		issue, ok := err.(Issue)
+		if !ok {
+			t.Fatalf("expected an Issue but got %+v", err)
+		}
+		if issue.Message != expected[i] {
+			t.Fatalf("expected %q but got %q", expected[i], issue.Message)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+)
+
+var _ Rule = TypedSDKTimeoutCheck{}
+
+type TypedSDKTimeoutCheck struct{}
+
+func (r TypedSDKTimeoutCheck) Run() (errors []error) {
+	for _, s := range provider.SupportedTypedServices() {
+		for _, resource := range s.Resources() {
+			funcs := map[string]sdk.ResourceFunc{
+				"Create": resource.Create(),
+				"Read":   resource.Read(),
+				"Delete": resource.Delete(),
+			}
+			if v, ok := resource.(sdk.ResourceWithUpdate); ok {
+				funcs["Update"] = v.Update()
+			}
+			errors = append(errors, r.checkFuncs(resource, resource.ResourceType(), funcs)...)
+		}
+		for _, datasource := range s.DataSources() {
+			funcs := map[string]sdk.ResourceFunc{
+				"Read": datasource.Read(),
+			}
+			errors = append(errors, r.checkFuncs(datasource, datasource.ResourceType(), funcs)...)
+		}
+	}
+	return
+}
+
+func (r TypedSDKTimeoutCheck) Name() string {
+	return "checkTimeouts"
+}
+
+func (r TypedSDKTimeoutCheck) Description() string {
+	return fmt.Sprintf(`
+The '%s' check function is used to check each ResourceFunc in TypedSDK specifies a default Timeout.
+`, r.Name())
+}
+
+func (r TypedSDKTimeoutCheck) checkFuncs(resource interface{}, resourceType string, funcs map[string]sdk.ResourceFunc) (errors []error) {
+	t := reflect.TypeOf(resource)
+	for name, fn := range funcs {
+		if fn.Timeout > 0 {
+			continue
+		}
+		pkg, decl := findMethod(t, name)
+		errors = append(errors, Issue{
+			Rule:     r.Name(),
+			Position: pkg.position(decl, t),
+			Message:  fmt.Sprintf("%s: the %s function does not specify a Timeout", resourceType, name),
+		})
+	}
+	return
+}
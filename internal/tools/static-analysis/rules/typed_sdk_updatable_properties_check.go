@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"fmt"
+	"go/ast"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var _ Rule = TypedSDKUpdatablePropertiesCheck{}
+
+type TypedSDKUpdatablePropertiesCheck struct{}
+
+func (r TypedSDKUpdatablePropertiesCheck) Run() (errors []error) {
+	for _, s := range provider.SupportedTypedServices() {
+		for _, resource := range s.Resources() {
+			if _, ok := resource.(sdk.ResourceWithUpdate); !ok {
+				continue
+			}
+
+			pkg, decl := findMethod(reflect.TypeOf(resource), "Update")
+			if decl == nil {
+				continue
+			}
+			errors = append(errors, r.checkUpdate(pkg, decl, resource.ResourceType(), resource.Arguments())...)
+		}
+	}
+	return
+}
+
+// checkUpdate returns an Issue for each property checked for changes by the Update function which either isn't an
+// Argument or is ForceNew
+func (r TypedSDKUpdatablePropertiesCheck) checkUpdate(pkg *sourcePackage, decl *ast.FuncDecl, resourceType string, arguments map[string]*pluginsdk.Schema) (errors []error) {
+	for _, name := range []string{"HasChange", "HasChanges"} {
+		for _, call := range findCalls(decl, name) {
+			for _, key := range stringArgs(call) {
+				// nested properties are checked by their top-level block, e.g. `identity` for `identity.0.type`
+				property := strings.Split(key, ".")[0]
+
+				var message string
+				if v, ok := arguments[property]; !ok {
+					message = fmt.Sprintf("%s: the Update function checks for changes to %q which is not an argument", resourceType, key)
+				} else if v.ForceNew {
+					message = fmt.Sprintf("%s: the Update function checks for changes to %q which is ForceNew", resourceType, key)
+				} else {
+					continue
+				}
+
+				errors = append(errors, Issue{
+					Rule:     r.Name(),
+					Position: pkg.fset.Position(call.Pos()),
+					Message:  message,
+				})
+			}
+		}
+	}
+	return
+}
+
+func (r TypedSDKUpdatablePropertiesCheck) Name() string {
+	return "checkUpdatableProperties"
+}
+
+func (r TypedSDKUpdatablePropertiesCheck) Description() string {
+	return fmt.Sprintf(`
+The '%s' check function is used to check the Update function of Resources in TypedSDK only updates properties which are Arguments that aren't ForceNew.
+`, r.Name())
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestTypedSDKUpdatablePropertiesCheck(t *testing.T) {
+	pkg, expected := testdataPackage(t, "updatableproperties")
+
+	arguments := map[string]*pluginsdk.Schema{
+		"identity": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+		},
+		"location": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"sku_name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+		},
+		"tags": {
+			Type:     pluginsdk.TypeMap,
+			Optional: true,
+		},
+	}
+
+	methods := testdataMethods(pkg, "Update")
+	errors := make([]error, 0)
+	for _, resourceType := range sortedKeys(methods) {
+		errors = append(errors, (TypedSDKUpdatablePropertiesCheck{}).checkUpdate(pkg, methods[resourceType], resourceType, arguments)...)
+	}
+
+	checkIssues(t, expected, errors)
+}
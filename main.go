@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
@@ -25,6 +26,9 @@ func main() {
 	flag.BoolVar(&debugMode, "debuggable", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	// allow the holders of any locks to be determined when an apply appears to have hung
+	locks.DumpHoldersOnSignal()
+
 	ctx := context.Background()
 
 	if features.FourPointOhBeta() {
@@ -3,12 +3,14 @@
 # SPDX-License-Identifier: MPL-2.0
 
 
+# any additional arguments are passed through to the detect mode, for example to output a
+# machine-readable report: `./scripts/run-breaking-change-detection.sh -format sarif -output report.sarif`
 function runDetect {
-  go run internal/tools/schema-api/main.go -detect .release/provider-schema.json
+  go run internal/tools/schema-api/main.go -detect .release/provider-schema.json "$@"
 }
 
 function main {
-  runDetect
+  runDetect "$@"
 }
 
-main
\ No newline at end of file
+main "$@"
\ No newline at end of file